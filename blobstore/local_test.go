@@ -0,0 +1,43 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	store, err := NewLocalBlobStore(t.TempDir())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NoError(t, store.Put(ctx, "attachments/1", strings.NewReader("content"), "text/plain"))
+
+	r, err := store.Get(ctx, "attachments/1")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	content, err := ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Close())
+	assert.Equal(t, "content", string(content))
+
+	assert.NoError(t, store.Delete(ctx, "attachments/1"))
+
+	_, err = store.Get(ctx, "attachments/1")
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+	assert.True(t, errors.Is(store.Delete(ctx, "attachments/1"), models.ErrNotFound))
+
+	assert.Error(t, store.Put(ctx, "../outside", strings.NewReader("content"), "text/plain"))
+}
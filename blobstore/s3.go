@@ -0,0 +1,104 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+// S3BlobStore keeps blobs in a bucket of S3 or of any S3-compatible storage, e.g. MinIO.
+type S3BlobStore struct {
+	client   *s3.S3
+	uploader *s3manager.Uploader
+	bucket   string
+}
+
+// NewS3BlobStore creates a store for the bucket. An empty endpoint means AWS itself,
+// any other endpoint is addressed in path style, as S3-compatible storages expect.
+func NewS3BlobStore(endpoint, region, bucket, accessKey, secretKey string) (*S3BlobStore, error) {
+	config := aws.NewConfig().
+		WithRegion(region).
+		WithCredentials(credentials.NewStaticCredentials(accessKey, secretKey, ""))
+
+	if endpoint != "" {
+		config = config.WithEndpoint(endpoint).WithS3ForcePathStyle(true)
+	}
+
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create S3 session: %w", err)
+	}
+
+	return &S3BlobStore{
+		client:   s3.New(sess),
+		uploader: s3manager.NewUploader(sess),
+		bucket:   bucket,
+	}, nil
+}
+
+func (s *S3BlobStore) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	_, err := s.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        r,
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("cannot upload blob: %w", err)
+	}
+
+	return nil
+}
+
+func (s *S3BlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	output, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get blob: %w", err)
+	}
+
+	return output.Body, nil
+}
+
+// Delete removes the blob. Unlike the local store, it does not report missing keys, as S3 does not.
+func (s *S3BlobStore) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("cannot delete blob: %w", err)
+	}
+
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var requestErr awserr.RequestFailure
+	if errors.As(err, &requestErr) {
+		return requestErr.StatusCode() == http.StatusNotFound
+	}
+
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return awsErr.Code() == s3.ErrCodeNoSuchKey
+	}
+
+	return false
+}
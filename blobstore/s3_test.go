@@ -0,0 +1,92 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeS3 stands in for an S3-compatible storage. It keeps objects in memory by their path-style paths.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		s.objects[r.URL.Path] = body
+		s.types[r.URL.Path] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		body, ok := s.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>not found</Message></Error>`))
+
+			return
+		}
+
+		_, _ = w.Write(body)
+	case http.MethodDelete:
+		delete(s.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestS3BlobStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage := &fakeS3{
+		objects: make(map[string][]byte),
+		types:   make(map[string]string),
+	}
+
+	server := httptest.NewServer(storage)
+	defer server.Close()
+
+	store, err := NewS3BlobStore(server.URL, "us-east-1", "bucket", "key", "secret")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NoError(t, store.Put(ctx, "attachments/1", strings.NewReader("content"), "text/plain"))
+	assert.Equal(t, []byte("content"), storage.objects["/bucket/attachments/1"])
+	assert.Equal(t, "text/plain", storage.types["/bucket/attachments/1"])
+
+	r, err := store.Get(ctx, "attachments/1")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	content, err := ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Close())
+	assert.Equal(t, "content", string(content))
+
+	assert.NoError(t, store.Delete(ctx, "attachments/1"))
+
+	_, err = store.Get(ctx, "attachments/1")
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+}
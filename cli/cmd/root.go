@@ -8,7 +8,7 @@ var rootCmd = &cobra.Command{
 	Use:   "cli",
 	Short: "Cli for blog-api",
 	Long: `Cli is a command line app for blog-api.
-	Available cache command only.`,
+	Available cache and posts commands.`,
 }
 
 func Execute() {
@@ -2,25 +2,86 @@ package configs
 
 import (
 	"fmt"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/serhiihuberniuk/blog-api/models"
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	HttpPort                  string `mapstructure:"HTTP_PORT"`
-	GrpcPort                  string `mapstructure:"GRPC_PORT"`
-	GraphqlPort               string `mapstructure:"GRAPHQL_PORT"`
-	HealthcheckPort           string `mapstructure:"HEALTHCHECK_PORT"`
-	PostgresUrl               string `mapstructure:"POSTGRESQL_URL"`
-	PostgresMigrationsPath    string `mapstructure:"POSTGRES_MIGRATIONS_PATH"`
-	PostgresMigrationsVersion uint   `mapstructure:"POSTGRES_MIGRATIONS_VERSION"`
-	PrivateKeyFile            string `mapstructure:"PRIVATE_KEY_FILE"`
-	RedisAddress              string `mapstructure:"REDIS_ADDRESS"`
+	HttpPort                  string        `mapstructure:"HTTP_PORT"`
+	GrpcPort                  string        `mapstructure:"GRPC_PORT"`
+	GraphqlPort               string        `mapstructure:"GRAPHQL_PORT"`
+	HealthcheckPort           string        `mapstructure:"HEALTHCHECK_PORT"`
+	PostgresUrl               string        `mapstructure:"POSTGRESQL_URL"`
+	PostgresMigrationsPath    string        `mapstructure:"POSTGRES_MIGRATIONS_PATH"`
+	PostgresMigrationsVersion uint          `mapstructure:"POSTGRES_MIGRATIONS_VERSION"`
+	PrivateKeyFile            string        `mapstructure:"PRIVATE_KEY_FILE"`
+	RedisAddress              string        `mapstructure:"REDIS_ADDRESS"`
+	Mailer                    string        `mapstructure:"MAILER"`
+	MailFrom                  string        `mapstructure:"MAIL_FROM"`
+	MailLogFile               string        `mapstructure:"MAIL_LOG_FILE"`
+	SmtpHost                  string        `mapstructure:"SMTP_HOST"`
+	SmtpPort                  string        `mapstructure:"SMTP_PORT"`
+	SmtpUsername              string        `mapstructure:"SMTP_USERNAME"`
+	SmtpPassword              string        `mapstructure:"SMTP_PASSWORD"`
+	UnverifiedAccess          string        `mapstructure:"UNVERIFIED_ACCESS"`
+	DeletedRetention          time.Duration `mapstructure:"DELETED_RETENTION"`
+	PurgeInterval             time.Duration `mapstructure:"PURGE_INTERVAL"`
+	DeletedUserContent        string        `mapstructure:"DELETED_USER_CONTENT"`
+	PageMaxLimit              uint64        `mapstructure:"PAGE_MAX_LIMIT"`
+	BlobStore                 string        `mapstructure:"BLOB_STORE"`
+	BlobStoreDir              string        `mapstructure:"BLOB_STORE_DIR"`
+	S3Endpoint                string        `mapstructure:"S3_ENDPOINT"`
+	S3Region                  string        `mapstructure:"S3_REGION"`
+	S3Bucket                  string        `mapstructure:"S3_BUCKET"`
+	S3AccessKey               string        `mapstructure:"S3_ACCESS_KEY"`
+	S3SecretKey               string        `mapstructure:"S3_SECRET_KEY"`
+	AttachmentMaxSize         int64         `mapstructure:"ATTACHMENT_MAX_SIZE"`
+	AttachmentUserQuota       int64         `mapstructure:"ATTACHMENT_USER_QUOTA"`
+	AttachmentBaseUrl         string        `mapstructure:"ATTACHMENT_BASE_URL"`
+	AttachmentVariants        string        `mapstructure:"ATTACHMENT_VARIANTS"`
+	VariantInterval           time.Duration `mapstructure:"VARIANT_INTERVAL"`
 }
 
+const (
+	MailerSMTP = "smtp"
+	MailerLog  = "log"
+)
+
+const (
+	BlobStoreLocal = "local"
+	BlobStoreS3    = "s3"
+)
+
+const (
+	UnverifiedAccessFull     = "full"
+	UnverifiedAccessReadOnly = "read-only"
+	UnverifiedAccessNone     = "none"
+)
+
+const (
+	DeletedUserContentCascade   = "cascade"
+	DeletedUserContentAnonymize = "anonymize"
+)
+
 func (c *Config) validate() error {
+	var smtpRules []validation.Rule
+	if c.Mailer == MailerSMTP {
+		smtpRules = append(smtpRules, validation.Required)
+	}
+
+	var localBlobStoreRules, s3Rules []validation.Rule
+
+	switch c.BlobStore {
+	case BlobStoreLocal:
+		localBlobStoreRules = append(localBlobStoreRules, validation.Required)
+	case BlobStoreS3:
+		s3Rules = append(s3Rules, validation.Required)
+	}
+
 	err := validation.ValidateStruct(c,
 		validation.Field(&c.HttpPort, validation.Required, is.Port),
 		validation.Field(&c.GrpcPort, validation.Required, is.Port, validation.NotIn(c.HttpPort)),
@@ -31,6 +92,33 @@ func (c *Config) validate() error {
 		validation.Field(&c.PostgresMigrationsVersion, validation.Required),
 		validation.Field(&c.PrivateKeyFile, validation.Required),
 		validation.Field(&c.RedisAddress, validation.Required),
+		validation.Field(&c.Mailer, validation.Required, validation.In(MailerSMTP, MailerLog)),
+		validation.Field(&c.MailFrom, validation.Required, is.Email),
+		validation.Field(&c.SmtpHost, smtpRules...),
+		validation.Field(&c.SmtpPort, append(smtpRules, is.Port)...),
+		validation.Field(&c.UnverifiedAccess, validation.Required,
+			validation.In(UnverifiedAccessFull, UnverifiedAccessReadOnly, UnverifiedAccessNone)),
+		validation.Field(&c.DeletedRetention, validation.Required),
+		validation.Field(&c.PurgeInterval, validation.Required),
+		validation.Field(&c.DeletedUserContent, validation.Required,
+			validation.In(DeletedUserContentCascade, DeletedUserContentAnonymize)),
+		validation.Field(&c.PageMaxLimit, validation.Required),
+		validation.Field(&c.BlobStore, validation.Required, validation.In(BlobStoreLocal, BlobStoreS3)),
+		validation.Field(&c.BlobStoreDir, localBlobStoreRules...),
+		validation.Field(&c.S3Endpoint, is.URL),
+		validation.Field(&c.S3Region, s3Rules...),
+		validation.Field(&c.S3Bucket, s3Rules...),
+		validation.Field(&c.S3AccessKey, s3Rules...),
+		validation.Field(&c.S3SecretKey, s3Rules...),
+		validation.Field(&c.AttachmentMaxSize, validation.Required, validation.Min(int64(1))),
+		validation.Field(&c.AttachmentUserQuota, validation.Required, validation.Min(c.AttachmentMaxSize)),
+		validation.Field(&c.AttachmentBaseUrl, validation.Required, is.URL),
+		validation.Field(&c.AttachmentVariants, validation.By(func(value interface{}) error {
+			_, err := models.ParseVariantSpecs(c.AttachmentVariants)
+
+			return err
+		})),
+		validation.Field(&c.VariantInterval, validation.Required),
 	)
 	if err != nil {
 		return fmt.Errorf("validation failed: %w", err)
@@ -53,6 +141,30 @@ func LoadConfig() (*Config, error) {
 		PostgresMigrationsVersion: viper.GetUint("POSTGRES_MIGRATIONS_VERSION"),
 		PrivateKeyFile:            viper.GetString("PRIVATE_KEY_FILE"),
 		RedisAddress:              viper.GetString("REDIS_ADDRESS"),
+		Mailer:                    viper.GetString("MAILER"),
+		MailFrom:                  viper.GetString("MAIL_FROM"),
+		MailLogFile:               viper.GetString("MAIL_LOG_FILE"),
+		SmtpHost:                  viper.GetString("SMTP_HOST"),
+		SmtpPort:                  viper.GetString("SMTP_PORT"),
+		SmtpUsername:              viper.GetString("SMTP_USERNAME"),
+		SmtpPassword:              viper.GetString("SMTP_PASSWORD"),
+		UnverifiedAccess:          viper.GetString("UNVERIFIED_ACCESS"),
+		DeletedRetention:          viper.GetDuration("DELETED_RETENTION"),
+		PurgeInterval:             viper.GetDuration("PURGE_INTERVAL"),
+		DeletedUserContent:        viper.GetString("DELETED_USER_CONTENT"),
+		PageMaxLimit:              viper.GetUint64("PAGE_MAX_LIMIT"),
+		BlobStore:                 viper.GetString("BLOB_STORE"),
+		BlobStoreDir:              viper.GetString("BLOB_STORE_DIR"),
+		S3Endpoint:                viper.GetString("S3_ENDPOINT"),
+		S3Region:                  viper.GetString("S3_REGION"),
+		S3Bucket:                  viper.GetString("S3_BUCKET"),
+		S3AccessKey:               viper.GetString("S3_ACCESS_KEY"),
+		S3SecretKey:               viper.GetString("S3_SECRET_KEY"),
+		AttachmentMaxSize:         viper.GetInt64("ATTACHMENT_MAX_SIZE"),
+		AttachmentUserQuota:       viper.GetInt64("ATTACHMENT_USER_QUOTA"),
+		AttachmentBaseUrl:         viper.GetString("ATTACHMENT_BASE_URL"),
+		AttachmentVariants:        viper.GetString("ATTACHMENT_VARIANTS"),
+		VariantInterval:           viper.GetDuration("VARIANT_INTERVAL"),
 	}
 
 	if err := config.validate(); err != nil {
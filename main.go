@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"net"
@@ -9,15 +11,20 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/golang-jwt/jwt"
 	"github.com/rs/cors"
+	"github.com/serhiihuberniuk/blog-api/blobstore"
 	"github.com/serhiihuberniuk/blog-api/configs"
 	"github.com/serhiihuberniuk/blog-api/health"
+	"github.com/serhiihuberniuk/blog-api/mailer"
+	"github.com/serhiihuberniuk/blog-api/models"
 	"github.com/serhiihuberniuk/blog-api/providers"
 	"github.com/serhiihuberniuk/blog-api/repository/decorator"
+	"github.com/serhiihuberniuk/blog-api/repository/denylist"
 	repository "github.com/serhiihuberniuk/blog-api/repository/postgresql"
 	"github.com/serhiihuberniuk/blog-api/service"
 	"github.com/serhiihuberniuk/blog-api/view/graphql/graph"
@@ -31,6 +38,9 @@ import (
 	"google.golang.org/grpc"
 )
 
+// variantBatchSize is the number of images whose variants are made at a time.
+const variantBatchSize = 10
+
 func main() {
 	ctx := context.Background()
 
@@ -50,6 +60,8 @@ func main() {
 
 	repoWithCache := decorator.NewRepositoryCacheDecorator(repo, config.RedisAddress)
 
+	tokenDenylist := denylist.NewRedisDenylist(config.RedisAddress)
+
 	privateKey, err := ioutil.ReadFile(config.PrivateKeyFile)
 	if err != nil {
 		log.Fatalf("cannot read Private Key from file: %v", err)
@@ -62,7 +74,29 @@ func main() {
 
 	userInfoProvider := providers.NewCurrentUserInformationProvider()
 
-	serv, err := service.NewService(repoWithCache, privateRSA, userInfoProvider)
+	mailSender, err := newMailer(config)
+	if err != nil {
+		log.Fatalf("error occurred while creating mailer: %v", err)
+	}
+
+	blobStore, err := newBlobStore(config)
+	if err != nil {
+		log.Fatalf("error occurred while creating blob store: %v", err)
+	}
+
+	variants, err := models.ParseVariantSpecs(config.AttachmentVariants)
+	if err != nil {
+		log.Fatalf("error occurred while parsing attachment variants: %v", err)
+	}
+
+	serv, err := service.NewService(repoWithCache, privateRSA, userInfoProvider, tokenDenylist, mailSender,
+		service.UnverifiedAccess(config.UnverifiedAccess), models.DeletedUserContent(config.DeletedUserContent),
+		blobStore, models.AttachmentConfig{
+			MaxSize:   config.AttachmentMaxSize,
+			UserQuota: config.AttachmentUserQuota,
+			BaseURL:   config.AttachmentBaseUrl,
+			Variants:  variants,
+		})
 	if err != nil {
 		log.Fatalf("error occurred while creating service: %v", err)
 	}
@@ -71,9 +105,35 @@ func main() {
 
 	errs := make(chan error)
 
+	// Purge of deleted objects
+
+	purgeTicker := time.NewTicker(config.PurgeInterval)
+	defer purgeTicker.Stop()
+
+	go func() {
+		for range purgeTicker.C {
+			if err := serv.PurgeDeleted(ctx, config.DeletedRetention); err != nil {
+				log.Printf("error occurred while purging deleted objects: %v", err)
+			}
+		}
+	}()
+
+	// Variants of attached images
+
+	variantTicker := time.NewTicker(config.VariantInterval)
+	defer variantTicker.Stop()
+
+	go func() {
+		for range variantTicker.C {
+			if err := serv.ProcessAttachmentVariants(ctx, variantBatchSize); err != nil {
+				log.Printf("error occurred while making variants of attachments: %v", err)
+			}
+		}
+	}()
+
 	// Health check server
 
-	healthHandler := health.NewHandlerHealth(repo.HealthCheck, repoWithCache.HealthCheck)
+	healthHandler := health.NewHandlerHealth(repo.HealthCheck, repoWithCache.HealthCheck, tokenDenylist.HealthCheck)
 
 	healthServer := http.Server{
 		Addr:    ":" + config.HealthcheckPort,
@@ -89,7 +149,7 @@ func main() {
 	log.Println(" Health check server is listening on ", healthServer.Addr)
 
 	middleware := middlewares.NewAuthMiddleware(authMiddlewareProvider)
-	handlerRest := handlers.NewRestHandlers(serv, middleware, userInfoProvider)
+	handlerRest := handlers.NewRestHandlers(serv, middleware, userInfoProvider, config.PageMaxLimit)
 
 	restServer := http.Server{
 		Addr:    ":" + config.HttpPort,
@@ -100,8 +160,9 @@ func main() {
 
 	go func() {
 		c := cors.New(cors.Options{
-			AllowedMethods: []string{"GET", "POST", "PUT", "DELETE"},
+			AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
 			AllowedHeaders: []string{"Authorization", "content-type"},
+			ExposedHeaders: []string{"Link", "X-Total-Count"},
 		})
 		handlerCors := c.Handler(restServer.Handler)
 
@@ -119,7 +180,7 @@ func main() {
 	grpcServer := grpc.NewServer(
 		grpc.UnaryInterceptor(authInterceptor.UnaryAuthInterceptor),
 		grpc.StreamInterceptor(authInterceptor.StreamAuthInterceptor))
-	grpcHandler := grpcHandlers.NewGrpcHandlers(serv, userInfoProvider)
+	grpcHandler := grpcHandlers.NewGrpcHandlers(serv, userInfoProvider, config.PageMaxLimit)
 
 	go func() {
 		lis, err := net.Listen("tcp", address)
@@ -139,7 +200,7 @@ func main() {
 	log.Println("gRPC server is listening on ", address)
 
 	// GraphQl server
-	resolverConfig := graph.NewResolverConfig(serv, userInfoProvider)
+	resolverConfig := graph.NewResolverConfig(serv, userInfoProvider, config.PageMaxLimit)
 
 	graphMiddleware := graphqlMiddlewares.NewAuthMiddleware(authMiddlewareProvider).Auth
 
@@ -190,3 +251,40 @@ func main() {
 
 	log.Print("Done")
 }
+
+type mailSender interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+func newMailer(config *configs.Config) (mailSender, error) {
+	if config.Mailer == configs.MailerSMTP {
+		return mailer.NewSMTPMailer(config.SmtpHost, config.SmtpPort, config.SmtpUsername, config.SmtpPassword,
+			config.MailFrom), nil
+	}
+
+	if config.MailLogFile == "" {
+		return mailer.NewLogMailer(os.Stdout, config.MailFrom), nil
+	}
+
+	file, err := os.OpenFile(config.MailLogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open mail log file: %w", err)
+	}
+
+	return mailer.NewLogMailer(file, config.MailFrom), nil
+}
+
+type blobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+func newBlobStore(config *configs.Config) (blobStore, error) {
+	if config.BlobStore == configs.BlobStoreS3 {
+		return blobstore.NewS3BlobStore(config.S3Endpoint, config.S3Region, config.S3Bucket, config.S3AccessKey,
+			config.S3SecretKey)
+	}
+
+	return blobstore.NewLocalBlobStore(config.BlobStoreDir)
+}
@@ -0,0 +1,81 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // registers the GIF decoder
+	"image/jpeg"
+	"image/png"
+
+	"github.com/nfnt/resize"
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+const (
+	// maxPixels limits decoded images, so a small file cannot expand into a huge image in memory.
+	maxPixels = 50000000
+
+	jpegQuality = 85
+)
+
+// ErrInvalidImage is returned for files which cannot be decoded as images. Such files never get variants.
+var ErrInvalidImage = errors.New("invalid image")
+
+// Decode decodes the image and turns it upright as told by its EXIF orientation. Metadata is not kept
+// in the decoded image, so it is not carried over to variants.
+func Decode(data []byte) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	if config.Width*config.Height > maxPixels {
+		return nil, fmt.Errorf("%w: image of %dx%d is too large", ErrInvalidImage, config.Width, config.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	return orient(img, exifOrientation(data)), nil
+}
+
+// Variant fits the image into the box of the variant, keeping its aspect ratio, and encodes it in the format
+// of the variant. Images smaller than the box are not enlarged.
+func Variant(img image.Image, spec models.VariantSpec) ([]byte, image.Point, error) {
+	resized := resize.Thumbnail(uint(spec.Width), uint(spec.Height), img, resize.Lanczos3)
+
+	var buf bytes.Buffer
+
+	switch spec.Format {
+	case models.ImageFormatJPEG:
+		if err := jpeg.Encode(&buf, flatten(resized), &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, image.Point{}, fmt.Errorf("cannot encode JPEG: %w", err)
+		}
+	case models.ImageFormatPNG:
+		if err := png.Encode(&buf, resized); err != nil {
+			return nil, image.Point{}, fmt.Errorf("cannot encode PNG: %w", err)
+		}
+	default:
+		return nil, image.Point{}, fmt.Errorf("unknown image format %q", spec.Format)
+	}
+
+	return buf.Bytes(), resized.Bounds().Size(), nil
+}
+
+// flatten draws the image over white background, since JPEG has no transparency
+// and transparent pixels would turn black.
+func flatten(img image.Image) image.Image {
+	bounds := img.Bounds()
+	flat := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+
+	draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), img, bounds.Min, draw.Over)
+
+	return flat
+}
@@ -0,0 +1,143 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// newJPEG encodes the image as JPEG with EXIF metadata which tells the orientation.
+func newJPEG(t *testing.T, img image.Image, orientation uint16) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tiff := []byte("II*\x00\x08\x00\x00\x00\x01\x00")
+	entry := make([]byte, 12)
+	binary.LittleEndian.PutUint16(entry, exifOrientationTag)
+	binary.LittleEndian.PutUint16(entry[2:], exifTypeShort)
+	binary.LittleEndian.PutUint32(entry[4:], 1)
+	binary.LittleEndian.PutUint16(entry[8:], orientation)
+	tiff = append(append(tiff, entry...), 0, 0, 0, 0)
+
+	segment := append(append([]byte{}, exifHeader...), tiff...)
+	app1 := []byte{jpegMarkerPrefix, jpegAPP1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(segment)+2))
+
+	data := buf.Bytes()
+
+	return append(append(append([]byte{}, data[:2]...), append(app1, segment...)...), data[2:]...)
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	wide := image.NewRGBA(image.Rect(0, 0, 40, 20))
+
+	testCases := []struct {
+		name     string
+		data     []byte
+		expected image.Point
+		isErr    bool
+	}{
+		{
+			name:     "Upright image keeps its size",
+			data:     newJPEG(t, wide, 1),
+			expected: image.Point{X: 40, Y: 20},
+			isErr:    false,
+		},
+		{
+			name:     "Rotated image is turned upright",
+			data:     newJPEG(t, wide, 6),
+			expected: image.Point{X: 20, Y: 40},
+			isErr:    false,
+		},
+		{
+			name:     "File is not an image",
+			data:     []byte("just some text"),
+			expected: image.Point{},
+			isErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			img, err := Decode(tc.data)
+			if tc.isErr {
+				assert.True(t, errors.Is(err, ErrInvalidImage))
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, img.Bounds().Size())
+		})
+	}
+}
+
+func TestOrient(t *testing.T) {
+	t.Parallel()
+
+	// The marked pixel is in the top left corner of the stored image.
+	img := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	img.Set(0, 0, color.White)
+
+	testCases := []struct {
+		orientation int
+		marked      image.Point
+	}{
+		{orientation: 1, marked: image.Point{X: 0, Y: 0}},
+		{orientation: 2, marked: image.Point{X: 2, Y: 0}},
+		{orientation: 3, marked: image.Point{X: 2, Y: 1}},
+		{orientation: 4, marked: image.Point{X: 0, Y: 1}},
+		{orientation: 5, marked: image.Point{X: 0, Y: 0}},
+		{orientation: 6, marked: image.Point{X: 1, Y: 0}},
+		{orientation: 7, marked: image.Point{X: 1, Y: 2}},
+		{orientation: 8, marked: image.Point{X: 0, Y: 2}},
+	}
+
+	for _, tc := range testCases {
+		oriented := orient(img, tc.orientation)
+
+		_, _, _, a := oriented.At(tc.marked.X, tc.marked.Y).RGBA()
+		assert.NotZero(t, a, "orientation %d", tc.orientation)
+	}
+}
+
+func TestVariant(t *testing.T) {
+	t.Parallel()
+
+	img, err := Decode(newJPEG(t, image.NewRGBA(image.Rect(0, 0, 400, 200)), 1))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	data, size, err := Variant(img, models.VariantSpec{Name: "thumbnail", Width: 100, Height: 100,
+		Format: models.ImageFormatJPEG})
+	assert.NoError(t, err)
+	assert.Equal(t, image.Point{X: 100, Y: 50}, size)
+	assert.False(t, bytes.Contains(data, exifHeader))
+	assert.Equal(t, 1, exifOrientation(data))
+
+	data, size, err = Variant(img, models.VariantSpec{Name: "large", Width: 1000, Height: 1000,
+		Format: models.ImageFormatPNG})
+	assert.NoError(t, err)
+	assert.Equal(t, image.Point{X: 400, Y: 200}, size)
+
+	_, err = png.Decode(bytes.NewReader(data))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,142 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+)
+
+const (
+	// originalJPEGQuality is higher than the quality of variants, since the original is re-encoded only
+	// to drop its metadata.
+	originalJPEGQuality = 95
+
+	webpHeaderLength = 12
+	webpChunkHeader  = 8
+	webpFlagEXIF     = 0x08
+	webpFlagXMP      = 0x04
+)
+
+// metadataTypes are the media types of images whose metadata is stripped.
+var metadataTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// HasMetadata tells whether files of the media type can carry metadata, such as EXIF with the location
+// where a photo was taken, which is stripped before the file is stored.
+func HasMetadata(contentType string) bool {
+	return metadataTypes[contentType]
+}
+
+// StripMetadata returns the image without its metadata. JPEG, PNG and GIF images are re-encoded, JPEG ones being
+// turned upright first, since their orientation is lost with the metadata. WebP images have their metadata chunks
+// cut out, as there is no pure Go encoder for them. Files of other types are returned as they are.
+func StripMetadata(data []byte, contentType string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	switch contentType {
+	case "image/jpeg":
+		img, err := Decode(data)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: originalJPEGQuality}); err != nil {
+			return nil, fmt.Errorf("cannot encode JPEG: %w", err)
+		}
+	case "image/png":
+		img, err := Decode(data)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, fmt.Errorf("cannot encode PNG: %w", err)
+		}
+	case "image/gif":
+		if err := checkSize(data); err != nil {
+			return nil, err
+		}
+
+		img, err := gif.DecodeAll(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+		}
+
+		if err := gif.EncodeAll(&buf, img); err != nil {
+			return nil, fmt.Errorf("cannot encode GIF: %w", err)
+		}
+	case "image/webp":
+		return stripWebP(data)
+	default:
+		return data, nil
+	}
+
+	return buf.Bytes(), nil
+}
+
+// checkSize refuses images which are not images at all or which are too large to be decoded.
+func checkSize(data []byte) error {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	if config.Width*config.Height > maxPixels {
+		return fmt.Errorf("%w: image of %dx%d is too large", ErrInvalidImage, config.Width, config.Height)
+	}
+
+	return nil
+}
+
+// stripWebP drops the EXIF and XMP chunks of the WebP image and clears the flags which announce them.
+func stripWebP(data []byte) ([]byte, error) {
+	if len(data) < webpHeaderLength || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, fmt.Errorf("%w: not a WebP image", ErrInvalidImage)
+	}
+
+	stripped := append([]byte{}, data[:webpHeaderLength]...)
+
+	for i := webpHeaderLength; i < len(data); {
+		if i+webpChunkHeader > len(data) {
+			return nil, fmt.Errorf("%w: truncated WebP chunk", ErrInvalidImage)
+		}
+
+		fourCC := string(data[i : i+4])
+		end := i + webpChunkHeader + int(binary.LittleEndian.Uint32(data[i+4:]))
+
+		if end%2 == 1 {
+			end++
+		}
+
+		if end > len(data) {
+			end = len(data)
+		}
+
+		switch fourCC {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			chunk := append([]byte{}, data[i:end]...)
+			if len(chunk) > webpChunkHeader {
+				chunk[webpChunkHeader] &^= webpFlagEXIF | webpFlagXMP
+			}
+
+			stripped = append(stripped, chunk...)
+		default:
+			stripped = append(stripped, data[i:end]...)
+		}
+
+		i = end
+	}
+
+	binary.LittleEndian.PutUint32(stripped[4:], uint32(len(stripped)-8))
+
+	return stripped, nil
+}
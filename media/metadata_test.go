@@ -0,0 +1,115 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newWebPChunk encodes the chunk of a WebP file, padding its payload to even length.
+func newWebPChunk(fourCC string, payload []byte) []byte {
+	chunk := append([]byte(fourCC), 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(chunk[4:], uint32(len(payload)))
+	chunk = append(chunk, payload...)
+
+	if len(payload)%2 == 1 {
+		chunk = append(chunk, 0)
+	}
+
+	return chunk
+}
+
+// newWebP encodes the WebP file of the chunks.
+func newWebP(chunks ...[]byte) []byte {
+	data := []byte("RIFF\x00\x00\x00\x00WEBP")
+	for _, chunk := range chunks {
+		data = append(data, chunk...)
+	}
+
+	binary.LittleEndian.PutUint32(data[4:], uint32(len(data)-8))
+
+	return data
+}
+
+func TestStripMetadata(t *testing.T) {
+	t.Parallel()
+
+	wide := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	vp8x := []byte{webpFlagEXIF | webpFlagXMP, 0, 0, 0, 39, 0, 0, 19, 0, 0}
+	vp8l := []byte("pixels")
+
+	testCases := []struct {
+		name        string
+		data        []byte
+		contentType string
+		expected    []byte
+		isErr       bool
+	}{
+		{
+			name: "WebP loses its metadata chunks and flags",
+			data: newWebP(newWebPChunk("VP8X", vp8x), newWebPChunk("VP8L", vp8l),
+				newWebPChunk("EXIF", []byte("gps")), newWebPChunk("XMP ", []byte("<xmp/>"))),
+			contentType: "image/webp",
+			expected:    newWebP(newWebPChunk("VP8X", append([]byte{0}, vp8x[1:]...)), newWebPChunk("VP8L", vp8l)),
+			isErr:       false,
+		},
+		{
+			name:        "Text is kept as it is",
+			data:        []byte("just some text"),
+			contentType: "text/plain",
+			expected:    []byte("just some text"),
+			isErr:       false,
+		},
+		{
+			name:        "Broken JPEG is refused",
+			data:        []byte("\xFF\xD8\xFFjust some text"),
+			contentType: "image/jpeg",
+			expected:    nil,
+			isErr:       true,
+		},
+		{
+			name:        "File is not WebP",
+			data:        []byte("just some text"),
+			contentType: "image/webp",
+			expected:    nil,
+			isErr:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := StripMetadata(tc.data, tc.contentType)
+			if tc.isErr {
+				assert.True(t, errors.Is(err, ErrInvalidImage))
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, data)
+		})
+	}
+
+	t.Run("JPEG loses its EXIF and is turned upright", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := StripMetadata(newJPEG(t, wide, 6), "image/jpeg")
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.False(t, bytes.Contains(data, exifHeader))
+
+		config, _, err := image.DecodeConfig(bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, 20, config.Width)
+		assert.Equal(t, 40, config.Height)
+	})
+}
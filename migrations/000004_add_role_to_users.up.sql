@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role varchar NOT NULL DEFAULT 'user';
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+                                              id uuid PRIMARY KEY,
+                                              user_id uuid REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+                                              family_id uuid NOT NULL,
+                                              token_hash varchar(64) UNIQUE NOT NULL,
+                                              created_at timestamp NOT NULL,
+                                              expires_at timestamp NOT NULL,
+                                              revoked_at timestamp
+);
+CREATE INDEX IF NOT EXISTS refresh_tokens_family_id_idx ON refresh_tokens (family_id);
+CREATE INDEX IF NOT EXISTS refresh_tokens_user_id_idx ON refresh_tokens (user_id);
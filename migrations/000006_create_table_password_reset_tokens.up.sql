@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+                                                     id uuid PRIMARY KEY,
+                                                     user_id uuid REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+                                                     token_hash varchar(64) UNIQUE NOT NULL,
+                                                     created_at timestamp NOT NULL,
+                                                     expires_at timestamp NOT NULL,
+                                                     used_at timestamp
+);
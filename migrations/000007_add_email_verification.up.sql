@@ -0,0 +1,12 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at timestamp;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS pending_email varchar;
+UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL;
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+                                                         id uuid PRIMARY KEY,
+                                                         user_id uuid REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+                                                         email varchar NOT NULL,
+                                                         token_hash varchar(64) UNIQUE NOT NULL,
+                                                         created_at timestamp NOT NULL,
+                                                         expires_at timestamp NOT NULL,
+                                                         used_at timestamp
+);
@@ -0,0 +1,9 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at timestamp;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS deleted_at timestamp;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS deleted_at timestamp;
+CREATE INDEX IF NOT EXISTS users_deleted_at_idx ON users (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS posts_deleted_at_idx ON posts (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS comments_deleted_at_idx ON comments (deleted_at) WHERE deleted_at IS NOT NULL;
+ALTER TABLE comments
+    DROP CONSTRAINT IF EXISTS comments_post_id_fkey,
+    ADD CONSTRAINT comments_post_id_fkey FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE;
@@ -0,0 +1,7 @@
+ALTER TABLE comments
+    DROP CONSTRAINT IF EXISTS comments_created_by_fkey,
+    ADD CONSTRAINT comments_created_by_fkey FOREIGN KEY (created_by) REFERENCES users (id);
+ALTER TABLE posts
+    DROP CONSTRAINT IF EXISTS posts_created_by_fkey,
+    ADD CONSTRAINT posts_created_by_fkey FOREIGN KEY (created_by) REFERENCES users (id);
+DELETE FROM users WHERE id = '00000000-0000-0000-0000-000000000000';
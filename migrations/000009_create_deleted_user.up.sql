@@ -0,0 +1,10 @@
+INSERT INTO users (id, name, email, created_at, updated_at, password, role, email_verified_at)
+VALUES ('00000000-0000-0000-0000-000000000000', 'Deleted user', 'deleted-user@blog-api.local', now(), now(), '',
+        'user', now())
+ON CONFLICT (id) DO NOTHING;
+ALTER TABLE posts
+    DROP CONSTRAINT IF EXISTS posts_created_by_fkey,
+    ADD CONSTRAINT posts_created_by_fkey FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE CASCADE;
+ALTER TABLE comments
+    DROP CONSTRAINT IF EXISTS comments_created_by_fkey,
+    ADD CONSTRAINT comments_created_by_fkey FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE CASCADE;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS comment_revisions;
+DROP TABLE IF EXISTS post_revisions;
+ALTER TABLE comments DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE posts DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,24 @@
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS updated_at timestamp;
+UPDATE posts SET updated_at = created_at WHERE updated_at IS NULL;
+ALTER TABLE posts ALTER COLUMN updated_at SET NOT NULL;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS updated_at timestamp;
+UPDATE comments SET updated_at = created_at WHERE updated_at IS NULL;
+ALTER TABLE comments ALTER COLUMN updated_at SET NOT NULL;
+CREATE TABLE IF NOT EXISTS post_revisions (
+                                              id uuid PRIMARY KEY,
+                                              post_id uuid REFERENCES posts (id) ON DELETE CASCADE NOT NULL,
+                                              edited_by uuid NOT NULL,
+                                              edited_at timestamp NOT NULL,
+                                              title text NOT NULL,
+                                              description text NOT NULL,
+                                              tags json
+);
+CREATE INDEX IF NOT EXISTS post_revisions_post_id_idx ON post_revisions (post_id, edited_at);
+CREATE TABLE IF NOT EXISTS comment_revisions (
+                                                 id uuid PRIMARY KEY,
+                                                 comment_id uuid REFERENCES comments (id) ON DELETE CASCADE NOT NULL,
+                                                 edited_by uuid NOT NULL,
+                                                 edited_at timestamp NOT NULL,
+                                                 content text NOT NULL
+);
+CREATE INDEX IF NOT EXISTS comment_revisions_comment_id_idx ON comment_revisions (comment_id, edited_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS posts_status_idx;
+ALTER TABLE posts DROP COLUMN IF EXISTS status;
@@ -0,0 +1,3 @@
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'published';
+ALTER TABLE posts ALTER COLUMN status SET DEFAULT 'draft';
+CREATE INDEX IF NOT EXISTS posts_status_idx ON posts (status) WHERE deleted_at IS NULL;
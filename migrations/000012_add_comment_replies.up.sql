@@ -0,0 +1,3 @@
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS parent_id uuid REFERENCES comments (id) ON DELETE SET NULL;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS depth integer NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS comments_parent_id_idx ON comments (parent_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS reactions (
+                                         target_type VARCHAR(20) NOT NULL,
+                                         target_id uuid NOT NULL,
+                                         user_id uuid REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+                                         type VARCHAR(20) NOT NULL,
+                                         created_at timestamp NOT NULL,
+                                         PRIMARY KEY (target_type, target_id, user_id, type)
+);
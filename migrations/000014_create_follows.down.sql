@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS posts_created_by_created_at_idx;
+DROP TABLE IF EXISTS follows;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS follows (
+                                       follower_id uuid REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+                                       followee_id uuid REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+                                       created_at timestamp NOT NULL,
+                                       PRIMARY KEY (follower_id, followee_id)
+);
+CREATE INDEX IF NOT EXISTS follows_followee_id_idx ON follows (followee_id, created_at);
+CREATE INDEX IF NOT EXISTS posts_created_by_created_at_idx ON posts (created_by, created_at DESC, id DESC)
+    WHERE deleted_at IS NULL;
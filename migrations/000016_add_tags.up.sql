@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS tags (
+                                    name VARCHAR(30) PRIMARY KEY
+);
+CREATE INDEX IF NOT EXISTS tags_name_prefix_idx ON tags (name varchar_pattern_ops);
+CREATE TABLE IF NOT EXISTS post_tags (
+                                         post_id uuid REFERENCES posts (id) ON DELETE CASCADE NOT NULL,
+                                         tag VARCHAR(30) REFERENCES tags (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                                         position integer NOT NULL,
+                                         PRIMARY KEY (post_id, tag)
+);
+CREATE INDEX IF NOT EXISTS post_tags_tag_idx ON post_tags (tag);
+CREATE TABLE IF NOT EXISTS tag_aliases (
+                                           alias VARCHAR(30) PRIMARY KEY,
+                                           tag VARCHAR(30) REFERENCES tags (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL
+);
+
+INSERT INTO tags (name)
+SELECT DISTINCT left(array_to_string(regexp_split_to_array(lower(trim(t.tag)), '\s+'), '-'), 30)
+FROM posts p,
+     json_array_elements_text(CASE WHEN json_typeof(p.tags) = 'array' THEN p.tags ELSE '[]' END) AS t(tag)
+WHERE trim(t.tag) <> ''
+ON CONFLICT DO NOTHING;
+
+INSERT INTO post_tags (post_id, tag, position)
+SELECT post_id, tag, min(position)
+FROM (SELECT p.id AS post_id,
+             left(array_to_string(regexp_split_to_array(lower(trim(t.tag)), '\s+'), '-'), 30) AS tag,
+             t.position
+      FROM posts p,
+           json_array_elements_text(CASE WHEN json_typeof(p.tags) = 'array' THEN p.tags ELSE '[]' END)
+               WITH ORDINALITY AS t(tag, position)
+      WHERE trim(t.tag) <> '') AS normalized
+GROUP BY post_id, tag
+ON CONFLICT DO NOTHING;
+
+UPDATE posts
+SET tags = (SELECT COALESCE(json_agg(tag ORDER BY position), '[]') FROM post_tags WHERE post_id = posts.id);
@@ -0,0 +1,2 @@
+ALTER TABLE posts DROP COLUMN IF EXISTS last_commented_at;
+ALTER TABLE posts DROP COLUMN IF EXISTS comment_count;
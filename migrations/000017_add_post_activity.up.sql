@@ -0,0 +1,11 @@
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS comment_count integer NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS last_commented_at timestamp;
+
+UPDATE posts
+SET comment_count     = activity.comment_count,
+    last_commented_at = activity.last_commented_at
+FROM (SELECT post_id, count(*) AS comment_count, max(created_at) AS last_commented_at
+      FROM comments
+      WHERE deleted_at IS NULL
+      GROUP BY post_id) AS activity
+WHERE activity.post_id = posts.id;
@@ -0,0 +1,22 @@
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS slug VARCHAR(70);
+
+UPDATE posts
+SET slug = numbered.slug || CASE WHEN numbered.n = 1 THEN '' ELSE '-' || left(numbered.id::text, 8) END
+FROM (SELECT id,
+             slug,
+             row_number() OVER (PARTITION BY slug ORDER BY created_at, id) AS n
+      FROM (SELECT id,
+                   created_at,
+                   COALESCE(NULLIF(trim(BOTH '-' FROM left(regexp_replace(lower(title), '[^a-z0-9]+', '-', 'g'), 60)),
+                                   ''), 'post') AS slug
+            FROM posts) AS base) AS numbered
+WHERE numbered.id = posts.id
+  AND posts.slug IS NULL;
+
+ALTER TABLE posts ALTER COLUMN slug SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS posts_slug_idx ON posts (slug);
+
+CREATE TABLE IF NOT EXISTS post_slug_redirects (
+                                                   slug VARCHAR(70) PRIMARY KEY,
+                                                   post_id uuid REFERENCES posts (id) ON DELETE CASCADE NOT NULL
+);
@@ -0,0 +1,7 @@
+ALTER TABLE comments DROP COLUMN IF EXISTS word_count;
+ALTER TABLE comments DROP COLUMN IF EXISTS content_html;
+ALTER TABLE comments DROP COLUMN IF EXISTS format;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS word_count;
+ALTER TABLE posts DROP COLUMN IF EXISTS description_html;
+ALTER TABLE posts DROP COLUMN IF EXISTS format;
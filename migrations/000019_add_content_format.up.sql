@@ -0,0 +1,36 @@
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS format VARCHAR(20) NOT NULL DEFAULT 'plain';
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS description_html TEXT NOT NULL DEFAULT '';
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS word_count integer NOT NULL DEFAULT 0;
+
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS format VARCHAR(20) NOT NULL DEFAULT 'plain';
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS content_html TEXT NOT NULL DEFAULT '';
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS word_count integer NOT NULL DEFAULT 0;
+
+CREATE OR REPLACE FUNCTION pg_temp.render_plain(text TEXT) RETURNS TEXT AS
+$$
+SELECT CASE
+           WHEN trimmed = '' THEN ''
+           ELSE '<p>' || replace(regexp_replace(
+                   regexp_replace(escaped, '[ \t]*\n\s*\n[ \t]*', chr(1), 'g'),
+                   '[ \t]*\n[ \t]*', '<br>', 'g'), chr(1), E'</p>\n<p>') || E'</p>\n'
+           END
+FROM (SELECT trimmed,
+             replace(replace(replace(replace(replace(trimmed, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'),
+                             '"', '&#34;'), '''', '&#39;') AS escaped
+      FROM (SELECT btrim(replace(text, E'\r\n', E'\n'), E' \t\n') AS trimmed) AS normalized) AS prepared
+$$ LANGUAGE SQL IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION pg_temp.count_words(text TEXT) RETURNS integer AS
+$$
+SELECT count(*)::integer
+FROM regexp_split_to_table(text, '\s+') AS word
+WHERE word ~ '[[:alnum:]]'
+$$ LANGUAGE SQL IMMUTABLE;
+
+UPDATE posts
+SET description_html = pg_temp.render_plain(description),
+    word_count       = pg_temp.count_words(description);
+
+UPDATE comments
+SET content_html = pg_temp.render_plain(content),
+    word_count   = pg_temp.count_words(content);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS attachments (
+                                           id uuid PRIMARY KEY,
+                                           post_id uuid REFERENCES posts (id) ON DELETE CASCADE NOT NULL,
+                                           created_by uuid NOT NULL,
+                                           created_at timestamp NOT NULL,
+                                           file_name VARCHAR(255) NOT NULL,
+                                           content_type VARCHAR(100) NOT NULL,
+                                           size bigint NOT NULL,
+                                           blob_key VARCHAR(100) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS attachments_post_id_idx ON attachments (post_id, created_at);
+CREATE INDEX IF NOT EXISTS attachments_created_by_idx ON attachments (created_by);
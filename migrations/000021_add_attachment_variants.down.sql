@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS attachments_pending_idx;
+ALTER TABLE attachments DROP COLUMN IF EXISTS variants;
+ALTER TABLE attachments DROP COLUMN IF EXISTS variant_status;
@@ -0,0 +1,3 @@
+ALTER TABLE attachments ADD COLUMN IF NOT EXISTS variant_status VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE attachments ADD COLUMN IF NOT EXISTS variants json NOT NULL DEFAULT '[]';
+CREATE INDEX IF NOT EXISTS attachments_pending_idx ON attachments (created_at) WHERE variant_status = 'pending';
@@ -0,0 +1,4 @@
+ALTER TABLE users DROP COLUMN IF EXISTS notification_preferences;
+DROP INDEX IF EXISTS notifications_unread_idx;
+DROP INDEX IF EXISTS notifications_user_id_idx;
+DROP TABLE IF EXISTS notifications;
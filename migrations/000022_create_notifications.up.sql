@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS notifications (
+                                             id uuid PRIMARY KEY,
+                                             user_id uuid REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+                                             type VARCHAR(20) NOT NULL,
+                                             actor_id uuid REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+                                             post_id uuid REFERENCES posts (id) ON DELETE CASCADE,
+                                             comment_id uuid REFERENCES comments (id) ON DELETE CASCADE,
+                                             reaction VARCHAR(20) NOT NULL DEFAULT '',
+                                             created_at timestamp NOT NULL,
+                                             read_at timestamp
+);
+CREATE INDEX IF NOT EXISTS notifications_user_id_idx ON notifications (user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS notifications_unread_idx ON notifications (user_id) WHERE read_at IS NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS notification_preferences json NOT NULL DEFAULT '{}';
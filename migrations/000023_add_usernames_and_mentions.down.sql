@@ -0,0 +1,4 @@
+ALTER TABLE comments DROP COLUMN IF EXISTS mentions;
+ALTER TABLE posts DROP COLUMN IF EXISTS mentions;
+DROP INDEX IF EXISTS users_username_idx;
+ALTER TABLE users DROP COLUMN IF EXISTS username;
@@ -0,0 +1,7 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS username VARCHAR(30);
+UPDATE users SET username = 'deleted' WHERE id = '00000000-0000-0000-0000-000000000000';
+UPDATE users SET username = 'user_' || substr(replace(id::text, '-', ''), 1, 12) WHERE username IS NULL;
+ALTER TABLE users ALTER COLUMN username SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS users_username_idx ON users (username);
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS mentions json NOT NULL DEFAULT '[]';
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS mentions json NOT NULL DEFAULT '[]';
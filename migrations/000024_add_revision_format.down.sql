@@ -0,0 +1,2 @@
+ALTER TABLE comment_revisions DROP COLUMN IF EXISTS format;
+ALTER TABLE post_revisions DROP COLUMN IF EXISTS format;
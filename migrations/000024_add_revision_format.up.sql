@@ -0,0 +1,12 @@
+ALTER TABLE post_revisions ADD COLUMN IF NOT EXISTS format VARCHAR(20) NOT NULL DEFAULT 'plain';
+ALTER TABLE comment_revisions ADD COLUMN IF NOT EXISTS format VARCHAR(20) NOT NULL DEFAULT 'plain';
+
+UPDATE post_revisions
+SET format = posts.format
+FROM posts
+WHERE posts.id = post_revisions.post_id;
+
+UPDATE comment_revisions
+SET format = comments.format
+FROM comments
+WHERE comments.id = comment_revisions.comment_id;
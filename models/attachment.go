@@ -0,0 +1,113 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	maxLengthOfFileName = 255
+
+	// SniffLength is the number of first bytes of a file its media type is detected by.
+	SniffLength = 512
+)
+
+// attachmentContentTypes are the media types of files which can be attached to posts.
+var attachmentContentTypes = []interface{}{
+	"image/png",
+	"image/jpeg",
+	"image/gif",
+	"image/webp",
+	"application/pdf",
+	"text/plain",
+}
+
+// Attachment is a file attached to a post. Its content is kept in the blob store under the blob key.
+type Attachment struct {
+	ID          string    `bson:"_id,omitempty"`
+	PostID      string    `bson:"post_id,omitempty"`
+	CreatedBy   string    `bson:"created_by,omitempty"`
+	CreatedAt   time.Time `bson:"created_at,omitempty"`
+	FileName    string    `bson:"file_name,omitempty"`
+	ContentType string    `bson:"content_type,omitempty"`
+	Size        int64     `bson:"size,omitempty"`
+	BlobKey     string    `bson:"blob_key,omitempty"`
+
+	VariantStatus VariantStatus       `bson:"variant_status,omitempty"`
+	Variants      []AttachmentVariant `bson:"variants,omitempty"`
+
+	// URL is where the content of the attachment is downloaded from. It is not stored.
+	URL string `bson:"-"`
+}
+
+// CreateAttachmentPayload carries a file uploaded to a post. The content is read once, as it is stored.
+type CreateAttachmentPayload struct {
+	PostID   string
+	FileName string
+	Content  io.Reader
+}
+
+// AttachmentConfig limits files attached to posts and tells where their content is served.
+type AttachmentConfig struct {
+	// MaxSize is the maximal size of a file in bytes.
+	MaxSize int64
+	// UserQuota is the maximal size in bytes of all files attached by a user.
+	UserQuota int64
+	// BaseURL is the URL of the REST API, which serves the content of attachments.
+	BaseURL string
+	// Variants are made of attached images.
+	Variants []VariantSpec
+}
+
+func (a *Attachment) Validate() error {
+	err := validation.ValidateStruct(a,
+		validation.Field(&a.PostID, validation.Required),
+		validation.Field(&a.CreatedBy, validation.Required),
+		validation.Field(&a.FileName, validation.Required, validation.Length(1, maxLengthOfFileName)),
+		validation.Field(&a.ContentType, validation.Required, validation.In(attachmentContentTypes...)),
+		validation.Field(&a.Size, validation.Required),
+	)
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return nil
+}
+
+// AttachmentBlobKey returns the key the content of the attachment is stored under.
+func AttachmentBlobKey(attachmentID string) string {
+	return "attachments/" + attachmentID
+}
+
+// AttachmentURL returns the URL the content of the attachment is downloaded from.
+func AttachmentURL(baseURL, attachmentID string) string {
+	return strings.TrimRight(baseURL, "/") + "/attachments/" + attachmentID + "/content"
+}
+
+// SniffContentType detects the media type of a file by its first bytes, so the type claimed by the client
+// is not trusted.
+func SniffContentType(head []byte) string {
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return "application/octet-stream"
+	}
+
+	return mediaType
+}
+
+// CleanFileName keeps only the base name of the uploaded file, dropping any directories the client sent.
+func CleanFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(strings.TrimSpace(name), `\`, "/"))
+	if name == "." || name == "/" {
+		return ""
+	}
+
+	return name
+}
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachment_Validate(t *testing.T) {
+	t.Parallel()
+
+	valid := Attachment{
+		PostID:      "315b6c09-36ff-4519-8579-492f3ae2a3be",
+		CreatedBy:   "a4a4d1f6-0f0a-4bd4-8b1c-6f5ac2b0e0f1",
+		FileName:    "photo.png",
+		ContentType: "image/png",
+		Size:        10,
+	}
+
+	testCases := []struct {
+		name   string
+		modify func(a *Attachment)
+		isErr  bool
+	}{
+		{
+			name:   "Attachment is valid",
+			modify: func(a *Attachment) {},
+			isErr:  false,
+		},
+		{
+			name:   "File name is missing",
+			modify: func(a *Attachment) { a.FileName = "" },
+			isErr:  true,
+		},
+		{
+			name:   "Content type is not allowed",
+			modify: func(a *Attachment) { a.ContentType = "text/html" },
+			isErr:  true,
+		},
+		{
+			name:   "File is empty",
+			modify: func(a *Attachment) { a.Size = 0 },
+			isErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			attachment := valid
+			tc.modify(&attachment)
+
+			err := attachment.Validate()
+			if tc.isErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSniffContentType(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		head     []byte
+		expected string
+	}{
+		{
+			name:     "PNG image",
+			head:     []byte("\x89PNG\x0D\x0A\x1A\x0A\x00\x00\x00\x0DIHDR"),
+			expected: "image/png",
+		},
+		{
+			name:     "Plain text drops charset",
+			head:     []byte("just some text"),
+			expected: "text/plain",
+		},
+		{
+			name:     "HTML is detected regardless of the file name",
+			head:     []byte("<html><script>alert(1)</script></html>"),
+			expected: "text/html",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, SniffContentType(tc.head))
+		})
+	}
+}
+
+func TestCleanFileName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "photo.png", CleanFileName(" photo.png "))
+	assert.Equal(t, "photo.png", CleanFileName("../../photo.png"))
+	assert.Equal(t, "photo.png", CleanFileName(`C:\Users\me\photo.png`))
+	assert.Equal(t, "", CleanFileName("/"))
+}
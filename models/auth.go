@@ -1,6 +1,152 @@
 package models
 
+import (
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+)
+
 type LoginPayload struct {
 	Email    string `bson:"email, omitempty"`
 	Password string `bson:"password, omitempty"`
 }
+
+type AuthInfo struct {
+	UserID    string
+	Role      Role
+	TokenID   string
+	SessionID string
+}
+
+type Tokens struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+// RefreshToken is stored only as a hash. Tokens issued by rotation of the same login share FamilyID,
+// so the whole family can be revoked when a rotated token is reused.
+type RefreshToken struct {
+	ID        string     `bson:"_id,omitempty"`
+	UserID    string     `bson:"user_id,omitempty"`
+	FamilyID  string     `bson:"family_id,omitempty"`
+	TokenHash string     `bson:"token_hash,omitempty"`
+	CreatedAt time.Time  `bson:"created_at,omitempty"`
+	ExpiresAt time.Time  `bson:"expires_at,omitempty"`
+	RevokedAt *time.Time `bson:"revoked_at,omitempty"`
+}
+
+type ChangePasswordPayload struct {
+	OldPassword string
+	NewPassword string
+}
+
+func (p *ChangePasswordPayload) Validate() error {
+	err := validation.ValidateStruct(p,
+		validation.Field(&p.OldPassword, validation.Required),
+		validation.Field(&p.NewPassword, validation.Required, validation.Length(minLengthOfPassword, maxLength)),
+	)
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return nil
+}
+
+type RefreshPayload struct {
+	RefreshToken string
+}
+
+type LogoutPayload struct {
+	RefreshToken string
+}
+
+func (p *RefreshPayload) Validate() error {
+	err := validation.ValidateStruct(p,
+		validation.Field(&p.RefreshToken, validation.Required),
+	)
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return nil
+}
+
+func (p *LogoutPayload) Validate() error {
+	err := validation.ValidateStruct(p,
+		validation.Field(&p.RefreshToken, validation.Required),
+	)
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return nil
+}
+
+type PasswordResetToken struct {
+	ID        string     `bson:"_id,omitempty"`
+	UserID    string     `bson:"user_id,omitempty"`
+	TokenHash string     `bson:"token_hash,omitempty"`
+	CreatedAt time.Time  `bson:"created_at,omitempty"`
+	ExpiresAt time.Time  `bson:"expires_at,omitempty"`
+	UsedAt    *time.Time `bson:"used_at,omitempty"`
+}
+
+// EmailVerificationToken confirms that the user owns Email. It is issued on registration and on email change.
+type EmailVerificationToken struct {
+	ID        string     `bson:"_id,omitempty"`
+	UserID    string     `bson:"user_id,omitempty"`
+	Email     string     `bson:"email,omitempty"`
+	TokenHash string     `bson:"token_hash,omitempty"`
+	CreatedAt time.Time  `bson:"created_at,omitempty"`
+	ExpiresAt time.Time  `bson:"expires_at,omitempty"`
+	UsedAt    *time.Time `bson:"used_at,omitempty"`
+}
+
+type VerifyEmailPayload struct {
+	Token string
+}
+
+type RequestPasswordResetPayload struct {
+	Email string
+}
+
+type ResetPasswordPayload struct {
+	Token       string
+	NewPassword string
+}
+
+func (p *RequestPasswordResetPayload) Validate() error {
+	err := validation.ValidateStruct(p,
+		validation.Field(&p.Email, validation.Required, is.Email),
+	)
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return nil
+}
+
+func (p *ResetPasswordPayload) Validate() error {
+	err := validation.ValidateStruct(p,
+		validation.Field(&p.Token, validation.Required),
+		validation.Field(&p.NewPassword, validation.Required, validation.Length(minLengthOfPassword, maxLength)),
+	)
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return nil
+}
+
+func (p *VerifyEmailPayload) Validate() error {
+	err := validation.ValidateStruct(p,
+		validation.Field(&p.Token, validation.Required),
+	)
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestChangePasswordPayload_Validate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		in         ChangePasswordPayload
+		errMessage string
+	}{
+		{
+			name: "Validation passed",
+			in: ChangePasswordPayload{
+				OldPassword: "old password",
+				NewPassword: "new password",
+			},
+			errMessage: "",
+		},
+		{
+			name: "OldPassword field is empty",
+			in: ChangePasswordPayload{
+				NewPassword: "new password",
+			},
+			errMessage: requiredErrMessage,
+		},
+		{
+			name: "NewPassword field is too short",
+			in: ChangePasswordPayload{
+				OldPassword: "old password",
+				NewPassword: "pass",
+			},
+			errMessage: lengthErrMessage,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.in.Validate()
+			checkValidateErrorMessage(t, tc.errMessage, err)
+		})
+	}
+}
+
+func TestResetPasswordPayload_Validate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		in         ResetPasswordPayload
+		errMessage string
+	}{
+		{
+			name: "Validation passed",
+			in: ResetPasswordPayload{
+				Token:       "token",
+				NewPassword: "password",
+			},
+			errMessage: "",
+		},
+		{
+			name: "Token field is empty",
+			in: ResetPasswordPayload{
+				NewPassword: "password",
+			},
+			errMessage: requiredErrMessage,
+		},
+		{
+			name: "NewPassword field is empty",
+			in: ResetPasswordPayload{
+				Token: "token",
+			},
+			errMessage: requiredErrMessage,
+		},
+		{
+			name: "NewPassword field is too short",
+			in: ResetPasswordPayload{
+				Token:       "token",
+				NewPassword: "pass",
+			},
+			errMessage: lengthErrMessage,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.in.Validate()
+			checkValidateErrorMessage(t, tc.errMessage, err)
+		})
+	}
+}
+
+func TestRequestPasswordResetPayload_Validate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		in         RequestPasswordResetPayload
+		errMessage string
+	}{
+		{
+			name: "Validation passed",
+			in: RequestPasswordResetPayload{
+				Email: "email@mail.com",
+			},
+			errMessage: "",
+		},
+		{
+			name:       "Email field is empty",
+			in:         RequestPasswordResetPayload{},
+			errMessage: requiredErrMessage,
+		},
+		{
+			name: "Email is invalid",
+			in: RequestPasswordResetPayload{
+				Email: "email",
+			},
+			errMessage: isMailErrMessage,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.in.Validate()
+			checkValidateErrorMessage(t, tc.errMessage, err)
+		})
+	}
+}
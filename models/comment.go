@@ -1,6 +1,7 @@
 package models
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -14,47 +15,243 @@ const (
 )
 
 const (
-	SortCommentByCreatedAt SortCommentsByField = "created_at"
+	SortCommentByCreatedAt     SortCommentsByField = "created_at"
+	SortCommentByUpdatedAt     SortCommentsByField = "updated_at"
+	SortCommentByReactionCount SortCommentsByField = "reaction_count"
 )
 
+var commentSortFields = map[string]bool{
+	string(SortCommentByCreatedAt):     true,
+	string(SortCommentByUpdatedAt):     true,
+	string(SortCommentByReactionCount): true,
+}
+
+// MaxCommentDepth is the deepest level of replies. Top-level comments of a post have depth 0.
+const MaxCommentDepth = 5
+
 type Comment struct {
-	ID        string    `bson:"_id,omitempty"`
-	Content   string    `bson:"content,omitempty"`
-	CreatedBy string    `bson:"created_by,omitempty"`
-	CreatedAt time.Time `bson:"created_at,omitempty"`
-	PostID    string    `bson:"post_id,omitempty"`
+	ID        string     `bson:"_id,omitempty"`
+	Content   string     `bson:"content,omitempty"`
+	CreatedBy string     `bson:"created_by,omitempty"`
+	CreatedAt time.Time  `bson:"created_at,omitempty"`
+	PostID    string     `bson:"post_id,omitempty"`
+	UpdatedAt time.Time  `bson:"updated_at,omitempty"`
+	ParentID  *string    `bson:"parent_id,omitempty"`
+	Depth     int        `bson:"depth"`
+	DeletedAt *time.Time `bson:"deleted_at,omitempty"`
+
+	// ContentHTML and WordCount are rendered from the content in its format when it is saved.
+	Format      ContentFormat `bson:"format,omitempty"`
+	ContentHTML string        `bson:"content_html,omitempty"`
+	WordCount   int           `bson:"word_count,omitempty"`
+
+	// Mentions are resolved from the content when it is saved.
+	Mentions []Mention `bson:"mentions,omitempty"`
+
+	// Reactions are counted on request and are not stored with the object.
+	Reactions ReactionCounts `bson:"-" db:"-"`
+
+	// ReactionCount is computed when comments are listed, so comments can be sorted by it.
+	ReactionCount int `bson:"reaction_count,omitempty"`
+}
+
+// IsEdited reports whether the comment was changed after it was created.
+func (c *Comment) IsEdited() bool {
+	return c.UpdatedAt.After(c.CreatedAt)
+}
+
+// Render renders the content of the comment in its format.
+func (c *Comment) Render() {
+	rendered := RenderContent(c.Format, c.Content)
+	c.ContentHTML = rendered.HTML
+	c.WordCount = rendered.WordCount
+}
+
+// ReadingTime returns the minutes it takes to read the content of the comment.
+func (c *Comment) ReadingTime() int {
+	return ReadingTime(c.WordCount)
 }
 
 type CreateCommentPayload struct {
 	Content  string
+	Format   ContentFormat
 	PostID   string
 	AuthorID string
+	ParentID string
+}
+
+// CommentThread is a comment together with all its replies.
+type CommentThread struct {
+	Comment *Comment
+	Replies []*CommentThread
+}
+
+// NewCommentThread builds the thread of the root comment from the root and its descendants.
+// Comments should be ordered so that every parent goes before its replies.
+func NewCommentThread(rootID string, comments []*Comment) *CommentThread {
+	threads := make(map[string]*CommentThread, len(comments))
+
+	var root *CommentThread
+
+	for _, comment := range comments {
+		thread := &CommentThread{
+			Comment: comment,
+			Replies: make([]*CommentThread, 0),
+		}
+		threads[comment.ID] = thread
+
+		if comment.ID == rootID {
+			root = thread
+
+			continue
+		}
+
+		if comment.ParentID == nil {
+			continue
+		}
+
+		if parent, ok := threads[*comment.ParentID]; ok {
+			parent.Replies = append(parent.Replies, thread)
+		}
+	}
+
+	return root
 }
 
+// UpdateCommentPayload describes a partial update of a comment. Nil fields are left unchanged.
 type UpdateCommentPayload struct {
 	CommentID string
-	Content   string
+	Content   *string
+	Format    *ContentFormat
 }
 
 type FilterCommentsByField string
 
+var commentFilterFields = map[FilterCommentsByField]fieldKind{
+	FilterCommentsByAuthor:    fieldKindID,
+	FilterCommentsByCreatedAt: fieldKindTime,
+	FilterCommentsByPost:      fieldKindID,
+}
+
+// CommentCondition compares the field of comments with the values.
+type CommentCondition struct {
+	Field    FilterCommentsByField
+	Operator FilterOperator
+	Values   []string
+}
+
+func (c *CommentCondition) Validate() error {
+	kind, ok := commentFilterFields[c.Field]
+	if !ok {
+		return validation.Errors{
+			"field": fmt.Errorf("comments cannot be filtered by %q", c.Field),
+		}
+	}
+
+	return validateCondition(kind, c.Operator, c.Values)
+}
+
+// Args returns the values converted to the type of the field.
+func (c *CommentCondition) Args() ([]interface{}, error) {
+	return conditionArgs(commentFilterFields[c.Field], c.Values)
+}
+
+// FilterComments matches the comments meeting all conditions which belong to posts seen with the visibility.
 type FilterComments struct {
-	Field FilterCommentsByField
-	Value string
+	Conditions []CommentCondition
+	Visibility PostVisibility
+}
+
+// FilterCommentsBy returns the filter matching the comments with the field equal to the value.
+func FilterCommentsBy(field FilterCommentsByField, value string) FilterComments {
+	return FilterComments{
+		Conditions: []CommentCondition{{Field: field, Operator: FilterOperatorEq, Values: []string{value}}},
+	}
+}
+
+func (f *FilterComments) Validate() error {
+	err := validateConditions(len(f.Conditions), func(i int) error {
+		return f.Conditions[i].Validate()
+	})
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return nil
 }
 
 type SortCommentsByField string
 
-type SortComments struct {
+type SortCommentsKey struct {
 	Field SortCommentsByField
 	IsASC bool
 }
 
+// SortComments sorts comments by the keys in order, each key ordering the comments equal by the previous ones.
+// Comments equal by all keys are ordered by ID. Comments are not sorted when there are no keys.
+type SortComments struct {
+	Keys []SortCommentsKey
+}
+
+// SortCommentsBy returns the sort by a single field.
+func SortCommentsBy(field SortCommentsByField, isASC bool) SortComments {
+	return SortComments{Keys: []SortCommentsKey{{Field: field, IsASC: isASC}}}
+}
+
+func (s *SortComments) Validate() error {
+	if err := validateSortKeys(s.SortKeys(), commentSortFields); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return nil
+}
+
+// SortKeys returns the keys with the fields converted to strings.
+func (s *SortComments) SortKeys() []SortKey {
+	keys := make([]SortKey, 0, len(s.Keys))
+	for _, key := range s.Keys {
+		keys = append(keys, SortKey{Field: string(key.Field), IsASC: key.IsASC})
+	}
+
+	return keys
+}
+
+// sortValue returns the value of the field the comments are sorted by.
+func (c *Comment) sortValue(field SortCommentsByField) interface{} {
+	switch field {
+	case SortCommentByUpdatedAt:
+		return c.UpdatedAt
+	case SortCommentByReactionCount:
+		return c.ReactionCount
+	default:
+		return c.CreatedAt
+	}
+}
+
 func (c *Comment) Validate() error {
-	err := validation.ValidateStruct(c, validation.Field(&c.Content, validation.Required))
+	err := validation.ValidateStruct(c,
+		validation.Field(&c.Content, validation.Required),
+		validation.Field(&c.Format, validation.Required, validation.In(ContentFormatPlain, ContentFormatMarkdown)))
 	if err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
 	return nil
 }
+
+// ValidateParent checks that the comment can be a reply to the parent.
+func (c *Comment) ValidateParent(parent *Comment) error {
+	if parent.PostID != c.PostID {
+		return validation.Errors{
+			"parentId": errors.New("parent comment belongs to other post"),
+		}
+	}
+
+	if parent.Depth+1 > MaxCommentDepth {
+		return validation.Errors{
+			"parentId": fmt.Errorf("replies cannot be nested deeper than %d levels", MaxCommentDepth),
+		}
+	}
+
+	return nil
+}
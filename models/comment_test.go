@@ -2,6 +2,8 @@ package models
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestComment_Validate(t *testing.T) {
@@ -16,6 +18,7 @@ func TestComment_Validate(t *testing.T) {
 			name: "Validation passed",
 			in: Comment{
 				Content: "content",
+				Format:  ContentFormatPlain,
 			},
 			errMessage: "",
 		},
@@ -24,6 +27,14 @@ func TestComment_Validate(t *testing.T) {
 			in:         Comment{},
 			errMessage: requiredErrMessage,
 		},
+		{
+			name: "Format is unknown",
+			in: Comment{
+				Content: "content",
+				Format:  "html",
+			},
+			errMessage: notInErrMessage,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -35,3 +46,66 @@ func TestComment_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestComment_ValidateParent(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		parent     Comment
+		errMessage string
+	}{
+		{
+			name: "Validation passed",
+			parent: Comment{
+				PostID: "post",
+				Depth:  MaxCommentDepth - 1,
+			},
+			errMessage: "",
+		},
+		{
+			name: "Parent belongs to other post",
+			parent: Comment{
+				PostID: "other post",
+			},
+			errMessage: "parent comment belongs to other post",
+		},
+		{
+			name: "Reply is too deep",
+			parent: Comment{
+				PostID: "post",
+				Depth:  MaxCommentDepth,
+			},
+			errMessage: "replies cannot be nested deeper",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			comment := Comment{PostID: "post"}
+			err := comment.ValidateParent(&tc.parent)
+			checkValidateErrorMessage(t, tc.errMessage, err)
+		})
+	}
+}
+
+func TestNewCommentThread(t *testing.T) {
+	t.Parallel()
+
+	rootID, replyID, nestedID := "root", "reply", "nested"
+
+	thread := NewCommentThread(rootID, []*Comment{
+		{ID: rootID},
+		{ID: replyID, ParentID: &rootID, Depth: 1},
+		{ID: nestedID, ParentID: &replyID, Depth: 2},
+	})
+
+	assert.Equal(t, rootID, thread.Comment.ID)
+	assert.Len(t, thread.Replies, 1)
+	assert.Equal(t, replyID, thread.Replies[0].Comment.ID)
+	assert.Len(t, thread.Replies[0].Replies, 1)
+	assert.Equal(t, nestedID, thread.Replies[0].Replies[0].Comment.ID)
+	assert.Empty(t, thread.Replies[0].Replies[0].Replies)
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/russross/blackfriday/v2"
+)
+
+const (
+	ContentFormatPlain    ContentFormat = "plain"
+	ContentFormatMarkdown ContentFormat = "markdown"
+)
+
+const (
+	ContentViewRaw  ContentView = "raw"
+	ContentViewHTML ContentView = "html"
+	ContentViewBoth ContentView = "both"
+)
+
+const wordsPerMinute = 200
+
+// ContentFormat tells how the text of a post or a comment is rendered to HTML.
+type ContentFormat string
+
+// ContentView selects which representations of the content are returned: the raw text, the HTML or both.
+type ContentView string
+
+var (
+	// contentPolicy is the allowlist of elements and attributes which are kept in rendered content.
+	contentPolicy = bluemonday.UGCPolicy()
+
+	// textPolicy strips all elements, so the words of rendered content can be counted.
+	textPolicy = bluemonday.StrictPolicy().AddSpaceWhenStrippingTag(true)
+
+	paragraphBreak = regexp.MustCompile(`\n\s*\n`)
+)
+
+// RenderedContent is the content rendered to HTML which is safe to embed into pages.
+type RenderedContent struct {
+	HTML      string
+	WordCount int
+}
+
+// RenderContent renders the text in the format to sanitized HTML and counts its words.
+// Plain text is escaped and split into paragraphs on blank lines.
+func RenderContent(format ContentFormat, text string) RenderedContent {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+
+	var rendered string
+
+	switch format {
+	case ContentFormatMarkdown:
+		rendered = contentPolicy.Sanitize(string(blackfriday.Run([]byte(text))))
+	default:
+		rendered = renderPlain(text)
+	}
+
+	return RenderedContent{
+		HTML:      rendered,
+		WordCount: countWords(html.UnescapeString(textPolicy.Sanitize(rendered))),
+	}
+}
+
+// countWords counts the words of the text. Punctuation standing apart is not a word.
+func countWords(text string) int {
+	count := 0
+
+	for _, field := range strings.Fields(text) {
+		if strings.IndexFunc(field, isWordRune) >= 0 {
+			count++
+		}
+	}
+
+	return count
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func renderPlain(text string) string {
+	if text == "" {
+		return ""
+	}
+
+	var b strings.Builder
+
+	for _, paragraph := range paragraphBreak.Split(text, -1) {
+		lines := strings.Split(strings.TrimSpace(paragraph), "\n")
+		for i, line := range lines {
+			lines[i] = html.EscapeString(strings.TrimSpace(line))
+		}
+
+		b.WriteString("<p>" + strings.Join(lines, "<br>") + "</p>\n")
+	}
+
+	return b.String()
+}
+
+// OrPlain returns the format, or plain text when the format is not given.
+func (f ContentFormat) OrPlain() ContentFormat {
+	if f == "" {
+		return ContentFormatPlain
+	}
+
+	return f
+}
+
+// ReadingTime returns the minutes it takes to read the words, rounded up.
+func ReadingTime(wordCount int) int {
+	return (wordCount + wordsPerMinute - 1) / wordsPerMinute
+}
+
+// ShowsRaw reports whether the raw text is returned with the view. The empty view returns both.
+func (v ContentView) ShowsRaw() bool {
+	return v != ContentViewHTML
+}
+
+// ShowsHTML reports whether the rendered HTML is returned with the view. The empty view returns both.
+func (v ContentView) ShowsHTML() bool {
+	return v != ContentViewRaw
+}
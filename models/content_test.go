@@ -0,0 +1,76 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderContent(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		format   ContentFormat
+		text     string
+		expected RenderedContent
+	}{
+		{
+			name:   "Plain text is escaped and split into paragraphs",
+			format: ContentFormatPlain,
+			text:   "Hello <b>world</b>\nsecond line\r\n\r\nNext & last",
+			expected: RenderedContent{
+				HTML:      "<p>Hello &lt;b&gt;world&lt;/b&gt;<br>second line</p>\n<p>Next &amp; last</p>\n",
+				WordCount: 6,
+			},
+		},
+		{
+			name:   "Markdown is rendered",
+			format: ContentFormatMarkdown,
+			text:   "# Title\n\nSome *emphasis* and [link](https://example.com).",
+			expected: RenderedContent{
+				HTML: "<h1>Title</h1>\n\n<p>Some <em>emphasis</em> and " +
+					"<a href=\"https://example.com\" rel=\"nofollow\">link</a>.</p>\n",
+				WordCount: 5,
+			},
+		},
+		{
+			name:   "Unsafe markup is removed from markdown",
+			format: ContentFormatMarkdown,
+			text:   "Hi <script>alert(1)</script><a href=\"javascript:alert(1)\" onclick=\"x()\">there</a>",
+			expected: RenderedContent{
+				HTML:      "<p>Hi there</p>\n",
+				WordCount: 2,
+			},
+		},
+		{
+			name:     "Empty text is rendered to nothing",
+			format:   ContentFormatPlain,
+			text:     "  \n ",
+			expected: RenderedContent{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, RenderContent(tc.format, tc.text))
+		})
+	}
+}
+
+func TestReadingTime(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0, ReadingTime(0))
+	assert.Equal(t, 1, ReadingTime(1))
+	assert.Equal(t, 1, ReadingTime(200))
+	assert.Equal(t, 2, ReadingTime(201))
+
+	post := Post{Format: ContentFormatPlain, Description: strings.Repeat("word ", 401)}
+	post.Render()
+	assert.Equal(t, 401, post.WordCount)
+	assert.Equal(t, 3, post.ReadingTime())
+}
@@ -5,4 +5,8 @@ import "errors"
 var (
 	ErrNotFound         = errors.New("not found")
 	ErrNotAuthenticated = errors.New("not authenticated")
+	ErrForbidden        = errors.New("forbidden")
+	ErrTooLarge         = errors.New("too large")
+	ErrQuotaExceeded    = errors.New("quota exceeded")
+	ErrSlugTaken        = errors.New("slug is taken")
 )
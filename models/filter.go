@@ -0,0 +1,107 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	FilterOperatorEq     FilterOperator = "eq"
+	FilterOperatorIn     FilterOperator = "in"
+	FilterOperatorPrefix FilterOperator = "prefix"
+	FilterOperatorGte    FilterOperator = "gte"
+	FilterOperatorLte    FilterOperator = "lte"
+)
+
+const (
+	maxFilterConditions = 10
+	maxFilterValues     = 50
+)
+
+// FilterOperator compares a field of objects with the values of a filter condition.
+// Only FilterOperatorIn takes more than one value.
+type FilterOperator string
+
+// fieldKind tells which operators can be applied to a field and how its values are parsed.
+type fieldKind int
+
+const (
+	fieldKindID fieldKind = iota
+	fieldKindText
+	fieldKindTime
+	// fieldKindCount is a number computed for the objects. Objects can be sorted but not filtered by it.
+	fieldKindCount
+)
+
+var fieldKindOperators = map[fieldKind][]interface{}{
+	fieldKindID:   {FilterOperatorEq, FilterOperatorIn},
+	fieldKindText: {FilterOperatorEq, FilterOperatorIn, FilterOperatorPrefix},
+	fieldKindTime: {FilterOperatorEq, FilterOperatorGte, FilterOperatorLte},
+}
+
+// validateCondition checks that the operator can be applied to the field and that it gets
+// the right number of values. Values of time fields must be formatted as RFC 3339.
+func validateCondition(kind fieldKind, operator FilterOperator, values []string) error {
+	err := validation.Validate(operator, validation.Required, validation.In(fieldKindOperators[kind]...))
+	if err != nil {
+		return validation.Errors{"operator": err}
+	}
+
+	count := validation.Length(1, 1)
+	if operator == FilterOperatorIn {
+		count = validation.Length(1, maxFilterValues)
+	}
+
+	if err := validation.Validate(values, validation.Required, count); err != nil {
+		return validation.Errors{"values": err}
+	}
+
+	if _, err := conditionArgs(kind, values); err != nil {
+		return validation.Errors{"values": fmt.Errorf("must be a time in RFC 3339 format")}
+	}
+
+	return nil
+}
+
+// conditionArgs converts the values of a condition to the type of the field.
+func conditionArgs(kind fieldKind, values []string) ([]interface{}, error) {
+	args := make([]interface{}, 0, len(values))
+
+	for _, value := range values {
+		if kind != fieldKindTime {
+			args = append(args, value)
+
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse time %q: %w", value, err)
+		}
+
+		args = append(args, t)
+	}
+
+	return args, nil
+}
+
+// validateConditions validates every condition of a filter.
+func validateConditions(count int, validate func(i int) error) error {
+	if count > maxFilterConditions {
+		return validation.Errors{
+			"conditions": fmt.Errorf("the length must be no more than %d", maxFilterConditions),
+		}
+	}
+
+	for i := 0; i < count; i++ {
+		if err := validate(i); err != nil {
+			return validation.Errors{
+				fmt.Sprintf("conditions.%d", i): err,
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterPosts_Validate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		filter FilterPosts
+		isErr  bool
+	}{
+		{
+			name: "Posts are filtered by author, tags and creation time",
+			filter: FilterPosts{Conditions: []PostCondition{
+				{Field: FilterPostsByCreatedBy, Operator: FilterOperatorEq, Values: []string{"author"}},
+				{Field: FilterPostsByTags, Operator: FilterOperatorIn, Values: []string{"go", "api"}},
+				{Field: FilterPostsByCreatedAt, Operator: FilterOperatorGte, Values: []string{"2021-06-01T00:00:00Z"}},
+			}},
+			isErr: false,
+		},
+		{
+			name: "Field is unknown",
+			filter: FilterPosts{Conditions: []PostCondition{
+				{Field: "deleted_at", Operator: FilterOperatorEq, Values: []string{"2021-06-01T00:00:00Z"}},
+			}},
+			isErr: true,
+		},
+		{
+			name: "Operator cannot be applied to field",
+			filter: FilterPosts{Conditions: []PostCondition{
+				{Field: FilterPostsByCreatedAt, Operator: FilterOperatorPrefix, Values: []string{"2021"}},
+			}},
+			isErr: true,
+		},
+		{
+			name: "Equality takes a single value",
+			filter: FilterPosts{Conditions: []PostCondition{
+				{Field: FilterPostsByStatus, Operator: FilterOperatorEq, Values: []string{"draft", "published"}},
+			}},
+			isErr: true,
+		},
+		{
+			name: "Time is not in RFC 3339 format",
+			filter: FilterPosts{Conditions: []PostCondition{
+				{Field: FilterPostsByCreatedAt, Operator: FilterOperatorLte, Values: []string{"yesterday"}},
+			}},
+			isErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.filter.Validate()
+			if tc.isErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCommentCondition_Args(t *testing.T) {
+	t.Parallel()
+
+	condition := CommentCondition{
+		Field:    FilterCommentsByCreatedAt,
+		Operator: FilterOperatorLte,
+		Values:   []string{"2021-06-01T10:00:00Z"},
+	}
+
+	args, err := condition.Args()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)}, args)
+}
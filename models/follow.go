@@ -0,0 +1,34 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// Follow puts published posts of the followed user into the home feed of the follower.
+type Follow struct {
+	FollowerID string    `bson:"follower_id,omitempty"`
+	FolloweeID string    `bson:"followee_id,omitempty"`
+	CreatedAt  time.Time `bson:"created_at,omitempty"`
+}
+
+func (f *Follow) Validate() error {
+	err := validation.ValidateStruct(f,
+		validation.Field(&f.FollowerID, validation.Required),
+		validation.Field(&f.FolloweeID, validation.Required),
+	)
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if f.FollowerID == f.FolloweeID {
+		return validation.Errors{
+			"userId": errors.New("users cannot follow themselves"),
+		}
+	}
+
+	return nil
+}
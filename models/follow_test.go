@@ -0,0 +1,51 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFollow_Validate(t *testing.T) {
+	t.Parallel()
+
+	userID := "315b6c09-36ff-4519-8579-492f3ae2a3be"
+
+	testCases := []struct {
+		name   string
+		follow Follow
+		isErr  bool
+	}{
+		{
+			name:   "User follows other user",
+			follow: Follow{FollowerID: userID, FolloweeID: "40d2f213-8e41-4abf-ad54-410997b19401"},
+			isErr:  false,
+		},
+		{
+			name:   "User follows themselves",
+			follow: Follow{FollowerID: userID, FolloweeID: userID},
+			isErr:  true,
+		},
+		{
+			name:   "Followed user is empty",
+			follow: Follow{FollowerID: userID},
+			isErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.follow.Validate()
+			if tc.isErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
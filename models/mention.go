@@ -0,0 +1,62 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+)
+
+// mentionPattern matches @username which does not continue a word, so emails are not taken for mentions.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z0-9_]{3,30})\b`)
+
+// Mention links the user mentioned with @username in a post or a comment.
+type Mention struct {
+	UserID   string `bson:"user_id" json:"userId"`
+	Username string `bson:"username" json:"username"`
+}
+
+// ParseMentions returns the usernames mentioned in the text in the order they first appear.
+func ParseMentions(text string) []string {
+	var usernames []string
+
+	seen := make(map[string]bool)
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		username := NormalizeUsername(match[1])
+		if seen[username] {
+			continue
+		}
+
+		seen[username] = true
+		usernames = append(usernames, username)
+	}
+
+	return usernames
+}
+
+// AddedMentions returns the mentions which are not among the previous ones.
+func AddedMentions(mentions, previous []Mention) []Mention {
+	var added []Mention
+
+	for _, mention := range mentions {
+		if !containsMention(previous, mention.UserID) {
+			added = append(added, mention)
+		}
+	}
+
+	return added
+}
+
+func containsMention(mentions []Mention, userID string) bool {
+	for _, mention := range mentions {
+		if mention.UserID == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NormalizeUsername brings the username to the form it is stored in. Usernames are not case-sensitive.
+func NormalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
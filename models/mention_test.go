@@ -0,0 +1,57 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMentions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		in       string
+		expected []string
+	}{
+		{
+			name:     "Mentions are parsed in order",
+			in:       "@alice meets @Bob_1 and @alice again",
+			expected: []string{"alice", "bob_1"},
+		},
+		{
+			name:     "Mentions are followed by punctuation",
+			in:       "Thanks, @alice! (cc @bob).",
+			expected: []string{"alice", "bob"},
+		},
+		{
+			name:     "Emails are not mentions",
+			in:       "write to alice@mail.com",
+			expected: nil,
+		},
+		{
+			name:     "Usernames are too short",
+			in:       "@al and @@bob",
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, ParseMentions(tc.in))
+		})
+	}
+}
+
+func TestAddedMentions(t *testing.T) {
+	t.Parallel()
+
+	alice := Mention{UserID: "1", Username: "alice"}
+	bob := Mention{UserID: "2", Username: "bob"}
+
+	assert.Equal(t, []Mention{bob}, AddedMentions([]Mention{alice, bob}, []Mention{alice}))
+	assert.Nil(t, AddedMentions([]Mention{alice}, []Mention{alice, bob}))
+}
@@ -10,6 +10,7 @@ const (
 	requiredErrMessage = "cannot be blank"
 	lengthErrMessage   = "the length must be between"
 	isMailErrMessage   = "must be a valid email address"
+	notInErrMessage    = "must be a valid value"
 )
 
 func checkValidateErrorMessage(t *testing.T, errMessage string, err error) {
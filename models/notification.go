@@ -0,0 +1,105 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	NotificationComment  NotificationType = "comment"
+	NotificationReply    NotificationType = "reply"
+	NotificationReaction NotificationType = "reaction"
+	NotificationFollow   NotificationType = "follow"
+	NotificationMention  NotificationType = "mention"
+)
+
+// NotificationTypes lists the types of notifications users can turn on and off.
+var NotificationTypes = []NotificationType{
+	NotificationComment, NotificationReply, NotificationReaction, NotificationFollow, NotificationMention,
+}
+
+type NotificationType string
+
+// Notification tells the user about an action of another user, the actor. PostID and CommentID point at
+// the objects the action concerns, and Reaction is set for reactions only.
+type Notification struct {
+	ID        string           `bson:"_id,omitempty"`
+	UserID    string           `bson:"user_id,omitempty"`
+	Type      NotificationType `bson:"type,omitempty"`
+	ActorID   string           `bson:"actor_id,omitempty"`
+	PostID    string           `bson:"post_id,omitempty"`
+	CommentID string           `bson:"comment_id,omitempty"`
+	Reaction  ReactionType     `bson:"reaction,omitempty"`
+	CreatedAt time.Time        `bson:"created_at,omitempty"`
+	ReadAt    *time.Time       `bson:"read_at,omitempty"`
+}
+
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+// NotificationList is a page of notifications of the user with the number of all unread ones.
+type NotificationList struct {
+	Notifications []*Notification
+	UnreadCount   int
+}
+
+// NotificationPreferences tells which types of notifications the user receives.
+// Types which are not listed are received.
+type NotificationPreferences map[NotificationType]bool
+
+// Enabled reports whether notifications of the type are received.
+func (p NotificationPreferences) Enabled(t NotificationType) bool {
+	enabled, ok := p[t]
+
+	return !ok || enabled
+}
+
+// Merge returns the preferences with the changes applied.
+func (p NotificationPreferences) Merge(changes NotificationPreferences) NotificationPreferences {
+	merged := make(NotificationPreferences, len(p)+len(changes))
+
+	for t, enabled := range p {
+		merged[t] = enabled
+	}
+
+	for t, enabled := range changes {
+		merged[t] = enabled
+	}
+
+	return merged
+}
+
+// All returns the preferences for every type of notifications.
+func (p NotificationPreferences) All() NotificationPreferences {
+	all := make(NotificationPreferences, len(NotificationTypes))
+
+	for _, t := range NotificationTypes {
+		all[t] = p.Enabled(t)
+	}
+
+	return all
+}
+
+func (p NotificationPreferences) Validate() error {
+	for t := range p {
+		if err := validation.Validate(t, validation.In(notificationTypeValues()...)); err != nil {
+			return fmt.Errorf("validation failed: %w", validation.Errors{
+				string(t): err,
+			})
+		}
+	}
+
+	return nil
+}
+
+func notificationTypeValues() []interface{} {
+	values := make([]interface{}, 0, len(NotificationTypes))
+	for _, t := range NotificationTypes {
+		values = append(values, t)
+	}
+
+	return values
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationPreferences_Validate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		preferences NotificationPreferences
+		isErr       bool
+	}{
+		{
+			name:        "Known types are valid",
+			preferences: NotificationPreferences{NotificationComment: false, NotificationFollow: true},
+			isErr:       false,
+		},
+		{
+			name:        "Empty preferences are valid",
+			preferences: NotificationPreferences{},
+			isErr:       false,
+		},
+		{
+			name:        "Unknown type is invalid",
+			preferences: NotificationPreferences{"digest": false},
+			isErr:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.preferences.Validate()
+			if tc.isErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNotificationPreferences_Merge(t *testing.T) {
+	t.Parallel()
+
+	preferences := NotificationPreferences{NotificationComment: false, NotificationFollow: false}
+
+	merged := preferences.Merge(NotificationPreferences{NotificationFollow: true, NotificationReply: false})
+
+	assert.False(t, merged.Enabled(NotificationComment))
+	assert.True(t, merged.Enabled(NotificationFollow))
+	assert.False(t, merged.Enabled(NotificationReply))
+	assert.True(t, merged.Enabled(NotificationReaction))
+	assert.False(t, preferences.Enabled(NotificationFollow))
+	assert.Len(t, merged.All(), len(NotificationTypes))
+}
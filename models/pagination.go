@@ -1,6 +1,217 @@
 package models
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// Pagination selects a page of a list. Lists of posts and comments can also be paged with the cursors,
+// which keep the pages stable while new objects are added. The offset is ignored when a cursor is given.
 type Pagination struct {
 	Limit  uint64
 	Offset uint64
+
+	// After selects the objects following the cursor, Before selects the objects preceding it.
+	After  *Cursor
+	Before *Cursor
+
+	// CountTotal asks to count all objects matching the filter.
+	CountTotal bool
+}
+
+// IsKeyset reports whether the page is selected by a cursor.
+func (p *Pagination) IsKeyset() bool {
+	return p.After != nil || p.Before != nil
+}
+
+// Validate checks that the cursors point into the list sorted by the keys.
+func (p *Pagination) Validate(sort []SortKey) error {
+	if p.After != nil && p.Before != nil {
+		return validation.Errors{
+			"cursor": errors.New("only one of after and before cursors can be given"),
+		}
+	}
+
+	for _, cursor := range []*Cursor{p.After, p.Before} {
+		if cursor != nil && sortSpec(cursor.Sort) != sortSpec(sort) {
+			return validation.Errors{
+				"cursor": fmt.Errorf("cursor was made for the list sorted by %s", sortSpec(cursor.Sort)),
+			}
+		}
+	}
+
+	return nil
+}
+
+// Lookahead returns the pagination fetching one more object past the page, which tells
+// whether there are objects after it.
+func (p Pagination) Lookahead() Pagination {
+	if p.Limit != 0 {
+		p.Limit++
+	}
+
+	return p
+}
+
+// PageBounds returns the range of the fetched objects which belongs to the page and tells whether
+// there are objects before and after the page. The objects must be fetched with one extra object
+// past the limit, which is the first one when the page is selected by the Before cursor.
+func (p *Pagination) PageBounds(fetched int) (from, to int, hasPrev, hasNext bool) {
+	from, to = 0, fetched
+	hasPrev = p.After != nil || (p.Before == nil && p.Offset != 0)
+	hasNext = p.Before != nil
+
+	if p.Limit == 0 || uint64(fetched) <= p.Limit {
+		return from, to, hasPrev, hasNext
+	}
+
+	if p.Before != nil {
+		return 1, fetched, true, hasNext
+	}
+
+	return 0, fetched - 1, hasPrev, true
+}
+
+// Cursor points at an object in a sorted list by the values of the sort fields and the ID of the object.
+type Cursor struct {
+	Sort []SortKey
+	Keys []string
+	ID   string
+}
+
+// cursorToken is the encoded form of a cursor.
+type cursorToken struct {
+	Sort string   `json:"s"`
+	Keys []string `json:"k"`
+	ID   string   `json:"id"`
+}
+
+// NewPostCursor returns the cursor pointing at the post in the list sorted in the given way.
+func NewPostCursor(post *Post, sort SortPosts) *Cursor {
+	keys := make([]string, 0, len(sort.Keys))
+	for _, key := range sort.Keys {
+		keys = append(keys, sortValue(post.sortValue(key.Field)))
+	}
+
+	return &Cursor{
+		Sort: sort.SortKeys(),
+		Keys: keys,
+		ID:   post.ID,
+	}
+}
+
+// NewCommentCursor returns the cursor pointing at the comment in the list sorted in the given way.
+func NewCommentCursor(comment *Comment, sort SortComments) *Cursor {
+	keys := make([]string, 0, len(sort.Keys))
+	for _, key := range sort.Keys {
+		keys = append(keys, sortValue(comment.sortValue(key.Field)))
+	}
+
+	return &Cursor{
+		Sort: sort.SortKeys(),
+		Keys: keys,
+		ID:   comment.ID,
+	}
+}
+
+// String encodes the cursor into an opaque token. It returns an empty string for the nil cursor.
+func (c *Cursor) String() string {
+	if c == nil {
+		return ""
+	}
+
+	token, err := json.Marshal(cursorToken{
+		Sort: sortSpec(c.Sort),
+		Keys: c.Keys,
+		ID:   c.ID,
+	})
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token)
+}
+
+// KeyArgs returns the values of the sort fields converted to their types.
+func (c *Cursor) KeyArgs() ([]interface{}, error) {
+	if len(c.Keys) != len(c.Sort) {
+		return nil, fmt.Errorf("cursor has %d keys for %d sort fields", len(c.Keys), len(c.Sort))
+	}
+
+	args := make([]interface{}, 0, len(c.Keys))
+
+	for i, key := range c.Keys {
+		arg, err := sortArg(c.Sort[i].Field, key)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse key of cursor: %w", err)
+		}
+
+		args = append(args, arg)
+	}
+
+	return args, nil
+}
+
+// ParseCursor decodes the token made by Cursor.String. The empty token gives the nil cursor.
+func ParseCursor(token string) (*Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	errInvalid := validation.Errors{
+		"cursor": errors.New("cursor is invalid"),
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errInvalid
+	}
+
+	var t cursorToken
+	if err := json.Unmarshal(decoded, &t); err != nil || t.ID == "" {
+		return nil, errInvalid
+	}
+
+	sort, err := parseSortSpec(t.Sort)
+	if err != nil {
+		return nil, errInvalid
+	}
+
+	cursor := &Cursor{
+		Sort: sort,
+		Keys: t.Keys,
+		ID:   t.ID,
+	}
+
+	if _, err := cursor.KeyArgs(); err != nil {
+		return nil, errInvalid
+	}
+
+	return cursor, nil
+}
+
+// PageInfo tells where the page is in the list. The cursors are nil when there are no objects
+// in their direction. Total is set only when it is asked for.
+type PageInfo struct {
+	Next  *Cursor
+	Prev  *Cursor
+	Total *int
+}
+
+// PostsPage is a page of the list of posts sorted by Sort.
+type PostsPage struct {
+	Posts []*Post
+	Sort  SortPosts
+	PageInfo
+}
+
+// CommentsPage is a page of the list of comments sorted by Sort.
+type CommentsPage struct {
+	Comments []*Comment
+	Sort     SortComments
+	PageInfo
 }
@@ -0,0 +1,205 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCursor(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2021, 6, 1, 12, 30, 0, 500, time.UTC)
+	byCommentsAndTime := SortPosts{Keys: []SortPostsKey{
+		{Field: SortPostsByCommentCount, IsASC: false},
+		{Field: SortPostsByCreatedAt, IsASC: true},
+	}}
+
+	testCases := []struct {
+		name   string
+		token  string
+		cursor *Cursor
+		isErr  bool
+	}{
+		{
+			name:  "Cursor of the list sorted by the number of comments and creation time",
+			token: NewPostCursor(&Post{ID: "id", CommentCount: 12, CreatedAt: createdAt}, byCommentsAndTime).String(),
+			cursor: &Cursor{
+				Sort: []SortKey{{Field: "comment_count", IsASC: false}, {Field: "created_at", IsASC: true}},
+				Keys: []string{"12", "2021-06-01T12:30:00.0000005Z"},
+				ID:   "id",
+			},
+			isErr: false,
+		},
+		{
+			name:  "Key contains separators",
+			token: NewPostCursor(&Post{ID: "id", Title: "a|b,c:d"}, SortPostsBy(SortPostsByTitle, true)).String(),
+			cursor: &Cursor{
+				Sort: []SortKey{{Field: "title", IsASC: true}},
+				Keys: []string{"a|b,c:d"},
+				ID:   "id",
+			},
+			isErr: false,
+		},
+		{
+			name:   "Token is empty",
+			token:  "",
+			cursor: nil,
+			isErr:  false,
+		},
+		{
+			name:  "Token is not encoded",
+			token: "created_at|2021|id",
+			isErr: true,
+		},
+		{
+			name: "Time of the key is invalid",
+			token: (&Cursor{
+				Sort: []SortKey{{Field: "created_at", IsASC: true}},
+				Keys: []string{"today"},
+				ID:   "id",
+			}).String(),
+			isErr: true,
+		},
+		{
+			name: "Number of keys differs from the sort",
+			token: (&Cursor{
+				Sort: []SortKey{{Field: "comment_count", IsASC: true}, {Field: "title", IsASC: true}},
+				Keys: []string{"1"},
+				ID:   "id",
+			}).String(),
+			isErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cursor, err := ParseCursor(tc.token)
+			if tc.isErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.cursor, cursor)
+		})
+	}
+}
+
+func TestPagination_PageBounds(t *testing.T) {
+	t.Parallel()
+
+	cursor := &Cursor{Sort: []SortKey{{Field: "created_at", IsASC: true}}, Keys: []string{"2021-06-01T12:30:00Z"}, ID: "id"}
+
+	testCases := []struct {
+		name       string
+		pagination Pagination
+		fetched    int
+		from, to   int
+		hasPrev    bool
+		hasNext    bool
+	}{
+		{
+			name:       "First page is followed by more objects",
+			pagination: Pagination{Limit: 2},
+			fetched:    3,
+			from:       0,
+			to:         2,
+			hasPrev:    false,
+			hasNext:    true,
+		},
+		{
+			name:       "Last page after the cursor",
+			pagination: Pagination{Limit: 2, After: cursor},
+			fetched:    2,
+			from:       0,
+			to:         2,
+			hasPrev:    true,
+			hasNext:    false,
+		},
+		{
+			name:       "Page before the cursor is preceded by more objects",
+			pagination: Pagination{Limit: 2, Before: cursor},
+			fetched:    3,
+			from:       1,
+			to:         3,
+			hasPrev:    true,
+			hasNext:    true,
+		},
+		{
+			name:       "Page is selected by the offset",
+			pagination: Pagination{Limit: 2, Offset: 4},
+			fetched:    1,
+			from:       0,
+			to:         1,
+			hasPrev:    true,
+			hasNext:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			from, to, hasPrev, hasNext := tc.pagination.PageBounds(tc.fetched)
+			assert.Equal(t, tc.from, from)
+			assert.Equal(t, tc.to, to)
+			assert.Equal(t, tc.hasPrev, hasPrev)
+			assert.Equal(t, tc.hasNext, hasNext)
+		})
+	}
+}
+
+func TestSortPosts_Validate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		sort  SortPosts
+		isErr bool
+	}{
+		{
+			name: "Posts are sorted by the number of reactions, then by the title",
+			sort: SortPosts{Keys: []SortPostsKey{
+				{Field: SortPostsByReactionCount, IsASC: false},
+				{Field: SortPostsByTitle, IsASC: true},
+			}},
+			isErr: false,
+		},
+		{
+			name:  "Field is unknown",
+			sort:  SortPostsBy("status", true),
+			isErr: true,
+		},
+		{
+			name: "Field is repeated",
+			sort: SortPosts{Keys: []SortPostsKey{
+				{Field: SortPostsByCreatedAt, IsASC: false},
+				{Field: SortPostsByCreatedAt, IsASC: true},
+			}},
+			isErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.sort.Validate()
+			if tc.isErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
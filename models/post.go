@@ -13,57 +13,251 @@ const (
 	FilterPostsByTitle     FilterPostsByField = "title"
 	FilterPostsByCreatedBy FilterPostsByField = "created_by"
 	FilterPostsByTags      FilterPostsByField = "tags"
+	FilterPostsByStatus    FilterPostsByField = "status"
+	FilterPostsByCreatedAt FilterPostsByField = "created_at"
 )
 
 const (
-	SortPostsByTitle     SortPostsByField = "title"
-	SortPostsByCreatedAt SortPostsByField = "created_at"
+	SortPostsByTitle         SortPostsByField = "title"
+	SortPostsByCreatedAt     SortPostsByField = "created_at"
+	SortPostsByUpdatedAt     SortPostsByField = "updated_at"
+	SortPostsByCommentCount  SortPostsByField = "comment_count"
+	SortPostsByReactionCount SortPostsByField = "reaction_count"
 )
 
+var postSortFields = map[string]bool{
+	string(SortPostsByTitle):         true,
+	string(SortPostsByCreatedAt):     true,
+	string(SortPostsByUpdatedAt):     true,
+	string(SortPostsByCommentCount):  true,
+	string(SortPostsByReactionCount): true,
+}
+
+const (
+	PostStatusDraft     PostStatus = "draft"
+	PostStatusInReview  PostStatus = "in_review"
+	PostStatusPublished PostStatus = "published"
+	PostStatusArchived  PostStatus = "archived"
+)
+
+// PostStatus is a stage of the post lifecycle. Only published posts are visible to everyone.
+type PostStatus string
+
+// postStatusTransitions lists the statuses each status can be changed to.
+var postStatusTransitions = map[PostStatus][]PostStatus{
+	PostStatusDraft:     {PostStatusInReview},
+	PostStatusInReview:  {PostStatusPublished, PostStatusDraft},
+	PostStatusPublished: {PostStatusArchived, PostStatusDraft},
+	PostStatusArchived:  {},
+}
+
 type Post struct {
-	ID          string    `bson:"_id,omitempty"`
-	Title       string    `bson:"title,omitempty"`
-	Description string    `bson:"description,omitempty"`
-	CreatedBy   string    `bson:"created_by,omitempty"`
-	CreatedAt   time.Time `bson:"created_at,omitempty"`
-	Tags        []string  `bson:"tags,omitempty"`
+	ID          string     `bson:"_id,omitempty"`
+	Title       string     `bson:"title,omitempty"`
+	Description string     `bson:"description,omitempty"`
+	CreatedBy   string     `bson:"created_by,omitempty"`
+	CreatedAt   time.Time  `bson:"created_at,omitempty"`
+	Tags        []string   `bson:"tags,omitempty"`
+	UpdatedAt   time.Time  `bson:"updated_at,omitempty"`
+	Status      PostStatus `bson:"status,omitempty"`
+	Slug        string     `bson:"slug,omitempty"`
+	DeletedAt   *time.Time `bson:"deleted_at,omitempty"`
+
+	// DescriptionHTML and WordCount are rendered from the description in its format when it is saved.
+	Format          ContentFormat `bson:"format,omitempty"`
+	DescriptionHTML string        `bson:"description_html,omitempty"`
+	WordCount       int           `bson:"word_count,omitempty"`
+
+	// Mentions are resolved from the description when it is saved.
+	Mentions []Mention `bson:"mentions,omitempty"`
+
+	// Reactions are counted on request and are not stored with the object.
+	Reactions ReactionCounts `bson:"-" db:"-"`
+
+	// CommentCount and LastCommentedAt are kept up to date when comments are created, deleted or restored.
+	CommentCount    int        `bson:"comment_count"`
+	LastCommentedAt *time.Time `bson:"last_commented_at,omitempty"`
+
+	// ReactionCount is computed when posts are listed, so posts can be sorted by it.
+	ReactionCount int `bson:"reaction_count,omitempty"`
+}
+
+// IsEdited reports whether the post was changed after it was created.
+func (p *Post) IsEdited() bool {
+	return p.UpdatedAt.After(p.CreatedAt)
+}
+
+// Render renders the description of the post in its format.
+func (p *Post) Render() {
+	rendered := RenderContent(p.Format, p.Description)
+	p.DescriptionHTML = rendered.HTML
+	p.WordCount = rendered.WordCount
+}
+
+// ReadingTime returns the minutes it takes to read the description of the post.
+func (p *Post) ReadingTime() int {
+	return ReadingTime(p.WordCount)
 }
 
 type CreatePostPayload struct {
 	Title       string
 	Description string
+	Format      ContentFormat
 	Tags        []string
 	AuthorID    string
 }
 
+// UpdatePostPayload describes a partial update of a post. Nil fields are left unchanged.
 type UpdatePostPayload struct {
 	PostID      string
-	Title       string
-	Description string
-	Tags        []string
+	Title       *string
+	Description *string
+	Format      *ContentFormat
+	Tags        *[]string
 }
 
 type FilterPostsByField string
 
+var postFilterFields = map[FilterPostsByField]fieldKind{
+	FilterPostsByTitle:     fieldKindText,
+	FilterPostsByCreatedBy: fieldKindID,
+	FilterPostsByTags:      fieldKindText,
+	FilterPostsByStatus:    fieldKindID,
+	FilterPostsByCreatedAt: fieldKindTime,
+}
+
+// PostCondition compares the field of posts with the values.
+type PostCondition struct {
+	Field    FilterPostsByField
+	Operator FilterOperator
+	Values   []string
+}
+
+func (c *PostCondition) Validate() error {
+	kind, ok := postFilterFields[c.Field]
+	if !ok {
+		return validation.Errors{
+			"field": fmt.Errorf("posts cannot be filtered by %q", c.Field),
+		}
+	}
+
+	return validateCondition(kind, c.Operator, c.Values)
+}
+
+// Args returns the values converted to the type of the field.
+func (c *PostCondition) Args() ([]interface{}, error) {
+	return conditionArgs(postFilterFields[c.Field], c.Values)
+}
+
+// FilterPosts matches the posts meeting all conditions which can be seen with the visibility.
+// Only posts of the users followed by the user with the FollowedBy ID are matched when it is given.
 type FilterPosts struct {
-	Field FilterPostsByField
-	Value string
+	Conditions []PostCondition
+	Visibility PostVisibility
+	FollowedBy string
+}
+
+// FilterPostsBy returns the filter matching the posts with the field equal to the value.
+func FilterPostsBy(field FilterPostsByField, value string) FilterPosts {
+	return FilterPosts{
+		Conditions: []PostCondition{{Field: field, Operator: FilterOperatorEq, Values: []string{value}}},
+	}
+}
+
+func (f *FilterPosts) Validate() error {
+	err := validateConditions(len(f.Conditions), func(i int) error {
+		return f.Conditions[i].Validate()
+	})
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return nil
+}
+
+// PostVisibility limits posts to the ones with one of the statuses and the ones created by the owner.
+// The zero value does not limit posts.
+type PostVisibility struct {
+	OwnerID  string
+	Statuses []PostStatus
 }
 
 type SortPostsByField string
 
+type SortPostsKey struct {
+	Field SortPostsByField
+	IsASC bool
+}
+
+// SortPosts sorts posts by the keys in order, each key ordering the posts equal by the previous ones.
+// Posts equal by all keys are ordered by ID. Posts are not sorted when there are no keys.
 type SortPosts struct {
-	SortByField SortPostsByField
-	IsASC       bool
+	Keys []SortPostsKey
+}
+
+// SortPostsBy returns the sort by a single field.
+func SortPostsBy(field SortPostsByField, isASC bool) SortPosts {
+	return SortPosts{Keys: []SortPostsKey{{Field: field, IsASC: isASC}}}
+}
+
+func (s *SortPosts) Validate() error {
+	if err := validateSortKeys(s.SortKeys(), postSortFields); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return nil
+}
+
+// SortKeys returns the keys with the fields converted to strings.
+func (s *SortPosts) SortKeys() []SortKey {
+	keys := make([]SortKey, 0, len(s.Keys))
+	for _, key := range s.Keys {
+		keys = append(keys, SortKey{Field: string(key.Field), IsASC: key.IsASC})
+	}
+
+	return keys
+}
+
+// sortValue returns the value of the field the posts are sorted by.
+func (p *Post) sortValue(field SortPostsByField) interface{} {
+	switch field {
+	case SortPostsByTitle:
+		return p.Title
+	case SortPostsByUpdatedAt:
+		return p.UpdatedAt
+	case SortPostsByCommentCount:
+		return p.CommentCount
+	case SortPostsByReactionCount:
+		return p.ReactionCount
+	default:
+		return p.CreatedAt
+	}
 }
 
 func (p *Post) Validate() error {
 	err := validation.ValidateStruct(p,
 		validation.Field(&p.Title, validation.Required, validation.Length(1, maxLengthTitle)),
-		validation.Field(&p.Description, validation.Required))
+		validation.Field(&p.Description, validation.Required),
+		validation.Field(&p.Format, validation.Required, validation.In(ContentFormatPlain, ContentFormatMarkdown)),
+		validation.Field(&p.Tags, validation.Each(validation.Required, validation.Length(1, maxLengthOfTag))),
+		validation.Field(&p.Status, validation.Required, validation.In(PostStatusDraft, PostStatusInReview,
+			PostStatusPublished, PostStatusArchived)))
 	if err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
 	return nil
 }
+
+// ValidateStatusChange checks that the post can be moved from its current status to the given one.
+func (p *Post) ValidateStatusChange(status PostStatus) error {
+	for _, allowed := range postStatusTransitions[p.Status] {
+		if allowed == status {
+			return nil
+		}
+	}
+
+	return validation.Errors{
+		"status": fmt.Errorf("cannot change status from %s to %s", p.Status, status),
+	}
+}
@@ -17,6 +17,8 @@ func TestPost_Validate(t *testing.T) {
 			in: Post{
 				Title:       "title",
 				Description: "description",
+				Format:      ContentFormatMarkdown,
+				Status:      PostStatusDraft,
 			},
 			errMessage: "",
 		},
@@ -42,6 +44,25 @@ func TestPost_Validate(t *testing.T) {
 			},
 			errMessage: requiredErrMessage,
 		},
+		{
+			name: "Status is unknown",
+			in: Post{
+				Title:       "title",
+				Description: "description",
+				Status:      "hidden",
+			},
+			errMessage: notInErrMessage,
+		},
+		{
+			name: "Format is unknown",
+			in: Post{
+				Title:       "title",
+				Description: "description",
+				Format:      "html",
+				Status:      PostStatusDraft,
+			},
+			errMessage: notInErrMessage,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -53,3 +74,61 @@ func TestPost_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestPost_ValidateStatusChange(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		from    PostStatus
+		to      PostStatus
+		isValid bool
+	}{
+		{
+			name:    "Draft is submitted for review",
+			from:    PostStatusDraft,
+			to:      PostStatusInReview,
+			isValid: true,
+		},
+		{
+			name:    "Draft cannot be published without review",
+			from:    PostStatusDraft,
+			to:      PostStatusPublished,
+			isValid: false,
+		},
+		{
+			name:    "Post in review is published",
+			from:    PostStatusInReview,
+			to:      PostStatusPublished,
+			isValid: true,
+		},
+		{
+			name:    "Published post is archived",
+			from:    PostStatusPublished,
+			to:      PostStatusArchived,
+			isValid: true,
+		},
+		{
+			name:    "Archived post cannot be published again",
+			from:    PostStatusArchived,
+			to:      PostStatusPublished,
+			isValid: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			post := Post{Status: tc.from}
+			err := post.ValidateStatusChange(tc.to)
+			if tc.isValid {
+				checkValidateErrorMessage(t, "", err)
+
+				return
+			}
+
+			checkValidateErrorMessage(t, "cannot change status", err)
+		})
+	}
+}
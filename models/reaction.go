@@ -0,0 +1,70 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	ReactionLike  ReactionType = "like"
+	ReactionLove  ReactionType = "love"
+	ReactionLaugh ReactionType = "laugh"
+	ReactionWow   ReactionType = "wow"
+	ReactionSad   ReactionType = "sad"
+	ReactionAngry ReactionType = "angry"
+)
+
+const (
+	ReactionTargetPost    ReactionTarget = "post"
+	ReactionTargetComment ReactionTarget = "comment"
+)
+
+// ReactionTypes lists the reaction types in the order they are shown.
+var ReactionTypes = []ReactionType{ReactionLike, ReactionLove, ReactionLaugh, ReactionWow, ReactionSad, ReactionAngry}
+
+type ReactionType string
+
+// ReactionTarget is the kind of object a reaction is left on.
+type ReactionTarget string
+
+// ReactionCounts holds the number of reactions of each type left on an object.
+type ReactionCounts map[ReactionType]int
+
+// Reaction is left by a user on a post or a comment. A user can leave only one reaction of each type on an object.
+type Reaction struct {
+	TargetType ReactionTarget `bson:"target_type,omitempty"`
+	TargetID   string         `bson:"target_id,omitempty"`
+	UserID     string         `bson:"user_id,omitempty"`
+	Type       ReactionType   `bson:"type,omitempty"`
+	CreatedAt  time.Time      `bson:"created_at,omitempty"`
+}
+
+type ReactionPayload struct {
+	TargetType ReactionTarget
+	TargetID   string
+	Type       ReactionType
+}
+
+func (r *Reaction) Validate() error {
+	err := validation.ValidateStruct(r,
+		validation.Field(&r.TargetType, validation.Required,
+			validation.In(ReactionTargetPost, ReactionTargetComment)),
+		validation.Field(&r.TargetID, validation.Required),
+		validation.Field(&r.Type, validation.Required, validation.In(reactionTypes()...)))
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return nil
+}
+
+func reactionTypes() []interface{} {
+	out := make([]interface{}, 0, len(ReactionTypes))
+	for _, reactionType := range ReactionTypes {
+		out = append(out, reactionType)
+	}
+
+	return out
+}
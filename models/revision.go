@@ -0,0 +1,67 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// PostRevision is a version of a post which was replaced by an edit.
+type PostRevision struct {
+	ID          string        `bson:"_id,omitempty"`
+	PostID      string        `bson:"post_id,omitempty"`
+	EditedBy    string        `bson:"edited_by,omitempty"`
+	EditedAt    time.Time     `bson:"edited_at,omitempty"`
+	Title       string        `bson:"title,omitempty"`
+	Description string        `bson:"description,omitempty"`
+	Tags        []string      `bson:"tags,omitempty"`
+	Format      ContentFormat `bson:"format,omitempty"`
+}
+
+// CommentRevision is a version of a comment which was replaced by an edit.
+type CommentRevision struct {
+	ID        string        `bson:"_id,omitempty"`
+	CommentID string        `bson:"comment_id,omitempty"`
+	EditedBy  string        `bson:"edited_by,omitempty"`
+	EditedAt  time.Time     `bson:"edited_at,omitempty"`
+	Content   string        `bson:"content,omitempty"`
+	Format    ContentFormat `bson:"format,omitempty"`
+}
+
+// FieldChange describes how a field differs between two revisions.
+type FieldChange struct {
+	Field string
+	From  string
+	To    string
+}
+
+// Diff returns the fields which differ between the revision and the other one.
+func (r *PostRevision) Diff(other *PostRevision) []FieldChange {
+	changes := make([]FieldChange, 0)
+	changes = appendChange(changes, "title", r.Title, other.Title)
+	changes = appendChange(changes, "description", r.Description, other.Description)
+	changes = appendChange(changes, "tags", strings.Join(r.Tags, ","), strings.Join(other.Tags, ","))
+	changes = appendChange(changes, "format", string(r.Format.OrPlain()), string(other.Format.OrPlain()))
+
+	return changes
+}
+
+// Diff returns the fields which differ between the revision and the other one.
+func (r *CommentRevision) Diff(other *CommentRevision) []FieldChange {
+	changes := make([]FieldChange, 0)
+	changes = appendChange(changes, "content", r.Content, other.Content)
+	changes = appendChange(changes, "format", string(r.Format.OrPlain()), string(other.Format.OrPlain()))
+
+	return changes
+}
+
+func appendChange(changes []FieldChange, field, from, to string) []FieldChange {
+	if from == to {
+		return changes
+	}
+
+	return append(changes, FieldChange{
+		Field: field,
+		From:  from,
+		To:    to,
+	})
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostRevision_Diff(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		from     PostRevision
+		to       PostRevision
+		expected []FieldChange
+	}{
+		{
+			name: "Nothing changed",
+			from: PostRevision{
+				Title:       "title",
+				Description: "description",
+				Tags:        []string{"go", "api"},
+			},
+			to: PostRevision{
+				Title:       "title",
+				Description: "description",
+				Tags:        []string{"go", "api"},
+			},
+			expected: []FieldChange{},
+		},
+		{
+			name: "Title and tags changed",
+			from: PostRevision{
+				Title:       "title",
+				Description: "description",
+				Tags:        []string{"go"},
+			},
+			to: PostRevision{
+				Title:       "new title",
+				Description: "description",
+				Tags:        []string{"go", "api"},
+			},
+			expected: []FieldChange{
+				{Field: "title", From: "title", To: "new title"},
+				{Field: "tags", From: "go", To: "go,api"},
+			},
+		},
+		{
+			name: "Format changed",
+			from: PostRevision{
+				Title:       "title",
+				Description: "description",
+				Format:      ContentFormatPlain,
+			},
+			to: PostRevision{
+				Title:       "title",
+				Description: "description",
+				Format:      ContentFormatMarkdown,
+			},
+			expected: []FieldChange{
+				{Field: "format", From: "plain", To: "markdown"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, tc.from.Diff(&tc.to))
+		})
+	}
+}
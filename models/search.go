@@ -0,0 +1,142 @@
+package models
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	SearchTargetPost    SearchTarget = "post"
+	SearchTargetComment SearchTarget = "comment"
+)
+
+const maxLengthOfSearchText = 200
+
+// Snippets mark the matched words with these tags. The rest of a snippet is HTML-escaped.
+const (
+	HighlightStart = "<mark>"
+	HighlightStop  = "</mark>"
+)
+
+// snippetWords is the number of words around the first match kept in a snippet.
+const snippetWords = 20
+
+// SearchTarget is the kind of object found by a search.
+type SearchTarget string
+
+// SearchQuery looks for the text in posts and comments. All targets are searched when Targets is empty.
+type SearchQuery struct {
+	Text       string
+	Targets    []SearchTarget
+	Visibility PostVisibility
+}
+
+func (q *SearchQuery) Validate() error {
+	err := validation.ValidateStruct(q,
+		validation.Field(&q.Text, validation.Required, validation.Length(1, maxLengthOfSearchText)),
+		validation.Field(&q.Targets, validation.Each(validation.Required,
+			validation.In(SearchTargetPost, SearchTargetComment))),
+	)
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return nil
+}
+
+// HasTarget reports whether the objects of the target are searched.
+func (q *SearchQuery) HasTarget(target SearchTarget) bool {
+	if len(q.Targets) == 0 {
+		return true
+	}
+
+	for _, t := range q.Targets {
+		if t == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SearchResult is a post or a comment matching the search query. Results with a higher rank match better.
+type SearchResult struct {
+	Target  SearchTarget
+	ID      string
+	Rank    float64
+	Snippet string
+
+	// Post or Comment is loaded depending on the target.
+	Post    *Post    `db:"-"`
+	Comment *Comment `db:"-"`
+}
+
+// Snippet returns the part of the text around the first word of the search text found in it,
+// with the found words highlighted. The beginning of the text is returned if nothing is found.
+// The text is HTML-escaped, so the highlight tags are the only markup in the snippet.
+func Snippet(text, searchText string) string {
+	terms := strings.Fields(searchText)
+	if len(terms) == 0 {
+		return html.EscapeString(text)
+	}
+
+	quoted := make([]string, 0, len(terms))
+	for _, term := range terms {
+		quoted = append(quoted, regexp.QuoteMeta(term))
+	}
+
+	matcher := regexp.MustCompile(`(?i)\b(` + strings.Join(quoted, "|") + `)\w*`)
+
+	words := strings.Fields(text)
+
+	first := 0
+	for i, word := range words {
+		if matcher.MatchString(word) {
+			first = i
+
+			break
+		}
+	}
+
+	start := first - snippetWords/2
+	if start < 0 {
+		start = 0
+	}
+
+	end := start + snippetWords
+	if end > len(words) {
+		end = len(words)
+	}
+
+	snippet := highlight(matcher, strings.Join(words[start:end], " "))
+
+	if start > 0 {
+		snippet = "..." + snippet
+	}
+
+	if end < len(words) {
+		snippet += "..."
+	}
+
+	return snippet
+}
+
+// highlight escapes the text and wraps the parts of it matched by the matcher into the highlight tags.
+func highlight(matcher *regexp.Regexp, text string) string {
+	var highlighted strings.Builder
+
+	last := 0
+	for _, match := range matcher.FindAllStringIndex(text, -1) {
+		highlighted.WriteString(html.EscapeString(text[last:match[0]]))
+		highlighted.WriteString(HighlightStart + html.EscapeString(text[match[0]:match[1]]) + HighlightStop)
+		last = match[1]
+	}
+
+	highlighted.WriteString(html.EscapeString(text[last:]))
+
+	return highlighted.String()
+}
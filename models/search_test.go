@@ -0,0 +1,97 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnippet(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		text       string
+		searchText string
+		expected   string
+	}{
+		{
+			name:       "Found words are highlighted",
+			text:       "Golang tips for writing APIs in Go",
+			searchText: "golang api",
+			expected:   "<mark>Golang</mark> tips for writing <mark>APIs</mark> in Go",
+		},
+		{
+			name: "Long text is cut around the first match",
+			text: "one two three four five six seven eight nine ten eleven twelve thirteen fourteen fifteen " +
+				"sixteen seventeen eighteen nineteen twenty twenty-one twenty-two twenty-three twenty-four golang " +
+				"twenty-six twenty-seven",
+			searchText: "golang",
+			expected: "...fifteen sixteen seventeen eighteen nineteen twenty twenty-one twenty-two twenty-three " +
+				"twenty-four <mark>golang</mark> twenty-six twenty-seven",
+		},
+		{
+			name:       "Nothing is found",
+			text:       "Golang tips",
+			searchText: "rust",
+			expected:   "Golang tips",
+		},
+		{
+			name:       "Markup of the text is escaped",
+			text:       "Golang <script>alert('golang')</script> tips",
+			searchText: "golang",
+			expected: "<mark>Golang</mark> &lt;script&gt;alert(&#39;<mark>golang</mark>&#39;)&lt;/script&gt; " +
+				"tips",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, Snippet(tc.text, tc.searchText))
+		})
+	}
+}
+
+func TestSearchQuery_Validate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		query SearchQuery
+		isErr bool
+	}{
+		{
+			name:  "Posts are searched",
+			query: SearchQuery{Text: "golang", Targets: []SearchTarget{SearchTargetPost}},
+			isErr: false,
+		},
+		{
+			name:  "Search text is empty",
+			query: SearchQuery{Text: ""},
+			isErr: true,
+		},
+		{
+			name:  "Target is unknown",
+			query: SearchQuery{Text: "golang", Targets: []SearchTarget{"user"}},
+			isErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.query.Validate()
+			if tc.isErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	maxLengthOfSlug = 60
+	defaultSlug     = "post"
+)
+
+// transliterations spell the letters which do not decompose into a latin letter and a mark.
+var transliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d", 'ł': "l", 'þ': "th", 'ı': "i",
+
+	'а': "a", 'б': "b", 'в': "v", 'г': "h", 'ґ': "g", 'д': "d", 'е': "e", 'є': "ie", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "y", 'і': "i", 'ї': "i", 'й': "i", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh",
+	'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "iu", 'я': "ia",
+
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i", 'κ': "k",
+	'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t",
+	'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// Slugify makes a URL-safe slug of the title: letters are transliterated to latin, case-folded,
+// and words are joined with a dash, so "Привіт, Café!" becomes "pryvit-cafe".
+func Slugify(title string) string {
+	var b strings.Builder
+
+	dash := false
+
+	for _, r := range norm.NFKD.String(strings.ToLower(title)) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+
+		spelled, ok := transliterations[r]
+		if !ok {
+			spelled = string(r)
+		}
+
+		for _, c := range spelled {
+			if c >= 'a' && c <= 'z' || c >= '0' && c <= '9' {
+				if dash && b.Len() > 0 {
+					b.WriteByte('-')
+				}
+
+				b.WriteRune(c)
+
+				dash = false
+
+				continue
+			}
+
+			dash = true
+		}
+
+		if b.Len() >= maxLengthOfSlug {
+			break
+		}
+	}
+
+	slug := b.String()
+	if len(slug) > maxLengthOfSlug {
+		slug = strings.TrimRight(slug[:maxLengthOfSlug], "-")
+	}
+
+	if slug == "" {
+		return defaultSlug
+	}
+
+	return slug
+}
+
+// SlugCandidate returns the n-th slug to try for the base slug when the previous ones are taken:
+// the base itself first and then the base with a numeric suffix.
+func SlugCandidate(base string, n int) string {
+	if n <= 1 {
+		return base
+	}
+
+	return base + "-" + strconv.Itoa(n)
+}
+
+// HasSlugBase reports whether the slug is the base slug, possibly with a suffix added on collision.
+func HasSlugBase(slug, base string) bool {
+	if slug == base {
+		return true
+	}
+
+	suffix := strings.TrimPrefix(slug, base+"-")
+	if suffix == slug || suffix == "" {
+		return false
+	}
+
+	_, err := strconv.Atoi(suffix)
+
+	return err == nil
+}
+
+// IsPostID reports whether the reference to a post is its ID rather than its slug.
+func IsPostID(ref string) bool {
+	_, err := uuid.Parse(ref)
+
+	return err == nil
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		title    string
+		expected string
+	}{
+		{
+			name:     "Words are case-folded and joined with dash",
+			title:    "  Hello,   World!  ",
+			expected: "hello-world",
+		},
+		{
+			name:     "Marks are dropped from letters",
+			title:    "Crème brûlée à la façon",
+			expected: "creme-brulee-a-la-facon",
+		},
+		{
+			name:     "Letters without marks are transliterated",
+			title:    "Straße Ærø Łódź",
+			expected: "strasse-aero-lodz",
+		},
+		{
+			name:     "Cyrillic and Greek letters are transliterated",
+			title:    "Привіт, світ! Γειά",
+			expected: "pryvit-svit-geia",
+		},
+		{
+			name:     "Digits are kept",
+			title:    "Go 1.16 release",
+			expected: "go-1-16-release",
+		},
+		{
+			name:     "Title without latin letters gets default slug",
+			title:    "🙂 ?!",
+			expected: "post",
+		},
+		{
+			name:     "Long slug is cut without trailing dash",
+			title:    strings.Repeat("abcd ", 20),
+			expected: strings.TrimSuffix(strings.Repeat("abcd-", 12), "-"),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, Slugify(tc.title))
+		})
+	}
+}
+
+func TestHasSlugBase(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		slug     string
+		expected bool
+	}{
+		{
+			name:     "Slug is the base",
+			slug:     "hello-world",
+			expected: true,
+		},
+		{
+			name:     "Slug is the base with suffix",
+			slug:     "hello-world-3",
+			expected: true,
+		},
+		{
+			name:     "Slug is of other title",
+			slug:     "hello-world-again",
+			expected: false,
+		},
+		{
+			name:     "Slug is prefix of the base",
+			slug:     "hello",
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, HasSlugBase(tc.slug, "hello-world"))
+		})
+	}
+}
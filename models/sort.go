@@ -0,0 +1,153 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const maxSortKeys = 5
+
+// sortFieldKinds tells how the values of the fields objects are sorted by are kept in cursors.
+var sortFieldKinds = map[string]fieldKind{
+	"title":          fieldKindText,
+	"created_at":     fieldKindTime,
+	"updated_at":     fieldKindTime,
+	"comment_count":  fieldKindCount,
+	"reaction_count": fieldKindCount,
+}
+
+// SortKey is a field to sort objects by together with the direction.
+type SortKey struct {
+	Field string
+	IsASC bool
+}
+
+// String formats the key as <field>:asc or <field>:desc.
+func (k SortKey) String() string {
+	if k.IsASC {
+		return k.Field + ":asc"
+	}
+
+	return k.Field + ":desc"
+}
+
+// sortSpec formats the keys, so lists sorted in different ways can be told apart.
+func sortSpec(keys []SortKey) string {
+	specs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		specs = append(specs, key.String())
+	}
+
+	return strings.Join(specs, ",")
+}
+
+// parseSortSpec parses the keys formatted by sortSpec.
+func parseSortSpec(spec string) ([]SortKey, error) {
+	if spec == "" {
+		return nil, errors.New("sort is empty")
+	}
+
+	var keys []SortKey
+
+	for _, s := range strings.Split(spec, ",") {
+		key, err := ParseSortKey(s, true)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// ParseSortKey parses the key formatted as <field>, <field>:asc or <field>:desc.
+// The key without the direction is sorted in the default one.
+func ParseSortKey(s string, isASC bool) (SortKey, error) {
+	parts := strings.SplitN(s, ":", 2)
+
+	key := SortKey{
+		Field: parts[0],
+		IsASC: isASC,
+	}
+
+	if len(parts) == 2 {
+		switch parts[1] {
+		case "asc":
+			key.IsASC = true
+		case "desc":
+			key.IsASC = false
+		default:
+			return SortKey{}, fmt.Errorf("%q must be formatted as <field>:asc or <field>:desc", s)
+		}
+	}
+
+	if key.Field == "" {
+		return SortKey{}, fmt.Errorf("field of %q is empty", s)
+	}
+
+	return key, nil
+}
+
+// validateSortKeys checks that the objects can be sorted by the fields and that no field is repeated.
+func validateSortKeys(keys []SortKey, fields map[string]bool) error {
+	if len(keys) > maxSortKeys {
+		return validation.Errors{
+			"keys": fmt.Errorf("the length must be no more than %d", maxSortKeys),
+		}
+	}
+
+	seen := make(map[string]bool, len(keys))
+
+	for i, key := range keys {
+		if !fields[key.Field] {
+			return validation.Errors{
+				fmt.Sprintf("keys.%d", i): fmt.Errorf("cannot sort by %q", key.Field),
+			}
+		}
+
+		if seen[key.Field] {
+			return validation.Errors{
+				fmt.Sprintf("keys.%d", i): fmt.Errorf("cannot sort by %q twice", key.Field),
+			}
+		}
+
+		seen[key.Field] = true
+	}
+
+	return nil
+}
+
+// sortValue formats the value of the sort field to be kept in a cursor.
+func sortValue(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case int:
+		return strconv.Itoa(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// sortArg converts the value of the sort field kept in a cursor back to the type of the field.
+func sortArg(field, value string) (interface{}, error) {
+	kind, ok := sortFieldKinds[field]
+	if !ok {
+		return nil, fmt.Errorf("cannot sort by %q", field)
+	}
+
+	switch kind {
+	case fieldKindTime:
+		return time.Parse(time.RFC3339Nano, value)
+	case fieldKindCount:
+		return strconv.Atoi(value)
+	default:
+		return value, nil
+	}
+}
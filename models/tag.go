@@ -0,0 +1,96 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const maxLengthOfTag = 30
+
+// Tag is a normalized tag of posts together with the number of published posts marked with it.
+type Tag struct {
+	Name      string `bson:"_id"`
+	PostCount int    `bson:"post_count"`
+}
+
+// TagAlias makes posts tagged with Alias be tagged with Tag instead. Aliases are left by merged tags.
+type TagAlias struct {
+	Alias string `bson:"_id"`
+	Tag   string `bson:"tag"`
+}
+
+type RenameTagPayload struct {
+	Name    string
+	NewName string
+}
+
+type MergeTagsPayload struct {
+	From string
+	Into string
+}
+
+// NormalizeTag case-folds the tag, trims it and joins its words with a dash,
+// so "Go ", "go" and "GO" become the same tag.
+func NormalizeTag(tag string) string {
+	return strings.Join(strings.Fields(strings.ToLower(tag)), "-")
+}
+
+// NormalizeTags normalizes every tag and drops empty and repeated tags keeping the order of the rest.
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	out := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		tag = NormalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+
+		seen[tag] = true
+
+		out = append(out, tag)
+	}
+
+	return out
+}
+
+// ValidateTagName checks a normalized name of a tag.
+func ValidateTagName(name string) error {
+	if err := validation.Validate(name, validation.Required, validation.Length(1, maxLengthOfTag)); err != nil {
+		return validation.Errors{
+			"name": err,
+		}
+	}
+
+	return nil
+}
+
+func (p *RenameTagPayload) Validate() error {
+	if err := ValidateTagName(p.NewName); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if p.Name == p.NewName {
+		return validation.Errors{
+			"name": fmt.Errorf("tag is already named %s", p.NewName),
+		}
+	}
+
+	return nil
+}
+
+func (p *MergeTagsPayload) Validate() error {
+	if err := ValidateTagName(p.Into); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if p.From == p.Into {
+		return validation.Errors{
+			"into": fmt.Errorf("tag cannot be merged into itself"),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		tags     []string
+		expected []string
+	}{
+		{
+			name:     "Tags are case-folded and trimmed",
+			tags:     []string{" Go ", "GraphQL"},
+			expected: []string{"go", "graphql"},
+		},
+		{
+			name:     "Words of tag are joined with dash",
+			tags:     []string{"Machine   Learning"},
+			expected: []string{"machine-learning"},
+		},
+		{
+			name:     "Repeated and empty tags are dropped",
+			tags:     []string{"go", "  ", "GO", "api", "Go"},
+			expected: []string{"go", "api"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, NormalizeTags(tc.tags))
+		})
+	}
+}
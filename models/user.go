@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"regexp"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation"
@@ -13,35 +14,122 @@ const (
 	minLengthOfEmail    = 8
 	maxLength           = 30
 	minLengthOfPassword = 8
+	minLengthOfUsername = 3
 )
 
+// usernamePattern allows lowercase letters, digits and underscores, so usernames can be mentioned with @username.
+var usernamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+const (
+	RoleUser      Role = "user"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+type Role string
+
+// DeletedUserID is the ID of the tombstone user which takes over the content of deleted users
+// when the content is anonymized.
+const DeletedUserID = "00000000-0000-0000-0000-000000000000"
+
+// DeletedUsername is the username of the tombstone user. It cannot be mentioned.
+const DeletedUsername = "deleted"
+
+const (
+	DeletedUserContentCascade   DeletedUserContent = "cascade"
+	DeletedUserContentAnonymize DeletedUserContent = "anonymize"
+)
+
+// DeletedUserContent defines what happens to posts and comments of a deleted user.
+type DeletedUserContent string
+
+// User is identified by the unique username in mentions. The username cannot be changed.
 type User struct {
 	ID        string    `bson:"_id,omitempty"`
 	Name      string    `bson:"name,omitempty"`
+	Username  string    `bson:"username,omitempty"`
 	Email     string    `bson:"email,omitempty"`
 	CreatedAt time.Time `bson:"created_at,omitempty"`
 	UpdatedAt time.Time `bson:"updated_at,omitempty"`
 	Password  string    `bson:"password, omitempty"`
+	Role      Role      `bson:"role,omitempty"`
+
+	EmailVerifiedAt *time.Time `bson:"email_verified_at,omitempty"`
+	PendingEmail    string     `bson:"pending_email,omitempty"`
+	DeletedAt       *time.Time `bson:"deleted_at,omitempty"`
+
+	NotificationPreferences NotificationPreferences `bson:"notification_preferences,omitempty"`
+}
+
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
 }
 
 type CreateUserPayload struct {
 	Name     string
+	Username string
 	Email    string
 	Password string
 }
 
+// UpdateUserPayload describes a partial update of a user. Nil fields are left unchanged.
 type UpdateUserPayload struct {
-	UserID   string
-	Name     string
-	Email    string
-	Password string
+	UserID string
+	Name   *string
+	Email  *string
+}
+
+type UpdateUserRolePayload struct {
+	UserID string
+	Role   Role
 }
 
 func (u *User) Validate() error {
-	err := validation.ValidateStruct(u,
+	rules := append(u.profileRules(),
+		validation.Field(&u.Password, validation.Required, validation.Length(minLengthOfPassword, maxLength)))
+
+	if err := validation.ValidateStruct(u, rules...); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateProfile validates the user without the password, which is not loaded together with the user.
+func (u *User) ValidateProfile() error {
+	if err := validation.ValidateStruct(u, u.profileRules()...); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return nil
+}
+
+func (u *User) profileRules() []*validation.FieldRules {
+	return []*validation.FieldRules{
 		validation.Field(&u.Name, validation.Required, validation.Length(minLengthOfName, maxLength)),
+		validation.Field(&u.Username, validation.Required, validation.Length(minLengthOfUsername, maxLength),
+			validation.Match(usernamePattern).Error("must contain only lowercase letters, digits and underscores")),
 		validation.Field(&u.Email, validation.Required, validation.Length(minLengthOfEmail, maxLength), is.Email),
-		validation.Field(&u.Password, validation.Required, validation.Length(minLengthOfPassword, maxLength)),
+		validation.Field(&u.Role, validation.In(RoleUser, RoleModerator, RoleAdmin)),
+	}
+}
+
+func (p *UpdateUserPayload) Validate() error {
+	err := validation.ValidateStruct(p,
+		validation.Field(&p.Name, validation.NilOrNotEmpty, validation.Length(minLengthOfName, maxLength)),
+		validation.Field(&p.Email, validation.NilOrNotEmpty, validation.Length(minLengthOfEmail, maxLength), is.Email),
+	)
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return nil
+}
+
+func (p *UpdateUserRolePayload) Validate() error {
+	err := validation.ValidateStruct(p,
+		validation.Field(&p.UserID, validation.Required),
+		validation.Field(&p.Role, validation.Required, validation.In(RoleUser, RoleModerator, RoleAdmin)),
 	)
 	if err != nil {
 		return fmt.Errorf("validation failed: %w", err)
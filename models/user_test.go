@@ -15,6 +15,7 @@ func TestUser_Validate(t *testing.T) {
 		{
 			name: "Validation passed",
 			in: User{
+				Username: "username",
 				Name:     "name",
 				Email:    "email@mail.com",
 				Password: "password",
@@ -24,6 +25,7 @@ func TestUser_Validate(t *testing.T) {
 		{
 			name: "Name field is empty",
 			in: User{
+				Username: "username",
 				Email:    "email@mail.com",
 				Password: "password",
 			},
@@ -32,6 +34,7 @@ func TestUser_Validate(t *testing.T) {
 		{
 			name: "Name field is too short",
 			in: User{
+				Username: "username",
 				Name:     "n",
 				Email:    "email@mail.com",
 				Password: "password",
@@ -41,15 +44,46 @@ func TestUser_Validate(t *testing.T) {
 		{
 			name: "Name field is too long",
 			in: User{
+				Username: "username",
 				Name:     "ssssssssssssssssssssssssssssssssssssssssss",
 				Email:    "email@mail.com",
 				Password: "password",
 			},
 			errMessage: lengthErrMessage,
 		},
+		{
+			name: "Username field is empty",
+			in: User{
+				Name:     "name",
+				Email:    "email@mail.com",
+				Password: "password",
+			},
+			errMessage: requiredErrMessage,
+		},
+		{
+			name: "Username field is too short",
+			in: User{
+				Name:     "name",
+				Username: "us",
+				Email:    "email@mail.com",
+				Password: "password",
+			},
+			errMessage: lengthErrMessage,
+		},
+		{
+			name: "Username contains not allowed characters",
+			in: User{
+				Name:     "name",
+				Username: "User.Name",
+				Email:    "email@mail.com",
+				Password: "password",
+			},
+			errMessage: "must contain only lowercase letters, digits and underscores",
+		},
 		{
 			name: "Email field is empty",
 			in: User{
+				Username: "username",
 				Name:     "name",
 				Password: "password",
 			},
@@ -58,6 +92,7 @@ func TestUser_Validate(t *testing.T) {
 		{
 			name: "Email field is too short",
 			in: User{
+				Username: "username",
 				Name:     "name",
 				Email:    "m@m.com",
 				Password: "password",
@@ -67,6 +102,7 @@ func TestUser_Validate(t *testing.T) {
 		{
 			name: "Email field is too long",
 			in: User{
+				Username: "username",
 				Name:     "name",
 				Email:    "sssssssssssss@ssssssssssssssmail.sssssssssssssss",
 				Password: "password",
@@ -76,6 +112,7 @@ func TestUser_Validate(t *testing.T) {
 		{
 			name: "Email field is not an email",
 			in: User{
+				Username: "username",
 				Name:     "name",
 				Email:    "not an email address",
 				Password: "password",
@@ -85,14 +122,16 @@ func TestUser_Validate(t *testing.T) {
 		{
 			name: "Password field is empty",
 			in: User{
-				Name:  "name",
-				Email: "email@mail.com",
+				Username: "username",
+				Name:     "name",
+				Email:    "email@mail.com",
 			},
 			errMessage: requiredErrMessage,
 		},
 		{
 			name: "Password is too short",
 			in: User{
+				Username: "username",
 				Name:     "name",
 				Email:    "email@mail.com",
 				Password: "short",
@@ -102,18 +141,185 @@ func TestUser_Validate(t *testing.T) {
 		{
 			name: "Password is too long",
 			in: User{
+				Username: "username",
 				Name:     "name",
 				Email:    "email@mail.com",
 				Password: "passwordpasswordpasswordpassword",
 			},
 			errMessage: lengthErrMessage,
 		},
+		{
+			name: "Role is valid",
+			in: User{
+				Username: "username",
+				Name:     "name",
+				Email:    "email@mail.com",
+				Password: "password",
+				Role:     RoleModerator,
+			},
+			errMessage: "",
+		},
+		{
+			name: "Role is not valid",
+			in: User{
+				Username: "username",
+				Name:     "name",
+				Email:    "email@mail.com",
+				Password: "password",
+				Role:     "superuser",
+			},
+			errMessage: notInErrMessage,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.in.Validate()
+			checkValidateErrorMessage(t, tc.errMessage, err)
+		})
+	}
+}
+
+func TestUser_ValidateProfile(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		in         User
+		errMessage string
+	}{
+		{
+			name: "Password is not required",
+			in: User{
+				Name:     "name",
+				Username: "username",
+				Email:    "email@mail.com",
+			},
+			errMessage: "",
+		},
+		{
+			name: "Username is not valid",
+			in: User{
+				Name:     "name",
+				Username: "User Name",
+				Email:    "email@mail.com",
+			},
+			errMessage: "must contain only lowercase letters, digits and underscores",
+		},
 	}
 
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
+			err := tc.in.ValidateProfile()
+			checkValidateErrorMessage(t, tc.errMessage, err)
+		})
+	}
+}
+
+func TestUpdateUserPayload_Validate(t *testing.T) {
+	t.Parallel()
+
+	name := "name"
+	email := "email@mail.com"
+	invalidEmail := "email.mail.com"
+	empty := ""
+
+	testCases := []struct {
+		name       string
+		in         UpdateUserPayload
+		errMessage string
+	}{
+		{
+			name: "Validation passed",
+			in: UpdateUserPayload{
+				Name:  &name,
+				Email: &email,
+			},
+			errMessage: "",
+		},
+		{
+			name:       "Nothing to update",
+			in:         UpdateUserPayload{},
+			errMessage: "",
+		},
+		{
+			name: "Name is empty",
+			in: UpdateUserPayload{
+				Name: &empty,
+			},
+			errMessage: requiredErrMessage,
+		},
+		{
+			name: "Email is not valid",
+			in: UpdateUserPayload{
+				Email: &invalidEmail,
+			},
+			errMessage: isMailErrMessage,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.in.Validate()
+			checkValidateErrorMessage(t, tc.errMessage, err)
+		})
+	}
+}
+
+func TestUpdateUserRolePayload_Validate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		in         UpdateUserRolePayload
+		errMessage string
+	}{
+		{
+			name: "Validation passed",
+			in: UpdateUserRolePayload{
+				UserID: "315b6c09-36ff-4519-8579-492f3ae2a3be",
+				Role:   RoleAdmin,
+			},
+			errMessage: "",
+		},
+		{
+			name: "UserID is empty",
+			in: UpdateUserRolePayload{
+				Role: RoleAdmin,
+			},
+			errMessage: requiredErrMessage,
+		},
+		{
+			name: "Role is empty",
+			in: UpdateUserRolePayload{
+				UserID: "315b6c09-36ff-4519-8579-492f3ae2a3be",
+			},
+			errMessage: requiredErrMessage,
+		},
+		{
+			name: "Role is not valid",
+			in: UpdateUserRolePayload{
+				UserID: "315b6c09-36ff-4519-8579-492f3ae2a3be",
+				Role:   "superuser",
+			},
+			errMessage: notInErrMessage,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
 			err := tc.in.Validate()
 			checkValidateErrorMessage(t, tc.errMessage, err)
 		})
@@ -0,0 +1,165 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	VariantStatusPending VariantStatus = "pending"
+	VariantStatusReady   VariantStatus = "ready"
+	VariantStatusFailed  VariantStatus = "failed"
+)
+
+const (
+	ImageFormatJPEG ImageFormat = "jpeg"
+	ImageFormatPNG  ImageFormat = "png"
+)
+
+const maxVariantSide = 4096
+
+// VariantStatus tells whether the variants of an attached image are made. It is empty for files
+// which have no variants.
+type VariantStatus string
+
+// ImageFormat is the format variants of images are encoded in.
+type ImageFormat string
+
+func (f ImageFormat) ContentType() string {
+	return "image/" + string(f)
+}
+
+// variantSourceTypes are the media types of images variants are made of. WebP images are kept as they are,
+// since there is no pure Go encoder for them and they are small enough anyway.
+var variantSourceTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+}
+
+// HasVariants tells whether variants are made of files of the media type.
+func HasVariants(contentType string) bool {
+	return variantSourceTypes[contentType]
+}
+
+var variantNamePattern = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// VariantSpec describes a variant of images: the box the image is fitted into and the format it is encoded in.
+type VariantSpec struct {
+	Name   string
+	Width  int
+	Height int
+	Format ImageFormat
+}
+
+func (v VariantSpec) Validate() error {
+	return validation.ValidateStruct(&v,
+		validation.Field(&v.Name, validation.Required, validation.Match(variantNamePattern)),
+		validation.Field(&v.Width, validation.Required, validation.Max(maxVariantSide)),
+		validation.Field(&v.Height, validation.Required, validation.Max(maxVariantSide)),
+		validation.Field(&v.Format, validation.Required, validation.In(ImageFormatJPEG, ImageFormatPNG)),
+	)
+}
+
+// ParseVariantSpecs parses variants given as comma separated <name>:<width>x<height>:<format>,
+// e.g. "thumbnail:200x200:jpeg,medium:800x800:jpeg".
+func ParseVariantSpecs(s string) ([]VariantSpec, error) {
+	var specs []VariantSpec
+
+	names := make(map[string]bool)
+
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		parts := strings.Split(item, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("variant %q is not in form <name>:<width>x<height>:<format>", item)
+		}
+
+		size := strings.Split(parts[1], "x")
+		if len(size) != 2 {
+			return nil, fmt.Errorf("size of variant %q is not in form <width>x<height>", item)
+		}
+
+		width, err := strconv.Atoi(size[0])
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse width of variant %q: %w", item, err)
+		}
+
+		height, err := strconv.Atoi(size[1])
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse height of variant %q: %w", item, err)
+		}
+
+		spec := VariantSpec{
+			Name:   parts[0],
+			Width:  width,
+			Height: height,
+			Format: ImageFormat(parts[2]),
+		}
+
+		if err := spec.Validate(); err != nil {
+			return nil, fmt.Errorf("variant %q is not valid: %w", item, err)
+		}
+
+		if names[spec.Name] {
+			return nil, fmt.Errorf("variant %q is given twice", spec.Name)
+		}
+
+		names[spec.Name] = true
+		specs = append(specs, spec)
+	}
+
+	return specs, nil
+}
+
+// AttachmentVariant is a resized copy of an attached image with its metadata stripped.
+type AttachmentVariant struct {
+	Name        string `bson:"name" json:"name"`
+	ContentType string `bson:"content_type" json:"content_type"`
+	Width       int    `bson:"width" json:"width"`
+	Height      int    `bson:"height" json:"height"`
+	Size        int64  `bson:"size" json:"size"`
+	BlobKey     string `bson:"blob_key" json:"blob_key"`
+
+	// URL is where the variant is downloaded from. It is not stored.
+	URL string `bson:"-" json:"-"`
+}
+
+// AttachmentVariantBlobKey returns the key the variant of the attachment is stored under.
+func AttachmentVariantBlobKey(attachmentID, name string) string {
+	return "attachment-variants/" + attachmentID + "/" + name
+}
+
+// AttachmentVariantURL returns the URL the variant of the attachment is downloaded from.
+func AttachmentVariantURL(baseURL, attachmentID, name string) string {
+	return strings.TrimRight(baseURL, "/") + "/attachments/" + attachmentID + "/variants/" + name
+}
+
+// Variant returns the variant of the attachment with the given name.
+func (a *Attachment) Variant(name string) (*AttachmentVariant, bool) {
+	for i := range a.Variants {
+		if a.Variants[i].Name == name {
+			return &a.Variants[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// BlobKeys returns the keys of the file and of all its variants.
+func (a *Attachment) BlobKeys() []string {
+	keys := []string{a.BlobKey}
+	for _, variant := range a.Variants {
+		keys = append(keys, variant.BlobKey)
+	}
+
+	return keys
+}
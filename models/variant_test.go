@@ -0,0 +1,69 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVariantSpecs(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		specs    string
+		expected []VariantSpec
+		isErr    bool
+	}{
+		{
+			name:  "Variants are parsed",
+			specs: "thumbnail:200x200:jpeg, medium:800x600:png",
+			expected: []VariantSpec{
+				{Name: "thumbnail", Width: 200, Height: 200, Format: ImageFormatJPEG},
+				{Name: "medium", Width: 800, Height: 600, Format: ImageFormatPNG},
+			},
+			isErr: false,
+		},
+		{
+			name:     "No variants are given",
+			specs:    "",
+			expected: nil,
+			isErr:    false,
+		},
+		{
+			name:     "Size is not in form of width and height",
+			specs:    "thumbnail:200:jpeg",
+			expected: nil,
+			isErr:    true,
+		},
+		{
+			name:     "Format is unknown",
+			specs:    "thumbnail:200x200:bmp",
+			expected: nil,
+			isErr:    true,
+		},
+		{
+			name:     "Name is given twice",
+			specs:    "thumbnail:200x200:jpeg,thumbnail:100x100:jpeg",
+			expected: nil,
+			isErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			specs, err := ParseVariantSpecs(tc.specs)
+			if tc.isErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, specs)
+		})
+	}
+}
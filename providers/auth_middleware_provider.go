@@ -27,11 +27,14 @@ func NewAuthInfoProvider(s service, p currentUserInformationProvider) *AuthMiddl
 }
 
 type service interface {
-	ParseToken(tokenString string) (string, error)
+	ParseToken(ctx context.Context, tokenString string) (*models.AuthInfo, error)
 }
 
 type currentUserInformationProvider interface {
 	SetCurrentUserID(ctx context.Context, userID string) context.Context
+	SetCurrentUserRole(ctx context.Context, role models.Role) context.Context
+	SetCurrentTokenID(ctx context.Context, tokenID string) context.Context
+	SetCurrentSessionID(ctx context.Context, sessionID string) context.Context
 }
 
 func (p *AuthMiddlewareProvider) BearerAuthMiddleware(r *http.Request) (context.Context, error) {
@@ -49,12 +52,15 @@ func (p *AuthMiddlewareProvider) BearerAuthMiddleware(r *http.Request) (context.
 		return r.Context(), models.ErrNotAuthenticated
 	}
 
-	userID, err := p.service.ParseToken(headerSplit[1])
+	authInfo, err := p.service.ParseToken(r.Context(), headerSplit[1])
 	if err != nil {
 		return r.Context(), fmt.Errorf("error occurred while parsing token: %w", err)
 	}
 
-	ctx := p.currentUserInformationProvider.SetCurrentUserID(r.Context(), userID)
+	ctx := p.currentUserInformationProvider.SetCurrentUserID(r.Context(), authInfo.UserID)
+	ctx = p.currentUserInformationProvider.SetCurrentUserRole(ctx, authInfo.Role)
+	ctx = p.currentUserInformationProvider.SetCurrentTokenID(ctx, authInfo.TokenID)
+	ctx = p.currentUserInformationProvider.SetCurrentSessionID(ctx, authInfo.SessionID)
 
 	return ctx, nil
 }
@@ -1,8 +1,17 @@
 package providers
 
-import "context"
+import (
+	"context"
 
-const userIdKey contextKey = "userId"
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+const (
+	userIdKey    contextKey = "userId"
+	userRoleKey  contextKey = "userRole"
+	tokenIdKey   contextKey = "tokenId"
+	sessionIdKey contextKey = "sessionId"
+)
 
 type contextKey string
 
@@ -24,3 +33,42 @@ func (p *CurrentUserInformationProvider) GetCurrentUserID(ctx context.Context) s
 
 	return userID.(string)
 }
+
+func (p *CurrentUserInformationProvider) SetCurrentUserRole(ctx context.Context, role models.Role) context.Context {
+	return context.WithValue(ctx, userRoleKey, role)
+}
+
+func (p *CurrentUserInformationProvider) GetCurrentUserRole(ctx context.Context) models.Role {
+	role := ctx.Value(userRoleKey)
+	if role == nil {
+		return ""
+	}
+
+	return role.(models.Role)
+}
+
+func (p *CurrentUserInformationProvider) SetCurrentTokenID(ctx context.Context, tokenID string) context.Context {
+	return context.WithValue(ctx, tokenIdKey, tokenID)
+}
+
+func (p *CurrentUserInformationProvider) GetCurrentTokenID(ctx context.Context) string {
+	tokenID := ctx.Value(tokenIdKey)
+	if tokenID == nil {
+		return ""
+	}
+
+	return tokenID.(string)
+}
+
+func (p *CurrentUserInformationProvider) SetCurrentSessionID(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, sessionIdKey, sessionID)
+}
+
+func (p *CurrentUserInformationProvider) GetCurrentSessionID(ctx context.Context) string {
+	sessionID := ctx.Value(sessionIdKey)
+	if sessionID == nil {
+		return ""
+	}
+
+	return sessionID.(string)
+}
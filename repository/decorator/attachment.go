@@ -0,0 +1,44 @@
+package decorator
+
+import (
+	"context"
+	"time"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+func (d *RepositoryCacheDecorator) CreateAttachment(ctx context.Context, attachment *models.Attachment,
+	quota int64) error {
+	return d.repository.CreateAttachment(ctx, attachment, quota)
+}
+
+func (d *RepositoryCacheDecorator) GetAttachment(ctx context.Context, attachmentID string) (*models.Attachment, error) {
+	return d.repository.GetAttachment(ctx, attachmentID)
+}
+
+func (d *RepositoryCacheDecorator) ListAttachments(ctx context.Context, postID string) ([]*models.Attachment, error) {
+	return d.repository.ListAttachments(ctx, postID)
+}
+
+func (d *RepositoryCacheDecorator) DeleteAttachment(ctx context.Context, attachmentID string) error {
+	return d.repository.DeleteAttachment(ctx, attachmentID)
+}
+
+func (d *RepositoryCacheDecorator) SumAttachmentSizes(ctx context.Context, userID string) (int64, error) {
+	return d.repository.SumAttachmentSizes(ctx, userID)
+}
+
+func (d *RepositoryCacheDecorator) ListPurgeableAttachments(ctx context.Context,
+	deletedBefore time.Time) ([]*models.Attachment, error) {
+	return d.repository.ListPurgeableAttachments(ctx, deletedBefore)
+}
+
+func (d *RepositoryCacheDecorator) ListPendingAttachments(ctx context.Context,
+	limit uint64) ([]*models.Attachment, error) {
+	return d.repository.ListPendingAttachments(ctx, limit)
+}
+
+func (d *RepositoryCacheDecorator) UpdateAttachmentVariants(ctx context.Context, attachmentID string,
+	status models.VariantStatus, variants []models.AttachmentVariant) error {
+	return d.repository.UpdateAttachmentVariants(ctx, attachmentID, status, variants)
+}
@@ -2,6 +2,7 @@ package decorator
 
 import (
 	"context"
+	"time"
 
 	"github.com/serhiihuberniuk/blog-api/models"
 )
@@ -9,3 +10,60 @@ import (
 func (d *RepositoryCacheDecorator) Login(ctx context.Context, email string) (*models.User, error) {
 	return d.repository.Login(ctx, email)
 }
+
+func (d *RepositoryCacheDecorator) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	return d.repository.CreateRefreshToken(ctx, token)
+}
+
+func (d *RepositoryCacheDecorator) GetRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	return d.repository.GetRefreshToken(ctx, tokenHash)
+}
+
+func (d *RepositoryCacheDecorator) RevokeRefreshToken(ctx context.Context, tokenID string, revokedAt time.Time) error {
+	return d.repository.RevokeRefreshToken(ctx, tokenID, revokedAt)
+}
+
+func (d *RepositoryCacheDecorator) RevokeRefreshTokenFamily(ctx context.Context, familyID string,
+	revokedAt time.Time) error {
+	return d.repository.RevokeRefreshTokenFamily(ctx, familyID, revokedAt)
+}
+
+func (d *RepositoryCacheDecorator) RevokeUserRefreshTokens(ctx context.Context, userID string,
+	revokedAt time.Time) error {
+	return d.repository.RevokeUserRefreshTokens(ctx, userID, revokedAt)
+}
+
+func (d *RepositoryCacheDecorator) RevokeOtherRefreshTokens(ctx context.Context, userID, familyID string,
+	revokedAt time.Time) error {
+	return d.repository.RevokeOtherRefreshTokens(ctx, userID, familyID, revokedAt)
+}
+
+func (d *RepositoryCacheDecorator) CreatePasswordResetToken(ctx context.Context,
+	token *models.PasswordResetToken) error {
+	return d.repository.CreatePasswordResetToken(ctx, token)
+}
+
+func (d *RepositoryCacheDecorator) GetPasswordResetToken(ctx context.Context,
+	tokenHash string) (*models.PasswordResetToken, error) {
+	return d.repository.GetPasswordResetToken(ctx, tokenHash)
+}
+
+func (d *RepositoryCacheDecorator) UsePasswordResetToken(ctx context.Context, tokenID string,
+	usedAt time.Time) error {
+	return d.repository.UsePasswordResetToken(ctx, tokenID, usedAt)
+}
+
+func (d *RepositoryCacheDecorator) CreateEmailVerificationToken(ctx context.Context,
+	token *models.EmailVerificationToken) error {
+	return d.repository.CreateEmailVerificationToken(ctx, token)
+}
+
+func (d *RepositoryCacheDecorator) GetEmailVerificationToken(ctx context.Context,
+	tokenHash string) (*models.EmailVerificationToken, error) {
+	return d.repository.GetEmailVerificationToken(ctx, tokenHash)
+}
+
+func (d *RepositoryCacheDecorator) UseEmailVerificationToken(ctx context.Context, tokenID string,
+	usedAt time.Time) error {
+	return d.repository.UseEmailVerificationToken(ctx, tokenID, usedAt)
+}
@@ -3,6 +3,7 @@ package decorator
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/serhiihuberniuk/blog-api/models"
 )
@@ -18,6 +19,11 @@ func (d *RepositoryCacheDecorator) CreateComment(ctx context.Context, comment *m
 		return fmt.Errorf("error occuers while setting to cache: %w", err)
 	}
 
+	err = d.deleteItemFromCache(ctx, comment.PostID, objectTypePost)
+	if err != nil {
+		return fmt.Errorf("error occurred while deleting post from cache: %w", err)
+	}
+
 	return nil
 }
 
@@ -45,8 +51,14 @@ func (d *RepositoryCacheDecorator) GetComment(ctx context.Context, commentID str
 	return comment, err
 }
 
-func (d *RepositoryCacheDecorator) UpdateComment(ctx context.Context, comment *models.Comment) error {
-	err := d.repository.UpdateComment(ctx, comment)
+func (d *RepositoryCacheDecorator) GetCommentThread(ctx context.Context,
+	commentID string) (*models.CommentThread, error) {
+	return d.repository.GetCommentThread(ctx, commentID)
+}
+
+func (d *RepositoryCacheDecorator) UpdateComment(ctx context.Context, comment *models.Comment,
+	revision *models.CommentRevision) error {
+	err := d.repository.UpdateComment(ctx, comment, revision)
 	if err != nil {
 		return fmt.Errorf("error occurred in repository layer: %w", err)
 	}
@@ -56,11 +68,21 @@ func (d *RepositoryCacheDecorator) UpdateComment(ctx context.Context, comment *m
 		return fmt.Errorf("error occurred while setting to cache: %w", err)
 	}
 
+	err = d.deleteItemFromCache(ctx, comment.PostID, objectTypePost)
+	if err != nil {
+		return fmt.Errorf("error occurred while deleting post from cache: %w", err)
+	}
+
 	return nil
 }
 
-func (d *RepositoryCacheDecorator) DeleteComment(ctx context.Context, commentID string) error {
-	err := d.repository.DeleteComment(ctx, commentID)
+func (d *RepositoryCacheDecorator) DeleteComment(ctx context.Context, commentID string, deletedAt time.Time) error {
+	comment, err := d.repository.GetComment(ctx, commentID)
+	if err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	err = d.repository.DeleteComment(ctx, commentID, deletedAt)
 	if err != nil {
 		return fmt.Errorf("error occurred in repository layer: %w", err)
 	}
@@ -70,6 +92,11 @@ func (d *RepositoryCacheDecorator) DeleteComment(ctx context.Context, commentID
 		return fmt.Errorf("error occurred while deleting client from cache: %w", err)
 	}
 
+	err = d.deleteItemFromCache(ctx, comment.PostID, objectTypePost)
+	if err != nil {
+		return fmt.Errorf("error occurred while deleting post from cache: %w", err)
+	}
+
 	return nil
 }
 
@@ -77,3 +104,30 @@ func (d *RepositoryCacheDecorator) ListComments(ctx context.Context, pagination
 	filter models.FilterComments, sort models.SortComments) ([]*models.Comment, error) {
 	return d.repository.ListComments(ctx, pagination, filter, sort)
 }
+
+func (d *RepositoryCacheDecorator) CountComments(ctx context.Context, filter models.FilterComments) (int, error) {
+	return d.repository.CountComments(ctx, filter)
+}
+
+func (d *RepositoryCacheDecorator) GetDeletedComment(ctx context.Context, commentID string) (*models.Comment, error) {
+	return d.repository.GetDeletedComment(ctx, commentID)
+}
+
+func (d *RepositoryCacheDecorator) RestoreComment(ctx context.Context, commentID string) error {
+	comment, err := d.repository.GetDeletedComment(ctx, commentID)
+	if err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	err = d.repository.RestoreComment(ctx, commentID)
+	if err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	err = d.deleteItemFromCache(ctx, comment.PostID, objectTypePost)
+	if err != nil {
+		return fmt.Errorf("error occurred while deleting post from cache: %w", err)
+	}
+
+	return nil
+}
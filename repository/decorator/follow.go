@@ -0,0 +1,25 @@
+package decorator
+
+import (
+	"context"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+func (d *RepositoryCacheDecorator) Follow(ctx context.Context, follow *models.Follow) (bool, error) {
+	return d.repository.Follow(ctx, follow)
+}
+
+func (d *RepositoryCacheDecorator) Unfollow(ctx context.Context, followerID, followeeID string) error {
+	return d.repository.Unfollow(ctx, followerID, followeeID)
+}
+
+func (d *RepositoryCacheDecorator) ListFollowers(ctx context.Context, userID string,
+	pagination models.Pagination) ([]*models.User, error) {
+	return d.repository.ListFollowers(ctx, userID, pagination)
+}
+
+func (d *RepositoryCacheDecorator) ListFollowing(ctx context.Context, userID string,
+	pagination models.Pagination) ([]*models.User, error) {
+	return d.repository.ListFollowing(ctx, userID, pagination)
+}
@@ -0,0 +1,48 @@
+package decorator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+func (d *RepositoryCacheDecorator) CreateNotification(ctx context.Context, notification *models.Notification) error {
+	return d.repository.CreateNotification(ctx, notification)
+}
+
+func (d *RepositoryCacheDecorator) ListNotifications(ctx context.Context, userID string, unreadOnly bool,
+	pagination models.Pagination) ([]*models.Notification, error) {
+	return d.repository.ListNotifications(ctx, userID, unreadOnly, pagination)
+}
+
+func (d *RepositoryCacheDecorator) CountUnreadNotifications(ctx context.Context, userID string) (int, error) {
+	return d.repository.CountUnreadNotifications(ctx, userID)
+}
+
+func (d *RepositoryCacheDecorator) MarkNotificationRead(ctx context.Context, userID, notificationID string,
+	readAt time.Time) error {
+	return d.repository.MarkNotificationRead(ctx, userID, notificationID, readAt)
+}
+
+func (d *RepositoryCacheDecorator) MarkAllNotificationsRead(ctx context.Context, userID string,
+	readAt time.Time) error {
+	return d.repository.MarkAllNotificationsRead(ctx, userID, readAt)
+}
+
+// UpdateNotificationPreferences drops the user from the cache, since the preferences are stored with the user.
+func (d *RepositoryCacheDecorator) UpdateNotificationPreferences(ctx context.Context, userID string,
+	preferences models.NotificationPreferences) error {
+	err := d.repository.UpdateNotificationPreferences(ctx, userID, preferences)
+	if err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	err = d.deleteItemFromCache(ctx, userID, objectTypeUser)
+	if err != nil {
+		return fmt.Errorf("error occurred while deleting user from cache: %w", err)
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package decorator
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/serhiihuberniuk/blog-api/models"
 )
@@ -45,8 +46,17 @@ func (d *RepositoryCacheDecorator) GetPost(ctx context.Context, postID string) (
 	return post, err
 }
 
-func (d *RepositoryCacheDecorator) UpdatePost(ctx context.Context, post *models.Post) error {
-	err := d.repository.UpdatePost(ctx, post)
+func (d *RepositoryCacheDecorator) GetPostBySlug(ctx context.Context, slug string) (*models.Post, error) {
+	return d.repository.GetPostBySlug(ctx, slug)
+}
+
+func (d *RepositoryCacheDecorator) IsSlugTaken(ctx context.Context, slug, postID string) (bool, error) {
+	return d.repository.IsSlugTaken(ctx, slug, postID)
+}
+
+func (d *RepositoryCacheDecorator) UpdatePost(ctx context.Context, post *models.Post,
+	revision *models.PostRevision) error {
+	err := d.repository.UpdatePost(ctx, post, revision)
 	if err != nil {
 		return fmt.Errorf("error occurred in repository layer: %w", err)
 	}
@@ -59,8 +69,29 @@ func (d *RepositoryCacheDecorator) UpdatePost(ctx context.Context, post *models.
 	return nil
 }
 
-func (d *RepositoryCacheDecorator) DeletePost(ctx context.Context, postID string) error {
-	err := d.repository.DeletePost(ctx, postID)
+func (d *RepositoryCacheDecorator) UpdatePostStatus(ctx context.Context, postID string,
+	status models.PostStatus) error {
+	err := d.repository.UpdatePostStatus(ctx, postID, status)
+	if err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	err = d.deleteItemFromCache(ctx, postID, objectTypePost)
+	if err != nil {
+		return fmt.Errorf("error occurred while deleting from cache: %w", err)
+	}
+
+	return nil
+}
+
+func (d *RepositoryCacheDecorator) DeletePost(ctx context.Context, postID string, deletedAt time.Time) error {
+	comments, err := d.repository.ListComments(ctx, models.Pagination{},
+		models.FilterCommentsBy(models.FilterCommentsByPost, postID), models.SortComments{})
+	if err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	err = d.repository.DeletePost(ctx, postID, deletedAt)
 	if err != nil {
 		return fmt.Errorf("error occurred in repository layer: %w", err)
 	}
@@ -70,10 +101,29 @@ func (d *RepositoryCacheDecorator) DeletePost(ctx context.Context, postID string
 		return fmt.Errorf("error occurred while deleting client from cache: %w", err)
 	}
 
+	for _, comment := range comments {
+		err = d.deleteItemFromCache(ctx, comment.ID, objectTypeComment)
+		if err != nil {
+			return fmt.Errorf("error occurred while deleting client from cache: %w", err)
+		}
+	}
+
 	return nil
 }
 
+func (d *RepositoryCacheDecorator) GetDeletedPost(ctx context.Context, postID string) (*models.Post, error) {
+	return d.repository.GetDeletedPost(ctx, postID)
+}
+
+func (d *RepositoryCacheDecorator) RestorePost(ctx context.Context, postID string) error {
+	return d.repository.RestorePost(ctx, postID)
+}
+
 func (d *RepositoryCacheDecorator) ListPosts(ctx context.Context, pagination models.Pagination,
 	filter models.FilterPosts, sort models.SortPosts) ([]*models.Post, error) {
 	return d.repository.ListPosts(ctx, pagination, filter, sort)
 }
+
+func (d *RepositoryCacheDecorator) CountPosts(ctx context.Context, filter models.FilterPosts) (int, error) {
+	return d.repository.CountPosts(ctx, filter)
+}
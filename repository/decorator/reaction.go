@@ -0,0 +1,86 @@
+package decorator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+func (d *RepositoryCacheDecorator) AddReaction(ctx context.Context, reaction *models.Reaction) (bool, error) {
+	added, err := d.repository.AddReaction(ctx, reaction)
+	if err != nil {
+		return false, fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	err = d.deleteItemFromCache(ctx, reactionsCacheID(reaction.TargetType, reaction.TargetID), objectTypeReactions)
+	if err != nil {
+		return false, fmt.Errorf("error occurred while deleting from cache: %w", err)
+	}
+
+	return added, nil
+}
+
+func (d *RepositoryCacheDecorator) RemoveReaction(ctx context.Context, reaction *models.Reaction) error {
+	err := d.repository.RemoveReaction(ctx, reaction)
+	if err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	err = d.deleteItemFromCache(ctx, reactionsCacheID(reaction.TargetType, reaction.TargetID), objectTypeReactions)
+	if err != nil {
+		return fmt.Errorf("error occurred while deleting from cache: %w", err)
+	}
+
+	return nil
+}
+
+// CountReactions takes the counts cached for the targets and counts the reactions left on the rest at once.
+func (d *RepositoryCacheDecorator) CountReactions(ctx context.Context, targetType models.ReactionTarget,
+	targetIDs []string) (map[string]models.ReactionCounts, error) {
+	counts := make(map[string]models.ReactionCounts, len(targetIDs))
+	uncached := make([]string, 0, len(targetIDs))
+
+	for _, targetID := range targetIDs {
+		cacheID := reactionsCacheID(targetType, targetID)
+
+		if !d.redisCache.Exists(ctx, objectTypeReactions+cacheID) {
+			uncached = append(uncached, targetID)
+
+			continue
+		}
+
+		var countsFromCache models.ReactionCounts
+
+		err := d.getItemFromCache(ctx, cacheID, objectTypeReactions, &countsFromCache)
+		if err != nil {
+			return nil, fmt.Errorf("error occurred getting from cache: %w", err)
+		}
+
+		counts[targetID] = countsFromCache
+	}
+
+	if len(uncached) == 0 {
+		return counts, nil
+	}
+
+	countsFromRepository, err := d.repository.CountReactions(ctx, targetType, uncached)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	for targetID, targetCounts := range countsFromRepository {
+		err = d.setItemToCache(ctx, reactionsCacheID(targetType, targetID), objectTypeReactions, targetCounts)
+		if err != nil {
+			return nil, fmt.Errorf("error occurred while setting to cache: %w", err)
+		}
+
+		counts[targetID] = targetCounts
+	}
+
+	return counts, nil
+}
+
+func reactionsCacheID(targetType models.ReactionTarget, targetID string) string {
+	return string(targetType) + ":" + targetID
+}
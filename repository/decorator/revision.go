@@ -0,0 +1,25 @@
+package decorator
+
+import (
+	"context"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+func (d *RepositoryCacheDecorator) ListPostRevisions(ctx context.Context, postID string) ([]*models.PostRevision, error) {
+	return d.repository.ListPostRevisions(ctx, postID)
+}
+
+func (d *RepositoryCacheDecorator) GetPostRevision(ctx context.Context, revisionID string) (*models.PostRevision, error) {
+	return d.repository.GetPostRevision(ctx, revisionID)
+}
+
+func (d *RepositoryCacheDecorator) ListCommentRevisions(ctx context.Context,
+	commentID string) ([]*models.CommentRevision, error) {
+	return d.repository.ListCommentRevisions(ctx, commentID)
+}
+
+func (d *RepositoryCacheDecorator) GetCommentRevision(ctx context.Context,
+	revisionID string) (*models.CommentRevision, error) {
+	return d.repository.GetCommentRevision(ctx, revisionID)
+}
@@ -0,0 +1,14 @@
+package decorator
+
+import (
+	"context"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+// Search results change with every edit of any post or comment, so they are not cached.
+// The found objects are loaded from the cache by the service.
+func (d *RepositoryCacheDecorator) Search(ctx context.Context, query models.SearchQuery,
+	pagination models.Pagination) ([]*models.SearchResult, error) {
+	return d.repository.Search(ctx, query, pagination)
+}
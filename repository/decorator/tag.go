@@ -0,0 +1,64 @@
+package decorator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+// Tags are counted over all published posts, so they are not cached.
+
+func (d *RepositoryCacheDecorator) GetTag(ctx context.Context, name string) (*models.Tag, error) {
+	return d.repository.GetTag(ctx, name)
+}
+
+func (d *RepositoryCacheDecorator) ListTags(ctx context.Context, pagination models.Pagination) ([]*models.Tag, error) {
+	return d.repository.ListTags(ctx, pagination)
+}
+
+func (d *RepositoryCacheDecorator) AutocompleteTags(ctx context.Context, prefix string,
+	limit uint64) ([]*models.Tag, error) {
+	return d.repository.AutocompleteTags(ctx, prefix, limit)
+}
+
+func (d *RepositoryCacheDecorator) GetTagAliases(ctx context.Context, tags []string) (map[string]string, error) {
+	return d.repository.GetTagAliases(ctx, tags)
+}
+
+func (d *RepositoryCacheDecorator) RenameTag(ctx context.Context, name, newName string) ([]string, error) {
+	postIDs, err := d.repository.RenameTag(ctx, name, newName)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	if err = d.deletePostsFromCache(ctx, postIDs); err != nil {
+		return nil, err
+	}
+
+	return postIDs, nil
+}
+
+func (d *RepositoryCacheDecorator) MergeTags(ctx context.Context, from, into string) ([]string, error) {
+	postIDs, err := d.repository.MergeTags(ctx, from, into)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	if err = d.deletePostsFromCache(ctx, postIDs); err != nil {
+		return nil, err
+	}
+
+	return postIDs, nil
+}
+
+func (d *RepositoryCacheDecorator) deletePostsFromCache(ctx context.Context, postIDs []string) error {
+	for _, postID := range postIDs {
+		err := d.deleteItemFromCache(ctx, postID, objectTypePost)
+		if err != nil {
+			return fmt.Errorf("error occurred while deleting from cache: %w", err)
+		}
+	}
+
+	return nil
+}
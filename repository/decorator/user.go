@@ -3,6 +3,7 @@ package decorator
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/serhiihuberniuk/blog-api/models"
 )
@@ -45,6 +46,27 @@ func (d *RepositoryCacheDecorator) GetUser(ctx context.Context, userID string) (
 	return user, err
 }
 
+func (d *RepositoryCacheDecorator) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	return d.repository.GetUserByEmail(ctx, email)
+}
+
+func (d *RepositoryCacheDecorator) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	return d.repository.GetUserByUsername(ctx, username)
+}
+
+func (d *RepositoryCacheDecorator) GetUsersByUsernames(ctx context.Context,
+	usernames []string) ([]*models.User, error) {
+	return d.repository.GetUsersByUsernames(ctx, usernames)
+}
+
+func (d *RepositoryCacheDecorator) IsUsernameTaken(ctx context.Context, username string) (bool, error) {
+	return d.repository.IsUsernameTaken(ctx, username)
+}
+
+func (d *RepositoryCacheDecorator) IsEmailTaken(ctx context.Context, email string) (bool, error) {
+	return d.repository.IsEmailTaken(ctx, email)
+}
+
 func (d *RepositoryCacheDecorator) UpdateUser(ctx context.Context, user *models.User) error {
 	err := d.repository.UpdateUser(ctx, user)
 	if err != nil {
@@ -59,8 +81,62 @@ func (d *RepositoryCacheDecorator) UpdateUser(ctx context.Context, user *models.
 	return nil
 }
 
-func (d *RepositoryCacheDecorator) DeleteUser(ctx context.Context, userID string) error {
-	err := d.repository.DeleteUser(ctx, userID)
+func (d *RepositoryCacheDecorator) UpdateUserRole(ctx context.Context, userID string, role models.Role) error {
+	err := d.repository.UpdateUserRole(ctx, userID, role)
+	if err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	err = d.deleteItemFromCache(ctx, userID, objectTypeUser)
+	if err != nil {
+		return fmt.Errorf("error occurred while deleting user from cache: %w", err)
+	}
+
+	return nil
+}
+
+func (d *RepositoryCacheDecorator) GetUserPassword(ctx context.Context, userID string) (string, error) {
+	return d.repository.GetUserPassword(ctx, userID)
+}
+
+func (d *RepositoryCacheDecorator) UpdateUserPassword(ctx context.Context, userID, password string,
+	updatedAt time.Time) error {
+	err := d.repository.UpdateUserPassword(ctx, userID, password, updatedAt)
+	if err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	err = d.deleteItemFromCache(ctx, userID, objectTypeUser)
+	if err != nil {
+		return fmt.Errorf("error occurred while deleting user from cache: %w", err)
+	}
+
+	return nil
+}
+
+func (d *RepositoryCacheDecorator) VerifyUserEmail(ctx context.Context, userID, email string,
+	verifiedAt time.Time) error {
+	err := d.repository.VerifyUserEmail(ctx, userID, email, verifiedAt)
+	if err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	err = d.deleteItemFromCache(ctx, userID, objectTypeUser)
+	if err != nil {
+		return fmt.Errorf("error occurred while deleting user from cache: %w", err)
+	}
+
+	return nil
+}
+
+func (d *RepositoryCacheDecorator) DeleteUser(ctx context.Context, userID string, deletedAt time.Time,
+	content models.DeletedUserContent) error {
+	posts, comments, err := d.listUserContent(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	err = d.repository.DeleteUser(ctx, userID, deletedAt, content)
 	if err != nil {
 		return fmt.Errorf("error occurred in repository layer: %w", err)
 	}
@@ -70,5 +146,80 @@ func (d *RepositoryCacheDecorator) DeleteUser(ctx context.Context, userID string
 		return fmt.Errorf("error occurred while deleting client from cache: %w", err)
 	}
 
+	for _, post := range posts {
+		err = d.deleteItemFromCache(ctx, post.ID, objectTypePost)
+		if err != nil {
+			return fmt.Errorf("error occurred while deleting client from cache: %w", err)
+		}
+	}
+
+	for _, comment := range comments {
+		err = d.deleteItemFromCache(ctx, comment.ID, objectTypeComment)
+		if err != nil {
+			return fmt.Errorf("error occurred while deleting client from cache: %w", err)
+		}
+
+		// The commented post is changed too, as its comments are counted again.
+		err = d.deleteItemFromCache(ctx, comment.PostID, objectTypePost)
+		if err != nil {
+			return fmt.Errorf("error occurred while deleting client from cache: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// listUserContent returns posts of the user and comments which are affected by deletion of the user:
+// the comments of the user and the comments to the posts of the user.
+func (d *RepositoryCacheDecorator) listUserContent(ctx context.Context,
+	userID string) ([]*models.Post, []*models.Comment, error) {
+	posts, err := d.repository.ListPosts(ctx, models.Pagination{},
+		models.FilterPostsBy(models.FilterPostsByCreatedBy, userID), models.SortPosts{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	comments, err := d.repository.ListComments(ctx, models.Pagination{},
+		models.FilterCommentsBy(models.FilterCommentsByAuthor, userID), models.SortComments{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, post := range posts {
+		postComments, err := d.repository.ListComments(ctx, models.Pagination{},
+			models.FilterCommentsBy(models.FilterCommentsByPost, post.ID), models.SortComments{})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		comments = append(comments, postComments...)
+	}
+
+	return posts, comments, nil
+}
+
+func (d *RepositoryCacheDecorator) GetDeletedUser(ctx context.Context, userID string) (*models.User, error) {
+	return d.repository.GetDeletedUser(ctx, userID)
+}
+
+func (d *RepositoryCacheDecorator) RestoreUser(ctx context.Context, userID string) error {
+	err := d.repository.RestoreUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	comments, err := d.repository.ListComments(ctx, models.Pagination{},
+		models.FilterCommentsBy(models.FilterCommentsByAuthor, userID), models.SortComments{})
+	if err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	for _, comment := range comments {
+		err = d.deleteItemFromCache(ctx, comment.PostID, objectTypePost)
+		if err != nil {
+			return fmt.Errorf("error occurred while deleting post from cache: %w", err)
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,57 @@
+package denylist
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const keyPrefix = "denylist:jti:"
+
+// RedisDenylist keeps IDs of revoked access tokens until they would have expired anyway.
+type RedisDenylist struct {
+	redisClient *redis.Client
+}
+
+func NewRedisDenylist(redisAddress string) *RedisDenylist {
+	client := redis.NewClient(&redis.Options{
+		Addr:     redisAddress,
+		DB:       0,
+		Password: "",
+	})
+
+	return &RedisDenylist{
+		redisClient: client,
+	}
+}
+
+func (d *RedisDenylist) Deny(ctx context.Context, tokenID string, ttl time.Duration) error {
+	if err := d.redisClient.Set(ctx, keyPrefix+tokenID, 1, ttl).Err(); err != nil {
+		return fmt.Errorf("error occurred while adding token to denylist: %w", err)
+	}
+
+	return nil
+}
+
+func (d *RedisDenylist) IsDenied(ctx context.Context, tokenID string) (bool, error) {
+	n, err := d.redisClient.Exists(ctx, keyPrefix+tokenID).Result()
+	if err != nil {
+		return false, fmt.Errorf("error occurred while checking denylist: %w", err)
+	}
+
+	return n > 0, nil
+}
+
+func (d *RedisDenylist) HealthCheck(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	err := d.redisClient.Ping(ctx).Err()
+	if err != nil {
+		return fmt.Errorf("connection to redis db failed: %w", err)
+	}
+
+	return nil
+}
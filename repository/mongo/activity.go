@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/serhiihuberniuk/blog-api/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ReconcilePostActivity recounts the comments of all posts and fixes the stored counts which drifted.
+// It returns IDs of the changed posts.
+func (r *Repository) ReconcilePostActivity(ctx context.Context) ([]string, error) {
+	postIDs, err := r.refreshPostActivity(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("cannot reconcile activity of posts: %w", err)
+	}
+
+	return postIDs, nil
+}
+
+// countComment adds the created comment to the counts of its post.
+func (r *Repository) countComment(ctx context.Context, postID string, createdAt time.Time) error {
+	_, err := usePostsCollection(r).UpdateOne(ctx, bson.M{"_id": postID}, bson.M{
+		"$inc": bson.M{"comment_count": 1},
+		"$max": bson.M{"last_commented_at": createdAt},
+	})
+
+	return err
+}
+
+// shiftCommentCount changes the count of comments of the post by delta and finds its latest comment again.
+func (r *Repository) shiftCommentCount(ctx context.Context, postID string, delta int) error {
+	var latest models.Comment
+
+	err := useCommentsCollection(r).FindOne(ctx, bson.M{"post_id": postID, "deleted_at": nil},
+		options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})).Decode(&latest)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+
+	var lastCommentedAt *time.Time
+	if err == nil {
+		lastCommentedAt = &latest.CreatedAt
+	}
+
+	update := activityUpdate(bson.M{}, lastCommentedAt)
+	update["$inc"] = bson.M{"comment_count": delta}
+
+	_, err = usePostsCollection(r).UpdateOne(ctx, bson.M{"_id": postID}, update)
+
+	return err
+}
+
+// refreshPostActivity recounts the comments of the posts matching the filter and saves the counts
+// which differ from the stored ones. It returns IDs of the changed posts.
+func (r *Repository) refreshPostActivity(ctx context.Context, filter bson.M) ([]string, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         useCommentsCollection(r).Name(),
+			"localField":   "_id",
+			"foreignField": "post_id",
+			"as":           "comments",
+		}}},
+		{{Key: "$addFields", Value: bson.M{
+			"comments": bson.M{"$filter": bson.M{
+				"input": "$comments",
+				"cond":  bson.M{"$not": bson.A{"$$this.deleted_at"}},
+			}},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"comment_count":     1,
+			"last_commented_at": 1,
+			"counted":           bson.M{"$size": "$comments"},
+			"latest":            bson.M{"$max": "$comments.created_at"},
+		}}},
+	}
+
+	cursor, err := usePostsCollection(r).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var activities []struct {
+		ID              string     `bson:"_id"`
+		CommentCount    int        `bson:"comment_count"`
+		LastCommentedAt *time.Time `bson:"last_commented_at"`
+		Counted         int        `bson:"counted"`
+		Latest          *time.Time `bson:"latest"`
+	}
+	if err = cursor.All(ctx, &activities); err != nil {
+		return nil, err
+	}
+
+	var postIDs []string
+
+	for _, a := range activities {
+		if a.CommentCount == a.Counted && sameTime(a.LastCommentedAt, a.Latest) {
+			continue
+		}
+
+		update := activityUpdate(bson.M{"comment_count": a.Counted}, a.Latest)
+
+		if _, err := usePostsCollection(r).UpdateOne(ctx, bson.M{"_id": a.ID}, update); err != nil {
+			return nil, err
+		}
+
+		postIDs = append(postIDs, a.ID)
+	}
+
+	return postIDs, nil
+}
+
+// refreshActivityOfCommentedPosts recounts the comments of the posts commented by the user.
+func (r *Repository) refreshActivityOfCommentedPosts(ctx context.Context, userID string) error {
+	postIDs, err := useCommentsCollection(r).Distinct(ctx, "post_id", bson.M{"created_by": userID})
+	if err != nil {
+		return err
+	}
+
+	_, err = r.refreshPostActivity(ctx, bson.M{"_id": bson.M{"$in": postIDs}})
+
+	return err
+}
+
+// activityUpdate sets the fields and the time of the latest comment, which is removed when there is none.
+func activityUpdate(set bson.M, lastCommentedAt *time.Time) bson.M {
+	update := bson.M{}
+
+	if lastCommentedAt == nil {
+		update["$unset"] = bson.M{"last_commented_at": ""}
+	} else {
+		set["last_commented_at"] = *lastCommentedAt
+	}
+
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+
+	return update
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
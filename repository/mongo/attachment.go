@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CreateAttachment saves the attachment unless it takes the total size of files attached by its author over
+// the quota. Zero quota means no quota. The attachment is counted after it is inserted and removed again
+// if it does not fit, so concurrent uploads can never go over the quota together.
+func (r *Repository) CreateAttachment(ctx context.Context, attachment *models.Attachment, quota int64) error {
+	attachmentsCollection := useAttachmentsCollection(r)
+
+	if _, err := attachmentsCollection.InsertOne(ctx, attachment); err != nil {
+		return fmt.Errorf("cannot create attachment: %w", err)
+	}
+
+	if quota <= 0 {
+		return nil
+	}
+
+	used, err := r.SumAttachmentSizes(ctx, attachment.CreatedBy)
+	if err == nil && used <= quota {
+		return nil
+	}
+
+	if _, deleteErr := attachmentsCollection.DeleteOne(ctx, bson.M{"_id": attachment.ID}); deleteErr != nil {
+		return fmt.Errorf("cannot delete attachment over quota: %w", deleteErr)
+	}
+
+	if err != nil {
+		return fmt.Errorf("cannot create attachment: %w", err)
+	}
+
+	return fmt.Errorf("cannot create attachment: %w", models.ErrQuotaExceeded)
+}
+
+func (r *Repository) GetAttachment(ctx context.Context, attachmentID string) (*models.Attachment, error) {
+	attachmentsCollection := useAttachmentsCollection(r)
+
+	var attachment models.Attachment
+
+	if err := attachmentsCollection.FindOne(ctx, bson.M{"_id": attachmentID}).Decode(&attachment); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get attachment: %w", err)
+	}
+
+	return &attachment, nil
+}
+
+func (r *Repository) ListAttachments(ctx context.Context, postID string) ([]*models.Attachment, error) {
+	return r.findAttachments(ctx, bson.M{"post_id": postID})
+}
+
+func (r *Repository) DeleteAttachment(ctx context.Context, attachmentID string) error {
+	attachmentsCollection := useAttachmentsCollection(r)
+
+	result, err := attachmentsCollection.DeleteOne(ctx, bson.M{"_id": attachmentID})
+	if err != nil {
+		return fmt.Errorf("cannot delete attachment: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+// SumAttachmentSizes returns the total size of files attached by the user.
+func (r *Repository) SumAttachmentSizes(ctx context.Context, userID string) (int64, error) {
+	attachmentsCollection := useAttachmentsCollection(r)
+
+	cursor, err := attachmentsCollection.Aggregate(ctx, bson.A{
+		bson.M{"$match": bson.M{"created_by": userID}},
+		bson.M{"$group": bson.M{"_id": nil, "size": bson.M{"$sum": "$size"}}},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("cannot count size of attachments: %w", err)
+	}
+
+	var sums []struct {
+		Size int64 `bson:"size"`
+	}
+	if err = cursor.All(ctx, &sums); err != nil {
+		return 0, fmt.Errorf("cannot count size of attachments: %w", err)
+	}
+
+	if len(sums) == 0 {
+		return 0, nil
+	}
+
+	return sums[0].Size, nil
+}
+
+// ListPurgeableAttachments returns attachments of posts which are purged together with the given deleted objects,
+// either as deleted posts or as content of deleted users.
+func (r *Repository) ListPurgeableAttachments(ctx context.Context, deletedBefore time.Time) ([]*models.Attachment,
+	error) {
+	_, postIDs, err := r.findPurgeableIDs(ctx, deletedBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.findAttachments(ctx, bson.M{"post_id": bson.M{"$in": postIDs}})
+}
+
+func (r *Repository) findAttachments(ctx context.Context, filter bson.M) ([]*models.Attachment, error) {
+	attachmentsCollection := useAttachmentsCollection(r)
+
+	cursor, err := attachmentsCollection.Find(ctx, filter, options.Find().SetSort(bson.M{"created_at": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("cannot get attachments: %w", err)
+	}
+
+	var attachments []*models.Attachment
+	if err = cursor.All(ctx, &attachments); err != nil {
+		return nil, fmt.Errorf("cannot get attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// ListPendingAttachments returns the oldest attachments which wait for their variants to be made.
+func (r *Repository) ListPendingAttachments(ctx context.Context, limit uint64) ([]*models.Attachment, error) {
+	attachmentsCollection := useAttachmentsCollection(r)
+
+	cursor, err := attachmentsCollection.Find(ctx, bson.M{"variant_status": models.VariantStatusPending},
+		options.Find().SetSort(bson.M{"created_at": 1}).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, fmt.Errorf("cannot get pending attachments: %w", err)
+	}
+
+	var attachments []*models.Attachment
+	if err = cursor.All(ctx, &attachments); err != nil {
+		return nil, fmt.Errorf("cannot get pending attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+func (r *Repository) UpdateAttachmentVariants(ctx context.Context, attachmentID string, status models.VariantStatus,
+	variants []models.AttachmentVariant) error {
+	attachmentsCollection := useAttachmentsCollection(r)
+
+	result, err := attachmentsCollection.UpdateOne(ctx, bson.M{"_id": attachmentID}, bson.M{
+		"$set": bson.M{
+			"variant_status": status,
+			"variants":       variants,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("cannot update variants of attachment: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/serhiihuberniuk/blog-api/models"
 	"go.mongodb.org/mongo-driver/bson"
@@ -14,7 +15,7 @@ func (r *Repository) Login(ctx context.Context, email string) (*models.User, err
 	usersCollection := useUsersCollection(r)
 
 	var user models.User
-	if err := usersCollection.FindOne(ctx, bson.M{"email": email}).Decode(&user); err != nil {
+	if err := usersCollection.FindOne(ctx, bson.M{"email": email, "deleted_at": nil}).Decode(&user); err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, models.ErrNotFound
 		}
@@ -24,3 +25,201 @@ func (r *Repository) Login(ctx context.Context, email string) (*models.User, err
 
 	return &user, nil
 }
+
+func (r *Repository) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	refreshTokensCollection := useRefreshTokensCollection(r)
+
+	if _, err := refreshTokensCollection.InsertOne(ctx, token); err != nil {
+		return fmt.Errorf("cannot create refresh token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	refreshTokensCollection := useRefreshTokensCollection(r)
+
+	var token models.RefreshToken
+	if err := refreshTokensCollection.FindOne(ctx, bson.M{"token_hash": tokenHash}).Decode(&token); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get refresh token: %w", err)
+	}
+
+	return &token, nil
+}
+
+func (r *Repository) RevokeRefreshToken(ctx context.Context, tokenID string, revokedAt time.Time) error {
+	refreshTokensCollection := useRefreshTokensCollection(r)
+
+	result, err := refreshTokensCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": tokenID, "revoked_at": nil},
+		bson.M{
+			"$set": bson.M{
+				"revoked_at": revokedAt,
+			},
+		})
+	if err != nil {
+		return fmt.Errorf("cannot revoke refresh token: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) RevokeRefreshTokenFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	refreshTokensCollection := useRefreshTokensCollection(r)
+
+	_, err := refreshTokensCollection.UpdateMany(
+		ctx,
+		bson.M{"family_id": familyID, "revoked_at": nil},
+		bson.M{
+			"$set": bson.M{
+				"revoked_at": revokedAt,
+			},
+		})
+	if err != nil {
+		return fmt.Errorf("cannot revoke refresh tokens: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) RevokeUserRefreshTokens(ctx context.Context, userID string, revokedAt time.Time) error {
+	refreshTokensCollection := useRefreshTokensCollection(r)
+
+	_, err := refreshTokensCollection.UpdateMany(
+		ctx,
+		bson.M{"user_id": userID, "revoked_at": nil},
+		bson.M{
+			"$set": bson.M{
+				"revoked_at": revokedAt,
+			},
+		})
+	if err != nil {
+		return fmt.Errorf("cannot revoke refresh tokens: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) RevokeOtherRefreshTokens(ctx context.Context, userID, familyID string,
+	revokedAt time.Time) error {
+	refreshTokensCollection := useRefreshTokensCollection(r)
+
+	_, err := refreshTokensCollection.UpdateMany(
+		ctx,
+		bson.M{"user_id": userID, "family_id": bson.M{"$ne": familyID}, "revoked_at": nil},
+		bson.M{
+			"$set": bson.M{
+				"revoked_at": revokedAt,
+			},
+		})
+	if err != nil {
+		return fmt.Errorf("cannot revoke refresh tokens: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) CreatePasswordResetToken(ctx context.Context, token *models.PasswordResetToken) error {
+	passwordResetTokensCollection := usePasswordResetTokensCollection(r)
+
+	if _, err := passwordResetTokensCollection.InsertOne(ctx, token); err != nil {
+		return fmt.Errorf("cannot create password reset token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetPasswordResetToken(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	passwordResetTokensCollection := usePasswordResetTokensCollection(r)
+
+	var token models.PasswordResetToken
+	if err := passwordResetTokensCollection.FindOne(ctx, bson.M{"token_hash": tokenHash}).Decode(&token); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get password reset token: %w", err)
+	}
+
+	return &token, nil
+}
+
+func (r *Repository) UsePasswordResetToken(ctx context.Context, tokenID string, usedAt time.Time) error {
+	passwordResetTokensCollection := usePasswordResetTokensCollection(r)
+
+	result, err := passwordResetTokensCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": tokenID, "used_at": nil},
+		bson.M{
+			"$set": bson.M{
+				"used_at": usedAt,
+			},
+		})
+	if err != nil {
+		return fmt.Errorf("cannot use password reset token: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) CreateEmailVerificationToken(ctx context.Context, token *models.EmailVerificationToken) error {
+	emailVerificationTokensCollection := useEmailVerificationTokensCollection(r)
+
+	if _, err := emailVerificationTokensCollection.InsertOne(ctx, token); err != nil {
+		return fmt.Errorf("cannot create email verification token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetEmailVerificationToken(ctx context.Context,
+	tokenHash string) (*models.EmailVerificationToken, error) {
+	emailVerificationTokensCollection := useEmailVerificationTokensCollection(r)
+
+	var token models.EmailVerificationToken
+	if err := emailVerificationTokensCollection.FindOne(ctx, bson.M{"token_hash": tokenHash}).
+		Decode(&token); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get email verification token: %w", err)
+	}
+
+	return &token, nil
+}
+
+func (r *Repository) UseEmailVerificationToken(ctx context.Context, tokenID string, usedAt time.Time) error {
+	emailVerificationTokensCollection := useEmailVerificationTokensCollection(r)
+
+	result, err := emailVerificationTokensCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": tokenID, "used_at": nil},
+		bson.M{
+			"$set": bson.M{
+				"used_at": usedAt,
+			},
+		})
+	if err != nil {
+		return fmt.Errorf("cannot use email verification token: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
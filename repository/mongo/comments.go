@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/serhiihuberniuk/blog-api/models"
@@ -18,6 +20,10 @@ func (r *Repository) CreateComment(ctx context.Context, comment *models.Comment)
 		return fmt.Errorf("cannot create comment: %w", err)
 	}
 
+	if err := r.countComment(ctx, comment.PostID, comment.CreatedAt); err != nil {
+		return fmt.Errorf("cannot count comment on post: %w", err)
+	}
+
 	return nil
 }
 
@@ -26,7 +32,7 @@ func (r *Repository) GetComment(ctx context.Context, commentID string) (*models.
 
 	var comment models.Comment
 
-	if err := commentsCollection.FindOne(ctx, bson.M{"_id": commentID}).Decode(&comment); err != nil {
+	if err := commentsCollection.FindOne(ctx, bson.M{"_id": commentID, "deleted_at": nil}).Decode(&comment); err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, models.ErrNotFound
 		}
@@ -37,15 +43,22 @@ func (r *Repository) GetComment(ctx context.Context, commentID string) (*models.
 	return &comment, nil
 }
 
-func (r *Repository) UpdateComment(ctx context.Context, comment *models.Comment) error {
+// UpdateComment saves the comment and records its previous version as the revision.
+func (r *Repository) UpdateComment(ctx context.Context, comment *models.Comment,
+	revision *models.CommentRevision) error {
 	commentsCollection := useCommentsCollection(r)
 
 	result, err := commentsCollection.UpdateOne(
 		ctx,
-		bson.M{"_id": comment.ID},
+		bson.M{"_id": comment.ID, "deleted_at": nil},
 		bson.M{
 			"$set": bson.M{
-				"content": comment.Content,
+				"content":      comment.Content,
+				"updated_at":   comment.UpdatedAt,
+				"format":       comment.Format,
+				"content_html": comment.ContentHTML,
+				"word_count":   comment.WordCount,
+				"mentions":     comment.Mentions,
 			},
 		})
 	if err != nil {
@@ -56,51 +69,153 @@ func (r *Repository) UpdateComment(ctx context.Context, comment *models.Comment)
 		return models.ErrNotFound
 	}
 
+	if _, err := useCommentRevisionsCollection(r).InsertOne(ctx, revision); err != nil {
+		return fmt.Errorf("cannot create revision of comment: %w", err)
+	}
+
 	return nil
 }
 
-func (r *Repository) DeleteComment(ctx context.Context, commentID string) error {
+// GetCommentThread returns the comment with all its replies. Replies of deleted comments are left out.
+func (r *Repository) GetCommentThread(ctx context.Context, commentID string) (*models.CommentThread, error) {
+	commentsCollection := useCommentsCollection(r)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"_id": commentID, "deleted_at": nil}}},
+		{{Key: "$graphLookup", Value: bson.M{
+			"from":                    commentsCollection.Name(),
+			"startWith":               "$_id",
+			"connectFromField":        "_id",
+			"connectToField":          "parent_id",
+			"as":                      "replies",
+			"restrictSearchWithMatch": bson.M{"deleted_at": nil},
+		}}},
+	}
+
+	cursor, err := commentsCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get thread of comment: %w", err)
+	}
+
+	var threads []struct {
+		models.Comment `bson:",inline"`
+		Replies        []*models.Comment `bson:"replies"`
+	}
+	if err = cursor.All(ctx, &threads); err != nil {
+		return nil, fmt.Errorf("cannot get thread of comment: %w", err)
+	}
+
+	if len(threads) == 0 {
+		return nil, models.ErrNotFound
+	}
+
+	comments := threads[0].Replies
+	sort.Slice(comments, func(i, j int) bool {
+		if comments[i].Depth != comments[j].Depth {
+			return comments[i].Depth < comments[j].Depth
+		}
+
+		return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+	})
+
+	return models.NewCommentThread(commentID, append([]*models.Comment{&threads[0].Comment}, comments...)), nil
+}
+
+func (r *Repository) DeleteComment(ctx context.Context, commentID string, deletedAt time.Time) error {
 	commentsCollection := useCommentsCollection(r)
 
-	result, err := commentsCollection.DeleteOne(ctx, bson.M{"_id": commentID})
+	var comment models.Comment
+
+	err := commentsCollection.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": commentID, "deleted_at": nil},
+		bson.M{
+			"$set": bson.M{
+				"deleted_at": deletedAt,
+			},
+		}).Decode(&comment)
 	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.ErrNotFound
+		}
+
 		return fmt.Errorf("cannot delete comment: %w", err)
 	}
 
-	if result.DeletedCount == 0 {
-		return models.ErrNotFound
+	if err := r.shiftCommentCount(ctx, comment.PostID, -1); err != nil {
+		return fmt.Errorf("cannot uncount comment on post: %w", err)
 	}
 
 	return nil
 }
 
-func (r *Repository) ListComments(ctx context.Context, pagination models.Pagination,
-	filter models.FilterComments, sort models.SortComments) ([]*models.Comment, error) {
+func (r *Repository) GetDeletedComment(ctx context.Context, commentID string) (*models.Comment, error) {
 	commentsCollection := useCommentsCollection(r)
 
-	filterComments := bson.M{}
-	if filter.Field != "" {
-		filterComments = bson.M{string(filter.Field): filter.Value}
+	var comment models.Comment
+
+	if err := commentsCollection.FindOne(ctx, bson.M{"_id": commentID, "deleted_at": bson.M{"$ne": nil}}).
+		Decode(&comment); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get deleted comment: %w", err)
 	}
 
-	opts := options.Find()
-	if sort.Field != "" {
-		opts.SetSort(bson.M{string(sort.Field): 1})
+	return &comment, nil
+}
+
+func (r *Repository) RestoreComment(ctx context.Context, commentID string) error {
+	commentsCollection := useCommentsCollection(r)
+
+	var comment models.Comment
 
-		if !sort.IsASC {
-			opts.SetSort(bson.M{string(sort.Field): -1})
+	err := commentsCollection.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": commentID, "deleted_at": bson.M{"$ne": nil}},
+		bson.M{
+			"$unset": bson.M{
+				"deleted_at": "",
+			},
+		}).Decode(&comment)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.ErrNotFound
 		}
+
+		return fmt.Errorf("cannot restore comment: %w", err)
+	}
+
+	if err := r.shiftCommentCount(ctx, comment.PostID, 1); err != nil {
+		return fmt.Errorf("cannot count comment on post: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) ListComments(ctx context.Context, pagination models.Pagination,
+	filter models.FilterComments, sort models.SortComments) ([]*models.Comment, error) {
+	filterComments, err := r.commentsFilter(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get comments: %w", err)
 	}
 
-	if pagination.Offset != 0 {
-		opts.SetSkip(int64(pagination.Offset))
+	keys, err := commentSortKeysOf(sort)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get comments: %w", err)
 	}
 
-	if pagination.Limit != 0 {
-		opts.SetLimit(int64(pagination.Limit))
+	page, reversed, err := paginate(keys, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get comments: %w", err)
 	}
 
-	cursor, err := commentsCollection.Find(ctx, filterComments, opts)
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: filterComments}}}
+	pipeline = append(pipeline, r.commentMetricsStages()...)
+	pipeline = append(pipeline, page...)
+
+	cursor, err := useCommentsCollection(r).Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, fmt.Errorf("cannot get comments: %w", err)
 	}
@@ -110,5 +225,66 @@ func (r *Repository) ListComments(ctx context.Context, pagination models.Paginat
 		return nil, fmt.Errorf("cannot get comments: %w", err)
 	}
 
+	if reversed {
+		for i, j := 0, len(comments)-1; i < j; i, j = i+1, j-1 {
+			comments[i], comments[j] = comments[j], comments[i]
+		}
+	}
+
 	return comments, nil
 }
+
+// CountComments returns the number of comments matching the filter.
+func (r *Repository) CountComments(ctx context.Context, filter models.FilterComments) (int, error) {
+	filterComments, err := r.commentsFilter(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("cannot count comments: %w", err)
+	}
+
+	count, err := useCommentsCollection(r).CountDocuments(ctx, filterComments)
+	if err != nil {
+		return 0, fmt.Errorf("cannot count comments: %w", err)
+	}
+
+	return int(count), nil
+}
+
+// commentsFilter selects the comments matching the filter. Comments of the posts which are not visible
+// are excluded by the IDs of these posts, as the filter is used for counting which cannot join posts.
+func (r *Repository) commentsFilter(ctx context.Context, filter models.FilterComments) (bson.M, error) {
+	filterComments := bson.M{"deleted_at": nil}
+
+	conditions, err := commentsConditions(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if visible := visibilityFilter(filter.Visibility, ""); visible != nil {
+		var hidden []struct {
+			ID string `bson:"_id"`
+		}
+
+		cursor, err := usePostsCollection(r).Find(ctx, bson.M{"$nor": visible},
+			options.Find().SetProjection(bson.M{"_id": 1}))
+		if err != nil {
+			return nil, err
+		}
+
+		if err = cursor.All(ctx, &hidden); err != nil {
+			return nil, err
+		}
+
+		hiddenIDs := make([]string, 0, len(hidden))
+		for _, post := range hidden {
+			hiddenIDs = append(hiddenIDs, post.ID)
+		}
+
+		conditions = append(conditions, bson.M{"post_id": bson.M{"$nin": hiddenIDs}})
+	}
+
+	if len(conditions) != 0 {
+		filterComments["$and"] = conditions
+	}
+
+	return filterComments, nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Keys are looked up instead of taking the names of fields, so a filter cannot inject operators.
+var (
+	postFilterKeys = map[models.FilterPostsByField]string{
+		models.FilterPostsByTitle:     "title",
+		models.FilterPostsByCreatedBy: "created_by",
+		models.FilterPostsByTags:      "tags",
+		models.FilterPostsByStatus:    "status",
+		models.FilterPostsByCreatedAt: "created_at",
+	}
+
+	commentFilterKeys = map[models.FilterCommentsByField]string{
+		models.FilterCommentsByAuthor:    "created_by",
+		models.FilterCommentsByCreatedAt: "created_at",
+		models.FilterCommentsByPost:      "post_id",
+	}
+)
+
+// postsConditions returns the expressions which must be met by the filtered posts.
+func postsConditions(filter models.FilterPosts) (bson.A, error) {
+	conditions := make(bson.A, 0, len(filter.Conditions))
+
+	for _, condition := range filter.Conditions {
+		key, ok := postFilterKeys[condition.Field]
+		if !ok {
+			return nil, fmt.Errorf("cannot filter posts by %q", condition.Field)
+		}
+
+		args, err := condition.Args()
+		if err != nil {
+			return nil, err
+		}
+
+		expression, err := conditionExpression(condition.Operator, args)
+		if err != nil {
+			return nil, err
+		}
+
+		conditions = append(conditions, bson.M{key: expression})
+	}
+
+	return conditions, nil
+}
+
+// commentsConditions returns the expressions which must be met by the filtered comments.
+func commentsConditions(filter models.FilterComments) (bson.A, error) {
+	conditions := make(bson.A, 0, len(filter.Conditions))
+
+	for _, condition := range filter.Conditions {
+		key, ok := commentFilterKeys[condition.Field]
+		if !ok {
+			return nil, fmt.Errorf("cannot filter comments by %q", condition.Field)
+		}
+
+		args, err := condition.Args()
+		if err != nil {
+			return nil, err
+		}
+
+		expression, err := conditionExpression(condition.Operator, args)
+		if err != nil {
+			return nil, err
+		}
+
+		conditions = append(conditions, bson.M{key: expression})
+	}
+
+	return conditions, nil
+}
+
+// conditionExpression compares a field with the arguments of a filter condition. Array fields match
+// when any of their elements meets the condition.
+func conditionExpression(operator models.FilterOperator, args []interface{}) (interface{}, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("no values to compare with")
+	}
+
+	switch operator {
+	case models.FilterOperatorEq:
+		return args[0], nil
+	case models.FilterOperatorIn:
+		return bson.M{"$in": args}, nil
+	case models.FilterOperatorPrefix:
+		prefix, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot compare with prefix %v", args[0])
+		}
+
+		return bson.M{"$regex": "^" + regexp.QuoteMeta(prefix)}, nil
+	case models.FilterOperatorGte:
+		return bson.M{"$gte": args[0]}, nil
+	case models.FilterOperatorLte:
+		return bson.M{"$lte": args[0]}, nil
+	}
+
+	return nil, fmt.Errorf("unknown filter operator %q", operator)
+}
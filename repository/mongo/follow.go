@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Follow saves the follow. Following the user again does nothing.
+func (r *Repository) Follow(ctx context.Context, follow *models.Follow) (bool, error) {
+	followsCollection := useFollowsCollection(r)
+
+	result, err := followsCollection.UpdateOne(ctx,
+		bson.M{"follower_id": follow.FollowerID, "followee_id": follow.FolloweeID},
+		bson.M{"$setOnInsert": bson.M{"created_at": follow.CreatedAt}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return false, fmt.Errorf("cannot follow user: %w", err)
+	}
+
+	return result.UpsertedCount != 0, nil
+}
+
+func (r *Repository) Unfollow(ctx context.Context, followerID, followeeID string) error {
+	followsCollection := useFollowsCollection(r)
+
+	_, err := followsCollection.DeleteOne(ctx, bson.M{"follower_id": followerID, "followee_id": followeeID})
+	if err != nil {
+		return fmt.Errorf("cannot unfollow user: %w", err)
+	}
+
+	return nil
+}
+
+// ListFollowers returns users following the user, the latest followers first.
+func (r *Repository) ListFollowers(ctx context.Context, userID string,
+	pagination models.Pagination) ([]*models.User, error) {
+	users, err := r.listFollowUsers(ctx, "follower_id", "followee_id", userID, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get followers: %w", err)
+	}
+
+	return users, nil
+}
+
+// ListFollowing returns users followed by the user, the latest followed first.
+func (r *Repository) ListFollowing(ctx context.Context, userID string,
+	pagination models.Pagination) ([]*models.User, error) {
+	users, err := r.listFollowUsers(ctx, "followee_id", "follower_id", userID, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get followed users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *Repository) listFollowUsers(ctx context.Context, userField, byField, userID string,
+	pagination models.Pagination) ([]*models.User, error) {
+	followsCollection := useFollowsCollection(r)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{byField: userID}}},
+		{{Key: "$sort", Value: bson.M{"created_at": -1}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         useUsersCollection(r).Name(),
+			"localField":   userField,
+			"foreignField": "_id",
+			"as":           "user",
+		}}},
+		{{Key: "$unwind", Value: "$user"}},
+		{{Key: "$match", Value: bson.M{"user.deleted_at": nil}}},
+	}
+
+	if pagination.Offset != 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$skip", Value: int64(pagination.Offset)}})
+	}
+
+	if pagination.Limit != 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: int64(pagination.Limit)}})
+	}
+
+	pipeline = append(pipeline, bson.D{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$user"}}})
+
+	cursor, err := followsCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []*models.User
+	if err = cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *Repository) followeeIDs(ctx context.Context, followerID string) ([]string, error) {
+	cursor, err := useFollowsCollection(r).Find(ctx, bson.M{"follower_id": followerID},
+		options.Find().SetProjection(bson.M{"followee_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	var follows []*models.Follow
+	if err = cursor.All(ctx, &follows); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(follows))
+	for _, follow := range follows {
+		ids = append(ids, follow.FolloweeID)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (r *Repository) CreateNotification(ctx context.Context, notification *models.Notification) error {
+	notificationsCollection := useNotificationsCollection(r)
+
+	if _, err := notificationsCollection.InsertOne(ctx, notification); err != nil {
+		return fmt.Errorf("cannot create notification: %w", err)
+	}
+
+	return nil
+}
+
+// ListNotifications returns the notifications of the user, newest first.
+func (r *Repository) ListNotifications(ctx context.Context, userID string, unreadOnly bool,
+	pagination models.Pagination) ([]*models.Notification, error) {
+	notificationsCollection := useNotificationsCollection(r)
+
+	filter := bson.M{"user_id": userID}
+	if unreadOnly {
+		filter["read_at"] = nil
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	if pagination.Offset != 0 {
+		opts.SetSkip(int64(pagination.Offset))
+	}
+
+	if pagination.Limit != 0 {
+		opts.SetLimit(int64(pagination.Limit))
+	}
+
+	cursor, err := notificationsCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get notifications: %w", err)
+	}
+
+	var notifications []*models.Notification
+	if err = cursor.All(ctx, &notifications); err != nil {
+		return nil, fmt.Errorf("cannot get notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (r *Repository) CountUnreadNotifications(ctx context.Context, userID string) (int, error) {
+	notificationsCollection := useNotificationsCollection(r)
+
+	count, err := notificationsCollection.CountDocuments(ctx, bson.M{"user_id": userID, "read_at": nil})
+	if err != nil {
+		return 0, fmt.Errorf("cannot count unread notifications: %w", err)
+	}
+
+	return int(count), nil
+}
+
+// MarkNotificationRead marks the notification of the user as read. A notification which is already read
+// keeps the time it was read at.
+func (r *Repository) MarkNotificationRead(ctx context.Context, userID, notificationID string,
+	readAt time.Time) error {
+	notificationsCollection := useNotificationsCollection(r)
+
+	filter := bson.M{"_id": notificationID, "user_id": userID}
+
+	result, err := notificationsCollection.UpdateOne(ctx,
+		bson.M{"_id": notificationID, "user_id": userID, "read_at": nil},
+		bson.M{"$set": bson.M{"read_at": readAt}},
+	)
+	if err != nil {
+		return fmt.Errorf("cannot mark notification as read: %w", err)
+	}
+
+	if result.MatchedCount != 0 {
+		return nil
+	}
+
+	count, err := notificationsCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("cannot mark notification as read: %w", err)
+	}
+
+	if count == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) MarkAllNotificationsRead(ctx context.Context, userID string, readAt time.Time) error {
+	notificationsCollection := useNotificationsCollection(r)
+
+	_, err := notificationsCollection.UpdateMany(ctx,
+		bson.M{"user_id": userID, "read_at": nil},
+		bson.M{"$set": bson.M{"read_at": readAt}},
+	)
+	if err != nil {
+		return fmt.Errorf("cannot mark notifications as read: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) UpdateNotificationPreferences(ctx context.Context, userID string,
+	preferences models.NotificationPreferences) error {
+	usersCollection := useUsersCollection(r)
+
+	result, err := usersCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": userID, "deleted_at": nil},
+		bson.M{
+			"$set": bson.M{
+				"notification_preferences": preferences,
+			},
+		})
+	if err != nil {
+		return fmt.Errorf("cannot update notification preferences: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// sortKey is a key to order the documents by together with the direction.
+type sortKey struct {
+	name  string
+	isASC bool
+}
+
+// paginate returns the stages ordering the documents by the keys and the ID and selecting the page.
+// The page before a cursor is selected in the reversed order, which the returned flag tells to restore.
+// Documents are not ordered and cannot be paged by a cursor when there are no keys.
+func paginate(keys []sortKey, pagination models.Pagination) (mongo.Pipeline, bool, error) {
+	if len(keys) == 0 && pagination.IsKeyset() {
+		return nil, false, fmt.Errorf("cannot page by cursor without sorting")
+	}
+
+	cursor := pagination.After
+	if pagination.Before != nil {
+		cursor = pagination.Before
+
+		reversed := make([]sortKey, 0, len(keys))
+		for _, key := range keys {
+			reversed = append(reversed, sortKey{name: key.name, isASC: !key.isASC})
+		}
+
+		keys = reversed
+	}
+
+	var stages mongo.Pipeline
+
+	if cursor != nil {
+		condition, err := keysetCondition(keys, cursor)
+		if err != nil {
+			return nil, false, err
+		}
+
+		stages = append(stages, bson.D{{Key: "$match", Value: condition}})
+	}
+
+	if len(keys) != 0 {
+		order := make(bson.D, 0, len(keys)+1)
+		for _, key := range withIDKey(keys) {
+			direction := -1
+			if key.isASC {
+				direction = 1
+			}
+
+			order = append(order, bson.E{Key: key.name, Value: direction})
+		}
+
+		stages = append(stages, bson.D{{Key: "$sort", Value: order}})
+	}
+
+	if pagination.Offset != 0 && !pagination.IsKeyset() {
+		stages = append(stages, bson.D{{Key: "$skip", Value: int64(pagination.Offset)}})
+	}
+
+	if pagination.Limit != 0 {
+		stages = append(stages, bson.D{{Key: "$limit", Value: int64(pagination.Limit)}})
+	}
+
+	return stages, pagination.Before != nil, nil
+}
+
+// keysetCondition selects the documents following the cursor in the order of the keys. A document follows
+// the cursor when it is equal to the cursor by some first keys and goes after it by the next one.
+func keysetCondition(keys []sortKey, cursor *models.Cursor) (bson.M, error) {
+	values, err := cursor.KeyArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values) != len(keys) {
+		return nil, fmt.Errorf("cursor has %d keys for %d sort keys", len(values), len(keys))
+	}
+
+	keys = withIDKey(keys)
+	values = append(values, cursor.ID)
+
+	conditions := make(bson.A, 0, len(keys))
+
+	for i, key := range keys {
+		following := bson.M{}
+		for j := 0; j < i; j++ {
+			following[keys[j].name] = values[j]
+		}
+
+		operator := "$lt"
+		if key.isASC {
+			operator = "$gt"
+		}
+
+		following[key.name] = bson.M{operator: values[i]}
+		conditions = append(conditions, following)
+	}
+
+	return bson.M{"$or": conditions}, nil
+}
+
+// withIDKey returns the keys followed by the ID, which breaks ties in the direction of the last key.
+func withIDKey(keys []sortKey) []sortKey {
+	out := make([]sortKey, 0, len(keys)+1)
+	out = append(out, keys...)
+
+	return append(out, sortKey{name: "_id", isASC: keys[len(keys)-1].isASC})
+}
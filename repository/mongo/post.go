@@ -4,18 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/serhiihuberniuk/blog-api/models"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func (r *Repository) CreatePost(ctx context.Context, post *models.Post) error {
 	postsCollection := usePostsCollection(r)
 
 	if _, err := postsCollection.InsertOne(ctx, post); err != nil {
-		return fmt.Errorf("cannot create post: %w", err)
+		return fmt.Errorf("cannot create post: %w", slugError(err))
 	}
 
 	return nil
@@ -26,7 +26,7 @@ func (r *Repository) GetPost(ctx context.Context, postID string) (*models.Post,
 
 	var post models.Post
 
-	if err := postsCollection.FindOne(ctx, bson.M{"_id": postID}).Decode(&post); err != nil {
+	if err := postsCollection.FindOne(ctx, bson.M{"_id": postID, "deleted_at": nil}).Decode(&post); err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, models.ErrNotFound
 		}
@@ -37,21 +37,57 @@ func (r *Repository) GetPost(ctx context.Context, postID string) (*models.Post,
 	return &post, nil
 }
 
-func (r *Repository) UpdatePost(ctx context.Context, post *models.Post) error {
+// UpdatePost saves the post and records its previous version as the revision.
+// A replaced slug is kept as a redirect to the post.
+func (r *Repository) UpdatePost(ctx context.Context, post *models.Post, revision *models.PostRevision) error {
 	postsCollection := usePostsCollection(r)
 
-	result, err := postsCollection.UpdateOne(
+	var previous models.Post
+
+	err := postsCollection.FindOneAndUpdate(
 		ctx,
-		bson.M{"_id": post.ID},
+		bson.M{"_id": post.ID, "deleted_at": nil},
 		bson.M{
 			"$set": bson.M{
-				"title":       post.Title,
-				"description": post.Description,
-				"tags":        post.Tags,
+				"title":            post.Title,
+				"description":      post.Description,
+				"tags":             post.Tags,
+				"updated_at":       post.UpdatedAt,
+				"slug":             post.Slug,
+				"format":           post.Format,
+				"description_html": post.DescriptionHTML,
+				"word_count":       post.WordCount,
+				"mentions":         post.Mentions,
 			},
-		})
+		}).Decode(&previous)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.ErrNotFound
+		}
+
+		return fmt.Errorf("cannot update post: %w", slugError(err))
+	}
+
+	if err := r.redirectSlug(ctx, post.ID, previous.Slug, post.Slug); err != nil {
+		return fmt.Errorf("cannot redirect slug of post: %w", err)
+	}
+
+	if _, err := usePostRevisionsCollection(r).InsertOne(ctx, revision); err != nil {
+		return fmt.Errorf("cannot create revision of post: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) UpdatePostStatus(ctx context.Context, postID string, status models.PostStatus) error {
+	postsCollection := usePostsCollection(r)
+
+	result, err := postsCollection.UpdateOne(ctx,
+		bson.M{"_id": postID, "deleted_at": nil},
+		bson.M{"$set": bson.M{"status": status}},
+	)
 	if err != nil {
-		return fmt.Errorf("cannot update post: %w", err)
+		return fmt.Errorf("cannot update status of post: %w", err)
 	}
 
 	if result.MatchedCount == 0 {
@@ -61,48 +97,119 @@ func (r *Repository) UpdatePost(ctx context.Context, post *models.Post) error {
 	return nil
 }
 
-func (r *Repository) DeletePost(ctx context.Context, postID string) error {
+// DeletePost marks the post and its comments as deleted with the same time,
+// so that RestorePost can tell them apart from comments deleted on their own.
+func (r *Repository) DeletePost(ctx context.Context, postID string, deletedAt time.Time) error {
 	postsCollection := usePostsCollection(r)
 
-	result, err := postsCollection.DeleteOne(ctx, bson.M{"_id": postID})
+	result, err := postsCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": postID, "deleted_at": nil},
+		bson.M{
+			"$set": bson.M{
+				"deleted_at": deletedAt,
+			},
+		})
 	if err != nil {
 		return fmt.Errorf("cannot delete post: %w", err)
 	}
 
-	if result.DeletedCount == 0 {
+	if result.MatchedCount == 0 {
 		return models.ErrNotFound
 	}
 
+	_, err = useCommentsCollection(r).UpdateMany(
+		ctx,
+		bson.M{"post_id": postID, "deleted_at": nil},
+		bson.M{
+			"$set": bson.M{
+				"deleted_at": deletedAt,
+			},
+		})
+	if err != nil {
+		return fmt.Errorf("cannot delete comments of post: %w", err)
+	}
+
 	return nil
 }
 
-func (r *Repository) ListPosts(ctx context.Context, pagination models.Pagination,
-	filter models.FilterPosts, sort models.SortPosts) ([]*models.Post, error) {
+func (r *Repository) GetDeletedPost(ctx context.Context, postID string) (*models.Post, error) {
 	postsCollection := usePostsCollection(r)
 
-	filterPosts := bson.M{}
-	if filter.Field != "" {
-		filterPosts = bson.M{string(filter.Field): filter.Value}
+	var post models.Post
+
+	if err := postsCollection.FindOne(ctx, bson.M{"_id": postID, "deleted_at": bson.M{"$ne": nil}}).
+		Decode(&post); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get deleted post: %w", err)
 	}
 
-	opts := options.Find()
-	if sort.SortByField != "" {
-		opts.SetSort(bson.M{string(sort.SortByField): 1})
+	return &post, nil
+}
 
-		if !sort.IsASC {
-			opts.SetSort(bson.M{string(sort.SortByField): -1})
-		}
+// RestorePost restores the post together with the comments that were deleted with it.
+func (r *Repository) RestorePost(ctx context.Context, postID string) error {
+	post, err := r.GetDeletedPost(ctx, postID)
+	if err != nil {
+		return err
+	}
+
+	_, err = useCommentsCollection(r).UpdateMany(
+		ctx,
+		bson.M{"post_id": postID, "deleted_at": post.DeletedAt},
+		bson.M{
+			"$unset": bson.M{
+				"deleted_at": "",
+			},
+		})
+	if err != nil {
+		return fmt.Errorf("cannot restore comments of post: %w", err)
+	}
+
+	result, err := usePostsCollection(r).UpdateOne(
+		ctx,
+		bson.M{"_id": postID, "deleted_at": bson.M{"$ne": nil}},
+		bson.M{
+			"$unset": bson.M{
+				"deleted_at": "",
+			},
+		})
+	if err != nil {
+		return fmt.Errorf("cannot restore post: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) ListPosts(ctx context.Context, pagination models.Pagination,
+	filter models.FilterPosts, sort models.SortPosts) ([]*models.Post, error) {
+	filterPosts, err := r.postsFilter(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get posts: %w", err)
 	}
 
-	if pagination.Offset != 0 {
-		opts.SetSkip(int64(pagination.Offset))
+	keys, err := postSortKeysOf(sort)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get posts: %w", err)
 	}
 
-	if pagination.Limit != 0 {
-		opts.SetLimit(int64(pagination.Limit))
+	page, reversed, err := paginate(keys, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get posts: %w", err)
 	}
 
-	cursor, err := postsCollection.Find(ctx, filterPosts, opts)
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: filterPosts}}}
+	pipeline = append(pipeline, r.postMetricsStages()...)
+	pipeline = append(pipeline, page...)
+
+	cursor, err := usePostsCollection(r).Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, fmt.Errorf("cannot get posts: %w", err)
 	}
@@ -112,5 +219,55 @@ func (r *Repository) ListPosts(ctx context.Context, pagination models.Pagination
 		return nil, fmt.Errorf("cannot get posts: %w", err)
 	}
 
+	if reversed {
+		for i, j := 0, len(posts)-1; i < j; i, j = i+1, j-1 {
+			posts[i], posts[j] = posts[j], posts[i]
+		}
+	}
+
 	return posts, nil
 }
+
+// CountPosts returns the number of posts matching the filter.
+func (r *Repository) CountPosts(ctx context.Context, filter models.FilterPosts) (int, error) {
+	filterPosts, err := r.postsFilter(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("cannot count posts: %w", err)
+	}
+
+	count, err := usePostsCollection(r).CountDocuments(ctx, filterPosts)
+	if err != nil {
+		return 0, fmt.Errorf("cannot count posts: %w", err)
+	}
+
+	return int(count), nil
+}
+
+// postsFilter selects the posts matching the filter.
+func (r *Repository) postsFilter(ctx context.Context, filter models.FilterPosts) (bson.M, error) {
+	filterPosts := bson.M{"deleted_at": nil}
+
+	conditions, err := postsConditions(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.FollowedBy != "" {
+		followeeIDs, err := r.followeeIDs(ctx, filter.FollowedBy)
+		if err != nil {
+			return nil, err
+		}
+
+		conditions = append(conditions, bson.M{"created_by": bson.M{"$in": followeeIDs}})
+	}
+
+	if len(conditions) != 0 {
+		filterPosts["$and"] = conditions
+	}
+
+	if visible := visibilityFilter(filter.Visibility, ""); visible != nil {
+		filterPosts["$or"] = visible
+	}
+
+	return filterPosts, nil
+}
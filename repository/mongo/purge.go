@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PurgeDeleted permanently removes users, posts and comments deleted before the given time together with
+// the content of purged users, the comments and attachments of purged posts, their revisions, reactions,
+// follows and notifications.
+func (r *Repository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) error {
+	deleted := bson.M{"deleted_at": bson.M{"$lt": deletedBefore}}
+
+	userIDs, postIDs, err := r.findPurgeableIDs(ctx, deletedBefore)
+	if err != nil {
+		return err
+	}
+
+	commentIDs, err := findIDs(ctx, useCommentsCollection(r), bson.M{
+		"$or": bson.A{
+			deleted,
+			bson.M{"created_by": bson.M{"$in": userIDs}},
+			bson.M{"post_id": bson.M{"$in": postIDs}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("cannot purge deleted comments: %w", err)
+	}
+
+	_, err = useCommentRevisionsCollection(r).DeleteMany(ctx, bson.M{"comment_id": bson.M{"$in": commentIDs}})
+	if err != nil {
+		return fmt.Errorf("cannot purge revisions of deleted comments: %w", err)
+	}
+
+	if _, err = useCommentsCollection(r).DeleteMany(ctx, bson.M{"_id": bson.M{"$in": commentIDs}}); err != nil {
+		return fmt.Errorf("cannot purge deleted comments: %w", err)
+	}
+
+	if _, err = usePostRevisionsCollection(r).DeleteMany(ctx, bson.M{"post_id": bson.M{"$in": postIDs}}); err != nil {
+		return fmt.Errorf("cannot purge revisions of deleted posts: %w", err)
+	}
+
+	if _, err = useAttachmentsCollection(r).DeleteMany(ctx, bson.M{"post_id": bson.M{"$in": postIDs}}); err != nil {
+		return fmt.Errorf("cannot purge attachments of deleted posts: %w", err)
+	}
+
+	_, err = usePostSlugRedirectsCollection(r).DeleteMany(ctx, bson.M{"post_id": bson.M{"$in": postIDs}})
+	if err != nil {
+		return fmt.Errorf("cannot purge slugs of deleted posts: %w", err)
+	}
+
+	if _, err = usePostsCollection(r).DeleteMany(ctx, bson.M{"_id": bson.M{"$in": postIDs}}); err != nil {
+		return fmt.Errorf("cannot purge deleted posts: %w", err)
+	}
+
+	_, err = useReactionsCollection(r).DeleteMany(ctx, bson.M{
+		"$or": bson.A{
+			bson.M{"target_type": models.ReactionTargetPost, "target_id": bson.M{"$in": postIDs}},
+			bson.M{"target_type": models.ReactionTargetComment, "target_id": bson.M{"$in": commentIDs}},
+			bson.M{"user_id": bson.M{"$in": userIDs}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("cannot purge reactions: %w", err)
+	}
+
+	_, err = useFollowsCollection(r).DeleteMany(ctx, bson.M{
+		"$or": bson.A{
+			bson.M{"follower_id": bson.M{"$in": userIDs}},
+			bson.M{"followee_id": bson.M{"$in": userIDs}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("cannot purge follows: %w", err)
+	}
+
+	_, err = useNotificationsCollection(r).DeleteMany(ctx, bson.M{
+		"$or": bson.A{
+			bson.M{"user_id": bson.M{"$in": userIDs}},
+			bson.M{"actor_id": bson.M{"$in": userIDs}},
+			bson.M{"post_id": bson.M{"$in": postIDs}},
+			bson.M{"comment_id": bson.M{"$in": commentIDs}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("cannot purge notifications: %w", err)
+	}
+
+	if _, err = useUsersCollection(r).DeleteMany(ctx, bson.M{"_id": bson.M{"$in": userIDs}}); err != nil {
+		return fmt.Errorf("cannot purge deleted users: %w", err)
+	}
+
+	return nil
+}
+
+// findPurgeableIDs returns the users deleted before the given time and the posts which are purged with them:
+// the deleted posts and the posts of the deleted users.
+func (r *Repository) findPurgeableIDs(ctx context.Context, deletedBefore time.Time) ([]string, []string, error) {
+	deleted := bson.M{"deleted_at": bson.M{"$lt": deletedBefore}}
+
+	userIDs, err := findIDs(ctx, useUsersCollection(r), deleted)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot purge deleted users: %w", err)
+	}
+
+	postIDs, err := findIDs(ctx, usePostsCollection(r), bson.M{
+		"$or": bson.A{deleted, bson.M{"created_by": bson.M{"$in": userIDs}}},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot purge deleted posts: %w", err)
+	}
+
+	return userIDs, postIDs, nil
+}
+
+func findIDs(ctx context.Context, c *mongo.Collection, filter bson.M) ([]string, error) {
+	cursor, err := c.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	var documents []struct {
+		ID string `bson:"_id"`
+	}
+	if err = cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(documents))
+	for _, d := range documents {
+		ids = append(ids, d.ID)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AddReaction saves the reaction. Adding a reaction which already exists does nothing.
+func (r *Repository) AddReaction(ctx context.Context, reaction *models.Reaction) (bool, error) {
+	reactionsCollection := useReactionsCollection(r)
+
+	result, err := reactionsCollection.UpdateOne(ctx,
+		reactionFilter(reaction),
+		bson.M{"$setOnInsert": bson.M{"created_at": reaction.CreatedAt}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return false, fmt.Errorf("cannot add reaction: %w", err)
+	}
+
+	return result.UpsertedCount != 0, nil
+}
+
+func (r *Repository) RemoveReaction(ctx context.Context, reaction *models.Reaction) error {
+	reactionsCollection := useReactionsCollection(r)
+
+	if _, err := reactionsCollection.DeleteOne(ctx, reactionFilter(reaction)); err != nil {
+		return fmt.Errorf("cannot remove reaction: %w", err)
+	}
+
+	return nil
+}
+
+// CountReactions returns the numbers of reactions left on each of the targets by their IDs.
+func (r *Repository) CountReactions(ctx context.Context, targetType models.ReactionTarget,
+	targetIDs []string) (map[string]models.ReactionCounts, error) {
+	reactionsCollection := useReactionsCollection(r)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"target_type": targetType, "target_id": bson.M{"$in": targetIDs}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"target_id": "$target_id", "type": "$type"},
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := reactionsCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("cannot count reactions: %w", err)
+	}
+
+	var rows []struct {
+		Key struct {
+			TargetID string              `bson:"target_id"`
+			Type     models.ReactionType `bson:"type"`
+		} `bson:"_id"`
+		Count int `bson:"count"`
+	}
+	if err = cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("cannot count reactions: %w", err)
+	}
+
+	counts := make(map[string]models.ReactionCounts, len(targetIDs))
+	for _, targetID := range targetIDs {
+		counts[targetID] = models.ReactionCounts{}
+	}
+
+	for _, row := range rows {
+		counts[row.Key.TargetID][row.Key.Type] = row.Count
+	}
+
+	return counts, nil
+}
+
+func reactionFilter(reaction *models.Reaction) bson.M {
+	return bson.M{
+		"target_type": reaction.TargetType,
+		"target_id":   reaction.TargetID,
+		"user_id":     reaction.UserID,
+		"type":        reaction.Type,
+	}
+}
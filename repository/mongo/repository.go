@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/serhiihuberniuk/blog-api/models"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -38,6 +39,50 @@ func NewMongoDb(ctx context.Context, r *Repository) (*mongo.Database, error) {
 		return nil, fmt.Errorf("cannot create database: %w", err)
 	}
 
+	if err := createIndexesForRefreshTokens(ctx, useRefreshTokensCollection(r)); err != nil {
+		return nil, fmt.Errorf("cannot create database: %w", err)
+	}
+
+	if err := createIndexForPasswordResetTokens(ctx, usePasswordResetTokensCollection(r)); err != nil {
+		return nil, fmt.Errorf("cannot create database: %w", err)
+	}
+
+	if err := createIndexForEmailVerificationTokens(ctx, useEmailVerificationTokensCollection(r)); err != nil {
+		return nil, fmt.Errorf("cannot create database: %w", err)
+	}
+
+	if err := createIndexForPostRevisions(ctx, usePostRevisionsCollection(r)); err != nil {
+		return nil, fmt.Errorf("cannot create database: %w", err)
+	}
+
+	if err := createIndexForCommentRevisions(ctx, useCommentRevisionsCollection(r)); err != nil {
+		return nil, fmt.Errorf("cannot create database: %w", err)
+	}
+
+	if err := createIndexForPostSlugRedirects(ctx, usePostSlugRedirectsCollection(r)); err != nil {
+		return nil, fmt.Errorf("cannot create database: %w", err)
+	}
+
+	if err := createIndexForReactions(ctx, useReactionsCollection(r)); err != nil {
+		return nil, fmt.Errorf("cannot create database: %w", err)
+	}
+
+	if err := createIndexesForFollows(ctx, useFollowsCollection(r)); err != nil {
+		return nil, fmt.Errorf("cannot create database: %w", err)
+	}
+
+	if err := createIndexesForNotifications(ctx, useNotificationsCollection(r)); err != nil {
+		return nil, fmt.Errorf("cannot create database: %w", err)
+	}
+
+	if err := createIndexesForAttachments(ctx, useAttachmentsCollection(r)); err != nil {
+		return nil, fmt.Errorf("cannot create database: %w", err)
+	}
+
+	if err := createDeletedUser(ctx, useUsersCollection(r)); err != nil {
+		return nil, fmt.Errorf("cannot create database: %w", err)
+	}
+
 	return r.Db, nil
 }
 
@@ -46,12 +91,19 @@ func useUsersCollection(r *Repository) *mongo.Collection {
 }
 
 func createIndexForUsers(ctx context.Context, c *mongo.Collection) error {
-	mod := mongo.IndexModel{
-		Keys:    bson.M{"email": 1},
-		Options: options.Index().SetUnique(true),
+	mods := []mongo.IndexModel{
+		{
+			Keys:    bson.M{"email": 1},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.M{"username": 1},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{"username": bson.M{"$exists": true}}),
+		},
 	}
 
-	_, err := c.Indexes().CreateOne(ctx, mod)
+	_, err := c.Indexes().CreateMany(ctx, mods)
 	if err != nil {
 		return fmt.Errorf("cannot create index, %w", err)
 	}
@@ -63,6 +115,32 @@ func usePostsCollection(r *Repository) *mongo.Collection {
 	return r.Db.Collection("posts")
 }
 
+// createDeletedUser adds the tombstone user which takes over the content of deleted users.
+func createDeletedUser(ctx context.Context, c *mongo.Collection) error {
+	now := time.Now()
+
+	_, err := c.UpdateOne(ctx,
+		bson.M{"_id": models.DeletedUserID},
+		bson.M{
+			"$setOnInsert": models.User{
+				ID:              models.DeletedUserID,
+				Name:            "Deleted user",
+				Username:        models.DeletedUsername,
+				Email:           "deleted-user@blog-api.local",
+				CreatedAt:       now,
+				UpdatedAt:       now,
+				Role:            models.RoleUser,
+				EmailVerifiedAt: &now,
+			},
+		},
+		options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("cannot create deleted user: %w", err)
+	}
+
+	return nil
+}
+
 func createIndexesForPosts(ctx context.Context, c *mongo.Collection) error {
 	mods := []mongo.IndexModel{
 		{
@@ -73,6 +151,23 @@ func createIndexesForPosts(ctx context.Context, c *mongo.Collection) error {
 			Keys:    bson.M{"created_at": 1},
 			Options: options.Index(),
 		},
+		{
+			Keys:    bson.D{{Key: "created_by", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index(),
+		},
+		{
+			Keys:    bson.M{"tags": 1},
+			Options: options.Index(),
+		},
+		{
+			Keys:    bson.M{"slug": 1},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+		{
+			Keys: bson.D{{Key: "title", Value: "text"}, {Key: "description", Value: "text"}, {Key: "tags", Value: "text"}},
+			Options: options.Index().SetWeights(bson.M{"title": 10, "description": 5, "tags": 2}).
+				SetDefaultLanguage("english"),
+		},
 	}
 
 	_, err := c.Indexes().CreateMany(ctx, mods)
@@ -97,6 +192,235 @@ func createIndexesForComments(ctx context.Context, c *mongo.Collection) error {
 			Keys:    bson.M{"post_id": 1},
 			Options: options.Index(),
 		},
+		{
+			Keys:    bson.M{"parent_id": 1},
+			Options: options.Index(),
+		},
+		{
+			Keys:    bson.M{"content": "text"},
+			Options: options.Index().SetDefaultLanguage("english"),
+		},
+	}
+
+	_, err := c.Indexes().CreateMany(ctx, mods)
+	if err != nil {
+		return fmt.Errorf("cannot create index, %w", err)
+	}
+
+	return nil
+}
+
+func useRefreshTokensCollection(r *Repository) *mongo.Collection {
+	return r.Db.Collection("refresh_tokens")
+}
+
+func createIndexesForRefreshTokens(ctx context.Context, c *mongo.Collection) error {
+	mods := []mongo.IndexModel{
+		{
+			Keys:    bson.M{"token_hash": 1},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.M{"family_id": 1},
+			Options: options.Index(),
+		},
+		{
+			Keys:    bson.M{"user_id": 1},
+			Options: options.Index(),
+		},
+	}
+
+	_, err := c.Indexes().CreateMany(ctx, mods)
+	if err != nil {
+		return fmt.Errorf("cannot create index, %w", err)
+	}
+
+	return nil
+}
+
+func usePasswordResetTokensCollection(r *Repository) *mongo.Collection {
+	return r.Db.Collection("password_reset_tokens")
+}
+
+func createIndexForPasswordResetTokens(ctx context.Context, c *mongo.Collection) error {
+	mod := mongo.IndexModel{
+		Keys:    bson.M{"token_hash": 1},
+		Options: options.Index().SetUnique(true),
+	}
+
+	_, err := c.Indexes().CreateOne(ctx, mod)
+	if err != nil {
+		return fmt.Errorf("cannot create index, %w", err)
+	}
+
+	return nil
+}
+
+func useEmailVerificationTokensCollection(r *Repository) *mongo.Collection {
+	return r.Db.Collection("email_verification_tokens")
+}
+
+func createIndexForEmailVerificationTokens(ctx context.Context, c *mongo.Collection) error {
+	mod := mongo.IndexModel{
+		Keys:    bson.M{"token_hash": 1},
+		Options: options.Index().SetUnique(true),
+	}
+
+	_, err := c.Indexes().CreateOne(ctx, mod)
+	if err != nil {
+		return fmt.Errorf("cannot create index, %w", err)
+	}
+
+	return nil
+}
+
+func usePostRevisionsCollection(r *Repository) *mongo.Collection {
+	return r.Db.Collection("post_revisions")
+}
+
+func createIndexForPostRevisions(ctx context.Context, c *mongo.Collection) error {
+	mod := mongo.IndexModel{
+		Keys:    bson.D{{Key: "post_id", Value: 1}, {Key: "edited_at", Value: 1}},
+		Options: options.Index(),
+	}
+
+	_, err := c.Indexes().CreateOne(ctx, mod)
+	if err != nil {
+		return fmt.Errorf("cannot create index, %w", err)
+	}
+
+	return nil
+}
+
+func useCommentRevisionsCollection(r *Repository) *mongo.Collection {
+	return r.Db.Collection("comment_revisions")
+}
+
+func createIndexForCommentRevisions(ctx context.Context, c *mongo.Collection) error {
+	mod := mongo.IndexModel{
+		Keys:    bson.D{{Key: "comment_id", Value: 1}, {Key: "edited_at", Value: 1}},
+		Options: options.Index(),
+	}
+
+	_, err := c.Indexes().CreateOne(ctx, mod)
+	if err != nil {
+		return fmt.Errorf("cannot create index, %w", err)
+	}
+
+	return nil
+}
+
+func usePostSlugRedirectsCollection(r *Repository) *mongo.Collection {
+	return r.Db.Collection("post_slug_redirects")
+}
+
+func createIndexForPostSlugRedirects(ctx context.Context, c *mongo.Collection) error {
+	mod := mongo.IndexModel{
+		Keys:    bson.M{"post_id": 1},
+		Options: options.Index(),
+	}
+
+	_, err := c.Indexes().CreateOne(ctx, mod)
+	if err != nil {
+		return fmt.Errorf("cannot create index, %w", err)
+	}
+
+	return nil
+}
+
+func useReactionsCollection(r *Repository) *mongo.Collection {
+	return r.Db.Collection("reactions")
+}
+
+func createIndexForReactions(ctx context.Context, c *mongo.Collection) error {
+	mod := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "target_type", Value: 1},
+			{Key: "target_id", Value: 1},
+			{Key: "user_id", Value: 1},
+			{Key: "type", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	}
+
+	_, err := c.Indexes().CreateOne(ctx, mod)
+	if err != nil {
+		return fmt.Errorf("cannot create index, %w", err)
+	}
+
+	return nil
+}
+
+func useFollowsCollection(r *Repository) *mongo.Collection {
+	return r.Db.Collection("follows")
+}
+
+func createIndexesForFollows(ctx context.Context, c *mongo.Collection) error {
+	mods := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "follower_id", Value: 1}, {Key: "followee_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "followee_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index(),
+		},
+	}
+
+	_, err := c.Indexes().CreateMany(ctx, mods)
+	if err != nil {
+		return fmt.Errorf("cannot create index, %w", err)
+	}
+
+	return nil
+}
+
+func useTagAliasesCollection(r *Repository) *mongo.Collection {
+	return r.Db.Collection("tag_aliases")
+}
+
+func useAttachmentsCollection(r *Repository) *mongo.Collection {
+	return r.Db.Collection("attachments")
+}
+
+func createIndexesForAttachments(ctx context.Context, c *mongo.Collection) error {
+	mods := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "post_id", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index(),
+		},
+		{
+			Keys:    bson.D{{Key: "created_by", Value: 1}},
+			Options: options.Index(),
+		},
+		{
+			Keys:    bson.D{{Key: "variant_status", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetSparse(true),
+		},
+	}
+
+	_, err := c.Indexes().CreateMany(ctx, mods)
+	if err != nil {
+		return fmt.Errorf("cannot create index, %w", err)
+	}
+
+	return nil
+}
+
+func useNotificationsCollection(r *Repository) *mongo.Collection {
+	return r.Db.Collection("notifications")
+}
+
+func createIndexesForNotifications(ctx context.Context, c *mongo.Collection) error {
+	mods := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index(),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "read_at", Value: 1}},
+			Options: options.Index(),
+		},
 	}
 
 	_, err := c.Indexes().CreateMany(ctx, mods)
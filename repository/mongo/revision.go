@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (r *Repository) ListPostRevisions(ctx context.Context, postID string) ([]*models.PostRevision, error) {
+	postRevisionsCollection := usePostRevisionsCollection(r)
+
+	cursor, err := postRevisionsCollection.Find(ctx, bson.M{"post_id": postID},
+		options.Find().SetSort(bson.M{"edited_at": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("cannot get revisions of post: %w", err)
+	}
+
+	var revisions []*models.PostRevision
+	if err = cursor.All(ctx, &revisions); err != nil {
+		return nil, fmt.Errorf("cannot get revisions of post: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (r *Repository) GetPostRevision(ctx context.Context, revisionID string) (*models.PostRevision, error) {
+	postRevisionsCollection := usePostRevisionsCollection(r)
+
+	var revision models.PostRevision
+
+	if err := postRevisionsCollection.FindOne(ctx, bson.M{"_id": revisionID}).Decode(&revision); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get revision of post: %w", err)
+	}
+
+	return &revision, nil
+}
+
+func (r *Repository) ListCommentRevisions(ctx context.Context, commentID string) ([]*models.CommentRevision, error) {
+	commentRevisionsCollection := useCommentRevisionsCollection(r)
+
+	cursor, err := commentRevisionsCollection.Find(ctx, bson.M{"comment_id": commentID},
+		options.Find().SetSort(bson.M{"edited_at": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("cannot get revisions of comment: %w", err)
+	}
+
+	var revisions []*models.CommentRevision
+	if err = cursor.All(ctx, &revisions); err != nil {
+		return nil, fmt.Errorf("cannot get revisions of comment: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (r *Repository) GetCommentRevision(ctx context.Context, revisionID string) (*models.CommentRevision, error) {
+	commentRevisionsCollection := useCommentRevisionsCollection(r)
+
+	var revision models.CommentRevision
+
+	if err := commentRevisionsCollection.FindOne(ctx, bson.M{"_id": revisionID}).Decode(&revision); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get revision of comment: %w", err)
+	}
+
+	return &revision, nil
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Search ranks posts and comments matching the search text by relevance. Comments are found only
+// on posts which can be seen with the visibility of the query.
+func (r *Repository) Search(ctx context.Context, query models.SearchQuery,
+	pagination models.Pagination) ([]*models.SearchResult, error) {
+	// Results of both collections are merged, so every collection returns enough of them to fill the page.
+	limit := int64(pagination.Offset + pagination.Limit)
+
+	var results []*models.SearchResult
+
+	if query.HasTarget(models.SearchTargetPost) {
+		posts, err := r.searchPosts(ctx, query, limit)
+		if err != nil {
+			return nil, fmt.Errorf("cannot search posts: %w", err)
+		}
+
+		results = append(results, posts...)
+	}
+
+	if query.HasTarget(models.SearchTargetComment) {
+		comments, err := r.searchComments(ctx, query, limit)
+		if err != nil {
+			return nil, fmt.Errorf("cannot search comments: %w", err)
+		}
+
+		results = append(results, comments...)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Rank > results[j].Rank
+	})
+
+	if pagination.Offset >= uint64(len(results)) {
+		return []*models.SearchResult{}, nil
+	}
+
+	results = results[pagination.Offset:]
+	if pagination.Limit != 0 && pagination.Limit < uint64(len(results)) {
+		results = results[:pagination.Limit]
+	}
+
+	return results, nil
+}
+
+func (r *Repository) searchPosts(ctx context.Context, query models.SearchQuery,
+	limit int64) ([]*models.SearchResult, error) {
+	match := bson.M{"$text": bson.M{"$search": query.Text}, "deleted_at": nil}
+	if visible := visibilityFilter(query.Visibility, ""); visible != nil {
+		match["$or"] = visible
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$addFields", Value: bson.M{"rank": bson.M{"$meta": "textScore"}}}},
+		{{Key: "$sort", Value: bson.M{"rank": -1}}},
+	}
+
+	if limit != 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+	}
+
+	cursor, err := usePostsCollection(r).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []struct {
+		models.Post `bson:",inline"`
+		Rank        float64 `bson:"rank"`
+	}
+	if err = cursor.All(ctx, &found); err != nil {
+		return nil, err
+	}
+
+	results := make([]*models.SearchResult, 0, len(found))
+	for _, f := range found {
+		results = append(results, &models.SearchResult{
+			Target:  models.SearchTargetPost,
+			ID:      f.ID,
+			Rank:    f.Rank,
+			Snippet: models.Snippet(f.Title+" "+f.Description, query.Text),
+		})
+	}
+
+	return results, nil
+}
+
+func (r *Repository) searchComments(ctx context.Context, query models.SearchQuery,
+	limit int64) ([]*models.SearchResult, error) {
+	postMatch := bson.M{"post.deleted_at": nil}
+	if visible := visibilityFilter(query.Visibility, "post."); visible != nil {
+		postMatch["$or"] = visible
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"$text": bson.M{"$search": query.Text}, "deleted_at": nil}}},
+		{{Key: "$addFields", Value: bson.M{"rank": bson.M{"$meta": "textScore"}}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         usePostsCollection(r).Name(),
+			"localField":   "post_id",
+			"foreignField": "_id",
+			"as":           "post",
+		}}},
+		{{Key: "$unwind", Value: "$post"}},
+		{{Key: "$match", Value: postMatch}},
+		{{Key: "$sort", Value: bson.M{"rank": -1}}},
+	}
+
+	if limit != 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+	}
+
+	cursor, err := useCommentsCollection(r).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []struct {
+		ID      string  `bson:"_id"`
+		Content string  `bson:"content"`
+		Rank    float64 `bson:"rank"`
+	}
+	if err = cursor.All(ctx, &found); err != nil {
+		return nil, err
+	}
+
+	results := make([]*models.SearchResult, 0, len(found))
+	for _, f := range found {
+		results = append(results, &models.SearchResult{
+			Target:  models.SearchTargetComment,
+			ID:      f.ID,
+			Rank:    f.Rank,
+			Snippet: models.Snippet(f.Content, query.Text),
+		})
+	}
+
+	return results, nil
+}
+
+// visibilityFilter returns the conditions of which at least one must be met by a visible post.
+// It returns nil when all posts are visible.
+func visibilityFilter(visibility models.PostVisibility, prefix string) bson.A {
+	if len(visibility.Statuses) == 0 {
+		return nil
+	}
+
+	visible := bson.A{bson.M{prefix + "status": bson.M{"$in": visibility.Statuses}}}
+	if visibility.OwnerID != "" {
+		visible = append(visible, bson.M{prefix + "created_by": visibility.OwnerID})
+	}
+
+	return visible
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/serhiihuberniuk/blog-api/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetPostBySlug returns the post by its current slug or by one of its previous slugs.
+func (r *Repository) GetPostBySlug(ctx context.Context, slug string) (*models.Post, error) {
+	var redirect struct {
+		PostID string `bson:"post_id"`
+	}
+
+	filter := bson.M{"slug": slug, "deleted_at": nil}
+
+	err := usePostSlugRedirectsCollection(r).FindOne(ctx, bson.M{"_id": slug}).Decode(&redirect)
+	switch {
+	case err == nil:
+		filter = bson.M{"_id": redirect.PostID, "deleted_at": nil}
+	case !errors.Is(err, mongo.ErrNoDocuments):
+		return nil, fmt.Errorf("cannot get post by slug: %w", err)
+	}
+
+	var post models.Post
+
+	if err := usePostsCollection(r).FindOne(ctx, filter).Decode(&post); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get post by slug: %w", err)
+	}
+
+	return &post, nil
+}
+
+// IsSlugTaken reports whether the slug is used by any other post, including deleted posts
+// and previous slugs which redirect to the posts.
+func (r *Repository) IsSlugTaken(ctx context.Context, slug, postID string) (bool, error) {
+	count, err := usePostsCollection(r).CountDocuments(ctx, bson.M{"slug": slug, "_id": bson.M{"$ne": postID}})
+	if err != nil {
+		return false, fmt.Errorf("cannot check slug: %w", err)
+	}
+
+	if count > 0 {
+		return true, nil
+	}
+
+	count, err = usePostSlugRedirectsCollection(r).CountDocuments(ctx,
+		bson.M{"_id": slug, "post_id": bson.M{"$ne": postID}})
+	if err != nil {
+		return false, fmt.Errorf("cannot check slug: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// redirectSlug keeps the previous slug of the post as a redirect when the post gets a new slug.
+func (r *Repository) redirectSlug(ctx context.Context, postID, previous, slug string) error {
+	if previous == slug {
+		return nil
+	}
+
+	redirects := usePostSlugRedirectsCollection(r)
+
+	if previous != "" {
+		_, err := redirects.UpdateOne(ctx,
+			bson.M{"_id": previous},
+			bson.M{"$set": bson.M{"post_id": postID}},
+			options.Update().SetUpsert(true))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := redirects.DeleteOne(ctx, bson.M{"_id": slug, "post_id": postID})
+
+	return err
+}
+
+// slugError returns models.ErrSlugTaken if the post was not saved because its slug is taken,
+// which happens when another post takes the slug after it was checked. The slug is the only
+// unique field of posts besides the ID.
+func slugError(err error) error {
+	if mongo.IsDuplicateKeyError(err) {
+		return models.ErrSlugTaken
+	}
+
+	return err
+}
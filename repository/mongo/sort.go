@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Keys are looked up instead of taking the names of fields, so a sort cannot inject operators.
+var (
+	postSortKeys = map[models.SortPostsByField]string{
+		models.SortPostsByTitle:         "title",
+		models.SortPostsByCreatedAt:     "created_at",
+		models.SortPostsByUpdatedAt:     "updated_at",
+		models.SortPostsByCommentCount:  "comment_count",
+		models.SortPostsByReactionCount: "reaction_count",
+	}
+
+	commentSortKeys = map[models.SortCommentsByField]string{
+		models.SortCommentByCreatedAt:     "created_at",
+		models.SortCommentByUpdatedAt:     "updated_at",
+		models.SortCommentByReactionCount: "reaction_count",
+	}
+)
+
+// postMetricsStages add the computed counts to the posts, so they can be sorted by the counts.
+// The count of comments is stored on the post itself.
+func (r *Repository) postMetricsStages() mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.M{
+			"from":         useReactionsCollection(r).Name(),
+			"localField":   "_id",
+			"foreignField": "target_id",
+			"as":           "reactions",
+		}}},
+		{{Key: "$addFields", Value: bson.M{
+			"reaction_count": bson.M{"$size": bson.M{"$filter": bson.M{
+				"input": "$reactions",
+				"cond":  bson.M{"$eq": bson.A{"$$this.target_type", models.ReactionTargetPost}},
+			}}},
+		}}},
+		{{Key: "$project", Value: bson.M{"reactions": 0}}},
+	}
+}
+
+// commentMetricsStages add the computed counts to the comments, so they can be sorted by the counts.
+func (r *Repository) commentMetricsStages() mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.M{
+			"from":         useReactionsCollection(r).Name(),
+			"localField":   "_id",
+			"foreignField": "target_id",
+			"as":           "reactions",
+		}}},
+		{{Key: "$addFields", Value: bson.M{
+			"reaction_count": bson.M{"$size": bson.M{"$filter": bson.M{
+				"input": "$reactions",
+				"cond":  bson.M{"$eq": bson.A{"$$this.target_type", models.ReactionTargetComment}},
+			}}},
+		}}},
+		{{Key: "$project", Value: bson.M{"reactions": 0}}},
+	}
+}
+
+func postSortKeysOf(sort models.SortPosts) ([]sortKey, error) {
+	keys := make([]sortKey, 0, len(sort.Keys))
+
+	for _, key := range sort.Keys {
+		name, ok := postSortKeys[key.Field]
+		if !ok {
+			return nil, fmt.Errorf("cannot sort posts by %q", key.Field)
+		}
+
+		keys = append(keys, sortKey{name: name, isASC: key.IsASC})
+	}
+
+	return keys, nil
+}
+
+func commentSortKeysOf(sort models.SortComments) ([]sortKey, error) {
+	keys := make([]sortKey, 0, len(sort.Keys))
+
+	for _, key := range sort.Keys {
+		name, ok := commentSortKeys[key.Field]
+		if !ok {
+			return nil, fmt.Errorf("cannot sort comments by %q", key.Field)
+		}
+
+		keys = append(keys, sortKey{name: name, isASC: key.IsASC})
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetTag returns the tag if any post is marked with it.
+func (r *Repository) GetTag(ctx context.Context, name string) (*models.Tag, error) {
+	postsCollection := usePostsCollection(r)
+
+	total, err := postsCollection.CountDocuments(ctx, bson.M{"tags": name})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get tag: %w", err)
+	}
+
+	if total == 0 {
+		return nil, models.ErrNotFound
+	}
+
+	published, err := postsCollection.CountDocuments(ctx, bson.M{
+		"tags":       name,
+		"status":     models.PostStatusPublished,
+		"deleted_at": nil,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get tag: %w", err)
+	}
+
+	return &models.Tag{
+		Name:      name,
+		PostCount: int(published),
+	}, nil
+}
+
+// ListTags returns tags of published posts, the most used first.
+func (r *Repository) ListTags(ctx context.Context, pagination models.Pagination) ([]*models.Tag, error) {
+	tags, err := r.countTags(ctx, bson.M{}, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get list of tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// AutocompleteTags returns tags of published posts starting with the prefix, the most used first.
+func (r *Repository) AutocompleteTags(ctx context.Context, prefix string, limit uint64) ([]*models.Tag, error) {
+	match := bson.M{"tags": bson.M{"$regex": "^" + regexp.QuoteMeta(prefix)}}
+
+	tags, err := r.countTags(ctx, match, models.Pagination{Limit: limit})
+	if err != nil {
+		return nil, fmt.Errorf("cannot autocomplete tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// countTags counts published posts by the tags matching the filter.
+func (r *Repository) countTags(ctx context.Context, match bson.M,
+	pagination models.Pagination) ([]*models.Tag, error) {
+	posts := bson.M{"status": models.PostStatusPublished, "deleted_at": nil}
+	for key, value := range match {
+		posts[key] = value
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: posts}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{"_id": "$tags", "post_count": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "post_count", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+
+	if pagination.Offset != 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$skip", Value: int64(pagination.Offset)}})
+	}
+
+	if pagination.Limit != 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: int64(pagination.Limit)}})
+	}
+
+	cursor, err := usePostsCollection(r).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []*models.Tag
+	if err = cursor.All(ctx, &tags); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// GetTagAliases returns the tags which replace the given aliases. Tags which are not aliases are left out.
+func (r *Repository) GetTagAliases(ctx context.Context, tags []string) (map[string]string, error) {
+	cursor, err := useTagAliasesCollection(r).Find(ctx, bson.M{"_id": bson.M{"$in": tags}})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get aliases of tags: %w", err)
+	}
+
+	var aliases []*models.TagAlias
+	if err = cursor.All(ctx, &aliases); err != nil {
+		return nil, fmt.Errorf("cannot get aliases of tags: %w", err)
+	}
+
+	out := make(map[string]string, len(aliases))
+	for _, alias := range aliases {
+		out[alias.Alias] = alias.Tag
+	}
+
+	return out, nil
+}
+
+// RenameTag renames the tag on all posts and keeps the old name as an alias of the new one.
+// It returns IDs of the changed posts.
+func (r *Repository) RenameTag(ctx context.Context, name, newName string) ([]string, error) {
+	postIDs, err := r.replaceTag(ctx, name, newName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot rename tag: %w", err)
+	}
+
+	return postIDs, nil
+}
+
+// MergeTags replaces the tag with the other one on all posts and keeps the merged tag as an alias.
+// It returns IDs of the changed posts.
+func (r *Repository) MergeTags(ctx context.Context, from, into string) ([]string, error) {
+	postIDs, err := r.replaceTag(ctx, from, into)
+	if err != nil {
+		return nil, fmt.Errorf("cannot merge tags: %w", err)
+	}
+
+	return postIDs, nil
+}
+
+func (r *Repository) replaceTag(ctx context.Context, tag, newTag string) ([]string, error) {
+	postsCollection := usePostsCollection(r)
+
+	postIDs, err := findIDs(ctx, postsCollection, bson.M{"tags": tag})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(postIDs) == 0 {
+		return nil, models.ErrNotFound
+	}
+
+	// Posts already marked with the new tag only lose the old one.
+	_, err = postsCollection.UpdateMany(ctx,
+		bson.M{"$and": bson.A{bson.M{"tags": tag}, bson.M{"tags": newTag}}},
+		bson.M{"$pull": bson.M{"tags": tag}},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = postsCollection.UpdateMany(ctx,
+		bson.M{"tags": tag},
+		bson.M{"$set": bson.M{"tags.$[tag]": newTag}},
+		options.Update().SetArrayFilters(options.ArrayFilters{Filters: []interface{}{bson.M{"tag": tag}}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	aliasesCollection := useTagAliasesCollection(r)
+
+	if _, err = aliasesCollection.UpdateMany(ctx, bson.M{"tag": tag}, bson.M{"$set": bson.M{"tag": newTag}}); err != nil {
+		return nil, err
+	}
+
+	_, err = aliasesCollection.UpdateOne(ctx,
+		bson.M{"_id": tag},
+		bson.M{"$set": bson.M{"tag": newTag}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return postIDs, nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/serhiihuberniuk/blog-api/models"
@@ -24,7 +25,7 @@ func (r *Repository) GetUser(ctx context.Context, userID string) (*models.User,
 	usersCollection := useUsersCollection(r)
 
 	var user models.User
-	if err := usersCollection.FindOne(ctx, bson.M{"_id": userID}).Decode(&user); err != nil {
+	if err := usersCollection.FindOne(ctx, bson.M{"_id": userID, "deleted_at": nil}).Decode(&user); err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, models.ErrNotFound
 		}
@@ -35,18 +36,102 @@ func (r *Repository) GetUser(ctx context.Context, userID string) (*models.User,
 	return &user, nil
 }
 
+func (r *Repository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	usersCollection := useUsersCollection(r)
+
+	var user models.User
+	if err := usersCollection.FindOne(ctx, bson.M{"email": email, "deleted_at": nil}).Decode(&user); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get user: %w", err)
+	}
+
+	return &user, nil
+}
+
+func (r *Repository) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	usersCollection := useUsersCollection(r)
+
+	var user models.User
+	if err := usersCollection.FindOne(ctx, bson.M{"username": username, "deleted_at": nil}).Decode(&user); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get user: %w", err)
+	}
+
+	return &user, nil
+}
+
+// GetUsersByUsernames returns the users with the given usernames. Unknown usernames are skipped.
+func (r *Repository) GetUsersByUsernames(ctx context.Context, usernames []string) ([]*models.User, error) {
+	usersCollection := useUsersCollection(r)
+
+	cursor, err := usersCollection.Find(ctx, bson.M{"username": bson.M{"$in": usernames}, "deleted_at": nil})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get users: %w", err)
+	}
+
+	var users []*models.User
+	if err = cursor.All(ctx, &users); err != nil {
+		return nil, fmt.Errorf("cannot get users: %w", err)
+	}
+
+	return users, nil
+}
+
+// IsUsernameTaken reports whether the username is used by any user, including deleted users
+// which still can be restored.
+func (r *Repository) IsUsernameTaken(ctx context.Context, username string) (bool, error) {
+	count, err := useUsersCollection(r).CountDocuments(ctx, bson.M{"username": username})
+	if err != nil {
+		return false, fmt.Errorf("cannot check username: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// IsEmailTaken reports whether the email is used by any user, including deleted users
+// which still can be restored.
+func (r *Repository) IsEmailTaken(ctx context.Context, email string) (bool, error) {
+	count, err := useUsersCollection(r).CountDocuments(ctx, bson.M{"email": email})
+	if err != nil {
+		return false, fmt.Errorf("cannot check email: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *Repository) GetUserPassword(ctx context.Context, userID string) (string, error) {
+	usersCollection := useUsersCollection(r)
+
+	var user models.User
+	if err := usersCollection.FindOne(ctx, bson.M{"_id": userID, "deleted_at": nil}).Decode(&user); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return "", models.ErrNotFound
+		}
+
+		return "", fmt.Errorf("cannot get password of user: %w", err)
+	}
+
+	return user.Password, nil
+}
+
 func (r *Repository) UpdateUser(ctx context.Context, user *models.User) error {
 	usersCollection := useUsersCollection(r)
 
 	result, err := usersCollection.UpdateOne(
 		ctx,
-		bson.M{"_id": user.ID},
+		bson.M{"_id": user.ID, "deleted_at": nil},
 		bson.M{
 			"$set": bson.M{
-				"name":       user.Name,
-				"email":      user.Email,
-				"password":   user.Password,
-				"updated_at": user.UpdatedAt,
+				"name":          user.Name,
+				"email":         user.Email,
+				"updated_at":    user.UpdatedAt,
+				"pending_email": user.PendingEmail,
 			},
 		})
 	if err != nil {
@@ -60,15 +145,236 @@ func (r *Repository) UpdateUser(ctx context.Context, user *models.User) error {
 	return nil
 }
 
-func (r *Repository) DeleteUser(ctx context.Context, userID string) error {
+func (r *Repository) UpdateUserRole(ctx context.Context, userID string, role models.Role) error {
+	usersCollection := useUsersCollection(r)
+
+	result, err := usersCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": userID, "deleted_at": nil},
+		bson.M{
+			"$set": bson.M{
+				"role": role,
+			},
+		})
+	if err != nil {
+		return fmt.Errorf("cannot update role of user: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) UpdateUserPassword(ctx context.Context, userID, password string, updatedAt time.Time) error {
 	usersCollection := useUsersCollection(r)
 
-	result, err := usersCollection.DeleteOne(ctx, bson.M{"_id": userID})
+	result, err := usersCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": userID, "deleted_at": nil},
+		bson.M{
+			"$set": bson.M{
+				"password":   password,
+				"updated_at": updatedAt,
+			},
+		})
+	if err != nil {
+		return fmt.Errorf("cannot update password of user: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) VerifyUserEmail(ctx context.Context, userID, email string, verifiedAt time.Time) error {
+	usersCollection := useUsersCollection(r)
+
+	result, err := usersCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": userID, "deleted_at": nil},
+		bson.M{
+			"$set": bson.M{
+				"email":             email,
+				"email_verified_at": verifiedAt,
+				"updated_at":        verifiedAt,
+			},
+		})
+	if err != nil {
+		return fmt.Errorf("cannot verify email of user: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return models.ErrNotFound
+	}
+
+	_, err = usersCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": userID, "pending_email": email},
+		bson.M{
+			"$unset": bson.M{
+				"pending_email": "",
+			},
+		})
+	if err != nil {
+		return fmt.Errorf("cannot clear pending email of user: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteUser marks the user as deleted. Depending on the policy, posts and comments of the user
+// are deleted with the same time or handed over to the tombstone user.
+func (r *Repository) DeleteUser(ctx context.Context, userID string, deletedAt time.Time,
+	content models.DeletedUserContent) error {
+	usersCollection := useUsersCollection(r)
+
+	result, err := usersCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": userID, "deleted_at": nil},
+		bson.M{
+			"$set": bson.M{
+				"deleted_at": deletedAt,
+			},
+		})
 	if err != nil {
 		return fmt.Errorf("cannot delete user: %w", err)
 	}
 
-	if result.DeletedCount == 0 {
+	if result.MatchedCount == 0 {
+		return models.ErrNotFound
+	}
+
+	if content == models.DeletedUserContentAnonymize {
+		return r.anonymizeUserContent(ctx, userID)
+	}
+
+	return r.deleteUserContent(ctx, userID, deletedAt)
+}
+
+func (r *Repository) anonymizeUserContent(ctx context.Context, userID string) error {
+	update := bson.M{
+		"$set": bson.M{
+			"created_by": models.DeletedUserID,
+		},
+	}
+
+	if _, err := usePostsCollection(r).UpdateMany(ctx, bson.M{"created_by": userID}, update); err != nil {
+		return fmt.Errorf("cannot anonymize posts of user: %w", err)
+	}
+
+	if _, err := useCommentsCollection(r).UpdateMany(ctx, bson.M{"created_by": userID}, update); err != nil {
+		return fmt.Errorf("cannot anonymize comments of user: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) deleteUserContent(ctx context.Context, userID string, deletedAt time.Time) error {
+	update := bson.M{
+		"$set": bson.M{
+			"deleted_at": deletedAt,
+		},
+	}
+
+	_, err := usePostsCollection(r).UpdateMany(ctx, bson.M{"created_by": userID, "deleted_at": nil}, update)
+	if err != nil {
+		return fmt.Errorf("cannot delete posts of user: %w", err)
+	}
+
+	postIDs, err := findIDs(ctx, usePostsCollection(r), bson.M{"created_by": userID, "deleted_at": deletedAt})
+	if err != nil {
+		return fmt.Errorf("cannot delete comments of user: %w", err)
+	}
+
+	_, err = useCommentsCollection(r).UpdateMany(ctx, bson.M{
+		"deleted_at": nil,
+		"$or": bson.A{
+			bson.M{"created_by": userID},
+			bson.M{"post_id": bson.M{"$in": postIDs}},
+		},
+	}, update)
+	if err != nil {
+		return fmt.Errorf("cannot delete comments of user: %w", err)
+	}
+
+	if err := r.refreshActivityOfCommentedPosts(ctx, userID); err != nil {
+		return fmt.Errorf("cannot recount comments of posts: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetDeletedUser(ctx context.Context, userID string) (*models.User, error) {
+	usersCollection := useUsersCollection(r)
+
+	var user models.User
+	if err := usersCollection.FindOne(ctx, bson.M{"_id": userID, "deleted_at": bson.M{"$ne": nil}}).
+		Decode(&user); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get deleted user: %w", err)
+	}
+
+	return &user, nil
+}
+
+// RestoreUser brings back the user together with the posts and comments which were deleted with it.
+func (r *Repository) RestoreUser(ctx context.Context, userID string) error {
+	user, err := r.GetDeletedUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	restore := bson.M{
+		"$unset": bson.M{
+			"deleted_at": "",
+		},
+	}
+
+	postIDs, err := findIDs(ctx, usePostsCollection(r), bson.M{"created_by": userID})
+	if err != nil {
+		return fmt.Errorf("cannot restore comments of user: %w", err)
+	}
+
+	_, err = useCommentsCollection(r).UpdateMany(ctx, bson.M{
+		"deleted_at": user.DeletedAt,
+		"$or": bson.A{
+			bson.M{"created_by": userID},
+			bson.M{"post_id": bson.M{"$in": postIDs}},
+		},
+	}, restore)
+	if err != nil {
+		return fmt.Errorf("cannot restore comments of user: %w", err)
+	}
+
+	if err := r.refreshActivityOfCommentedPosts(ctx, userID); err != nil {
+		return fmt.Errorf("cannot recount comments of posts: %w", err)
+	}
+
+	_, err = usePostsCollection(r).UpdateMany(ctx, bson.M{"created_by": userID, "deleted_at": user.DeletedAt}, restore)
+	if err != nil {
+		return fmt.Errorf("cannot restore posts of user: %w", err)
+	}
+
+	result, err := useUsersCollection(r).UpdateOne(
+		ctx,
+		bson.M{"_id": userID, "deleted_at": bson.M{"$ne": nil}},
+		bson.M{
+			"$unset": bson.M{
+				"deleted_at": "",
+			},
+		})
+	if err != nil {
+		return fmt.Errorf("cannot restore user: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
 		return models.ErrNotFound
 	}
 
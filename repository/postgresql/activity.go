@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+// commentedByUser is the condition of refreshPostActivity selecting the posts commented by the user.
+const commentedByUser = "p.id IN (SELECT post_id FROM comments WHERE created_by=$1)"
+
+// ReconcilePostActivity recounts the comments of all posts and fixes the stored counts which drifted.
+// It returns IDs of the changed posts.
+func (r *Repository) ReconcilePostActivity(ctx context.Context) ([]string, error) {
+	postIDs, err := refreshPostActivity(ctx, r.Db, "TRUE")
+	if err != nil {
+		return nil, fmt.Errorf("cannot reconcile activity of posts: %w", err)
+	}
+
+	return postIDs, nil
+}
+
+// refreshPostActivity recounts the comments of the posts matching the condition and saves the counts
+// which differ from the stored ones. It returns IDs of the changed posts.
+func refreshPostActivity(ctx context.Context, db pgxscan.Querier, condition string,
+	args ...interface{}) ([]string, error) {
+	sql := "UPDATE posts SET comment_count=a.comment_count, last_commented_at=a.last_commented_at " +
+		"FROM (SELECT p.id, COUNT(c.id) AS comment_count, MAX(c.created_at) AS last_commented_at FROM posts p " +
+		"LEFT JOIN comments c ON c.post_id=p.id AND c.deleted_at IS NULL " +
+		"WHERE " + condition + " GROUP BY p.id) a " +
+		"WHERE a.id=posts.id AND (posts.comment_count<>a.comment_count " +
+		"OR posts.last_commented_at IS DISTINCT FROM a.last_commented_at) " +
+		"RETURNING posts.id"
+
+	var postIDs []string
+
+	if err := pgxscan.Select(ctx, db, &postIDs, sql, args...); err != nil {
+		return nil, err
+	}
+
+	return postIDs, nil
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+const attachmentColumns = "id, post_id, created_by, created_at, file_name, content_type, size, blob_key, " +
+	"variant_status, variants"
+
+// CreateAttachment saves the attachment unless it takes the total size of files attached by its author over
+// the quota. Zero quota means no quota. The row of the author is locked, so concurrent uploads are counted
+// one after another.
+func (r *Repository) CreateAttachment(ctx context.Context, attachment *models.Attachment, quota int64) error {
+	const (
+		lockUserSql = "SELECT id FROM users WHERE id=$1 FOR UPDATE"
+		sumSizesSql = "SELECT COALESCE(SUM(size), 0) FROM attachments WHERE created_by=$1"
+		insertSql   = "INSERT INTO attachments (" + attachmentColumns + ") " +
+			"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)"
+	)
+
+	err := r.Db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if quota > 0 {
+			var userID string
+			if err := tx.QueryRow(ctx, lockUserSql, attachment.CreatedBy).Scan(&userID); err != nil {
+				return err
+			}
+
+			var used int64
+			if err := tx.QueryRow(ctx, sumSizesSql, attachment.CreatedBy).Scan(&used); err != nil {
+				return err
+			}
+
+			if used+attachment.Size > quota {
+				return models.ErrQuotaExceeded
+			}
+		}
+
+		_, err := tx.Exec(ctx, insertSql, attachment.ID, attachment.PostID, attachment.CreatedBy,
+			attachment.CreatedAt, attachment.FileName, attachment.ContentType, attachment.Size, attachment.BlobKey,
+			attachment.VariantStatus, variantsValue(attachment.Variants))
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("cannot create attachment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetAttachment(ctx context.Context, attachmentID string) (*models.Attachment, error) {
+	const sql = "SELECT " + attachmentColumns + " FROM attachments WHERE id=$1"
+
+	var attachment models.Attachment
+
+	err := pgxscan.Get(ctx, r.Db, &attachment, sql, attachmentID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get attachment: %w", err)
+	}
+
+	return &attachment, nil
+}
+
+func (r *Repository) ListAttachments(ctx context.Context, postID string) ([]*models.Attachment, error) {
+	const sql = "SELECT " + attachmentColumns + " FROM attachments WHERE post_id=$1 ORDER BY created_at"
+
+	var attachments []*models.Attachment
+
+	if err := pgxscan.Select(ctx, r.Db, &attachments, sql, postID); err != nil {
+		return nil, fmt.Errorf("cannot get attachments of post: %w", err)
+	}
+
+	return attachments, nil
+}
+
+func (r *Repository) DeleteAttachment(ctx context.Context, attachmentID string) error {
+	const sql = "DELETE FROM attachments WHERE id=$1"
+
+	tag, err := r.Db.Exec(ctx, sql, attachmentID)
+	if err != nil {
+		return fmt.Errorf("cannot delete attachment: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+// SumAttachmentSizes returns the total size of files attached by the user.
+func (r *Repository) SumAttachmentSizes(ctx context.Context, userID string) (int64, error) {
+	const sql = "SELECT COALESCE(SUM(size), 0) FROM attachments WHERE created_by=$1"
+
+	var size int64
+
+	if err := r.Db.QueryRow(ctx, sql, userID).Scan(&size); err != nil {
+		return 0, fmt.Errorf("cannot count size of attachments: %w", err)
+	}
+
+	return size, nil
+}
+
+// ListPurgeableAttachments returns attachments of posts which are purged together with the given deleted objects,
+// either as deleted posts or as content of deleted users.
+func (r *Repository) ListPurgeableAttachments(ctx context.Context, deletedBefore time.Time) ([]*models.Attachment,
+	error) {
+	const sql = "SELECT a.id, a.post_id, a.created_by, a.created_at, a.file_name, a.content_type, a.size, " +
+		"a.blob_key, a.variant_status, a.variants FROM attachments a JOIN posts p ON p.id=a.post_id " +
+		"JOIN users u ON u.id=p.created_by WHERE p.deleted_at < $1 OR u.deleted_at < $1"
+
+	var attachments []*models.Attachment
+
+	if err := pgxscan.Select(ctx, r.Db, &attachments, sql, deletedBefore); err != nil {
+		return nil, fmt.Errorf("cannot get attachments of deleted posts: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// ListPendingAttachments returns the oldest attachments which wait for their variants to be made.
+func (r *Repository) ListPendingAttachments(ctx context.Context, limit uint64) ([]*models.Attachment, error) {
+	const sql = "SELECT " + attachmentColumns + " FROM attachments WHERE variant_status=$1 " +
+		"ORDER BY created_at LIMIT $2"
+
+	var attachments []*models.Attachment
+
+	if err := pgxscan.Select(ctx, r.Db, &attachments, sql, models.VariantStatusPending, limit); err != nil {
+		return nil, fmt.Errorf("cannot get pending attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+func (r *Repository) UpdateAttachmentVariants(ctx context.Context, attachmentID string, status models.VariantStatus,
+	variants []models.AttachmentVariant) error {
+	const sql = "UPDATE attachments SET variant_status=$1, variants=$2 WHERE id=$3"
+
+	tag, err := r.Db.Exec(ctx, sql, status, variantsValue(variants), attachmentID)
+	if err != nil {
+		return fmt.Errorf("cannot update variants of attachment: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+// variantsValue keeps the column an array when there are no variants.
+func variantsValue(variants []models.AttachmentVariant) []models.AttachmentVariant {
+	if variants == nil {
+		return []models.AttachmentVariant{}
+	}
+
+	return variants
+}
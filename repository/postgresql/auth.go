@@ -3,13 +3,15 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/georgysavva/scany/pgxscan"
 	"github.com/serhiihuberniuk/blog-api/models"
 )
 
 func (r *Repository) Login(ctx context.Context, email string) (*models.User, error) {
-	const sql = "SELECT id, name, email, created_at, updated_at, password FROM users WHERE email=$1"
+	const sql = "SELECT id, name, username, email, created_at, updated_at, password, role, email_verified_at, " +
+		"COALESCE(pending_email, '') AS pending_email FROM users WHERE email=$1 AND deleted_at IS NULL"
 
 	var user models.User
 
@@ -24,3 +26,172 @@ func (r *Repository) Login(ctx context.Context, email string) (*models.User, err
 
 	return &user, nil
 }
+
+func (r *Repository) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	const sql = "INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, created_at, expires_at) " +
+		"VALUES ($1, $2, $3, $4, $5, $6)"
+
+	_, err := r.Db.Exec(ctx, sql, token.ID, token.UserID, token.FamilyID, token.TokenHash, token.CreatedAt,
+		token.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("cannot create refresh token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	const sql = "SELECT id, user_id, family_id, token_hash, created_at, expires_at, revoked_at " +
+		"FROM refresh_tokens WHERE token_hash=$1"
+
+	var token models.RefreshToken
+
+	err := pgxscan.Get(ctx, r.Db, &token, sql, tokenHash)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get refresh token: %w", err)
+	}
+
+	return &token, nil
+}
+
+func (r *Repository) RevokeRefreshToken(ctx context.Context, tokenID string, revokedAt time.Time) error {
+	const sql = "UPDATE refresh_tokens SET revoked_at=$1 WHERE id=$2 AND revoked_at IS NULL"
+
+	result, err := r.Db.Exec(ctx, sql, revokedAt, tokenID)
+	if err != nil {
+		return fmt.Errorf("cannot revoke refresh token: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) RevokeRefreshTokenFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	const sql = "UPDATE refresh_tokens SET revoked_at=$1 WHERE family_id=$2 AND revoked_at IS NULL"
+
+	if _, err := r.Db.Exec(ctx, sql, revokedAt, familyID); err != nil {
+		return fmt.Errorf("cannot revoke refresh tokens: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) RevokeUserRefreshTokens(ctx context.Context, userID string, revokedAt time.Time) error {
+	const sql = "UPDATE refresh_tokens SET revoked_at=$1 WHERE user_id=$2 AND revoked_at IS NULL"
+
+	if _, err := r.Db.Exec(ctx, sql, revokedAt, userID); err != nil {
+		return fmt.Errorf("cannot revoke refresh tokens: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) RevokeOtherRefreshTokens(ctx context.Context, userID, familyID string,
+	revokedAt time.Time) error {
+	const sql = "UPDATE refresh_tokens SET revoked_at=$1 WHERE user_id=$2 AND family_id<>$3 AND revoked_at IS NULL"
+
+	if _, err := r.Db.Exec(ctx, sql, revokedAt, userID, familyID); err != nil {
+		return fmt.Errorf("cannot revoke refresh tokens: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) CreatePasswordResetToken(ctx context.Context, token *models.PasswordResetToken) error {
+	const sql = "INSERT INTO password_reset_tokens (id, user_id, token_hash, created_at, expires_at) " +
+		"VALUES ($1, $2, $3, $4, $5)"
+
+	_, err := r.Db.Exec(ctx, sql, token.ID, token.UserID, token.TokenHash, token.CreatedAt, token.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("cannot create password reset token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetPasswordResetToken(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	const sql = "SELECT id, user_id, token_hash, created_at, expires_at, used_at " +
+		"FROM password_reset_tokens WHERE token_hash=$1"
+
+	var token models.PasswordResetToken
+
+	err := pgxscan.Get(ctx, r.Db, &token, sql, tokenHash)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get password reset token: %w", err)
+	}
+
+	return &token, nil
+}
+
+func (r *Repository) UsePasswordResetToken(ctx context.Context, tokenID string, usedAt time.Time) error {
+	const sql = "UPDATE password_reset_tokens SET used_at=$1 WHERE id=$2 AND used_at IS NULL"
+
+	result, err := r.Db.Exec(ctx, sql, usedAt, tokenID)
+	if err != nil {
+		return fmt.Errorf("cannot use password reset token: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) CreateEmailVerificationToken(ctx context.Context, token *models.EmailVerificationToken) error {
+	const sql = "INSERT INTO email_verification_tokens (id, user_id, email, token_hash, created_at, expires_at) " +
+		"VALUES ($1, $2, $3, $4, $5, $6)"
+
+	_, err := r.Db.Exec(ctx, sql, token.ID, token.UserID, token.Email, token.TokenHash, token.CreatedAt,
+		token.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("cannot create email verification token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetEmailVerificationToken(ctx context.Context,
+	tokenHash string) (*models.EmailVerificationToken, error) {
+	const sql = "SELECT id, user_id, email, token_hash, created_at, expires_at, used_at " +
+		"FROM email_verification_tokens WHERE token_hash=$1"
+
+	var token models.EmailVerificationToken
+
+	err := pgxscan.Get(ctx, r.Db, &token, sql, tokenHash)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get email verification token: %w", err)
+	}
+
+	return &token, nil
+}
+
+func (r *Repository) UseEmailVerificationToken(ctx context.Context, tokenID string, usedAt time.Time) error {
+	const sql = "UPDATE email_verification_tokens SET used_at=$1 WHERE id=$2 AND used_at IS NULL"
+
+	result, err := r.Db.Exec(ctx, sql, usedAt, tokenID)
+	if err != nil {
+		return fmt.Errorf("cannot use email verification token: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
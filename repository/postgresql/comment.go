@@ -3,16 +3,44 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
 	"github.com/serhiihuberniuk/blog-api/models"
 )
 
+// CreateComment saves the comment and counts it on its post.
 func (r *Repository) CreateComment(ctx context.Context, comment *models.Comment) error {
-	const sql = "INSERT INTO comments (id, content, created_by, created_at, post_id) VALUES ($1, $2, $3, $4, $5)"
+	const (
+		createCommentSql = "INSERT INTO comments " +
+			"(id, content, created_by, created_at, post_id, updated_at, parent_id, depth, format, content_html, word_count, " +
+			"mentions) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)"
+		countCommentSql = "UPDATE posts SET comment_count=comment_count+1, " +
+			"last_commented_at=GREATEST(last_commented_at, $2) WHERE id=$1 AND deleted_at IS NULL"
+	)
 
-	_, err := r.Db.Exec(ctx, sql, comment.ID, comment.Content, comment.CreatedBy, comment.CreatedAt, comment.PostID)
+	err := r.Db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, createCommentSql, comment.ID, comment.Content, comment.CreatedBy, comment.CreatedAt,
+			comment.PostID, comment.UpdatedAt, comment.ParentID, comment.Depth, comment.Format, comment.ContentHTML,
+			comment.WordCount, comment.Mentions)
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.Exec(ctx, countCommentSql, comment.PostID, comment.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		// Comments cannot be added to deleted posts.
+		if result.RowsAffected() == 0 {
+			return models.ErrNotFound
+		}
+
+		return nil
+	})
 	if err != nil {
 		return fmt.Errorf("cannot create comment, %w", err)
 	}
@@ -21,7 +49,8 @@ func (r *Repository) CreateComment(ctx context.Context, comment *models.Comment)
 }
 
 func (r *Repository) GetComment(ctx context.Context, commentID string) (*models.Comment, error) {
-	const sql = "SELECT id, content, created_by, created_at, post_id FROM comments WHERE id=$1"
+	const sql = "SELECT id, content, created_by, created_at, post_id, updated_at, parent_id, depth, format, " +
+		"content_html, word_count, mentions FROM comments WHERE id=$1 AND deleted_at IS NULL"
 
 	var comment models.Comment
 
@@ -37,31 +66,129 @@ func (r *Repository) GetComment(ctx context.Context, commentID string) (*models.
 	return &comment, nil
 }
 
-func (r *Repository) UpdateComment(ctx context.Context, comment *models.Comment) error {
-	const sql = "UPDATE comments SET content=$1 WHERE id=$2"
+// UpdateComment saves the comment and records its previous version as the revision.
+func (r *Repository) UpdateComment(ctx context.Context, comment *models.Comment,
+	revision *models.CommentRevision) error {
+	const (
+		updateCommentSql = "UPDATE comments SET content=$1, updated_at=$2, format=$3, content_html=$4, word_count=$5, " +
+			"mentions=$6 WHERE id=$7 AND deleted_at IS NULL"
+		createRevisionSql = "INSERT INTO comment_revisions (id, comment_id, edited_by, edited_at, content, format) " +
+			"VALUES ($1, $2, $3, $4, $5, $6)"
+	)
+
+	err := r.Db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		result, err := tx.Exec(ctx, updateCommentSql, comment.Content, comment.UpdatedAt, comment.Format,
+			comment.ContentHTML, comment.WordCount, comment.Mentions, comment.ID)
+		if err != nil {
+			return err
+		}
+
+		if result.RowsAffected() == 0 {
+			return models.ErrNotFound
+		}
+
+		_, err = tx.Exec(ctx, createRevisionSql, revision.ID, revision.CommentID, revision.EditedBy, revision.EditedAt,
+			revision.Content, revision.Format)
 
-	result, err := r.Db.Exec(ctx, sql, comment.Content, comment.ID)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("cannot update comment: %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
-		return models.ErrNotFound
+	return nil
+}
+
+// DeleteComment marks the comment as deleted and recounts the comments of its post.
+func (r *Repository) DeleteComment(ctx context.Context, commentID string, deletedAt time.Time) error {
+	const sql = "UPDATE comments SET deleted_at=$1 WHERE id=$2 AND deleted_at IS NULL RETURNING post_id"
+
+	err := r.Db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var postID string
+
+		if err := pgxscan.Get(ctx, tx, &postID, sql, deletedAt, commentID); err != nil {
+			if pgxscan.NotFound(err) {
+				return models.ErrNotFound
+			}
+
+			return err
+		}
+
+		_, err := refreshPostActivity(ctx, tx, "p.id=$1", postID)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("cannot delete comment: %w", err)
 	}
 
 	return nil
 }
 
-func (r *Repository) DeleteComment(ctx context.Context, commentID string) error {
-	const sql = "DELETE FROM comments WHERE id=$1"
+// GetCommentThread returns the comment with all its replies. Replies of deleted comments are left out.
+func (r *Repository) GetCommentThread(ctx context.Context, commentID string) (*models.CommentThread, error) {
+	const sql = "WITH RECURSIVE thread AS (" +
+		"SELECT id, content, created_by, created_at, post_id, updated_at, parent_id, depth, format, content_html, " +
+		"word_count, mentions FROM comments " +
+		"WHERE id=$1 AND deleted_at IS NULL " +
+		"UNION ALL " +
+		"SELECT c.id, c.content, c.created_by, c.created_at, c.post_id, c.updated_at, c.parent_id, c.depth, " +
+		"c.format, c.content_html, c.word_count, c.mentions " +
+		"FROM comments c JOIN thread t ON c.parent_id=t.id WHERE c.deleted_at IS NULL" +
+		") SELECT * FROM thread ORDER BY depth, created_at"
 
-	result, err := r.Db.Exec(ctx, sql, commentID)
+	var comments []*models.Comment
+
+	if err := pgxscan.Select(ctx, r.Db, &comments, sql, commentID); err != nil {
+		return nil, fmt.Errorf("cannot get thread of comment: %w", err)
+	}
+
+	if len(comments) == 0 {
+		return nil, models.ErrNotFound
+	}
+
+	return models.NewCommentThread(commentID, comments), nil
+}
+
+func (r *Repository) GetDeletedComment(ctx context.Context, commentID string) (*models.Comment, error) {
+	const sql = "SELECT id, content, created_by, created_at, post_id, updated_at, parent_id, depth, format, " +
+		"content_html, word_count, mentions, deleted_at FROM comments WHERE id=$1 AND deleted_at IS NOT NULL"
+
+	var comment models.Comment
+
+	err := pgxscan.Get(ctx, r.Db, &comment, sql, commentID)
 	if err != nil {
-		return fmt.Errorf("cannot delete comment: %w", err)
+		if pgxscan.NotFound(err) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get deleted comment: %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
-		return models.ErrNotFound
+	return &comment, nil
+}
+
+// RestoreComment clears the deletion mark of the comment and recounts the comments of its post.
+func (r *Repository) RestoreComment(ctx context.Context, commentID string) error {
+	const sql = "UPDATE comments SET deleted_at=NULL WHERE id=$1 AND deleted_at IS NOT NULL RETURNING post_id"
+
+	err := r.Db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var postID string
+
+		if err := pgxscan.Get(ctx, tx, &postID, sql, commentID); err != nil {
+			if pgxscan.NotFound(err) {
+				return models.ErrNotFound
+			}
+
+			return err
+		}
+
+		_, err := refreshPostActivity(ctx, tx, "p.id=$1", postID)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("cannot restore comment: %w", err)
 	}
 
 	return nil
@@ -69,26 +196,19 @@ func (r *Repository) DeleteComment(ctx context.Context, commentID string) error
 
 func (r *Repository) ListComments(ctx context.Context, pagination models.Pagination,
 	filter models.FilterComments, sort models.SortComments) ([]*models.Comment, error) {
-	query := squirrel.Select("*").
-		From("comments")
-	if filter.Field != "" {
-		query = query.Where(fmt.Sprintf("%s=$1", filter.Field), filter.Value)
-	}
-
-	if sort.Field != "" {
-		if !sort.IsASC {
-			query = query.OrderBy(string(sort.Field) + " DESC")
-		} else {
-			query = query.OrderBy(string(sort.Field))
-		}
+	query, err := commentsQuery(filter)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get list of commentss: %w", err)
 	}
 
-	if pagination.Offset != 0 {
-		query = query.Offset(pagination.Offset)
+	columns, err := commentSortColumnsOf(sort)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get list of commentss: %w", err)
 	}
 
-	if pagination.Limit != 0 {
-		query = query.Limit(pagination.Limit)
+	query, reversed, err := paginate(withCommentMetrics(query), columns, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get list of commentss: %w", err)
 	}
 
 	sql, args, err := query.ToSql()
@@ -103,5 +223,66 @@ func (r *Repository) ListComments(ctx context.Context, pagination models.Paginat
 		return nil, fmt.Errorf("cannot get list of commentss: %w", err)
 	}
 
+	if reversed {
+		for i, j := 0, len(comments)-1; i < j; i, j = i+1, j-1 {
+			comments[i], comments[j] = comments[j], comments[i]
+		}
+	}
+
 	return comments, nil
 }
+
+// CountComments returns the number of comments matching the filter.
+func (r *Repository) CountComments(ctx context.Context, filter models.FilterComments) (int, error) {
+	query, err := commentsQuery(filter)
+	if err != nil {
+		return 0, fmt.Errorf("cannot count comments: %w", err)
+	}
+
+	sql, args, err := countQuery(query).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("cannot count comments: %w", err)
+	}
+
+	var count int
+
+	if err = pgxscan.Get(ctx, r.Db, &count, sql, args...); err != nil {
+		return 0, fmt.Errorf("cannot count comments: %w", err)
+	}
+
+	return count, nil
+}
+
+// commentsQuery selects the comments matching the filter.
+func commentsQuery(filter models.FilterComments) (squirrel.SelectBuilder, error) {
+	query := squirrel.Select("id", "content", "created_by", "created_at", "post_id", "updated_at", "parent_id",
+		"depth", "format", "content_html", "word_count", "mentions", "deleted_at").
+		From("comments").
+		Where("deleted_at IS NULL").
+		PlaceholderFormat(squirrel.Dollar)
+
+	for _, condition := range filter.Conditions {
+		predicate, err := commentConditionPredicate(condition)
+		if err != nil {
+			return query, err
+		}
+
+		query = query.Where(predicate)
+	}
+
+	if len(filter.Visibility.Statuses) != 0 {
+		visible := squirrel.Or{squirrel.Eq{"status": filter.Visibility.Statuses}}
+		if filter.Visibility.OwnerID != "" {
+			visible = append(visible, squirrel.Eq{"created_by": filter.Visibility.OwnerID})
+		}
+
+		postsSql, postsArgs, err := squirrel.Select("id").From("posts").Where(visible).ToSql()
+		if err != nil {
+			return query, err
+		}
+
+		query = query.Where("post_id IN ("+postsSql+")", postsArgs...)
+	}
+
+	return query, nil
+}
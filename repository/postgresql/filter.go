@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+// Columns are looked up instead of taking the names of fields, so a filter cannot inject SQL.
+var (
+	postFilterColumns = map[models.FilterPostsByField]string{
+		models.FilterPostsByTitle:     "title",
+		models.FilterPostsByCreatedBy: "created_by",
+		models.FilterPostsByTags:      "tag",
+		models.FilterPostsByStatus:    "status",
+		models.FilterPostsByCreatedAt: "created_at",
+	}
+
+	commentFilterColumns = map[models.FilterCommentsByField]string{
+		models.FilterCommentsByAuthor:    "created_by",
+		models.FilterCommentsByCreatedAt: "created_at",
+		models.FilterCommentsByPost:      "post_id",
+	}
+)
+
+func postConditionPredicate(condition models.PostCondition) (squirrel.Sqlizer, error) {
+	column, ok := postFilterColumns[condition.Field]
+	if !ok {
+		return nil, fmt.Errorf("cannot filter posts by %q", condition.Field)
+	}
+
+	args, err := condition.Args()
+	if err != nil {
+		return nil, err
+	}
+
+	predicate, err := conditionPredicate(column, condition.Operator, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if condition.Field != models.FilterPostsByTags {
+		return predicate, nil
+	}
+
+	// A post matches when any of its tags meets the condition.
+	sql, sqlArgs, err := predicate.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return squirrel.Expr("EXISTS (SELECT 1 FROM post_tags WHERE post_id = posts.id AND "+sql+")", sqlArgs...), nil
+}
+
+func commentConditionPredicate(condition models.CommentCondition) (squirrel.Sqlizer, error) {
+	column, ok := commentFilterColumns[condition.Field]
+	if !ok {
+		return nil, fmt.Errorf("cannot filter comments by %q", condition.Field)
+	}
+
+	args, err := condition.Args()
+	if err != nil {
+		return nil, err
+	}
+
+	return conditionPredicate(column, condition.Operator, args)
+}
+
+// conditionPredicate compares the column with the arguments of a filter condition.
+func conditionPredicate(column string, operator models.FilterOperator, args []interface{}) (squirrel.Sqlizer, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("no values to compare %s with", column)
+	}
+
+	switch operator {
+	case models.FilterOperatorEq:
+		return squirrel.Eq{column: args[0]}, nil
+	case models.FilterOperatorIn:
+		return squirrel.Eq{column: args}, nil
+	case models.FilterOperatorPrefix:
+		prefix, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot compare %s with prefix %v", column, args[0])
+		}
+
+		return squirrel.Like{column: likePrefix(prefix)}, nil
+	case models.FilterOperatorGte:
+		return squirrel.GtOrEq{column: args[0]}, nil
+	case models.FilterOperatorLte:
+		return squirrel.LtOrEq{column: args[0]}, nil
+	}
+
+	return nil, fmt.Errorf("unknown filter operator %q", operator)
+}
+
+// likePrefix returns the LIKE pattern matching the strings starting with the prefix.
+func likePrefix(prefix string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix) + "%"
+}
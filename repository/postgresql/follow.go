@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+// Follow saves the follow and tells whether it is new. Following the user again does nothing.
+func (r *Repository) Follow(ctx context.Context, follow *models.Follow) (bool, error) {
+	const sql = "INSERT INTO follows (follower_id, followee_id, created_at) VALUES ($1, $2, $3) " +
+		"ON CONFLICT DO NOTHING"
+
+	result, err := r.Db.Exec(ctx, sql, follow.FollowerID, follow.FolloweeID, follow.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("cannot follow user: %w", err)
+	}
+
+	return result.RowsAffected() != 0, nil
+}
+
+func (r *Repository) Unfollow(ctx context.Context, followerID, followeeID string) error {
+	const sql = "DELETE FROM follows WHERE follower_id=$1 AND followee_id=$2"
+
+	_, err := r.Db.Exec(ctx, sql, followerID, followeeID)
+	if err != nil {
+		return fmt.Errorf("cannot unfollow user: %w", err)
+	}
+
+	return nil
+}
+
+// ListFollowers returns users following the user, the latest followers first.
+func (r *Repository) ListFollowers(ctx context.Context, userID string,
+	pagination models.Pagination) ([]*models.User, error) {
+	users, err := r.listFollowUsers(ctx, "follower_id", "followee_id", userID, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get followers: %w", err)
+	}
+
+	return users, nil
+}
+
+// ListFollowing returns users followed by the user, the latest followed first.
+func (r *Repository) ListFollowing(ctx context.Context, userID string,
+	pagination models.Pagination) ([]*models.User, error) {
+	users, err := r.listFollowUsers(ctx, "followee_id", "follower_id", userID, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get followed users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *Repository) listFollowUsers(ctx context.Context, userColumn, byColumn, userID string,
+	pagination models.Pagination) ([]*models.User, error) {
+	query := squirrel.Select("u.id", "u.name", "u.username", "u.email", "u.created_at", "u.updated_at", "u.role",
+		"u.email_verified_at", "COALESCE(u.pending_email, '') AS pending_email").
+		From("follows f").
+		Join(fmt.Sprintf("users u ON u.id = f.%s", userColumn)).
+		Where(squirrel.Eq{"f." + byColumn: userID}).
+		Where("u.deleted_at IS NULL").
+		OrderBy("f.created_at DESC").
+		PlaceholderFormat(squirrel.Dollar)
+
+	if pagination.Offset != 0 {
+		query = query.Offset(pagination.Offset)
+	}
+
+	if pagination.Limit != 0 {
+		query = query.Limit(pagination.Limit)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var users []*models.User
+
+	if err := pgxscan.Select(ctx, r.Db, &users, sql, args...); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+func (r *Repository) CreateNotification(ctx context.Context, notification *models.Notification) error {
+	const sql = "INSERT INTO notifications (id, user_id, type, actor_id, post_id, comment_id, reaction, created_at) " +
+		"VALUES ($1, $2, $3, $4, NULLIF($5, '')::uuid, NULLIF($6, '')::uuid, $7, $8)"
+
+	_, err := r.Db.Exec(ctx, sql, notification.ID, notification.UserID, notification.Type, notification.ActorID,
+		notification.PostID, notification.CommentID, notification.Reaction, notification.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("cannot create notification: %w", err)
+	}
+
+	return nil
+}
+
+// ListNotifications returns the notifications of the user, newest first.
+func (r *Repository) ListNotifications(ctx context.Context, userID string, unreadOnly bool,
+	pagination models.Pagination) ([]*models.Notification, error) {
+	query := squirrel.Select("id", "user_id", "type", "actor_id", "COALESCE(post_id::text, '') AS post_id",
+		"COALESCE(comment_id::text, '') AS comment_id", "reaction", "created_at", "read_at").
+		From("notifications").
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("created_at DESC", "id DESC").
+		PlaceholderFormat(squirrel.Dollar)
+
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	if pagination.Offset != 0 {
+		query = query.Offset(pagination.Offset)
+	}
+
+	if pagination.Limit != 0 {
+		query = query.Limit(pagination.Limit)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get notifications: %w", err)
+	}
+
+	var notifications []*models.Notification
+
+	if err := pgxscan.Select(ctx, r.Db, &notifications, sql, args...); err != nil {
+		return nil, fmt.Errorf("cannot get notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (r *Repository) CountUnreadNotifications(ctx context.Context, userID string) (int, error) {
+	const sql = "SELECT COUNT(*) FROM notifications WHERE user_id=$1 AND read_at IS NULL"
+
+	var count int
+
+	if err := pgxscan.Get(ctx, r.Db, &count, sql, userID); err != nil {
+		return 0, fmt.Errorf("cannot count unread notifications: %w", err)
+	}
+
+	return count, nil
+}
+
+// MarkNotificationRead marks the notification of the user as read. A notification which is already read
+// keeps the time it was read at.
+func (r *Repository) MarkNotificationRead(ctx context.Context, userID, notificationID string,
+	readAt time.Time) error {
+	const sql = "UPDATE notifications SET read_at=COALESCE(read_at, $1) WHERE id=$2 AND user_id=$3"
+
+	result, err := r.Db.Exec(ctx, sql, readAt, notificationID, userID)
+	if err != nil {
+		return fmt.Errorf("cannot mark notification as read: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) MarkAllNotificationsRead(ctx context.Context, userID string, readAt time.Time) error {
+	const sql = "UPDATE notifications SET read_at=$1 WHERE user_id=$2 AND read_at IS NULL"
+
+	if _, err := r.Db.Exec(ctx, sql, readAt, userID); err != nil {
+		return fmt.Errorf("cannot mark notifications as read: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) UpdateNotificationPreferences(ctx context.Context, userID string,
+	preferences models.NotificationPreferences) error {
+	const sql = "UPDATE users SET notification_preferences=$1 WHERE id=$2 AND deleted_at IS NULL"
+
+	if preferences == nil {
+		preferences = models.NotificationPreferences{}
+	}
+
+	result, err := r.Db.Exec(ctx, sql, preferences, userID)
+	if err != nil {
+		return fmt.Errorf("cannot update notification preferences: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+// sortColumn is a column to order the rows by together with the direction.
+type sortColumn struct {
+	name  string
+	isASC bool
+}
+
+// paginate orders the query by the columns and the ID and selects the page. The page before a cursor
+// is selected in the reversed order, which the returned flag tells to restore. Queries are not ordered
+// and cannot be paged by a cursor when there are no columns.
+func paginate(query squirrel.SelectBuilder, columns []sortColumn,
+	pagination models.Pagination) (squirrel.SelectBuilder, bool, error) {
+	if len(columns) == 0 && pagination.IsKeyset() {
+		return query, false, fmt.Errorf("cannot page by cursor without sorting")
+	}
+
+	cursor := pagination.After
+	if pagination.Before != nil {
+		cursor = pagination.Before
+
+		reversed := make([]sortColumn, 0, len(columns))
+		for _, column := range columns {
+			reversed = append(reversed, sortColumn{name: column.name, isASC: !column.isASC})
+		}
+
+		columns = reversed
+	}
+
+	if cursor != nil {
+		predicate, err := keysetPredicate(columns, cursor)
+		if err != nil {
+			return query, false, err
+		}
+
+		query = query.Where(predicate)
+	}
+
+	if len(columns) != 0 {
+		orderBy := make([]string, 0, len(columns)+1)
+		for _, column := range withIDColumn(columns) {
+			direction := " DESC"
+			if column.isASC {
+				direction = ""
+			}
+
+			orderBy = append(orderBy, column.name+direction)
+		}
+
+		query = query.OrderBy(orderBy...)
+	}
+
+	if pagination.Offset != 0 && !pagination.IsKeyset() {
+		query = query.Offset(pagination.Offset)
+	}
+
+	if pagination.Limit != 0 {
+		query = query.Limit(pagination.Limit)
+	}
+
+	return query, pagination.Before != nil, nil
+}
+
+// keysetPredicate selects the rows following the cursor in the order of the columns. A row follows
+// the cursor when it is equal to the cursor by some first columns and goes after it by the next one.
+func keysetPredicate(columns []sortColumn, cursor *models.Cursor) (squirrel.Sqlizer, error) {
+	keys, err := cursor.KeyArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) != len(columns) {
+		return nil, fmt.Errorf("cursor has %d keys for %d sort columns", len(keys), len(columns))
+	}
+
+	columns = withIDColumn(columns)
+	keys = append(keys, cursor.ID)
+
+	predicate := make(squirrel.Or, 0, len(columns))
+
+	for i, column := range columns {
+		following := make(squirrel.And, 0, i+1)
+		for j := 0; j < i; j++ {
+			following = append(following, squirrel.Eq{columns[j].name: keys[j]})
+		}
+
+		operator := "<"
+		if column.isASC {
+			operator = ">"
+		}
+
+		following = append(following, squirrel.Expr(column.name+" "+operator+" ?", keys[i]))
+		predicate = append(predicate, following)
+	}
+
+	return predicate, nil
+}
+
+// withIDColumn returns the columns followed by the ID, which breaks ties in the direction of the last column.
+func withIDColumn(columns []sortColumn) []sortColumn {
+	out := make([]sortColumn, 0, len(columns)+1)
+	out = append(out, columns...)
+
+	return append(out, sortColumn{name: "id", isASC: columns[len(columns)-1].isASC})
+}
+
+// countQuery returns the number of rows selected by the query.
+func countQuery(query squirrel.SelectBuilder) squirrel.SelectBuilder {
+	return squirrel.Select("COUNT(*)").FromSelect(query, "q").PlaceholderFormat(squirrel.Dollar)
+}
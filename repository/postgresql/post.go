@@ -3,16 +3,29 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
 	"github.com/serhiihuberniuk/blog-api/models"
 )
 
+// CreatePost saves the post together with its tags.
 func (r *Repository) CreatePost(ctx context.Context, post *models.Post) error {
-	const sql = "INSERT INTO posts (id, title, description, created_by, created_at, tags) VALUES ($1,$2,$3,$4,$5,$6)"
+	const sql = "INSERT INTO posts (id, title, description, created_by, created_at, tags, updated_at, status, slug, " +
+		"format, description_html, word_count, mentions) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)"
 
-	_, err := r.Db.Exec(ctx, sql, post.ID, post.Title, post.Description, post.CreatedBy, post.CreatedAt, post.Tags)
+	err := r.Db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, sql, post.ID, post.Title, post.Description, post.CreatedBy, post.CreatedAt, post.Tags,
+			post.UpdatedAt, post.Status, post.Slug, post.Format, post.DescriptionHTML, post.WordCount,
+			post.Mentions)
+		if err != nil {
+			return slugError(err)
+		}
+
+		return savePostTags(ctx, tx, post.ID, post.Tags)
+	})
 	if err != nil {
 		return fmt.Errorf("cannot create post: %w", err)
 	}
@@ -21,7 +34,9 @@ func (r *Repository) CreatePost(ctx context.Context, post *models.Post) error {
 }
 
 func (r *Repository) GetPost(ctx context.Context, postID string) (*models.Post, error) {
-	const sql = "SELECT id, title, description, created_by, created_at, tags FROM posts WHERE id=$1"
+	const sql = "SELECT id, title, description, created_by, created_at, tags, updated_at, status, slug, " +
+		"comment_count, last_commented_at, format, description_html, word_count, mentions FROM posts " +
+		"WHERE id=$1 AND deleted_at IS NULL"
 
 	var post models.Post
 
@@ -37,14 +52,54 @@ func (r *Repository) GetPost(ctx context.Context, postID string) (*models.Post,
 	return &post, nil
 }
 
-func (r *Repository) UpdatePost(ctx context.Context, post *models.Post) error {
-	const sql = "UPDATE posts SET title=$1, description=$2, tags=$3 WHERE id=$4"
+// UpdatePost saves the post with its tags and records its previous version as the revision.
+// A replaced slug is kept as a redirect to the post.
+func (r *Repository) UpdatePost(ctx context.Context, post *models.Post, revision *models.PostRevision) error {
+	const (
+		updatePostSql = "UPDATE posts SET title=$1, description=$2, tags=$3, updated_at=$4, slug=$5, format=$6, " +
+			"description_html=$7, word_count=$8, mentions=$9 WHERE id=$10 AND deleted_at IS NULL"
+		createRevisionSql = "INSERT INTO post_revisions (id, post_id, edited_by, edited_at, title, description, tags, " +
+			"format) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)"
+	)
+
+	err := r.Db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if err := redirectSlug(ctx, tx, post.ID, post.Slug); err != nil {
+			return err
+		}
+
+		result, err := tx.Exec(ctx, updatePostSql, post.Title, post.Description, post.Tags, post.UpdatedAt, post.Slug,
+			post.Format, post.DescriptionHTML, post.WordCount, post.Mentions, post.ID)
+		if err != nil {
+			return slugError(err)
+		}
+
+		if result.RowsAffected() == 0 {
+			return models.ErrNotFound
+		}
+
+		_, err = tx.Exec(ctx, createRevisionSql, revision.ID, revision.PostID, revision.EditedBy, revision.EditedAt,
+			revision.Title, revision.Description, revision.Tags, revision.Format)
+		if err != nil {
+			return err
+		}
 
-	result, err := r.Db.Exec(ctx, sql, post.Title, post.Description, post.Tags, post.ID)
+		return savePostTags(ctx, tx, post.ID, post.Tags)
+	})
 	if err != nil {
 		return fmt.Errorf("cannot update post: %w", err)
 	}
 
+	return nil
+}
+
+func (r *Repository) UpdatePostStatus(ctx context.Context, postID string, status models.PostStatus) error {
+	const sql = "UPDATE posts SET status=$1 WHERE id=$2 AND deleted_at IS NULL"
+
+	result, err := r.Db.Exec(ctx, sql, status, postID)
+	if err != nil {
+		return fmt.Errorf("cannot update status of post: %w", err)
+	}
+
 	if result.RowsAffected() == 0 {
 		return models.ErrNotFound
 	}
@@ -52,48 +107,99 @@ func (r *Repository) UpdatePost(ctx context.Context, post *models.Post) error {
 	return nil
 }
 
-func (r *Repository) DeletePost(ctx context.Context, postID string) error {
-	const sql = "DELETE FROM posts WHERE id=$1"
+// DeletePost marks the post and its comments as deleted.
+func (r *Repository) DeletePost(ctx context.Context, postID string, deletedAt time.Time) error {
+	const (
+		deletePostSql     = "UPDATE posts SET deleted_at=$1 WHERE id=$2 AND deleted_at IS NULL"
+		deleteCommentsSql = "UPDATE comments SET deleted_at=$1 WHERE post_id=$2 AND deleted_at IS NULL"
+	)
+
+	err := r.Db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		result, err := tx.Exec(ctx, deletePostSql, deletedAt, postID)
+		if err != nil {
+			return err
+		}
+
+		if result.RowsAffected() == 0 {
+			return models.ErrNotFound
+		}
 
-	result, err := r.Db.Exec(ctx, sql, postID)
+		_, err = tx.Exec(ctx, deleteCommentsSql, deletedAt, postID)
+
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("cannot delete post, %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
-		return models.ErrNotFound
-	}
-
 	return nil
 }
 
-func (r *Repository) ListPosts(ctx context.Context, pagination models.Pagination,
-	filter models.FilterPosts, sort models.SortPosts) ([]*models.Post, error) {
-	query := squirrel.Select("*").
-		From("posts")
-
-	if filter.Field != "" {
-		if filter.Field == models.FilterPostsByTags {
-			query = query.Where(fmt.Sprintf("%s::jsonb ? $1", filter.Field), filter.Value)
-		} else {
-			query = query.Where(fmt.Sprintf("%s=$1", filter.Field), filter.Value)
+func (r *Repository) GetDeletedPost(ctx context.Context, postID string) (*models.Post, error) {
+	const sql = "SELECT id, title, description, created_by, created_at, tags, updated_at, status, slug, " +
+		"comment_count, last_commented_at, format, description_html, word_count, mentions, deleted_at FROM posts " +
+		"WHERE id=$1 AND deleted_at IS NOT NULL"
+
+	var post models.Post
+
+	err := pgxscan.Get(ctx, r.Db, &post, sql, postID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, models.ErrNotFound
 		}
+
+		return nil, fmt.Errorf("cannot get deleted post: %w", err)
 	}
 
-	if sort.SortByField != "" {
-		if !sort.IsASC {
-			query = query.OrderBy(string(sort.SortByField) + " DESC")
-		} else {
-			query = query.OrderBy(string(sort.SortByField))
+	return &post, nil
+}
+
+// RestorePost brings back the post together with the comments which were deleted with it.
+func (r *Repository) RestorePost(ctx context.Context, postID string) error {
+	const (
+		restoreCommentsSql = "UPDATE comments SET deleted_at=NULL " +
+			"WHERE post_id=$1 AND deleted_at=(SELECT deleted_at FROM posts WHERE id=$1)"
+		restorePostSql = "UPDATE posts SET deleted_at=NULL WHERE id=$1 AND deleted_at IS NOT NULL"
+	)
+
+	err := r.Db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, restoreCommentsSql, postID); err != nil {
+			return err
+		}
+
+		result, err := tx.Exec(ctx, restorePostSql, postID)
+		if err != nil {
+			return err
 		}
+
+		if result.RowsAffected() == 0 {
+			return models.ErrNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("cannot restore post: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) ListPosts(ctx context.Context, pagination models.Pagination,
+	filter models.FilterPosts, sort models.SortPosts) ([]*models.Post, error) {
+	query, err := postsQuery(filter)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get list of posts: %w", err)
 	}
 
-	if pagination.Offset != 0 {
-		query = query.Offset(pagination.Offset)
+	columns, err := postSortColumnsOf(sort)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get list of posts: %w", err)
 	}
 
-	if pagination.Limit != 0 {
-		query = query.Limit(pagination.Limit)
+	query, reversed, err := paginate(withPostMetrics(query), columns, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get list of posts: %w", err)
 	}
 
 	sql, args, err := query.ToSql()
@@ -108,5 +214,88 @@ func (r *Repository) ListPosts(ctx context.Context, pagination models.Pagination
 		return nil, fmt.Errorf("cannot get list of posts: %w", err)
 	}
 
+	if reversed {
+		for i, j := 0, len(posts)-1; i < j; i, j = i+1, j-1 {
+			posts[i], posts[j] = posts[j], posts[i]
+		}
+	}
+
 	return posts, nil
 }
+
+// CountPosts returns the number of posts matching the filter.
+func (r *Repository) CountPosts(ctx context.Context, filter models.FilterPosts) (int, error) {
+	query, err := postsQuery(filter)
+	if err != nil {
+		return 0, fmt.Errorf("cannot count posts: %w", err)
+	}
+
+	sql, args, err := countQuery(query).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("cannot count posts: %w", err)
+	}
+
+	var count int
+
+	if err = pgxscan.Get(ctx, r.Db, &count, sql, args...); err != nil {
+		return 0, fmt.Errorf("cannot count posts: %w", err)
+	}
+
+	return count, nil
+}
+
+// postsQuery selects the posts matching the filter.
+func postsQuery(filter models.FilterPosts) (squirrel.SelectBuilder, error) {
+	query := squirrel.Select("id", "title", "description", "created_by", "created_at", "tags", "updated_at",
+		"status", "slug", "comment_count", "last_commented_at", "format", "description_html", "word_count", "mentions",
+		"deleted_at").
+		From("posts").
+		Where("deleted_at IS NULL").
+		PlaceholderFormat(squirrel.Dollar)
+
+	for _, condition := range filter.Conditions {
+		predicate, err := postConditionPredicate(condition)
+		if err != nil {
+			return query, err
+		}
+
+		query = query.Where(predicate)
+	}
+
+	if len(filter.Visibility.Statuses) != 0 {
+		visible := squirrel.Or{squirrel.Eq{"status": filter.Visibility.Statuses}}
+		if filter.Visibility.OwnerID != "" {
+			visible = append(visible, squirrel.Eq{"created_by": filter.Visibility.OwnerID})
+		}
+
+		query = query.Where(visible)
+	}
+
+	if filter.FollowedBy != "" {
+		query = query.Where("created_by IN (SELECT followee_id FROM follows WHERE follower_id = ?)", filter.FollowedBy)
+	}
+
+	return query, nil
+}
+
+// savePostTags replaces the tags of the post. Tags which are not known yet are added.
+func savePostTags(ctx context.Context, tx pgx.Tx, postID string, tags []string) error {
+	const (
+		createTagsSql     = "INSERT INTO tags (name) SELECT unnest($1::text[]) ON CONFLICT DO NOTHING"
+		deletePostTagsSql = "DELETE FROM post_tags WHERE post_id=$1"
+		createPostTagsSql = "INSERT INTO post_tags (post_id, tag, position) " +
+			"SELECT $1, t.tag, t.position FROM unnest($2::text[]) WITH ORDINALITY AS t(tag, position)"
+	)
+
+	if _, err := tx.Exec(ctx, createTagsSql, tags); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, deletePostTagsSql, postID); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(ctx, createPostTagsSql, postID, tags)
+
+	return err
+}
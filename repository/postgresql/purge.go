@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// PurgeDeleted permanently removes users, posts and comments deleted before the given time.
+// Content of purged users and comments of purged posts are removed by the foreign keys.
+// Reactions left on removed posts and comments are removed afterwards.
+func (r *Repository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) error {
+	queries := []string{
+		"DELETE FROM comments WHERE deleted_at < $1",
+		"DELETE FROM posts WHERE deleted_at < $1",
+		"DELETE FROM users WHERE deleted_at < $1",
+	}
+
+	reactionQueries := []string{
+		"DELETE FROM reactions r WHERE target_type='post' AND NOT EXISTS (SELECT 1 FROM posts WHERE id=r.target_id)",
+		"DELETE FROM reactions r WHERE target_type='comment' " +
+			"AND NOT EXISTS (SELECT 1 FROM comments WHERE id=r.target_id)",
+	}
+
+	err := r.Db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		for _, sql := range queries {
+			if _, err := tx.Exec(ctx, sql, deletedBefore); err != nil {
+				return err
+			}
+		}
+
+		for _, sql := range reactionQueries {
+			if _, err := tx.Exec(ctx, sql); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("cannot purge deleted objects: %w", err)
+	}
+
+	return nil
+}
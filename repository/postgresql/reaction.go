@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+// AddReaction saves the reaction and tells whether it is new. Adding a reaction which already exists does nothing.
+func (r *Repository) AddReaction(ctx context.Context, reaction *models.Reaction) (bool, error) {
+	const sql = "INSERT INTO reactions (target_type, target_id, user_id, type, created_at) " +
+		"VALUES ($1, $2, $3, $4, $5) ON CONFLICT DO NOTHING"
+
+	result, err := r.Db.Exec(ctx, sql, reaction.TargetType, reaction.TargetID, reaction.UserID, reaction.Type,
+		reaction.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("cannot add reaction: %w", err)
+	}
+
+	return result.RowsAffected() != 0, nil
+}
+
+func (r *Repository) RemoveReaction(ctx context.Context, reaction *models.Reaction) error {
+	const sql = "DELETE FROM reactions WHERE target_type=$1 AND target_id=$2 AND user_id=$3 AND type=$4"
+
+	_, err := r.Db.Exec(ctx, sql, reaction.TargetType, reaction.TargetID, reaction.UserID, reaction.Type)
+	if err != nil {
+		return fmt.Errorf("cannot remove reaction: %w", err)
+	}
+
+	return nil
+}
+
+// CountReactions returns the numbers of reactions left on each of the targets by their IDs.
+func (r *Repository) CountReactions(ctx context.Context, targetType models.ReactionTarget,
+	targetIDs []string) (map[string]models.ReactionCounts, error) {
+	const sql = "SELECT target_id, type, COUNT(*) AS count FROM reactions " +
+		"WHERE target_type=$1 AND target_id=ANY($2) GROUP BY target_id, type"
+
+	var rows []struct {
+		TargetID string
+		Type     models.ReactionType
+		Count    int
+	}
+
+	if err := pgxscan.Select(ctx, r.Db, &rows, sql, targetType, targetIDs); err != nil {
+		return nil, fmt.Errorf("cannot count reactions: %w", err)
+	}
+
+	counts := make(map[string]models.ReactionCounts, len(targetIDs))
+	for _, targetID := range targetIDs {
+		counts[targetID] = models.ReactionCounts{}
+	}
+
+	for _, row := range rows {
+		counts[row.TargetID][row.Type] = row.Count
+	}
+
+	return counts, nil
+}
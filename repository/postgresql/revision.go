@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+func (r *Repository) ListPostRevisions(ctx context.Context, postID string) ([]*models.PostRevision, error) {
+	const sql = "SELECT id, post_id, edited_by, edited_at, title, description, tags, format FROM post_revisions " +
+		"WHERE post_id=$1 ORDER BY edited_at"
+
+	var revisions []*models.PostRevision
+
+	if err := pgxscan.Select(ctx, r.Db, &revisions, sql, postID); err != nil {
+		return nil, fmt.Errorf("cannot get revisions of post: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (r *Repository) GetPostRevision(ctx context.Context, revisionID string) (*models.PostRevision, error) {
+	const sql = "SELECT id, post_id, edited_by, edited_at, title, description, tags, format FROM post_revisions " +
+		"WHERE id=$1"
+
+	var revision models.PostRevision
+
+	err := pgxscan.Get(ctx, r.Db, &revision, sql, revisionID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get revision of post: %w", err)
+	}
+
+	return &revision, nil
+}
+
+func (r *Repository) ListCommentRevisions(ctx context.Context, commentID string) ([]*models.CommentRevision, error) {
+	const sql = "SELECT id, comment_id, edited_by, edited_at, content, format FROM comment_revisions " +
+		"WHERE comment_id=$1 ORDER BY edited_at"
+
+	var revisions []*models.CommentRevision
+
+	if err := pgxscan.Select(ctx, r.Db, &revisions, sql, commentID); err != nil {
+		return nil, fmt.Errorf("cannot get revisions of comment: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (r *Repository) GetCommentRevision(ctx context.Context, revisionID string) (*models.CommentRevision, error) {
+	const sql = "SELECT id, comment_id, edited_by, edited_at, content, format FROM comment_revisions WHERE id=$1"
+
+	var revision models.CommentRevision
+
+	err := pgxscan.Get(ctx, r.Db, &revision, sql, revisionID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get revision of comment: %w", err)
+	}
+
+	return &revision, nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+// escapeHTML and escapeHTMLEnd enclose the SQL expression of a text to escape it like html.EscapeString does.
+const (
+	escapeHTML    = "replace(replace(replace(replace(replace("
+	escapeHTMLEnd = ", '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '\"', '&#34;'), '''', '&#39;')"
+)
+
+// Search ranks posts and comments matching the search text by relevance. Comments are found only
+// on posts which can be seen with the visibility of the query.
+func (r *Repository) Search(ctx context.Context, query models.SearchQuery,
+	pagination models.Pagination) ([]*models.SearchResult, error) {
+	const (
+		headlineOptions = "'StartSel=" + models.HighlightStart + ", StopSel=" + models.HighlightStop +
+			", MaxFragments=2, MaxWords=20, MinWords=5'"
+		visible = "($2::text[] IS NULL OR p.status = ANY($2) OR p.created_by::text = $3)"
+		// The text is escaped before the headline is made, so the highlight tags are the only markup in it.
+		postText    = escapeHTML + "(p.title || ' ' || p.description)" + escapeHTMLEnd
+		commentText = escapeHTML + "c.content" + escapeHTMLEnd
+		sql         = "SELECT 'post' AS target, p.id, ts_rank(p.search_vector, q) AS rank, " +
+			"ts_headline('english', " + postText + ", q, " + headlineOptions + ") AS snippet " +
+			"FROM posts p, websearch_to_tsquery('english', $1) q " +
+			"WHERE $4 AND p.search_vector @@ q AND p.deleted_at IS NULL AND " + visible + " " +
+			"UNION ALL " +
+			"SELECT 'comment' AS target, c.id, ts_rank(c.search_vector, q) AS rank, " +
+			"ts_headline('english', " + commentText + ", q, " + headlineOptions + ") AS snippet " +
+			"FROM comments c JOIN posts p ON p.id = c.post_id, websearch_to_tsquery('english', $1) q " +
+			"WHERE $5 AND c.search_vector @@ q AND c.deleted_at IS NULL AND p.deleted_at IS NULL AND " + visible + " " +
+			"ORDER BY rank DESC, id " +
+			"LIMIT NULLIF($6, 0) OFFSET $7"
+	)
+
+	var statuses []string
+	if len(query.Visibility.Statuses) != 0 {
+		statuses = make([]string, 0, len(query.Visibility.Statuses))
+		for _, status := range query.Visibility.Statuses {
+			statuses = append(statuses, string(status))
+		}
+	}
+
+	var results []*models.SearchResult
+
+	err := pgxscan.Select(ctx, r.Db, &results, sql, query.Text, statuses, query.Visibility.OwnerID,
+		query.HasTarget(models.SearchTargetPost), query.HasTarget(models.SearchTargetComment),
+		pagination.Limit, pagination.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("cannot search: %w", err)
+	}
+
+	return results, nil
+}
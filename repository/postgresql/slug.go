@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+// GetPostBySlug returns the post by its current slug or by one of its previous slugs.
+func (r *Repository) GetPostBySlug(ctx context.Context, slug string) (*models.Post, error) {
+	const sql = "SELECT id, title, description, created_by, created_at, tags, updated_at, status, slug, " +
+		"comment_count, last_commented_at, format, description_html, word_count, mentions FROM posts " +
+		"WHERE deleted_at IS NULL " +
+		"AND (slug=$1 OR id=(SELECT post_id FROM post_slug_redirects WHERE slug=$1))"
+
+	var post models.Post
+
+	err := pgxscan.Get(ctx, r.Db, &post, sql, slug)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get post by slug: %w", err)
+	}
+
+	return &post, nil
+}
+
+// IsSlugTaken reports whether the slug is used by any other post, including deleted posts
+// and previous slugs which redirect to the posts.
+func (r *Repository) IsSlugTaken(ctx context.Context, slug, postID string) (bool, error) {
+	const sql = "SELECT EXISTS (SELECT 1 FROM posts WHERE slug=$1 AND id<>$2) " +
+		"OR EXISTS (SELECT 1 FROM post_slug_redirects WHERE slug=$1 AND post_id<>$2)"
+
+	var taken bool
+
+	if err := r.Db.QueryRow(ctx, sql, slug, postID).Scan(&taken); err != nil {
+		return false, fmt.Errorf("cannot check slug: %w", err)
+	}
+
+	return taken, nil
+}
+
+// redirectSlug keeps the current slug of the post as a redirect when the post gets a new slug.
+func redirectSlug(ctx context.Context, tx pgx.Tx, postID, slug string) error {
+	const (
+		redirectSql = "INSERT INTO post_slug_redirects (slug, post_id) " +
+			"SELECT slug, id FROM posts WHERE id=$1 AND slug<>$2 " +
+			"ON CONFLICT (slug) DO UPDATE SET post_id=EXCLUDED.post_id"
+		reuseSql = "DELETE FROM post_slug_redirects WHERE slug=$1 AND post_id=$2"
+	)
+
+	if _, err := tx.Exec(ctx, redirectSql, postID, slug); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(ctx, reuseSql, slug, postID)
+
+	return err
+}
+
+// uniqueViolation is the SQLSTATE of a duplicate value of a unique column.
+const uniqueViolation = "23505"
+
+// slugError returns models.ErrSlugTaken if the post was not saved because its slug is taken,
+// which happens when another post takes the slug after it was checked.
+func slugError(err error) error {
+	var pgErr interface{ SQLState() string }
+	if errors.As(err, &pgErr) && pgErr.SQLState() == uniqueViolation {
+		return models.ErrSlugTaken
+	}
+
+	return err
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+// Columns are looked up instead of taking the names of fields, so a sort cannot inject SQL.
+var (
+	postSortColumns = map[models.SortPostsByField]string{
+		models.SortPostsByTitle:         "title",
+		models.SortPostsByCreatedAt:     "created_at",
+		models.SortPostsByUpdatedAt:     "updated_at",
+		models.SortPostsByCommentCount:  "comment_count",
+		models.SortPostsByReactionCount: "reaction_count",
+	}
+
+	commentSortColumns = map[models.SortCommentsByField]string{
+		models.SortCommentByCreatedAt:     "created_at",
+		models.SortCommentByUpdatedAt:     "updated_at",
+		models.SortCommentByReactionCount: "reaction_count",
+	}
+)
+
+// withPostMetrics adds the computed counts to the selected posts, so they can be sorted by the counts.
+// The count of comments is stored on the post itself.
+func withPostMetrics(query squirrel.SelectBuilder) squirrel.SelectBuilder {
+	query = query.
+		Column(squirrel.Expr("(SELECT COUNT(*) FROM reactions r WHERE r.target_type=? AND r.target_id=posts.id) "+
+			"AS reaction_count", models.ReactionTargetPost))
+
+	return squirrel.Select("*").FromSelect(query, "posts").PlaceholderFormat(squirrel.Dollar)
+}
+
+// withCommentMetrics adds the computed counts to the selected comments, so they can be sorted by the counts.
+func withCommentMetrics(query squirrel.SelectBuilder) squirrel.SelectBuilder {
+	query = query.
+		Column(squirrel.Expr("(SELECT COUNT(*) FROM reactions r WHERE r.target_type=? AND r.target_id=comments.id) "+
+			"AS reaction_count", models.ReactionTargetComment))
+
+	return squirrel.Select("*").FromSelect(query, "comments").PlaceholderFormat(squirrel.Dollar)
+}
+
+func postSortColumnsOf(sort models.SortPosts) ([]sortColumn, error) {
+	columns := make([]sortColumn, 0, len(sort.Keys))
+
+	for _, key := range sort.Keys {
+		column, ok := postSortColumns[key.Field]
+		if !ok {
+			return nil, fmt.Errorf("cannot sort posts by %q", key.Field)
+		}
+
+		columns = append(columns, sortColumn{name: column, isASC: key.IsASC})
+	}
+
+	return columns, nil
+}
+
+func commentSortColumnsOf(sort models.SortComments) ([]sortColumn, error) {
+	columns := make([]sortColumn, 0, len(sort.Keys))
+
+	for _, key := range sort.Keys {
+		column, ok := commentSortColumns[key.Field]
+		if !ok {
+			return nil, fmt.Errorf("cannot sort comments by %q", key.Field)
+		}
+
+		columns = append(columns, sortColumn{name: column, isASC: key.IsASC})
+	}
+
+	return columns, nil
+}
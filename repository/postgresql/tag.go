@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+// GetTag returns the tag with the number of published posts marked with it.
+func (r *Repository) GetTag(ctx context.Context, name string) (*models.Tag, error) {
+	const sql = "SELECT t.name, COUNT(p.id) AS post_count FROM tags t " +
+		"LEFT JOIN post_tags pt ON pt.tag=t.name " +
+		"LEFT JOIN posts p ON p.id=pt.post_id AND p.deleted_at IS NULL AND p.status=$2 " +
+		"WHERE t.name=$1 GROUP BY t.name"
+
+	var tag models.Tag
+
+	err := pgxscan.Get(ctx, r.Db, &tag, sql, name, models.PostStatusPublished)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get tag: %w", err)
+	}
+
+	return &tag, nil
+}
+
+// ListTags returns tags of published posts, the most used first.
+func (r *Repository) ListTags(ctx context.Context, pagination models.Pagination) ([]*models.Tag, error) {
+	const sql = "SELECT pt.tag AS name, COUNT(*) AS post_count FROM post_tags pt " +
+		"JOIN posts p ON p.id=pt.post_id AND p.deleted_at IS NULL AND p.status=$1 " +
+		"GROUP BY pt.tag ORDER BY post_count DESC, name LIMIT NULLIF($2, 0) OFFSET $3"
+
+	var tags []*models.Tag
+
+	err := pgxscan.Select(ctx, r.Db, &tags, sql, models.PostStatusPublished, pagination.Limit, pagination.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get list of tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// AutocompleteTags returns tags of published posts starting with the prefix, the most used first.
+func (r *Repository) AutocompleteTags(ctx context.Context, prefix string, limit uint64) ([]*models.Tag, error) {
+	const sql = "SELECT t.name, COUNT(*) AS post_count FROM tags t " +
+		"JOIN post_tags pt ON pt.tag=t.name " +
+		"JOIN posts p ON p.id=pt.post_id AND p.deleted_at IS NULL AND p.status=$2 " +
+		"WHERE t.name LIKE $1 GROUP BY t.name ORDER BY post_count DESC, t.name LIMIT NULLIF($3, 0)"
+
+	var tags []*models.Tag
+
+	err := pgxscan.Select(ctx, r.Db, &tags, sql, likePrefix(prefix), models.PostStatusPublished, limit)
+	if err != nil {
+		return nil, fmt.Errorf("cannot autocomplete tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// GetTagAliases returns the tags which replace the given aliases. Tags which are not aliases are left out.
+func (r *Repository) GetTagAliases(ctx context.Context, tags []string) (map[string]string, error) {
+	const sql = "SELECT alias, tag FROM tag_aliases WHERE alias=ANY($1)"
+
+	var aliases []*models.TagAlias
+
+	if err := pgxscan.Select(ctx, r.Db, &aliases, sql, tags); err != nil {
+		return nil, fmt.Errorf("cannot get aliases of tags: %w", err)
+	}
+
+	out := make(map[string]string, len(aliases))
+	for _, alias := range aliases {
+		out[alias.Alias] = alias.Tag
+	}
+
+	return out, nil
+}
+
+// RenameTag renames the tag on all posts and keeps the old name as an alias of the new one.
+// It returns IDs of the changed posts.
+func (r *Repository) RenameTag(ctx context.Context, name, newName string) ([]string, error) {
+	const renameTagSql = "UPDATE tags SET name=$2 WHERE name=$1"
+
+	var postIDs []string
+
+	err := r.Db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var err error
+
+		postIDs, err = taggedPostIDs(ctx, tx, name)
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.Exec(ctx, renameTagSql, name, newName)
+		if err != nil {
+			return err
+		}
+
+		if result.RowsAffected() == 0 {
+			return models.ErrNotFound
+		}
+
+		return aliasTag(ctx, tx, name, newName, postIDs)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot rename tag: %w", err)
+	}
+
+	return postIDs, nil
+}
+
+// MergeTags replaces the tag with the other one on all posts and keeps the merged tag as an alias.
+// It returns IDs of the changed posts.
+func (r *Repository) MergeTags(ctx context.Context, from, into string) ([]string, error) {
+	const (
+		deleteDuplicatesSql = "DELETE FROM post_tags WHERE tag=$1 " +
+			"AND post_id IN (SELECT post_id FROM post_tags WHERE tag=$2)"
+		moveTagSql     = "UPDATE post_tags SET tag=$2 WHERE tag=$1"
+		moveAliasesSql = "UPDATE tag_aliases SET tag=$2 WHERE tag=$1"
+		deleteTagSql   = "DELETE FROM tags WHERE name=$1"
+	)
+
+	var postIDs []string
+
+	err := r.Db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var err error
+
+		postIDs, err = taggedPostIDs(ctx, tx, from)
+		if err != nil {
+			return err
+		}
+
+		for _, sql := range []string{deleteDuplicatesSql, moveTagSql, moveAliasesSql} {
+			if _, err := tx.Exec(ctx, sql, from, into); err != nil {
+				return err
+			}
+		}
+
+		result, err := tx.Exec(ctx, deleteTagSql, from)
+		if err != nil {
+			return err
+		}
+
+		if result.RowsAffected() == 0 {
+			return models.ErrNotFound
+		}
+
+		return aliasTag(ctx, tx, from, into, postIDs)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot merge tags: %w", err)
+	}
+
+	return postIDs, nil
+}
+
+func taggedPostIDs(ctx context.Context, tx pgx.Tx, tag string) ([]string, error) {
+	const sql = "SELECT post_id FROM post_tags WHERE tag=$1"
+
+	var postIDs []string
+
+	if err := pgxscan.Select(ctx, tx, &postIDs, sql, tag); err != nil {
+		return nil, err
+	}
+
+	return postIDs, nil
+}
+
+// aliasTag makes the old name of the tag an alias of the new one and updates the tags of the changed posts.
+func aliasTag(ctx context.Context, tx pgx.Tx, alias, tag string, postIDs []string) error {
+	const (
+		createAliasSql = "INSERT INTO tag_aliases (alias, tag) VALUES ($1, $2) " +
+			"ON CONFLICT (alias) DO UPDATE SET tag=excluded.tag"
+		// Tags of the posts are copied from post_tags, so the posts are read with the changed tags.
+		updatePostsSql = "UPDATE posts SET tags=(SELECT COALESCE(json_agg(tag ORDER BY position), '[]') " +
+			"FROM post_tags WHERE post_id=posts.id) WHERE id=ANY($1)"
+	)
+
+	if _, err := tx.Exec(ctx, createAliasSql, alias, tag); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(ctx, updatePostsSql, postIDs)
+
+	return err
+}
@@ -3,16 +3,19 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
 	"github.com/serhiihuberniuk/blog-api/models"
 )
 
 func (r *Repository) CreateUser(ctx context.Context, user *models.User) error {
-	const sql = "INSERT INTO users (id, name, email, created_at, updated_at, password) " +
-		"VALUES ($1, $2, $3, $4, $5, $6)"
+	const sql = "INSERT INTO users (id, name, username, email, created_at, updated_at, password, role, " +
+		"email_verified_at, pending_email) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''))"
 
-	_, err := r.Db.Exec(ctx, sql, user.ID, user.Name, user.Email, user.CreatedAt, user.UpdatedAt, user.Password)
+	_, err := r.Db.Exec(ctx, sql, user.ID, user.Name, user.Username, user.Email, user.CreatedAt, user.UpdatedAt,
+		user.Password, user.Role, user.EmailVerifiedAt, user.PendingEmail)
 	if err != nil {
 		return fmt.Errorf("cannot create user: %w", err)
 	}
@@ -21,7 +24,9 @@ func (r *Repository) CreateUser(ctx context.Context, user *models.User) error {
 }
 
 func (r *Repository) GetUser(ctx context.Context, userID string) (*models.User, error) {
-	const sql = "SELECT id, name, email, created_at, updated_at FROM users WHERE id=$1"
+	const sql = "SELECT id, name, username, email, created_at, updated_at, role, email_verified_at, " +
+		"COALESCE(pending_email, '') AS pending_email, notification_preferences FROM users " +
+		"WHERE id=$1 AND deleted_at IS NULL"
 
 	var user models.User
 
@@ -37,10 +42,106 @@ func (r *Repository) GetUser(ctx context.Context, userID string) (*models.User,
 	return &user, nil
 }
 
+func (r *Repository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	const sql = "SELECT id, name, username, email, created_at, updated_at, role, email_verified_at, " +
+		"COALESCE(pending_email, '') AS pending_email FROM users WHERE email=$1 AND deleted_at IS NULL"
+
+	var user models.User
+
+	err := pgxscan.Get(ctx, r.Db, &user, sql, email)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get user: %w", err)
+	}
+
+	return &user, nil
+}
+
+func (r *Repository) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	const sql = "SELECT id, name, username, email, created_at, updated_at, role, email_verified_at, " +
+		"COALESCE(pending_email, '') AS pending_email FROM users WHERE username=$1 AND deleted_at IS NULL"
+
+	var user models.User
+
+	err := pgxscan.Get(ctx, r.Db, &user, sql, username)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get user: %w", err)
+	}
+
+	return &user, nil
+}
+
+// GetUsersByUsernames returns the users with the given usernames. Unknown usernames are skipped.
+func (r *Repository) GetUsersByUsernames(ctx context.Context, usernames []string) ([]*models.User, error) {
+	const sql = "SELECT id, name, username, email, created_at, updated_at, role, email_verified_at, " +
+		"COALESCE(pending_email, '') AS pending_email FROM users WHERE username=ANY($1) AND deleted_at IS NULL"
+
+	var users []*models.User
+
+	if err := pgxscan.Select(ctx, r.Db, &users, sql, usernames); err != nil {
+		return nil, fmt.Errorf("cannot get users: %w", err)
+	}
+
+	return users, nil
+}
+
+// IsUsernameTaken reports whether the username is used by any user, including deleted users
+// which still can be restored.
+func (r *Repository) IsUsernameTaken(ctx context.Context, username string) (bool, error) {
+	const sql = "SELECT EXISTS (SELECT 1 FROM users WHERE username=$1)"
+
+	var taken bool
+
+	if err := r.Db.QueryRow(ctx, sql, username).Scan(&taken); err != nil {
+		return false, fmt.Errorf("cannot check username: %w", err)
+	}
+
+	return taken, nil
+}
+
+// IsEmailTaken reports whether the email is used by any user, including deleted users
+// which still can be restored.
+func (r *Repository) IsEmailTaken(ctx context.Context, email string) (bool, error) {
+	const sql = "SELECT EXISTS (SELECT 1 FROM users WHERE email=$1)"
+
+	var taken bool
+
+	if err := r.Db.QueryRow(ctx, sql, email).Scan(&taken); err != nil {
+		return false, fmt.Errorf("cannot check email: %w", err)
+	}
+
+	return taken, nil
+}
+
+func (r *Repository) GetUserPassword(ctx context.Context, userID string) (string, error) {
+	const sql = "SELECT password FROM users WHERE id=$1 AND deleted_at IS NULL"
+
+	var password string
+
+	err := pgxscan.Get(ctx, r.Db, &password, sql, userID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return "", models.ErrNotFound
+		}
+
+		return "", fmt.Errorf("cannot get password of user: %w", err)
+	}
+
+	return password, nil
+}
+
 func (r *Repository) UpdateUser(ctx context.Context, user *models.User) error {
-	const sql = "UPDATE users SET name=$1, email=$2, password=$3, updated_at=$4 WHERE id=$5"
+	const sql = "UPDATE users SET name=$1, email=$2, updated_at=$3, pending_email=NULLIF($4, '') " +
+		"WHERE id=$5 AND deleted_at IS NULL"
 
-	result, err := r.Db.Exec(ctx, sql, user.Name, user.Email, user.Password, user.UpdatedAt, user.ID)
+	result, err := r.Db.Exec(ctx, sql, user.Name, user.Email, user.UpdatedAt, user.PendingEmail, user.ID)
 	if err != nil {
 		return fmt.Errorf("cannot update user: %w", err)
 	}
@@ -52,12 +153,12 @@ func (r *Repository) UpdateUser(ctx context.Context, user *models.User) error {
 	return nil
 }
 
-func (r *Repository) DeleteUser(ctx context.Context, userID string) error {
-	const sql = "DELETE FROM users WHERE id=$1"
+func (r *Repository) UpdateUserRole(ctx context.Context, userID string, role models.Role) error {
+	const sql = "UPDATE users SET role=$1 WHERE id=$2 AND deleted_at IS NULL"
 
-	result, err := r.Db.Exec(ctx, sql, userID)
+	result, err := r.Db.Exec(ctx, sql, role, userID)
 	if err != nil {
-		return fmt.Errorf("cannot delete user: %w", err)
+		return fmt.Errorf("cannot update role of user: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
@@ -66,3 +167,149 @@ func (r *Repository) DeleteUser(ctx context.Context, userID string) error {
 
 	return nil
 }
+
+func (r *Repository) UpdateUserPassword(ctx context.Context, userID, password string, updatedAt time.Time) error {
+	const sql = "UPDATE users SET password=$1, updated_at=$2 WHERE id=$3 AND deleted_at IS NULL"
+
+	result, err := r.Db.Exec(ctx, sql, password, updatedAt, userID)
+	if err != nil {
+		return fmt.Errorf("cannot update password of user: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) VerifyUserEmail(ctx context.Context, userID, email string, verifiedAt time.Time) error {
+	const sql = "UPDATE users SET email=$1, email_verified_at=$2, updated_at=$2, " +
+		"pending_email=CASE WHEN pending_email=$1 THEN NULL ELSE pending_email END " +
+		"WHERE id=$3 AND deleted_at IS NULL"
+
+	result, err := r.Db.Exec(ctx, sql, email, verifiedAt, userID)
+	if err != nil {
+		return fmt.Errorf("cannot verify email of user: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteUser marks the user as deleted. Depending on the policy, posts and comments of the user
+// are deleted with the same time or handed over to the tombstone user.
+func (r *Repository) DeleteUser(ctx context.Context, userID string, deletedAt time.Time,
+	content models.DeletedUserContent) error {
+	const (
+		deleteUserSql = "UPDATE users SET deleted_at=$1 WHERE id=$2 AND deleted_at IS NULL"
+
+		deletePostsSql    = "UPDATE posts SET deleted_at=$1 WHERE created_by=$2 AND deleted_at IS NULL"
+		deleteCommentsSql = "UPDATE comments SET deleted_at=$1 WHERE deleted_at IS NULL " +
+			"AND (created_by=$2 OR post_id IN (SELECT id FROM posts WHERE created_by=$2 AND deleted_at=$1))"
+
+		anonymizePostsSql    = "UPDATE posts SET created_by=$1 WHERE created_by=$2"
+		anonymizeCommentsSql = "UPDATE comments SET created_by=$1 WHERE created_by=$2"
+	)
+
+	err := r.Db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		result, err := tx.Exec(ctx, deleteUserSql, deletedAt, userID)
+		if err != nil {
+			return err
+		}
+
+		if result.RowsAffected() == 0 {
+			return models.ErrNotFound
+		}
+
+		if content == models.DeletedUserContentAnonymize {
+			if _, err := tx.Exec(ctx, anonymizePostsSql, models.DeletedUserID, userID); err != nil {
+				return err
+			}
+
+			_, err = tx.Exec(ctx, anonymizeCommentsSql, models.DeletedUserID, userID)
+
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, deletePostsSql, deletedAt, userID); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, deleteCommentsSql, deletedAt, userID); err != nil {
+			return err
+		}
+
+		_, err = refreshPostActivity(ctx, tx, commentedByUser, userID)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("cannot delete user: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetDeletedUser(ctx context.Context, userID string) (*models.User, error) {
+	const sql = "SELECT id, name, username, email, created_at, updated_at, role, email_verified_at, " +
+		"COALESCE(pending_email, '') AS pending_email, deleted_at FROM users WHERE id=$1 AND deleted_at IS NOT NULL"
+
+	var user models.User
+
+	err := pgxscan.Get(ctx, r.Db, &user, sql, userID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, models.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("cannot get deleted user: %w", err)
+	}
+
+	return &user, nil
+}
+
+// RestoreUser brings back the user together with the posts and comments which were deleted with it.
+func (r *Repository) RestoreUser(ctx context.Context, userID string) error {
+	const (
+		restoreCommentsSql = "UPDATE comments SET deleted_at=NULL " +
+			"WHERE deleted_at=(SELECT deleted_at FROM users WHERE id=$1) " +
+			"AND (created_by=$1 OR post_id IN (SELECT id FROM posts WHERE created_by=$1))"
+		restorePostsSql = "UPDATE posts SET deleted_at=NULL " +
+			"WHERE created_by=$1 AND deleted_at=(SELECT deleted_at FROM users WHERE id=$1)"
+		restoreUserSql = "UPDATE users SET deleted_at=NULL WHERE id=$1 AND deleted_at IS NOT NULL"
+	)
+
+	err := r.Db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, restoreCommentsSql, userID); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, restorePostsSql, userID); err != nil {
+			return err
+		}
+
+		if _, err := refreshPostActivity(ctx, tx, commentedByUser, userID); err != nil {
+			return err
+		}
+
+		result, err := tx.Exec(ctx, restoreUserSql, userID)
+		if err != nil {
+			return err
+		}
+
+		if result.RowsAffected() == 0 {
+			return models.ErrNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("cannot restore user: %w", err)
+	}
+
+	return nil
+}
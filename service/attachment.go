@@ -0,0 +1,404 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/google/uuid"
+	"github.com/serhiihuberniuk/blog-api/media"
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+// CreateAttachment stores the uploaded file and attaches it to the post. The media type is detected by the content
+// of the file, and the file is refused as soon as it grows over the size limit or the quota of the current user.
+// The quota is checked again when the attachment is saved, since other uploads of the user may finish meanwhile.
+func (s *Service) CreateAttachment(ctx context.Context, payload models.CreateAttachmentPayload) (*models.Attachment,
+	error) {
+	post, err := s.getPost(ctx, payload.PostID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get post: %w", err)
+	}
+
+	if err := s.authorize(ctx, post.CreatedBy, permissionUpdatePost); err != nil {
+		return nil, fmt.Errorf("cannot create attachment: %w", err)
+	}
+
+	attachment := &models.Attachment{
+		ID:        uuid.New().String(),
+		PostID:    post.ID,
+		CreatedBy: s.currentUserInformationProvider.GetCurrentUserID(ctx),
+		CreatedAt: time.Now(),
+		FileName:  models.CleanFileName(payload.FileName),
+	}
+	attachment.BlobKey = models.AttachmentBlobKey(attachment.ID)
+
+	limit, byQuota, err := s.attachmentLimit(ctx, attachment.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create attachment: %w", err)
+	}
+
+	head := make([]byte, models.SniffLength)
+
+	n, err := io.ReadFull(payload.Content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("cannot read attachment: %w", err)
+	}
+
+	head = head[:n]
+	attachment.ContentType = models.SniffContentType(head)
+	attachment.Size = int64(n)
+
+	if err := attachment.Validate(); err != nil {
+		return nil, fmt.Errorf("cannot create attachment: %w", err)
+	}
+
+	if len(s.attachments.Variants) != 0 && models.HasVariants(attachment.ContentType) {
+		attachment.VariantStatus = models.VariantStatusPending
+	}
+
+	counter := &countingReader{
+		r:     io.MultiReader(bytes.NewReader(head), payload.Content),
+		limit: limit,
+	}
+
+	var (
+		content  io.Reader = counter
+		stripped []byte
+	)
+
+	if media.HasMetadata(attachment.ContentType) {
+		stripped, err = stripMetadata(counter, attachment.ContentType, byQuota)
+		if err != nil {
+			return nil, err
+		}
+
+		content = bytes.NewReader(stripped)
+	}
+
+	if err := s.blobStore.Put(ctx, attachment.BlobKey, content, attachment.ContentType); err != nil {
+		if counter.exceeded {
+			return nil, uploadLimitError(byQuota)
+		}
+
+		return nil, fmt.Errorf("cannot store attachment: %w", err)
+	}
+
+	attachment.Size = counter.n
+	if stripped != nil {
+		attachment.Size = int64(len(stripped))
+	}
+
+	if err := s.repo.CreateAttachment(ctx, attachment, s.attachments.UserQuota); err != nil {
+		if blobErr := s.blobStore.Delete(ctx, attachment.BlobKey); blobErr != nil {
+			return nil, fmt.Errorf("cannot create attachment: %v, cannot delete stored file: %w", err, blobErr)
+		}
+
+		return nil, fmt.Errorf("cannot create attachment: %w", err)
+	}
+
+	s.setAttachmentURLs(attachment)
+
+	return attachment, nil
+}
+
+// stripMetadata reads the whole uploaded image and drops its metadata, so the location where a photo was taken
+// and alike are not served to everyone who can see the post. Images which cannot be decoded are refused.
+func stripMetadata(content *countingReader, contentType string, byQuota bool) ([]byte, error) {
+	data, err := ioutil.ReadAll(content)
+	if err != nil {
+		if content.exceeded {
+			return nil, uploadLimitError(byQuota)
+		}
+
+		return nil, fmt.Errorf("cannot read attachment: %w", err)
+	}
+
+	data, err = media.StripMetadata(data, contentType)
+	if errors.Is(err, media.ErrInvalidImage) {
+		return nil, fmt.Errorf("cannot create attachment: %w", validation.Errors{"content": err})
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot strip metadata of attachment: %w", err)
+	}
+
+	return data, nil
+}
+
+// uploadLimitError tells which limit the uploaded file went over.
+func uploadLimitError(byQuota bool) error {
+	if byQuota {
+		return fmt.Errorf("cannot create attachment: %w", models.ErrQuotaExceeded)
+	}
+
+	return fmt.Errorf("cannot create attachment: %w", models.ErrTooLarge)
+}
+
+// attachmentLimit returns how many bytes the user can upload as one file and whether the limit is set by the
+// quota of the user rather than by the size limit. Zero size limit or quota means no limit.
+func (s *Service) attachmentLimit(ctx context.Context, userID string) (int64, bool, error) {
+	if s.attachments.UserQuota <= 0 {
+		return s.attachments.MaxSize, false, nil
+	}
+
+	used, err := s.repo.SumAttachmentSizes(ctx, userID)
+	if err != nil {
+		return 0, false, fmt.Errorf("cannot count size of attachments: %w", err)
+	}
+
+	left := s.attachments.UserQuota - used
+	if left <= 0 {
+		return 0, false, models.ErrQuotaExceeded
+	}
+
+	if s.attachments.MaxSize <= 0 || left < s.attachments.MaxSize {
+		return left, true, nil
+	}
+
+	return s.attachments.MaxSize, false, nil
+}
+
+// GetAttachment returns the attachment if its post is visible to the current user.
+func (s *Service) GetAttachment(ctx context.Context, attachmentID string) (*models.Attachment, error) {
+	attachment, err := s.repo.GetAttachment(ctx, attachmentID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get attachment: %w", err)
+	}
+
+	if _, err := s.getPost(ctx, attachment.PostID); err != nil {
+		return nil, fmt.Errorf("cannot get post: %w", err)
+	}
+
+	s.setAttachmentURLs(attachment)
+
+	return attachment, nil
+}
+
+// OpenAttachment returns the attachment with its content. The caller closes the content.
+func (s *Service) OpenAttachment(ctx context.Context, attachmentID string) (*models.Attachment, io.ReadCloser,
+	error) {
+	attachment, err := s.GetAttachment(ctx, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.blobStore.Get(ctx, attachment.BlobKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot get content of attachment: %w", err)
+	}
+
+	return attachment, content, nil
+}
+
+func (s *Service) ListAttachments(ctx context.Context, postID string) ([]*models.Attachment, error) {
+	if _, err := s.getPost(ctx, postID); err != nil {
+		return nil, fmt.Errorf("cannot get post: %w", err)
+	}
+
+	attachments, err := s.repo.ListAttachments(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get attachments of post: %w", err)
+	}
+
+	for _, attachment := range attachments {
+		s.setAttachmentURLs(attachment)
+	}
+
+	return attachments, nil
+}
+
+// DeleteAttachment removes the attachment and then its file, so the file is never referenced after it is gone.
+func (s *Service) DeleteAttachment(ctx context.Context, attachmentID string) error {
+	attachment, err := s.repo.GetAttachment(ctx, attachmentID)
+	if err != nil {
+		return fmt.Errorf("cannot get attachment: %w", err)
+	}
+
+	post, err := s.getPost(ctx, attachment.PostID)
+	if err != nil {
+		return fmt.Errorf("cannot get post: %w", err)
+	}
+
+	if err := s.authorize(ctx, post.CreatedBy, permissionUpdatePost); err != nil {
+		return fmt.Errorf("cannot delete attachment: %w", err)
+	}
+
+	if err := s.repo.DeleteAttachment(ctx, attachment.ID); err != nil {
+		return fmt.Errorf("cannot delete attachment: %w", err)
+	}
+
+	if err := s.deleteAttachmentBlobs(ctx, attachment); err != nil {
+		return fmt.Errorf("cannot delete content of attachment: %w", err)
+	}
+
+	return nil
+}
+
+// purgeAttachments removes files of attachments whose posts are about to be purged. Their records are removed
+// together with the posts.
+func (s *Service) purgeAttachments(ctx context.Context, deletedBefore time.Time) error {
+	attachments, err := s.repo.ListPurgeableAttachments(ctx, deletedBefore)
+	if err != nil {
+		return fmt.Errorf("cannot get attachments of deleted posts: %w", err)
+	}
+
+	for _, attachment := range attachments {
+		if err := s.deleteAttachmentBlobs(ctx, attachment); err != nil {
+			return fmt.Errorf("cannot delete content of attachment: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// OpenAttachmentVariant returns the variant of the attached image with its content. The caller closes the content.
+func (s *Service) OpenAttachmentVariant(ctx context.Context, attachmentID, name string) (*models.AttachmentVariant,
+	io.ReadCloser, error) {
+	attachment, err := s.GetAttachment(ctx, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	variant, ok := attachment.Variant(name)
+	if !ok {
+		return nil, nil, fmt.Errorf("cannot get variant of attachment: %w", models.ErrNotFound)
+	}
+
+	content, err := s.blobStore.Get(ctx, variant.BlobKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot get content of variant: %w", err)
+	}
+
+	return variant, content, nil
+}
+
+// ProcessAttachmentVariants makes the variants of images waiting for them. It is run in the background,
+// so uploads are not held up by resizing. Images which cannot be decoded are marked as failed, while the ones
+// which fail for other reasons are tried again next time.
+func (s *Service) ProcessAttachmentVariants(ctx context.Context, limit uint64) error {
+	attachments, err := s.repo.ListPendingAttachments(ctx, limit)
+	if err != nil {
+		return fmt.Errorf("cannot get attachments waiting for variants: %w", err)
+	}
+
+	var processErr error
+
+	for _, attachment := range attachments {
+		status := models.VariantStatusReady
+
+		variants, err := s.makeAttachmentVariants(ctx, attachment)
+		if errors.Is(err, media.ErrInvalidImage) {
+			status = models.VariantStatusFailed
+		} else if err != nil {
+			processErr = fmt.Errorf("cannot make variants of attachment %s: %w", attachment.ID, err)
+
+			continue
+		}
+
+		err = s.repo.UpdateAttachmentVariants(ctx, attachment.ID, status, variants)
+		if errors.Is(err, models.ErrNotFound) {
+			// The attachment was deleted while its variants were made.
+			attachment.Variants = variants
+			err = s.deleteAttachmentBlobs(ctx, attachment)
+		}
+
+		if err != nil {
+			processErr = fmt.Errorf("cannot save variants of attachment %s: %w", attachment.ID, err)
+		}
+	}
+
+	return processErr
+}
+
+func (s *Service) makeAttachmentVariants(ctx context.Context, attachment *models.Attachment) (
+	[]models.AttachmentVariant, error) {
+	content, err := s.blobStore.Get(ctx, attachment.BlobKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get content of attachment: %w", err)
+	}
+	defer content.Close()
+
+	data, err := ioutil.ReadAll(content)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read content of attachment: %w", err)
+	}
+
+	img, err := media.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	variants := make([]models.AttachmentVariant, 0, len(s.attachments.Variants))
+
+	for _, spec := range s.attachments.Variants {
+		data, size, err := media.Variant(img, spec)
+		if err != nil {
+			return nil, fmt.Errorf("cannot make variant %s: %w", spec.Name, err)
+		}
+
+		variant := models.AttachmentVariant{
+			Name:        spec.Name,
+			ContentType: spec.Format.ContentType(),
+			Width:       size.X,
+			Height:      size.Y,
+			Size:        int64(len(data)),
+			BlobKey:     models.AttachmentVariantBlobKey(attachment.ID, spec.Name),
+		}
+
+		if err := s.blobStore.Put(ctx, variant.BlobKey, bytes.NewReader(data), variant.ContentType); err != nil {
+			return nil, fmt.Errorf("cannot store variant %s: %w", spec.Name, err)
+		}
+
+		variants = append(variants, variant)
+	}
+
+	return variants, nil
+}
+
+// deleteAttachmentBlobs removes the file of the attachment and all its variants. Files which are already gone
+// are skipped.
+func (s *Service) deleteAttachmentBlobs(ctx context.Context, attachment *models.Attachment) error {
+	for _, key := range attachment.BlobKeys() {
+		if err := s.blobStore.Delete(ctx, key); err != nil && !errors.Is(err, models.ErrNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) setAttachmentURLs(attachment *models.Attachment) {
+	attachment.URL = models.AttachmentURL(s.attachments.BaseURL, attachment.ID)
+
+	for i := range attachment.Variants {
+		attachment.Variants[i].URL = models.AttachmentVariantURL(s.attachments.BaseURL, attachment.ID,
+			attachment.Variants[i].Name)
+	}
+}
+
+// countingReader counts bytes read through it and fails once there are more of them than the limit.
+type countingReader struct {
+	r        io.Reader
+	limit    int64
+	n        int64
+	exceeded bool
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+
+	if r.limit > 0 && r.n > r.limit {
+		r.exceeded = true
+
+		return 0, models.ErrTooLarge
+	}
+
+	return n, err
+}
@@ -0,0 +1,352 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/serhiihuberniuk/blog-api/media"
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/serhiihuberniuk/blog-api/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_CreateAttachment(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *Mockrepository, b *MockblobStore, p *MockcurrentUserInformationProvider,
+		ctx context.Context)
+
+	ownerID := "315b6c09-36ff-4519-8579-492f3ae2a3be"
+	post := &models.Post{
+		ID:        "a4a4d1f6-0f0a-4bd4-8b1c-6f5ac2b0e0f1",
+		Title:     "title",
+		CreatedBy: ownerID,
+		CreatedAt: time.Now(),
+		Status:    models.PostStatusPublished,
+	}
+	config := models.AttachmentConfig{
+		MaxSize:   20,
+		UserQuota: 100,
+		BaseURL:   "http://localhost:8080/",
+	}
+
+	// readAll stands for a blob store which consumes the uploaded content.
+	readAll := func(_ context.Context, _ string, r io.Reader, _ string) error {
+		_, err := ioutil.ReadAll(r)
+
+		return err
+	}
+
+	testCases := []struct {
+		name         string
+		content      string
+		mockBehavior mockBehavior
+		errMessage   string
+	}{
+		{
+			name:    "Attachment is stored",
+			content: "plain text file",
+			mockBehavior: func(r *Mockrepository, b *MockblobStore, p *MockcurrentUserInformationProvider,
+				ctx context.Context) {
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(post, nil)
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(ownerID).Times(2)
+				r.EXPECT().SumAttachmentSizes(gomock.Eq(ctx), gomock.Eq(ownerID)).Return(int64(10), nil)
+				b.EXPECT().Put(gomock.Eq(ctx), gomock.Any(), gomock.Any(), gomock.Eq("text/plain")).DoAndReturn(
+					func(_ context.Context, key string, r io.Reader, _ string) error {
+						content, err := ioutil.ReadAll(r)
+						assert.Equal(t, "plain text file", string(content))
+						assert.True(t, strings.HasPrefix(key, "attachments/"))
+
+						return err
+					})
+				r.EXPECT().CreateAttachment(gomock.Eq(ctx), gomock.Any(), gomock.Eq(config.UserQuota)).DoAndReturn(
+					func(_ context.Context, attachment *models.Attachment, _ int64) error {
+						assert.Equal(t, int64(len("plain text file")), attachment.Size)
+						assert.Equal(t, "file.txt", attachment.FileName)
+						assert.Equal(t, ownerID, attachment.CreatedBy)
+
+						return nil
+					})
+			},
+			errMessage: "",
+		},
+		{
+			name:    "File is larger than size limit",
+			content: "plain text file which is too large",
+			mockBehavior: func(r *Mockrepository, b *MockblobStore, p *MockcurrentUserInformationProvider,
+				ctx context.Context) {
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(post, nil)
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(ownerID).Times(2)
+				r.EXPECT().SumAttachmentSizes(gomock.Eq(ctx), gomock.Eq(ownerID)).Return(int64(10), nil)
+				b.EXPECT().Put(gomock.Eq(ctx), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(readAll)
+			},
+			errMessage: models.ErrTooLarge.Error(),
+		},
+		{
+			name:    "File does not fit into quota",
+			content: "plain text file",
+			mockBehavior: func(r *Mockrepository, b *MockblobStore, p *MockcurrentUserInformationProvider,
+				ctx context.Context) {
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(post, nil)
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(ownerID).Times(2)
+				r.EXPECT().SumAttachmentSizes(gomock.Eq(ctx), gomock.Eq(ownerID)).Return(int64(95), nil)
+				b.EXPECT().Put(gomock.Eq(ctx), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(readAll)
+			},
+			errMessage: models.ErrQuotaExceeded.Error(),
+		},
+		{
+			name:    "Quota is used up",
+			content: "plain text file",
+			mockBehavior: func(r *Mockrepository, b *MockblobStore, p *MockcurrentUserInformationProvider,
+				ctx context.Context) {
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(post, nil)
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(ownerID).Times(2)
+				r.EXPECT().SumAttachmentSizes(gomock.Eq(ctx), gomock.Eq(ownerID)).Return(int64(100), nil)
+			},
+			errMessage: models.ErrQuotaExceeded.Error(),
+		},
+		{
+			name:    "Content type is not allowed",
+			content: "<html><body>page</body></html>",
+			mockBehavior: func(r *Mockrepository, b *MockblobStore, p *MockcurrentUserInformationProvider,
+				ctx context.Context) {
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(post, nil)
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(ownerID).Times(2)
+				r.EXPECT().SumAttachmentSizes(gomock.Eq(ctx), gomock.Eq(ownerID)).Return(int64(0), nil)
+			},
+			errMessage: "validation failed",
+		},
+		{
+			name:    "Stored file is removed if attachment cannot be created",
+			content: "plain text file",
+			mockBehavior: func(r *Mockrepository, b *MockblobStore, p *MockcurrentUserInformationProvider,
+				ctx context.Context) {
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(post, nil)
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(ownerID).Times(2)
+				r.EXPECT().SumAttachmentSizes(gomock.Eq(ctx), gomock.Eq(ownerID)).Return(int64(0), nil)
+				b.EXPECT().Put(gomock.Eq(ctx), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(readAll)
+				r.EXPECT().CreateAttachment(gomock.Eq(ctx), gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+				b.EXPECT().Delete(gomock.Eq(ctx), gomock.Any()).Return(nil)
+			},
+			errMessage: "some error",
+		},
+		{
+			name:    "Quota is taken by other upload meanwhile",
+			content: "plain text file",
+			mockBehavior: func(r *Mockrepository, b *MockblobStore, p *MockcurrentUserInformationProvider,
+				ctx context.Context) {
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(post, nil)
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(ownerID).Times(2)
+				r.EXPECT().SumAttachmentSizes(gomock.Eq(ctx), gomock.Eq(ownerID)).Return(int64(10), nil)
+				b.EXPECT().Put(gomock.Eq(ctx), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(readAll)
+				r.EXPECT().CreateAttachment(gomock.Eq(ctx), gomock.Any(), gomock.Eq(config.UserQuota)).
+					Return(fmt.Errorf("cannot create attachment: %w", models.ErrQuotaExceeded))
+				b.EXPECT().Delete(gomock.Eq(ctx), gomock.Any()).Return(nil)
+			},
+			errMessage: models.ErrQuotaExceeded.Error(),
+		},
+		{
+			name:    "Image cannot be decoded",
+			content: "\x89PNG\x0D\x0A\x1A\x0Abroken",
+			mockBehavior: func(r *Mockrepository, b *MockblobStore, p *MockcurrentUserInformationProvider,
+				ctx context.Context) {
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(post, nil)
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(ownerID).Times(2)
+				r.EXPECT().SumAttachmentSizes(gomock.Eq(ctx), gomock.Eq(ownerID)).Return(int64(10), nil)
+			},
+			errMessage: media.ErrInvalidImage.Error(),
+		},
+		{
+			name:    "Post belongs to other user",
+			content: "plain text file",
+			mockBehavior: func(r *Mockrepository, b *MockblobStore, p *MockcurrentUserInformationProvider,
+				ctx context.Context) {
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(post, nil)
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return("40d2f213-8e41-4abf-ad54-410997b19401")
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser)
+			},
+			errMessage: models.ErrForbidden.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+			blobStoreMock := NewMockblobStore(ctrl)
+			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+			serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, blobStoreMock, config)
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			tc.mockBehavior(repoMock, blobStoreMock, providerMock, ctx)
+
+			attachment, err := serv.CreateAttachment(ctx, models.CreateAttachmentPayload{
+				PostID:   post.ID,
+				FileName: "../file.txt",
+				Content:  strings.NewReader(tc.content),
+			})
+			if tc.errMessage == "" {
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				assert.Equal(t, "http://localhost:8080/attachments/"+attachment.ID+"/content", attachment.URL)
+
+				return
+			}
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
+
+func TestService_ProcessAttachmentVariants(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *Mockrepository, b *MockblobStore, ctx context.Context)
+
+	var picture bytes.Buffer
+	if err := png.Encode(&picture, image.NewRGBA(image.Rect(0, 0, 400, 200))); err != nil {
+		t.Fatal(err)
+	}
+
+	attachment := &models.Attachment{
+		ID:            "c1f0a6a2-5b0e-4d7b-9d57-2f1c3b8e7a10",
+		PostID:        "a4a4d1f6-0f0a-4bd4-8b1c-6f5ac2b0e0f1",
+		ContentType:   "image/png",
+		BlobKey:       models.AttachmentBlobKey("c1f0a6a2-5b0e-4d7b-9d57-2f1c3b8e7a10"),
+		VariantStatus: models.VariantStatusPending,
+	}
+	thumbnailKey := models.AttachmentVariantBlobKey(attachment.ID, "thumbnail")
+	config := models.AttachmentConfig{
+		Variants: []models.VariantSpec{
+			{Name: "thumbnail", Width: 100, Height: 100, Format: models.ImageFormatJPEG},
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		mockBehavior mockBehavior
+		errMessage   string
+	}{
+		{
+			name: "Variants are made",
+			mockBehavior: func(r *Mockrepository, b *MockblobStore, ctx context.Context) {
+				r.EXPECT().ListPendingAttachments(gomock.Eq(ctx), gomock.Eq(uint64(10))).
+					Return([]*models.Attachment{attachment}, nil)
+				b.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(attachment.BlobKey)).
+					Return(ioutil.NopCloser(bytes.NewReader(picture.Bytes())), nil)
+				b.EXPECT().Put(gomock.Eq(ctx), gomock.Eq(thumbnailKey), gomock.Any(), gomock.Eq("image/jpeg")).
+					Return(nil)
+				r.EXPECT().UpdateAttachmentVariants(gomock.Eq(ctx), gomock.Eq(attachment.ID),
+					gomock.Eq(models.VariantStatusReady), gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ string, _ models.VariantStatus, variants []models.AttachmentVariant) error {
+						if assert.Len(t, variants, 1) {
+							assert.Equal(t, 100, variants[0].Width)
+							assert.Equal(t, 50, variants[0].Height)
+							assert.Equal(t, thumbnailKey, variants[0].BlobKey)
+						}
+
+						return nil
+					})
+			},
+			errMessage: "",
+		},
+		{
+			name: "File which is not an image is marked as failed",
+			mockBehavior: func(r *Mockrepository, b *MockblobStore, ctx context.Context) {
+				r.EXPECT().ListPendingAttachments(gomock.Eq(ctx), gomock.Eq(uint64(10))).
+					Return([]*models.Attachment{attachment}, nil)
+				b.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(attachment.BlobKey)).
+					Return(ioutil.NopCloser(strings.NewReader("not an image")), nil)
+				r.EXPECT().UpdateAttachmentVariants(gomock.Eq(ctx), gomock.Eq(attachment.ID),
+					gomock.Eq(models.VariantStatusFailed), gomock.Len(0)).Return(nil)
+			},
+			errMessage: "",
+		},
+		{
+			name: "Attachment stays pending if its content cannot be read",
+			mockBehavior: func(r *Mockrepository, b *MockblobStore, ctx context.Context) {
+				r.EXPECT().ListPendingAttachments(gomock.Eq(ctx), gomock.Eq(uint64(10))).
+					Return([]*models.Attachment{attachment}, nil)
+				b.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(attachment.BlobKey)).Return(nil, fmt.Errorf("some error"))
+			},
+			errMessage: "some error",
+		},
+		{
+			name: "Variants of deleted attachment are removed",
+			mockBehavior: func(r *Mockrepository, b *MockblobStore, ctx context.Context) {
+				r.EXPECT().ListPendingAttachments(gomock.Eq(ctx), gomock.Eq(uint64(10))).
+					Return([]*models.Attachment{attachment}, nil)
+				b.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(attachment.BlobKey)).
+					Return(ioutil.NopCloser(bytes.NewReader(picture.Bytes())), nil)
+				b.EXPECT().Put(gomock.Eq(ctx), gomock.Eq(thumbnailKey), gomock.Any(), gomock.Any()).Return(nil)
+				r.EXPECT().UpdateAttachmentVariants(gomock.Eq(ctx), gomock.Eq(attachment.ID),
+					gomock.Eq(models.VariantStatusReady), gomock.Any()).Return(models.ErrNotFound)
+				b.EXPECT().Delete(gomock.Eq(ctx), gomock.Eq(attachment.BlobKey)).Return(models.ErrNotFound)
+				b.EXPECT().Delete(gomock.Eq(ctx), gomock.Eq(thumbnailKey)).Return(nil)
+			},
+			errMessage: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+			blobStoreMock := NewMockblobStore(ctrl)
+
+			serv, err := service.NewService(repoMock, nil, nil, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, blobStoreMock, config)
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			tc.mockBehavior(repoMock, blobStoreMock, ctx)
+
+			err = serv.ProcessAttachmentVariants(ctx, 10)
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
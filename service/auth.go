@@ -2,51 +2,141 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
 	"github.com/serhiihuberniuk/blog-api/models"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	accessTokenTTL  = time.Minute * 10
+	refreshTokenTTL = time.Hour * 24 * 30
+	tokenLength     = 32
+)
+
 type tokenClaims struct {
 	jwt.StandardClaims
-	UserID string `json:"userId"`
+	UserID string      `json:"userId"`
+	Role   models.Role `json:"role"`
+	// SessionID is the family of refresh tokens the access token was issued with.
+	SessionID string `json:"sid,omitempty"`
 }
 
-func (s *Service) Login(ctx context.Context, payload models.LoginPayload) (string, error) {
+func (s *Service) Login(ctx context.Context, payload models.LoginPayload) (*models.Tokens, error) {
 	user, err := s.repo.Login(ctx, payload.Email)
 	if err != nil {
-		return "", models.ErrNotAuthenticated
+		return nil, models.ErrNotAuthenticated
 	}
 
 	if err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(payload.Password)); err != nil {
 		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
-			return "", models.ErrNotAuthenticated
+			return nil, models.ErrNotAuthenticated
 		}
 
-		return "", fmt.Errorf("error occurred while checking the password, %w", err)
+		return nil, fmt.Errorf("error occurred while checking the password, %w", err)
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, &tokenClaims{
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(time.Minute * 10).Unix(),
-			IssuedAt:  time.Now().Unix(),
-		},
-		UserID: user.ID,
-	})
+	if s.unverifiedAccess == UnverifiedAccessNone && !user.IsEmailVerified() {
+		return nil, fmt.Errorf("email is not verified: %w", models.ErrForbidden)
+	}
+
+	tokens, err := s.issueTokens(ctx, user, uuid.New().String())
+	if err != nil {
+		return nil, fmt.Errorf("cannot issue tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// Refresh exchanges a refresh token for a new pair of tokens. Every refresh token can be used once:
+// presenting an already rotated token revokes all tokens issued from the same login.
+func (s *Service) Refresh(ctx context.Context, payload models.RefreshPayload) (*models.Tokens, error) {
+	if err := payload.Validate(); err != nil {
+		return nil, fmt.Errorf("cannot refresh tokens: %w", err)
+	}
+
+	token, err := s.repo.GetRefreshToken(ctx, hashToken(payload.RefreshToken))
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, models.ErrNotAuthenticated
+		}
+
+		return nil, fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	now := time.Now()
 
-	tokenString, err := token.SignedString(s.privateKey)
+	if token.RevokedAt != nil {
+		return nil, s.revokeReusedRefreshToken(ctx, token.FamilyID, now)
+	}
+
+	if now.After(token.ExpiresAt) {
+		return nil, models.ErrNotAuthenticated
+	}
+
+	if err := s.repo.RevokeRefreshToken(ctx, token.ID, now); err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, s.revokeReusedRefreshToken(ctx, token.FamilyID, now)
+		}
+
+		return nil, fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	user, err := s.repo.GetUser(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, models.ErrNotAuthenticated
+		}
+
+		return nil, fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	tokens, err := s.issueTokens(ctx, user, token.FamilyID)
 	if err != nil {
-		return "", fmt.Errorf("error occurred while signing token: %w", err)
+		return nil, fmt.Errorf("cannot issue tokens: %w", err)
 	}
 
-	return tokenString, nil
+	return tokens, nil
 }
 
-func (s *Service) ParseToken(tokenString string) (string, error) {
+// Logout revokes the given refresh token together with its rotated successors
+// and denies the access token the request was authenticated with.
+func (s *Service) Logout(ctx context.Context, payload models.LogoutPayload) error {
+	if err := payload.Validate(); err != nil {
+		return fmt.Errorf("cannot logout: %w", err)
+	}
+
+	token, err := s.repo.GetRefreshToken(ctx, hashToken(payload.RefreshToken))
+	if err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	if token.UserID != s.currentUserInformationProvider.GetCurrentUserID(ctx) {
+		return fmt.Errorf("cannot logout: %w", models.ErrForbidden)
+	}
+
+	if err := s.repo.RevokeRefreshTokenFamily(ctx, token.FamilyID, time.Now()); err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	if tokenID := s.currentUserInformationProvider.GetCurrentTokenID(ctx); tokenID != "" {
+		if err := s.denylist.Deny(ctx, tokenID, accessTokenTTL); err != nil {
+			return fmt.Errorf("cannot revoke access token: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) ParseToken(ctx context.Context, tokenString string) (*models.AuthInfo, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &tokenClaims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
 			return nil, errors.New("invalid signing method")
@@ -55,17 +145,94 @@ func (s *Service) ParseToken(tokenString string) (string, error) {
 		return s.privateKey.Public(), nil
 	})
 	if err != nil {
-		return "", fmt.Errorf("error occurred while parsing token: %w", err)
+		return nil, fmt.Errorf("error occurred while parsing token: %w", err)
 	}
 
 	claims, ok := token.Claims.(*tokenClaims)
 	if !ok {
-		return "", fmt.Errorf("token claims are not of type *tokenClaims; %w", models.ErrNotAuthenticated)
+		return nil, fmt.Errorf("token claims are not of type *tokenClaims; %w", models.ErrNotAuthenticated)
+	}
+
+	if claims.Id != "" {
+		denied, err := s.denylist.IsDenied(ctx, claims.Id)
+		if err != nil {
+			return nil, fmt.Errorf("cannot check if token is revoked: %w", err)
+		}
+
+		if denied {
+			return nil, fmt.Errorf("token is revoked: %w", models.ErrNotAuthenticated)
+		}
 	}
 
-	return claims.UserID, nil
+	return &models.AuthInfo{
+		UserID:    claims.UserID,
+		Role:      claims.Role,
+		TokenID:   claims.Id,
+		SessionID: claims.SessionID,
+	}, nil
 }
 
-func (s *Service) checkCurrentUserIsOwner(ctx context.Context, id string) bool {
-	return id == s.currentUserInformationProvider.GetCurrentUserID(ctx)
+func (s *Service) issueTokens(ctx context.Context, user *models.User, familyID string) (*models.Tokens, error) {
+	now := time.Now()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, &tokenClaims{
+		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.New().String(),
+			ExpiresAt: now.Add(accessTokenTTL).Unix(),
+			IssuedAt:  now.Unix(),
+		},
+		UserID:    user.ID,
+		Role:      user.Role,
+		SessionID: familyID,
+	})
+
+	accessToken, err := token.SignedString(s.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred while signing token: %w", err)
+	}
+
+	refreshToken, err := generateToken()
+	if err != nil {
+		return nil, fmt.Errorf("error occurred while generating refresh token: %w", err)
+	}
+
+	err = s.repo.CreateRefreshToken(ctx, &models.RefreshToken{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(refreshToken),
+		CreatedAt: now,
+		ExpiresAt: now.Add(refreshTokenTTL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	return &models.Tokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func (s *Service) revokeReusedRefreshToken(ctx context.Context, familyID string, now time.Time) error {
+	if err := s.repo.RevokeRefreshTokenFamily(ctx, familyID, now); err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	return fmt.Errorf("refresh token is already used: %w", models.ErrNotAuthenticated)
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, tokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("cannot read random bytes: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
 }
@@ -4,6 +4,9 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -50,12 +53,13 @@ func TestService_Login(t *testing.T) {
 	}
 
 	testCases := []struct {
-		name         string
-		inCtx        context.Context
-		inPayload    models.LoginPayload
-		mockBehavior mockBehavior
-		expectedUser *models.User
-		errMessage   string
+		name             string
+		inCtx            context.Context
+		inPayload        models.LoginPayload
+		unverifiedAccess service.UnverifiedAccess
+		mockBehavior     mockBehavior
+		expectedUser     *models.User
+		errMessage       string
 	}{
 		{
 			name:  "Login OK",
@@ -66,6 +70,7 @@ func TestService_Login(t *testing.T) {
 			},
 			mockBehavior: func(r *Mockrepository, ctx context.Context, email string, user *models.User) {
 				r.EXPECT().Login(gomock.Eq(ctx), gomock.Eq(email)).Return(user, nil)
+				r.EXPECT().CreateRefreshToken(gomock.Eq(ctx), gomock.Any()).Return(nil)
 			},
 			expectedUser: user,
 			errMessage:   "",
@@ -106,6 +111,20 @@ func TestService_Login(t *testing.T) {
 			expectedUser: user,
 			errMessage:   models.ErrNotAuthenticated.Error(),
 		},
+		{
+			name:  "Email is not verified",
+			inCtx: context.Background(),
+			inPayload: models.LoginPayload{
+				Email:    input.Email,
+				Password: input.Password,
+			},
+			unverifiedAccess: service.UnverifiedAccessNone,
+			mockBehavior: func(r *Mockrepository, ctx context.Context, email string, user *models.User) {
+				r.EXPECT().Login(gomock.Eq(ctx), gomock.Eq(email)).Return(user, nil)
+			},
+			expectedUser: user,
+			errMessage:   models.ErrForbidden.Error(),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -120,7 +139,13 @@ func TestService_Login(t *testing.T) {
 			repoMock := NewMockrepository(ctrl)
 			providerMock := NewMockcurrentUserInformationProvider(ctrl)
 
-			serv, err := service.NewService(repoMock, privatKey, providerMock)
+			unverifiedAccess := tc.unverifiedAccess
+			if unverifiedAccess == "" {
+				unverifiedAccess = service.UnverifiedAccessFull
+			}
+
+			serv, err := service.NewService(repoMock, privatKey, providerMock, nil, nil, unverifiedAccess,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
 			if err != nil {
 				t.Log(fmt.Errorf("error occurred while init service: %w", err))
 				t.Fail()
@@ -130,15 +155,401 @@ func TestService_Login(t *testing.T) {
 
 			tc.mockBehavior(repoMock, tc.inCtx, tc.inPayload.Email, tc.expectedUser)
 
-			token, err := serv.Login(tc.inCtx, tc.inPayload)
+			tokens, err := serv.Login(tc.inCtx, tc.inPayload)
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, tokens.AccessToken)
+				assert.NotEmpty(t, tokens.RefreshToken)
+
+				return
+			}
+
+			assert.Nil(t, tokens)
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
+
+func TestService_Refresh(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *Mockrepository, ctx context.Context, tokenHash string)
+
+	privatKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Log(fmt.Errorf("error occurred while generating privat key: %w", err))
+		t.Fail()
+
+		return
+	}
+
+	refreshToken := "Ck1pDw0yR0n2CLnq7Gd4t9bz5o3LqjZ0m6GW2qf8s1A"
+	hash := sha256.Sum256([]byte(refreshToken))
+	tokenHash := hex.EncodeToString(hash[:])
+	revokedAt := time.Now().Add(-time.Minute)
+
+	user := &models.User{
+		ID:   "315b6c09-36ff-4519-8579-492f3ae2a3be",
+		Role: models.RoleUser,
+	}
+
+	storedToken := func(expiresAt time.Time, revokedAt *time.Time) *models.RefreshToken {
+		return &models.RefreshToken{
+			ID:        "a4a4d1f6-0f0a-4bd4-8b1c-6f5ac2b0e0f1",
+			UserID:    user.ID,
+			FamilyID:  "c6a3a1e0-0c7e-4c4b-9d6f-3e1a1f7d2b5e",
+			TokenHash: tokenHash,
+			CreatedAt: time.Now().Add(-time.Hour),
+			ExpiresAt: expiresAt,
+			RevokedAt: revokedAt,
+		}
+	}
+
+	testCases := []struct {
+		name         string
+		inPayload    models.RefreshPayload
+		mockBehavior mockBehavior
+		errMessage   string
+	}{
+		{
+			name:      "Tokens are rotated",
+			inPayload: models.RefreshPayload{RefreshToken: refreshToken},
+			mockBehavior: func(r *Mockrepository, ctx context.Context, tokenHash string) {
+				token := storedToken(time.Now().Add(time.Hour), nil)
+				r.EXPECT().GetRefreshToken(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(token, nil)
+				r.EXPECT().RevokeRefreshToken(gomock.Eq(ctx), gomock.Eq(token.ID), gomock.Any()).Return(nil)
+				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(token.UserID)).Return(user, nil)
+				r.EXPECT().CreateRefreshToken(gomock.Eq(ctx), gomock.Any()).Return(nil)
+			},
+			errMessage: "",
+		},
+		{
+			name:      "Refresh token is unknown",
+			inPayload: models.RefreshPayload{RefreshToken: refreshToken},
+			mockBehavior: func(r *Mockrepository, ctx context.Context, tokenHash string) {
+				r.EXPECT().GetRefreshToken(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(nil, models.ErrNotFound)
+			},
+			errMessage: models.ErrNotAuthenticated.Error(),
+		},
+		{
+			name:      "Refresh token is reused",
+			inPayload: models.RefreshPayload{RefreshToken: refreshToken},
+			mockBehavior: func(r *Mockrepository, ctx context.Context, tokenHash string) {
+				token := storedToken(time.Now().Add(time.Hour), &revokedAt)
+				r.EXPECT().GetRefreshToken(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(token, nil)
+				r.EXPECT().RevokeRefreshTokenFamily(gomock.Eq(ctx), gomock.Eq(token.FamilyID), gomock.Any()).
+					Return(nil)
+			},
+			errMessage: models.ErrNotAuthenticated.Error(),
+		},
+		{
+			name:      "Refresh token is reused concurrently",
+			inPayload: models.RefreshPayload{RefreshToken: refreshToken},
+			mockBehavior: func(r *Mockrepository, ctx context.Context, tokenHash string) {
+				token := storedToken(time.Now().Add(time.Hour), nil)
+				r.EXPECT().GetRefreshToken(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(token, nil)
+				r.EXPECT().RevokeRefreshToken(gomock.Eq(ctx), gomock.Eq(token.ID), gomock.Any()).
+					Return(models.ErrNotFound)
+				r.EXPECT().RevokeRefreshTokenFamily(gomock.Eq(ctx), gomock.Eq(token.FamilyID), gomock.Any()).
+					Return(nil)
+			},
+			errMessage: models.ErrNotAuthenticated.Error(),
+		},
+		{
+			name:      "Refresh token is expired",
+			inPayload: models.RefreshPayload{RefreshToken: refreshToken},
+			mockBehavior: func(r *Mockrepository, ctx context.Context, tokenHash string) {
+				token := storedToken(time.Now().Add(-time.Minute), nil)
+				r.EXPECT().GetRefreshToken(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(token, nil)
+			},
+			errMessage: models.ErrNotAuthenticated.Error(),
+		},
+		{
+			name:         "Invalid payload(empty refresh token)",
+			inPayload:    models.RefreshPayload{},
+			mockBehavior: func(r *Mockrepository, ctx context.Context, tokenHash string) {},
+			errMessage:   "validation failed",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+			serv, err := service.NewService(repoMock, privatKey, providerMock, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			tc.mockBehavior(repoMock, ctx, tokenHash)
+
+			tokens, err := serv.Refresh(ctx, tc.inPayload)
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, tokens.AccessToken)
+				assert.NotEqual(t, refreshToken, tokens.RefreshToken)
+
+				return
+			}
+
+			assert.Nil(t, tokens)
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
+
+func TestService_Logout(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *Mockrepository, p *MockcurrentUserInformationProvider, d *MocktokenDenylist,
+		ctx context.Context)
+
+	refreshToken := "Ck1pDw0yR0n2CLnq7Gd4t9bz5o3LqjZ0m6GW2qf8s1A"
+	hash := sha256.Sum256([]byte(refreshToken))
+	tokenHash := hex.EncodeToString(hash[:])
+	userID := "315b6c09-36ff-4519-8579-492f3ae2a3be"
+	tokenID := "5b1f3d7c-2a9e-4f55-8e0b-7c4d6a2e9f13"
+
+	storedToken := &models.RefreshToken{
+		ID:        "a4a4d1f6-0f0a-4bd4-8b1c-6f5ac2b0e0f1",
+		UserID:    userID,
+		FamilyID:  "c6a3a1e0-0c7e-4c4b-9d6f-3e1a1f7d2b5e",
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	testCases := []struct {
+		name         string
+		inPayload    models.LogoutPayload
+		mockBehavior mockBehavior
+		errMessage   string
+	}{
+		{
+			name:      "Tokens are revoked",
+			inPayload: models.LogoutPayload{RefreshToken: refreshToken},
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, d *MocktokenDenylist,
+				ctx context.Context) {
+				r.EXPECT().GetRefreshToken(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(storedToken, nil)
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID)
+				r.EXPECT().RevokeRefreshTokenFamily(gomock.Eq(ctx), gomock.Eq(storedToken.FamilyID), gomock.Any()).
+					Return(nil)
+				p.EXPECT().GetCurrentTokenID(gomock.Eq(ctx)).Return(tokenID)
+				d.EXPECT().Deny(gomock.Eq(ctx), gomock.Eq(tokenID), gomock.Any()).Return(nil)
+			},
+			errMessage: "",
+		},
+		{
+			name:      "Refresh token belongs to another user",
+			inPayload: models.LogoutPayload{RefreshToken: refreshToken},
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, d *MocktokenDenylist,
+				ctx context.Context) {
+				r.EXPECT().GetRefreshToken(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(storedToken, nil)
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return("8d1e6f0a-3b2c-4d5e-9f60-7a8b9c0d1e2f")
+			},
+			errMessage: models.ErrForbidden.Error(),
+		},
+		{
+			name:      "Refresh token is not found",
+			inPayload: models.LogoutPayload{RefreshToken: refreshToken},
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, d *MocktokenDenylist,
+				ctx context.Context) {
+				r.EXPECT().GetRefreshToken(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(nil, models.ErrNotFound)
+			},
+			errMessage: models.ErrNotFound.Error(),
+		},
+		{
+			name:      "Denylist error",
+			inPayload: models.LogoutPayload{RefreshToken: refreshToken},
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, d *MocktokenDenylist,
+				ctx context.Context) {
+				r.EXPECT().GetRefreshToken(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(storedToken, nil)
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID)
+				r.EXPECT().RevokeRefreshTokenFamily(gomock.Eq(ctx), gomock.Eq(storedToken.FamilyID), gomock.Any()).
+					Return(nil)
+				p.EXPECT().GetCurrentTokenID(gomock.Eq(ctx)).Return(tokenID)
+				d.EXPECT().Deny(gomock.Eq(ctx), gomock.Eq(tokenID), gomock.Any()).
+					Return(errors.New("redis is unavailable"))
+			},
+			errMessage: "redis is unavailable",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+			denylistMock := NewMocktokenDenylist(ctrl)
+
+			serv, err := service.NewService(repoMock, nil, providerMock, denylistMock, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			tc.mockBehavior(repoMock, providerMock, denylistMock, ctx)
+
+			err = serv.Logout(ctx, tc.inPayload)
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
+
+func TestService_ParseToken(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(d *MocktokenDenylist, ctx context.Context)
+
+	privatKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Log(fmt.Errorf("error occurred while generating privat key: %w", err))
+		t.Fail()
+
+		return
+	}
+
+	password := "password"
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		t.Log(fmt.Errorf("error occurred while running test: %w", err))
+		t.Fail()
+
+		return
+	}
+
+	user := &models.User{
+		ID:       "315b6c09-36ff-4519-8579-492f3ae2a3be",
+		Email:    "email@mail.com",
+		Password: string(hashedPassword),
+		Role:     models.RoleModerator,
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockrepository(ctrl)
+	repoMock.EXPECT().Login(gomock.Any(), gomock.Eq(user.Email)).Return(user, nil)
+	repoMock.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any()).Return(nil)
+
+	serv, err := service.NewService(repoMock, privatKey, nil, nil, nil, service.UnverifiedAccessFull,
+		models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+	if err != nil {
+		t.Log(fmt.Errorf("error occurred while init service: %w", err))
+		t.Fail()
+
+		return
+	}
+
+	tokens, err := serv.Login(context.Background(), models.LoginPayload{Email: user.Email, Password: password})
+	if err != nil {
+		t.Log(fmt.Errorf("error occurred while running test: %w", err))
+		t.Fail()
+
+		return
+	}
+
+	testCases := []struct {
+		name         string
+		inToken      string
+		mockBehavior mockBehavior
+		errMessage   string
+	}{
+		{
+			name:    "Token is valid",
+			inToken: tokens.AccessToken,
+			mockBehavior: func(d *MocktokenDenylist, ctx context.Context) {
+				d.EXPECT().IsDenied(gomock.Eq(ctx), gomock.Any()).Return(false, nil)
+			},
+			errMessage: "",
+		},
+		{
+			name:    "Token is revoked",
+			inToken: tokens.AccessToken,
+			mockBehavior: func(d *MocktokenDenylist, ctx context.Context) {
+				d.EXPECT().IsDenied(gomock.Eq(ctx), gomock.Any()).Return(true, nil)
+			},
+			errMessage: models.ErrNotAuthenticated.Error(),
+		},
+		{
+			name:    "Denylist error",
+			inToken: tokens.AccessToken,
+			mockBehavior: func(d *MocktokenDenylist, ctx context.Context) {
+				d.EXPECT().IsDenied(gomock.Eq(ctx), gomock.Any()).Return(false, errors.New("redis is unavailable"))
+			},
+			errMessage: "redis is unavailable",
+		},
+		{
+			name:         "Token is malformed",
+			inToken:      "malformed token",
+			mockBehavior: func(d *MocktokenDenylist, ctx context.Context) {},
+			errMessage:   "error occurred while parsing token",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			denylistMock := NewMocktokenDenylist(ctrl)
+
+			serv, err := service.NewService(nil, privatKey, nil, denylistMock, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			tc.mockBehavior(denylistMock, ctx)
+
+			authInfo, err := serv.ParseToken(ctx, tc.inToken)
 			if tc.errMessage == "" {
 				assert.NoError(t, err)
-				assert.NotEmpty(t, token)
+				assert.Equal(t, user.ID, authInfo.UserID)
+				assert.Equal(t, user.Role, authInfo.Role)
+				assert.NotEmpty(t, authInfo.TokenID)
 
 				return
 			}
 
-			assert.Empty(t, token)
+			assert.Nil(t, authInfo)
 			assert.Contains(t, err.Error(), tc.errMessage)
 		})
 	}
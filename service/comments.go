@@ -10,25 +10,87 @@ import (
 )
 
 func (s *Service) CreateComment(ctx context.Context, payload models.CreateCommentPayload) (string, error) {
+	now := time.Now()
+
 	comment := &models.Comment{
 		ID:        uuid.New().String(),
 		Content:   payload.Content,
+		Format:    payload.Format.OrPlain(),
 		CreatedBy: s.currentUserInformationProvider.GetCurrentUserID(ctx),
-		CreatedAt: time.Now(),
+		CreatedAt: now,
 		PostID:    payload.PostID,
+		UpdatedAt: now,
+	}
+
+	if err := s.authorize(ctx, comment.CreatedBy, permissionCreateComment); err != nil {
+		return "", fmt.Errorf("cannot create comment: %w", err)
 	}
+
 	if err := comment.Validate(); err != nil {
 		return "", fmt.Errorf("cannot create comment: %w", err)
 	}
 
+	post, err := s.getPost(ctx, comment.PostID)
+	if err != nil {
+		return "", fmt.Errorf("cannot get commented post: %w", err)
+	}
+
+	comment.Render()
+
+	comment.Mentions, err = s.resolveMentions(ctx, comment.Content)
+	if err != nil {
+		return "", fmt.Errorf("cannot create comment: %w", err)
+	}
+
+	var parent *models.Comment
+
+	if payload.ParentID != "" {
+		parent, err = s.repo.GetComment(ctx, payload.ParentID)
+		if err != nil {
+			return "", fmt.Errorf("cannot get parent comment: %w", err)
+		}
+
+		if err := comment.ValidateParent(parent); err != nil {
+			return "", fmt.Errorf("cannot create comment: %w", err)
+		}
+
+		comment.ParentID = &parent.ID
+		comment.Depth = parent.Depth + 1
+	}
+
 	if err := s.repo.CreateComment(ctx, comment); err != nil {
 		return "", fmt.Errorf("cannot create comment: %w", err)
 	}
 
+	s.notifyComment(ctx, comment, parent, post)
+
+	// Mentioned users could not open a post which is not published.
+	if post.Status == models.PostStatusPublished {
+		s.notifyMentions(ctx, comment.Mentions, comment.CreatedBy, comment.PostID, comment.ID)
+	}
+
 	return comment.ID, nil
 }
 
+// GetComment returns the comment with its reactions.
 func (s *Service) GetComment(ctx context.Context, commentID string) (*models.Comment, error) {
+	comment, err := s.getComment(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getPost(ctx, comment.PostID); err != nil {
+		return nil, fmt.Errorf("cannot get commented post: %w", err)
+	}
+
+	if err := s.countCommentsReactions(ctx, []*models.Comment{comment}); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (s *Service) getComment(ctx context.Context, commentID string) (*models.Comment, error) {
 	comment, err := s.repo.GetComment(ctx, commentID)
 	if err != nil {
 		return nil, fmt.Errorf("cannot get comment: %w", err)
@@ -37,52 +99,194 @@ func (s *Service) GetComment(ctx context.Context, commentID string) (*models.Com
 	return comment, nil
 }
 
+// ListReplies returns the replies to the comment. Every reply contains its own replies.
+func (s *Service) ListReplies(ctx context.Context, commentID string) ([]*models.CommentThread, error) {
+	thread, err := s.repo.GetCommentThread(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get replies to comment: %w", err)
+	}
+
+	if _, err := s.getPost(ctx, thread.Comment.PostID); err != nil {
+		return nil, fmt.Errorf("cannot get commented post: %w", err)
+	}
+
+	if err := s.countThreadReactions(ctx, thread.Replies); err != nil {
+		return nil, err
+	}
+
+	return thread.Replies, nil
+}
+
+func (s *Service) countThreadReactions(ctx context.Context, replies []*models.CommentThread) error {
+	return s.countCommentsReactions(ctx, threadComments(nil, replies))
+}
+
+// threadComments appends the comments of the replies and of all their replies to the comments.
+func threadComments(comments []*models.Comment, replies []*models.CommentThread) []*models.Comment {
+	for _, reply := range replies {
+		comments = threadComments(append(comments, reply.Comment), reply.Replies)
+	}
+
+	return comments
+}
+
+// UpdateComment changes the comment and keeps its previous version as a revision.
+// An update which does not change anything is not recorded. Newly mentioned users are notified.
 func (s *Service) UpdateComment(ctx context.Context, payload models.UpdateCommentPayload) error {
-	comment, err := s.GetComment(ctx, payload.CommentID)
+	comment, err := s.getComment(ctx, payload.CommentID)
 	if err != nil {
 		return fmt.Errorf("cannot get comment: %w", err)
 	}
 
-	if !s.checkCurrentUserIsOwner(ctx, comment.CreatedBy) {
-		return models.ErrNotAuthenticated
+	if err := s.authorize(ctx, comment.CreatedBy, permissionUpdateComment); err != nil {
+		return fmt.Errorf("cannot update comment: %w", err)
+	}
+
+	previous := commentRevision(comment)
+	previousMentions := comment.Mentions
+
+	if payload.Content != nil {
+		comment.Content = *payload.Content
 	}
 
-	comment.Content = payload.Content
+	comment.Format = previous.Format
+	if payload.Format != nil {
+		comment.Format = *payload.Format
+	}
 
 	if err := comment.Validate(); err != nil {
 		return fmt.Errorf("cannot update comment: %w", err)
 	}
 
-	if err := s.repo.UpdateComment(ctx, comment); err != nil {
+	if len(previous.Diff(commentRevision(comment))) == 0 {
+		return nil
+	}
+
+	comment.Render()
+
+	comment.Mentions, err = s.resolveMentions(ctx, comment.Content)
+	if err != nil {
 		return fmt.Errorf("cannot update comment: %w", err)
 	}
 
+	post, err := s.repo.GetPost(ctx, comment.PostID)
+	if err != nil {
+		return fmt.Errorf("cannot get commented post: %w", err)
+	}
+
+	now := time.Now()
+
+	previous.ID = uuid.New().String()
+	previous.EditedBy = s.currentUserInformationProvider.GetCurrentUserID(ctx)
+	previous.EditedAt = now
+	comment.UpdatedAt = now
+
+	if err := s.repo.UpdateComment(ctx, comment, previous); err != nil {
+		return fmt.Errorf("cannot update comment: %w", err)
+	}
+
+	if post.Status == models.PostStatusPublished {
+		s.notifyMentions(ctx, models.AddedMentions(comment.Mentions, previousMentions), comment.CreatedBy,
+			comment.PostID, comment.ID)
+	}
+
 	return nil
 }
 
 func (s *Service) DeleteComment(ctx context.Context, commentID string) error {
-	comment, err := s.GetComment(ctx, commentID)
+	comment, err := s.getComment(ctx, commentID)
 	if err != nil {
 		return fmt.Errorf("cannot get comment: %w", err)
 	}
 
-	if !s.checkCurrentUserIsOwner(ctx, comment.CreatedBy) {
-		return models.ErrNotAuthenticated
+	if err := s.authorize(ctx, comment.CreatedBy, permissionDeleteComment); err != nil {
+		return fmt.Errorf("cannot delete comment: %w", err)
 	}
 
-	if err := s.repo.DeleteComment(ctx, commentID); err != nil {
+	if err := s.repo.DeleteComment(ctx, commentID, time.Now()); err != nil {
 		return fmt.Errorf("cannot delete comment: %w", err)
 	}
 
 	return nil
 }
 
+// RestoreComment brings back a deleted comment. The comment can be restored only while its post exists.
+func (s *Service) RestoreComment(ctx context.Context, commentID string) error {
+	comment, err := s.repo.GetDeletedComment(ctx, commentID)
+	if err != nil {
+		return fmt.Errorf("cannot get deleted comment: %w", err)
+	}
+
+	if err := s.authorize(ctx, comment.CreatedBy, permissionRestoreComment); err != nil {
+		return fmt.Errorf("cannot restore comment: %w", err)
+	}
+
+	if _, err := s.repo.GetPost(ctx, comment.PostID); err != nil {
+		return fmt.Errorf("cannot get post of comment: %w", err)
+	}
+
+	if err := s.repo.RestoreComment(ctx, commentID); err != nil {
+		return fmt.Errorf("cannot restore comment: %w", err)
+	}
+
+	return nil
+}
+
+// ListComments returns the page of comments. Comments are sorted by the creation time unless other keys
+// are given, and the total number of comments is counted when it is asked for. Only comments of the posts
+// visible to the current user are listed.
 func (s *Service) ListComments(ctx context.Context, pagination models.Pagination,
-	filter models.FilterComments, sort models.SortComments) ([]*models.Comment, error) {
-	comments, err := s.repo.ListComments(ctx, pagination, filter, sort)
+	filter models.FilterComments, sort models.SortComments) (*models.CommentsPage, error) {
+	filter.Visibility = s.postVisibility(ctx)
+
+	if err := filter.Validate(); err != nil {
+		return nil, fmt.Errorf("cannot get comments: %w", err)
+	}
+
+	if len(sort.Keys) == 0 {
+		sort = models.SortCommentsBy(models.SortCommentByCreatedAt, true)
+	}
+
+	if err := sort.Validate(); err != nil {
+		return nil, fmt.Errorf("cannot get comments: %w", err)
+	}
+
+	if err := pagination.Validate(sort.SortKeys()); err != nil {
+		return nil, fmt.Errorf("cannot get comments: %w", err)
+	}
+
+	comments, err := s.repo.ListComments(ctx, pagination.Lookahead(), filter, sort)
 	if err != nil {
 		return nil, fmt.Errorf("cannot get comments: %w", err)
 	}
 
-	return comments, nil
+	from, to, hasPrev, hasNext := pagination.PageBounds(len(comments))
+
+	page := &models.CommentsPage{
+		Comments: comments[from:to],
+		Sort:     sort,
+	}
+
+	if len(page.Comments) != 0 && hasPrev {
+		page.Prev = models.NewCommentCursor(page.Comments[0], sort)
+	}
+
+	if len(page.Comments) != 0 && hasNext {
+		page.Next = models.NewCommentCursor(page.Comments[len(page.Comments)-1], sort)
+	}
+
+	if pagination.CountTotal {
+		total, err := s.repo.CountComments(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("cannot count comments: %w", err)
+		}
+
+		page.Total = &total
+	}
+
+	if err := s.countCommentsReactions(ctx, page.Comments); err != nil {
+		return nil, err
+	}
+
+	return page, nil
 }
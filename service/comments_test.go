@@ -0,0 +1,283 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/serhiihuberniuk/blog-api/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_CreateComment(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *Mockrepository, ctx context.Context)
+
+	userID := "315b6c09-36ff-4519-8579-492f3ae2a3be"
+	postID := "a4a4d1f6-0f0a-4bd4-8b1c-6f5ac2b0e0f1"
+
+	post := &models.Post{
+		ID:        postID,
+		CreatedBy: "40d2f213-8e41-4abf-ad54-410997b19401",
+		Status:    models.PostStatusPublished,
+	}
+	postAuthor := &models.User{
+		ID: post.CreatedBy,
+	}
+	parent := &models.Comment{
+		ID:        "7c0f6f83-45a5-4bb0-9a43-8bb0d3c3c0a4",
+		PostID:    postID,
+		CreatedBy: post.CreatedBy,
+		Depth:     1,
+	}
+
+	testCases := []struct {
+		name         string
+		parentID     string
+		postErr      error
+		mockBehavior mockBehavior
+		errMessage   string
+	}{
+		{
+			name:     "Top-level comment is created",
+			parentID: "",
+			mockBehavior: func(r *Mockrepository, ctx context.Context) {
+				r.EXPECT().CreateComment(gomock.Eq(ctx), gomock.Any()).DoAndReturn(
+					func(_ context.Context, comment *models.Comment) error {
+						assert.Nil(t, comment.ParentID)
+						assert.Equal(t, 0, comment.Depth)
+
+						return nil
+					})
+				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(post.CreatedBy)).Return(postAuthor, nil)
+				r.EXPECT().CreateNotification(gomock.Eq(ctx), gomock.Any()).DoAndReturn(
+					func(_ context.Context, notification *models.Notification) error {
+						assert.Equal(t, post.CreatedBy, notification.UserID)
+						assert.Equal(t, models.NotificationComment, notification.Type)
+						assert.Equal(t, userID, notification.ActorID)
+						assert.NotEmpty(t, notification.CommentID)
+
+						return nil
+					})
+			},
+			errMessage: "",
+		},
+		{
+			name:     "Author who turned off comment notifications is not notified",
+			parentID: "",
+			mockBehavior: func(r *Mockrepository, ctx context.Context) {
+				r.EXPECT().CreateComment(gomock.Eq(ctx), gomock.Any()).Return(nil)
+				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(post.CreatedBy)).Return(&models.User{
+					ID:                      post.CreatedBy,
+					NotificationPreferences: models.NotificationPreferences{models.NotificationComment: false},
+				}, nil)
+			},
+			errMessage: "",
+		},
+		{
+			name:     "Reply is created one level deeper than parent",
+			parentID: parent.ID,
+			mockBehavior: func(r *Mockrepository, ctx context.Context) {
+				r.EXPECT().GetComment(gomock.Eq(ctx), gomock.Eq(parent.ID)).Return(parent, nil)
+				r.EXPECT().CreateComment(gomock.Eq(ctx), gomock.Any()).DoAndReturn(
+					func(_ context.Context, comment *models.Comment) error {
+						assert.Equal(t, parent.ID, *comment.ParentID)
+						assert.Equal(t, 2, comment.Depth)
+
+						return nil
+					})
+				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(parent.CreatedBy)).Return(postAuthor, nil)
+				r.EXPECT().CreateNotification(gomock.Eq(ctx), gomock.Any()).DoAndReturn(
+					func(_ context.Context, notification *models.Notification) error {
+						assert.Equal(t, models.NotificationReply, notification.Type)
+
+						return nil
+					})
+			},
+			errMessage: "",
+		},
+		{
+			name:     "Reply to own comment notifies author of post",
+			parentID: parent.ID,
+			mockBehavior: func(r *Mockrepository, ctx context.Context) {
+				ownParent := *parent
+				ownParent.CreatedBy = userID
+
+				r.EXPECT().GetComment(gomock.Eq(ctx), gomock.Eq(parent.ID)).Return(&ownParent, nil)
+				r.EXPECT().CreateComment(gomock.Eq(ctx), gomock.Any()).Return(nil)
+				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(post.CreatedBy)).Return(postAuthor, nil)
+				r.EXPECT().CreateNotification(gomock.Eq(ctx), gomock.Any()).DoAndReturn(
+					func(_ context.Context, notification *models.Notification) error {
+						assert.Equal(t, models.NotificationComment, notification.Type)
+
+						return nil
+					})
+			},
+			errMessage: "",
+		},
+		{
+			name:     "Parent belongs to other post",
+			parentID: parent.ID,
+			mockBehavior: func(r *Mockrepository, ctx context.Context) {
+				otherParent := *parent
+				otherParent.PostID = "40d2f213-8e41-4abf-ad54-410997b19401"
+
+				r.EXPECT().GetComment(gomock.Eq(ctx), gomock.Eq(parent.ID)).Return(&otherParent, nil)
+			},
+			errMessage: "parent comment belongs to other post",
+		},
+		{
+			name:     "Parent is too deep",
+			parentID: parent.ID,
+			mockBehavior: func(r *Mockrepository, ctx context.Context) {
+				deepParent := *parent
+				deepParent.Depth = models.MaxCommentDepth
+
+				r.EXPECT().GetComment(gomock.Eq(ctx), gomock.Eq(parent.ID)).Return(&deepParent, nil)
+			},
+			errMessage: "replies cannot be nested deeper",
+		},
+		{
+			name:         "Post is deleted",
+			parentID:     "",
+			postErr:      models.ErrNotFound,
+			mockBehavior: func(r *Mockrepository, ctx context.Context) {},
+			errMessage:   models.ErrNotFound.Error(),
+		},
+		{
+			name:     "Parent is not found",
+			parentID: parent.ID,
+			mockBehavior: func(r *Mockrepository, ctx context.Context) {
+				r.EXPECT().GetComment(gomock.Eq(ctx), gomock.Eq(parent.ID)).Return(nil, models.ErrNotFound)
+			},
+			errMessage: models.ErrNotFound.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+			serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			providerMock.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).Times(2)
+
+			if tc.postErr != nil {
+				repoMock.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(postID)).Return(nil, tc.postErr)
+			} else {
+				repoMock.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(postID)).Return(post, nil)
+			}
+
+			tc.mockBehavior(repoMock, ctx)
+
+			_, err = serv.CreateComment(ctx, models.CreateCommentPayload{
+				Content:  "content",
+				PostID:   postID,
+				ParentID: tc.parentID,
+			})
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
+
+func TestService_GetComment(t *testing.T) {
+	t.Parallel()
+
+	userID := "315b6c09-36ff-4519-8579-492f3ae2a3be"
+
+	comment := &models.Comment{
+		ID:        "7c0f6f83-45a5-4bb0-9a43-8bb0d3c3c0a4",
+		PostID:    "a4a4d1f6-0f0a-4bd4-8b1c-6f5ac2b0e0f1",
+		CreatedBy: userID,
+	}
+
+	testCases := []struct {
+		name       string
+		post       *models.Post
+		errMessage string
+	}{
+		{
+			name: "Comment of published post is got",
+			post: &models.Post{
+				ID:        comment.PostID,
+				CreatedBy: "40d2f213-8e41-4abf-ad54-410997b19401",
+				Status:    models.PostStatusPublished,
+			},
+			errMessage: "",
+		},
+		{
+			name: "Comment of draft of other user is not found",
+			post: &models.Post{
+				ID:        comment.PostID,
+				CreatedBy: "40d2f213-8e41-4abf-ad54-410997b19401",
+				Status:    models.PostStatusDraft,
+			},
+			errMessage: models.ErrNotFound.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+			serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			providerMock.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).AnyTimes()
+			providerMock.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser).AnyTimes()
+			repoMock.EXPECT().GetComment(gomock.Eq(ctx), gomock.Eq(comment.ID)).Return(comment, nil)
+			repoMock.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(comment.PostID)).Return(tc.post, nil)
+			repoMock.EXPECT().CountReactions(gomock.Eq(ctx), gomock.Eq(models.ReactionTargetComment),
+				gomock.Eq([]string{comment.ID})).Return(map[string]models.ReactionCounts{}, nil).AnyTimes()
+
+			_, err = serv.GetComment(ctx, comment.ID)
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+// UnverifiedAccess defines what users with unverified email are allowed to do.
+type UnverifiedAccess string
+
+const (
+	UnverifiedAccessFull     UnverifiedAccess = "full"
+	UnverifiedAccessReadOnly UnverifiedAccess = "read-only"
+	UnverifiedAccessNone     UnverifiedAccess = "none"
+)
+
+const (
+	emailVerificationTokenTTL = time.Hour * 24
+	emailVerificationSubject  = "Email verification"
+	emailVerificationBody     = "Please confirm your email address.\n\n" +
+		"Use this token to verify it: %s\n\n" +
+		"The token expires in %v. If it was not you, just ignore this message."
+)
+
+// VerifyEmail confirms the address the token was sent to. If it is a pending email, it replaces the current one.
+func (s *Service) VerifyEmail(ctx context.Context, payload models.VerifyEmailPayload) error {
+	if err := payload.Validate(); err != nil {
+		return fmt.Errorf("cannot verify email: %w", err)
+	}
+
+	token, err := s.repo.GetEmailVerificationToken(ctx, hashToken(payload.Token))
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return fmt.Errorf("email verification token is invalid: %w", models.ErrNotAuthenticated)
+		}
+
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	now := time.Now()
+
+	if token.UsedAt != nil || now.After(token.ExpiresAt) {
+		return fmt.Errorf("email verification token is used or expired: %w", models.ErrNotAuthenticated)
+	}
+
+	user, err := s.repo.GetUser(ctx, token.UserID)
+	if err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	if token.Email != user.Email && token.Email != user.PendingEmail {
+		return fmt.Errorf("email verification token is outdated: %w", models.ErrNotAuthenticated)
+	}
+
+	if err := s.repo.UseEmailVerificationToken(ctx, token.ID, now); err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return fmt.Errorf("email verification token is used or expired: %w", models.ErrNotAuthenticated)
+		}
+
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	if err := s.repo.VerifyUserEmail(ctx, token.UserID, token.Email, now); err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	return nil
+}
+
+// ResendEmailVerification sends a new verification token for the pending email
+// of the current user or for the current email if it is not verified yet.
+func (s *Service) ResendEmailVerification(ctx context.Context) error {
+	userID := s.currentUserInformationProvider.GetCurrentUserID(ctx)
+	if userID == "" {
+		return models.ErrNotAuthenticated
+	}
+
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	email := user.PendingEmail
+	if email == "" {
+		if user.IsEmailVerified() {
+			return nil
+		}
+
+		email = user.Email
+	}
+
+	if err := s.sendEmailVerification(ctx, user.ID, email); err != nil {
+		return fmt.Errorf("cannot send email verification: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) sendEmailVerification(ctx context.Context, userID, email string) error {
+	verificationToken, err := generateToken()
+	if err != nil {
+		return fmt.Errorf("error occurred while generating email verification token: %w", err)
+	}
+
+	now := time.Now()
+
+	err = s.repo.CreateEmailVerificationToken(ctx, &models.EmailVerificationToken{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Email:     email,
+		TokenHash: hashToken(verificationToken),
+		CreatedAt: now,
+		ExpiresAt: now.Add(emailVerificationTokenTTL),
+	})
+	if err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	err = s.mailer.Send(ctx, email, emailVerificationSubject,
+		fmt.Sprintf(emailVerificationBody, verificationToken, emailVerificationTokenTTL))
+	if err != nil {
+		return fmt.Errorf("cannot send email: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) checkEmailVerified(ctx context.Context, userID string) error {
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	if !user.IsEmailVerified() {
+		return fmt.Errorf("email is not verified: %w", models.ErrForbidden)
+	}
+
+	return nil
+}
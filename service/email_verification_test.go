@@ -0,0 +1,230 @@
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/serhiihuberniuk/blog-api/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_VerifyEmail(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *Mockrepository, ctx context.Context, tokenHash string)
+
+	verificationToken := "Zx3pDw0yR0n2CLnq7Gd4t9bz5o3LqjZ0m6GW2qf8s1A"
+	hash := sha256.Sum256([]byte(verificationToken))
+	tokenHash := hex.EncodeToString(hash[:])
+	usedAt := time.Now().Add(-time.Minute)
+
+	user := &models.User{
+		ID:           "315b6c09-36ff-4519-8579-492f3ae2a3be",
+		Email:        "email@mail.com",
+		PendingEmail: "new@mail.com",
+	}
+
+	storedToken := func(email string, expiresAt time.Time, usedAt *time.Time) *models.EmailVerificationToken {
+		return &models.EmailVerificationToken{
+			ID:        "a4a4d1f6-0f0a-4bd4-8b1c-6f5ac2b0e0f1",
+			UserID:    user.ID,
+			Email:     email,
+			TokenHash: tokenHash,
+			CreatedAt: time.Now().Add(-time.Minute * 10),
+			ExpiresAt: expiresAt,
+			UsedAt:    usedAt,
+		}
+	}
+
+	testCases := []struct {
+		name         string
+		inPayload    models.VerifyEmailPayload
+		mockBehavior mockBehavior
+		errMessage   string
+	}{
+		{
+			name:      "Current email is verified",
+			inPayload: models.VerifyEmailPayload{Token: verificationToken},
+			mockBehavior: func(r *Mockrepository, ctx context.Context, tokenHash string) {
+				token := storedToken(user.Email, time.Now().Add(time.Hour), nil)
+				r.EXPECT().GetEmailVerificationToken(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(token, nil)
+				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(user.ID)).Return(user, nil)
+				r.EXPECT().UseEmailVerificationToken(gomock.Eq(ctx), gomock.Eq(token.ID), gomock.Any()).Return(nil)
+				r.EXPECT().VerifyUserEmail(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(user.Email), gomock.Any()).
+					Return(nil)
+			},
+			errMessage: "",
+		},
+		{
+			name:      "Pending email is verified",
+			inPayload: models.VerifyEmailPayload{Token: verificationToken},
+			mockBehavior: func(r *Mockrepository, ctx context.Context, tokenHash string) {
+				token := storedToken(user.PendingEmail, time.Now().Add(time.Hour), nil)
+				r.EXPECT().GetEmailVerificationToken(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(token, nil)
+				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(user.ID)).Return(user, nil)
+				r.EXPECT().UseEmailVerificationToken(gomock.Eq(ctx), gomock.Eq(token.ID), gomock.Any()).Return(nil)
+				r.EXPECT().VerifyUserEmail(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(user.PendingEmail),
+					gomock.Any()).Return(nil)
+			},
+			errMessage: "",
+		},
+		{
+			name:      "Token was sent to outdated email",
+			inPayload: models.VerifyEmailPayload{Token: verificationToken},
+			mockBehavior: func(r *Mockrepository, ctx context.Context, tokenHash string) {
+				token := storedToken("old@mail.com", time.Now().Add(time.Hour), nil)
+				r.EXPECT().GetEmailVerificationToken(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(token, nil)
+				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(user.ID)).Return(user, nil)
+			},
+			errMessage: models.ErrNotAuthenticated.Error(),
+		},
+		{
+			name:      "Token is unknown",
+			inPayload: models.VerifyEmailPayload{Token: verificationToken},
+			mockBehavior: func(r *Mockrepository, ctx context.Context, tokenHash string) {
+				r.EXPECT().GetEmailVerificationToken(gomock.Eq(ctx), gomock.Eq(tokenHash)).
+					Return(nil, models.ErrNotFound)
+			},
+			errMessage: models.ErrNotAuthenticated.Error(),
+		},
+		{
+			name:      "Token is already used",
+			inPayload: models.VerifyEmailPayload{Token: verificationToken},
+			mockBehavior: func(r *Mockrepository, ctx context.Context, tokenHash string) {
+				token := storedToken(user.Email, time.Now().Add(time.Hour), &usedAt)
+				r.EXPECT().GetEmailVerificationToken(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(token, nil)
+			},
+			errMessage: models.ErrNotAuthenticated.Error(),
+		},
+		{
+			name:      "Token is expired",
+			inPayload: models.VerifyEmailPayload{Token: verificationToken},
+			mockBehavior: func(r *Mockrepository, ctx context.Context, tokenHash string) {
+				token := storedToken(user.Email, time.Now().Add(-time.Minute), nil)
+				r.EXPECT().GetEmailVerificationToken(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(token, nil)
+			},
+			errMessage: models.ErrNotAuthenticated.Error(),
+		},
+		{
+			name:         "Invalid payload(token is empty)",
+			inPayload:    models.VerifyEmailPayload{},
+			mockBehavior: func(r *Mockrepository, ctx context.Context, tokenHash string) {},
+			errMessage:   "validation failed",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+
+			serv, err := service.NewService(repoMock, nil, nil, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			tc.mockBehavior(repoMock, ctx, tokenHash)
+
+			err = serv.VerifyEmail(ctx, tc.inPayload)
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
+
+func TestService_CreatePost_UnverifiedAccess(t *testing.T) {
+	t.Parallel()
+
+	verifiedAt := time.Now()
+	userID := "315b6c09-36ff-4519-8579-492f3ae2a3be"
+	payload := models.CreatePostPayload{
+		Title:       "title",
+		Description: "description",
+		Tags:        []string{"tag"},
+	}
+
+	testCases := []struct {
+		name             string
+		unverifiedAccess service.UnverifiedAccess
+		user             *models.User
+		errMessage       string
+	}{
+		{
+			name:             "Verified user creates post",
+			unverifiedAccess: service.UnverifiedAccessReadOnly,
+			user:             &models.User{ID: userID, Role: models.RoleUser, EmailVerifiedAt: &verifiedAt},
+			errMessage:       "",
+		},
+		{
+			name:             "Unverified user is read-only",
+			unverifiedAccess: service.UnverifiedAccessReadOnly,
+			user:             &models.User{ID: userID, Role: models.RoleUser},
+			errMessage:       models.ErrForbidden.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+			providerMock.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).AnyTimes()
+			providerMock.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser).AnyTimes()
+			repoMock.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(userID)).Return(tc.user, nil)
+
+			if tc.errMessage == "" {
+				repoMock.EXPECT().GetTagAliases(gomock.Eq(ctx), gomock.Any()).Return(map[string]string{}, nil)
+				repoMock.EXPECT().IsSlugTaken(gomock.Eq(ctx), gomock.Any(), gomock.Any()).Return(false, nil)
+				repoMock.EXPECT().CreatePost(gomock.Eq(ctx), gomock.Any()).Return(nil)
+			}
+
+			serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, tc.unverifiedAccess,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			_, err = serv.CreatePost(ctx, payload)
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+// Follow makes the current user follow the user, so published posts of the user get into the home feed.
+func (s *Service) Follow(ctx context.Context, userID string) error {
+	follow := &models.Follow{
+		FollowerID: s.currentUserInformationProvider.GetCurrentUserID(ctx),
+		FolloweeID: userID,
+		CreatedAt:  time.Now(),
+	}
+
+	if err := s.authorize(ctx, follow.FollowerID, permissionFollow); err != nil {
+		return fmt.Errorf("cannot follow user: %w", err)
+	}
+
+	if err := follow.Validate(); err != nil {
+		return fmt.Errorf("cannot follow user: %w", err)
+	}
+
+	if _, err := s.repo.GetUser(ctx, userID); err != nil {
+		return fmt.Errorf("cannot get followed user: %w", err)
+	}
+
+	followed, err := s.repo.Follow(ctx, follow)
+	if err != nil {
+		return fmt.Errorf("cannot follow user: %w", err)
+	}
+
+	// Following the user again changes nothing, so it is not notified again.
+	if followed {
+		s.notify(ctx, &models.Notification{
+			UserID:  follow.FolloweeID,
+			Type:    models.NotificationFollow,
+			ActorID: follow.FollowerID,
+		})
+	}
+
+	return nil
+}
+
+func (s *Service) Unfollow(ctx context.Context, userID string) error {
+	followerID := s.currentUserInformationProvider.GetCurrentUserID(ctx)
+
+	if err := s.authorize(ctx, followerID, permissionFollow); err != nil {
+		return fmt.Errorf("cannot unfollow user: %w", err)
+	}
+
+	if err := s.repo.Unfollow(ctx, followerID, userID); err != nil {
+		return fmt.Errorf("cannot unfollow user: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) ListFollowers(ctx context.Context, userID string,
+	pagination models.Pagination) ([]*models.User, error) {
+	if _, err := s.repo.GetUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("cannot get user: %w", err)
+	}
+
+	users, err := s.repo.ListFollowers(ctx, userID, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get followers: %w", err)
+	}
+
+	s.hideEmails(ctx, users...)
+
+	return users, nil
+}
+
+func (s *Service) ListFollowing(ctx context.Context, userID string,
+	pagination models.Pagination) ([]*models.User, error) {
+	if _, err := s.repo.GetUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("cannot get user: %w", err)
+	}
+
+	users, err := s.repo.ListFollowing(ctx, userID, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get followed users: %w", err)
+	}
+
+	s.hideEmails(ctx, users...)
+
+	return users, nil
+}
+
+// Feed returns the home feed of the current user: published posts of the followed users, newest first.
+func (s *Service) Feed(ctx context.Context, pagination models.Pagination) (*models.PostsPage, error) {
+	userID := s.currentUserInformationProvider.GetCurrentUserID(ctx)
+	if userID == "" {
+		return nil, models.ErrNotAuthenticated
+	}
+
+	filter := models.FilterPosts{
+		FollowedBy: userID,
+		Visibility: models.PostVisibility{Statuses: []models.PostStatus{models.PostStatusPublished}},
+	}
+	sort := models.SortPostsBy(models.SortPostsByCreatedAt, false)
+
+	if err := pagination.Validate(sort.SortKeys()); err != nil {
+		return nil, fmt.Errorf("cannot get feed: %w", err)
+	}
+
+	posts, err := s.repo.ListPosts(ctx, pagination.Lookahead(), filter, sort)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get feed: %w", err)
+	}
+
+	page := newPostsPage(posts, pagination, sort)
+
+	if pagination.CountTotal {
+		total, err := s.repo.CountPosts(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("cannot count feed: %w", err)
+		}
+
+		page.Total = &total
+	}
+
+	if err := s.countPostsReactions(ctx, page.Posts); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
@@ -0,0 +1,126 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/serhiihuberniuk/blog-api/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Feed(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context)
+
+	userID := "315b6c09-36ff-4519-8579-492f3ae2a3be"
+	now := time.Now()
+
+	posts := []*models.Post{
+		{ID: "a4a4d1f6-0f0a-4bd4-8b1c-6f5ac2b0e0f1", CreatedAt: now, Status: models.PostStatusPublished},
+		{ID: "7f3c0a1e-5b2d-4c8e-9a6f-1d2e3f4a5b6c", CreatedAt: now.Add(-time.Hour), Status: models.PostStatusPublished},
+		{ID: "0b9e6c1d-2a3f-4e5d-8c7b-6a5f4e3d2c1b", CreatedAt: now.Add(-2 * time.Hour), Status: models.PostStatusPublished},
+	}
+
+	sort := models.SortPostsBy(models.SortPostsByCreatedAt, false)
+	filter := models.FilterPosts{
+		FollowedBy: userID,
+		Visibility: models.PostVisibility{Statuses: []models.PostStatus{models.PostStatusPublished}},
+	}
+
+	testCases := []struct {
+		name          string
+		pagination    models.Pagination
+		mockBehavior  mockBehavior
+		expectedPosts int
+		expectedNext  *models.Cursor
+		errMessage    string
+	}{
+		{
+			name:       "Feed has next page",
+			pagination: models.Pagination{Limit: 2},
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID)
+				r.EXPECT().ListPosts(gomock.Eq(ctx), gomock.Eq(models.Pagination{Limit: 3}), gomock.Eq(filter),
+					gomock.Eq(sort)).Return(posts, nil)
+				r.EXPECT().CountReactions(gomock.Eq(ctx), gomock.Eq(models.ReactionTargetPost), gomock.Any()).
+					Return(map[string]models.ReactionCounts{}, nil)
+			},
+			expectedPosts: 2,
+			expectedNext:  models.NewPostCursor(posts[1], sort),
+			errMessage:    "",
+		},
+		{
+			name:       "Feed ends on the page",
+			pagination: models.Pagination{Limit: 5, After: models.NewPostCursor(posts[0], sort)},
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID)
+				r.EXPECT().ListPosts(gomock.Eq(ctx),
+					gomock.Eq(models.Pagination{Limit: 6, After: models.NewPostCursor(posts[0], sort)}),
+					gomock.Eq(filter), gomock.Eq(sort)).Return(posts[1:], nil)
+				r.EXPECT().CountReactions(gomock.Eq(ctx), gomock.Eq(models.ReactionTargetPost), gomock.Any()).
+					Return(map[string]models.ReactionCounts{}, nil)
+			},
+			expectedPosts: 2,
+			expectedNext:  nil,
+			errMessage:    "",
+		},
+		{
+			name:       "Cursor of other sorting is rejected",
+			pagination: models.Pagination{Limit: 2, After: models.NewPostCursor(posts[0], models.SortPosts{})},
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID)
+			},
+			errMessage: "cursor was made for the list sorted by",
+		},
+		{
+			name:       "User is not authenticated",
+			pagination: models.Pagination{Limit: 2},
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return("")
+			},
+			errMessage: models.ErrNotAuthenticated.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+			serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			tc.mockBehavior(repoMock, providerMock, ctx)
+
+			feed, err := serv.Feed(ctx, tc.pagination)
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+				assert.Len(t, feed.Posts, tc.expectedPosts)
+				assert.Equal(t, tc.expectedNext, feed.Next)
+
+				return
+			}
+
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+// resolveMentions returns the users mentioned in the text in the order they are mentioned.
+// Usernames which do not belong to any user are not mentions.
+func (s *Service) resolveMentions(ctx context.Context, text string) ([]models.Mention, error) {
+	usernames := models.ParseMentions(text)
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+
+	users, err := s.repo.GetUsersByUsernames(ctx, usernames)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get mentioned users: %w", err)
+	}
+
+	byUsername := make(map[string]*models.User, len(users))
+	for _, user := range users {
+		byUsername[user.Username] = user
+	}
+
+	var mentions []models.Mention
+
+	for _, username := range usernames {
+		user, ok := byUsername[username]
+		if !ok || user.ID == models.DeletedUserID {
+			continue
+		}
+
+		mentions = append(mentions, models.Mention{
+			UserID:   user.ID,
+			Username: user.Username,
+		})
+	}
+
+	return mentions, nil
+}
+
+// notifyMentions tells the mentioned users that the actor mentioned them in the post or in the comment.
+func (s *Service) notifyMentions(ctx context.Context, mentions []models.Mention, actorID, postID,
+	commentID string) {
+	for _, mention := range mentions {
+		s.notify(ctx, &models.Notification{
+			UserID:    mention.UserID,
+			Type:      models.NotificationMention,
+			ActorID:   actorID,
+			PostID:    postID,
+			CommentID: commentID,
+		})
+	}
+}
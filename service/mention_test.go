@@ -0,0 +1,231 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/serhiihuberniuk/blog-api/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_UpdateComment_Mentions(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *Mockrepository, ctx context.Context)
+
+	authorID := "315b6c09-36ff-4519-8579-492f3ae2a3be"
+	alice := &models.User{ID: "40d2f213-8e41-4abf-ad54-410997b19401", Username: "alice"}
+	bob := &models.User{ID: "7c0f6f83-45a5-4bb0-9a43-8bb0d3c3c0a4", Username: "bob"}
+	tombstone := &models.User{ID: models.DeletedUserID, Username: models.DeletedUsername}
+
+	comment := &models.Comment{
+		ID:        "a4a4d1f6-0f0a-4bd4-8b1c-6f5ac2b0e0f1",
+		Content:   "Hi @alice",
+		CreatedBy: authorID,
+		PostID:    "7f3c0a1e-5b2d-4c8e-9a6f-1d2e3f4a5b6c",
+		Mentions:  []models.Mention{{UserID: alice.ID, Username: alice.Username}},
+	}
+	published := &models.Post{ID: comment.PostID, CreatedBy: authorID, Status: models.PostStatusPublished}
+	content := "Hi @alice and @Bob, @ghost and @deleted"
+	usernames := []string{"alice", "bob", "ghost", "deleted"}
+
+	testCases := []struct {
+		name         string
+		mockBehavior mockBehavior
+		errMessage   string
+	}{
+		{
+			name: "Only newly mentioned users are notified",
+			mockBehavior: func(r *Mockrepository, ctx context.Context) {
+				r.EXPECT().GetUsersByUsernames(gomock.Eq(ctx), gomock.Eq(usernames)).
+					Return([]*models.User{bob, tombstone, alice}, nil)
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(comment.PostID)).Return(published, nil)
+				r.EXPECT().UpdateComment(gomock.Eq(ctx), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, updated *models.Comment, _ *models.CommentRevision) error {
+						assert.Equal(t, []models.Mention{
+							{UserID: alice.ID, Username: alice.Username},
+							{UserID: bob.ID, Username: bob.Username},
+						}, updated.Mentions)
+
+						return nil
+					})
+				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(bob.ID)).Return(bob, nil)
+				r.EXPECT().CreateNotification(gomock.Eq(ctx), gomock.Any()).DoAndReturn(
+					func(_ context.Context, notification *models.Notification) error {
+						assert.Equal(t, bob.ID, notification.UserID)
+						assert.Equal(t, models.NotificationMention, notification.Type)
+						assert.Equal(t, authorID, notification.ActorID)
+						assert.Equal(t, comment.ID, notification.CommentID)
+
+						return nil
+					})
+			},
+			errMessage: "",
+		},
+		{
+			name: "User who turned off mention notifications is not notified",
+			mockBehavior: func(r *Mockrepository, ctx context.Context) {
+				r.EXPECT().GetUsersByUsernames(gomock.Eq(ctx), gomock.Eq(usernames)).
+					Return([]*models.User{alice, bob}, nil)
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(comment.PostID)).Return(published, nil)
+				r.EXPECT().UpdateComment(gomock.Eq(ctx), gomock.Any(), gomock.Any()).Return(nil)
+				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(bob.ID)).Return(&models.User{
+					ID:                      bob.ID,
+					NotificationPreferences: models.NotificationPreferences{models.NotificationMention: false},
+				}, nil)
+			},
+			errMessage: "",
+		},
+		{
+			name: "Users mentioned on unpublished post are not notified",
+			mockBehavior: func(r *Mockrepository, ctx context.Context) {
+				r.EXPECT().GetUsersByUsernames(gomock.Eq(ctx), gomock.Eq(usernames)).
+					Return([]*models.User{alice, bob}, nil)
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(comment.PostID)).Return(&models.Post{
+					ID:        comment.PostID,
+					CreatedBy: authorID,
+					Status:    models.PostStatusDraft,
+				}, nil)
+				r.EXPECT().UpdateComment(gomock.Eq(ctx), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			errMessage: "",
+		},
+		{
+			name: "Mentioned users cannot be got",
+			mockBehavior: func(r *Mockrepository, ctx context.Context) {
+				r.EXPECT().GetUsersByUsernames(gomock.Eq(ctx), gomock.Eq(usernames)).
+					Return(nil, errors.New("connection refused"))
+			},
+			errMessage: "cannot get mentioned users",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+			serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			stored := *comment
+
+			providerMock.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(authorID).AnyTimes()
+			repoMock.EXPECT().GetComment(gomock.Eq(ctx), gomock.Eq(comment.ID)).Return(&stored, nil)
+			tc.mockBehavior(repoMock, ctx)
+
+			err = serv.UpdateComment(ctx, models.UpdateCommentPayload{
+				CommentID: comment.ID,
+				Content:   &content,
+			})
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
+
+func TestService_CreateComment_Mentions(t *testing.T) {
+	t.Parallel()
+
+	authorID := "315b6c09-36ff-4519-8579-492f3ae2a3be"
+	alice := &models.User{ID: "40d2f213-8e41-4abf-ad54-410997b19401", Username: "alice"}
+
+	testCases := []struct {
+		name       string
+		status     models.PostStatus
+		isNotified bool
+	}{
+		{
+			name:       "Users mentioned on published post are notified",
+			status:     models.PostStatusPublished,
+			isNotified: true,
+		},
+		{
+			name:       "Users mentioned on draft are not notified",
+			status:     models.PostStatusDraft,
+			isNotified: false,
+		},
+		{
+			name:       "Users mentioned on post in review are not notified",
+			status:     models.PostStatusInReview,
+			isNotified: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+			serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			post := &models.Post{
+				ID:        "7f3c0a1e-5b2d-4c8e-9a6f-1d2e3f4a5b6c",
+				CreatedBy: authorID,
+				Status:    tc.status,
+			}
+
+			providerMock.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(authorID).AnyTimes()
+			providerMock.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser).AnyTimes()
+			repoMock.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(post, nil)
+			repoMock.EXPECT().GetUsersByUsernames(gomock.Eq(ctx), gomock.Eq([]string{"alice"})).
+				Return([]*models.User{alice}, nil)
+			repoMock.EXPECT().CreateComment(gomock.Eq(ctx), gomock.Any()).Return(nil)
+
+			if tc.isNotified {
+				repoMock.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(alice.ID)).Return(alice, nil)
+				repoMock.EXPECT().CreateNotification(gomock.Eq(ctx), gomock.Any()).DoAndReturn(
+					func(_ context.Context, notification *models.Notification) error {
+						assert.Equal(t, alice.ID, notification.UserID)
+						assert.Equal(t, models.NotificationMention, notification.Type)
+
+						return nil
+					})
+			}
+
+			_, err = serv.CreateComment(ctx, models.CreateCommentPayload{
+				Content: "Hi @alice",
+				PostID:  post.ID,
+			})
+			assert.NoError(t, err)
+		})
+	}
+}
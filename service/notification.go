@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+// ListNotifications returns the notifications of the current user, newest first, with the number of unread ones.
+func (s *Service) ListNotifications(ctx context.Context, pagination models.Pagination,
+	unreadOnly bool) (*models.NotificationList, error) {
+	userID := s.currentUserInformationProvider.GetCurrentUserID(ctx)
+	if userID == "" {
+		return nil, models.ErrNotAuthenticated
+	}
+
+	notifications, err := s.repo.ListNotifications(ctx, userID, unreadOnly, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get notifications: %w", err)
+	}
+
+	unreadCount, err := s.repo.CountUnreadNotifications(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot count unread notifications: %w", err)
+	}
+
+	return &models.NotificationList{
+		Notifications: notifications,
+		UnreadCount:   unreadCount,
+	}, nil
+}
+
+// MarkNotificationRead marks the notification of the current user as read. Notifications of other users
+// are not found.
+func (s *Service) MarkNotificationRead(ctx context.Context, notificationID string) error {
+	userID := s.currentUserInformationProvider.GetCurrentUserID(ctx)
+	if userID == "" {
+		return models.ErrNotAuthenticated
+	}
+
+	if err := s.repo.MarkNotificationRead(ctx, userID, notificationID, time.Now()); err != nil {
+		return fmt.Errorf("cannot mark notification as read: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) MarkAllNotificationsRead(ctx context.Context) error {
+	userID := s.currentUserInformationProvider.GetCurrentUserID(ctx)
+	if userID == "" {
+		return models.ErrNotAuthenticated
+	}
+
+	if err := s.repo.MarkAllNotificationsRead(ctx, userID, time.Now()); err != nil {
+		return fmt.Errorf("cannot mark notifications as read: %w", err)
+	}
+
+	return nil
+}
+
+// GetNotificationPreferences returns whether the current user receives each type of notifications.
+func (s *Service) GetNotificationPreferences(ctx context.Context) (models.NotificationPreferences, error) {
+	userID := s.currentUserInformationProvider.GetCurrentUserID(ctx)
+	if userID == "" {
+		return nil, models.ErrNotAuthenticated
+	}
+
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get user: %w", err)
+	}
+
+	return user.NotificationPreferences.All(), nil
+}
+
+// UpdateNotificationPreferences turns the given types of notifications on or off for the current user.
+// Other types are left unchanged.
+func (s *Service) UpdateNotificationPreferences(ctx context.Context,
+	changes models.NotificationPreferences) (models.NotificationPreferences, error) {
+	userID := s.currentUserInformationProvider.GetCurrentUserID(ctx)
+	if userID == "" {
+		return nil, models.ErrNotAuthenticated
+	}
+
+	if err := changes.Validate(); err != nil {
+		return nil, fmt.Errorf("cannot update notification preferences: %w", err)
+	}
+
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get user: %w", err)
+	}
+
+	preferences := user.NotificationPreferences.Merge(changes)
+
+	if err := s.repo.UpdateNotificationPreferences(ctx, userID, preferences); err != nil {
+		return nil, fmt.Errorf("cannot update notification preferences: %w", err)
+	}
+
+	return preferences.All(), nil
+}
+
+// notify saves the notification for its user. Users are not notified about their own actions
+// and about the types of notifications they turned off. The action the notification tells about is already
+// saved, so a failed notification must not fail the request and is only logged.
+func (s *Service) notify(ctx context.Context, notification *models.Notification) {
+	if notification.UserID == notification.ActorID || notification.UserID == models.DeletedUserID {
+		return
+	}
+
+	user, err := s.repo.GetUser(ctx, notification.UserID)
+	if errors.Is(err, models.ErrNotFound) {
+		return
+	}
+
+	if err != nil {
+		log.Printf("cannot get user %s to notify about %s: %v", notification.UserID, notification.Type, err)
+
+		return
+	}
+
+	if !user.NotificationPreferences.Enabled(notification.Type) {
+		return
+	}
+
+	notification.ID = uuid.New().String()
+	notification.CreatedAt = time.Now()
+
+	if err := s.repo.CreateNotification(ctx, notification); err != nil {
+		log.Printf("cannot notify user %s about %s: %v", notification.UserID, notification.Type, err)
+	}
+}
+
+// notifyComment tells the author of the parent comment about the reply and the author of the post
+// about the comment. Authors who are notified about the reply are not notified about the comment.
+func (s *Service) notifyComment(ctx context.Context, comment, parent *models.Comment, post *models.Post) {
+	if parent != nil {
+		s.notify(ctx, &models.Notification{
+			UserID:    parent.CreatedBy,
+			Type:      models.NotificationReply,
+			ActorID:   comment.CreatedBy,
+			PostID:    comment.PostID,
+			CommentID: comment.ID,
+		})
+	}
+
+	if parent != nil && parent.CreatedBy == post.CreatedBy {
+		return
+	}
+
+	s.notify(ctx, &models.Notification{
+		UserID:    post.CreatedBy,
+		Type:      models.NotificationComment,
+		ActorID:   comment.CreatedBy,
+		PostID:    comment.PostID,
+		CommentID: comment.ID,
+	})
+}
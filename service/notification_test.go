@@ -0,0 +1,175 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/serhiihuberniuk/blog-api/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_ListNotifications(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context)
+
+	userID := "315b6c09-36ff-4519-8579-492f3ae2a3be"
+	pagination := models.Pagination{Limit: 10}
+
+	notifications := []*models.Notification{
+		{ID: "a4a4d1f6-0f0a-4bd4-8b1c-6f5ac2b0e0f1", UserID: userID, Type: models.NotificationFollow},
+		{ID: "7f3c0a1e-5b2d-4c8e-9a6f-1d2e3f4a5b6c", UserID: userID, Type: models.NotificationComment},
+	}
+
+	testCases := []struct {
+		name          string
+		unreadOnly    bool
+		mockBehavior  mockBehavior
+		expectedCount int
+		errMessage    string
+	}{
+		{
+			name:       "Notifications are listed with unread count",
+			unreadOnly: true,
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID)
+				r.EXPECT().ListNotifications(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(true),
+					gomock.Eq(pagination)).Return(notifications, nil)
+				r.EXPECT().CountUnreadNotifications(gomock.Eq(ctx), gomock.Eq(userID)).Return(5, nil)
+			},
+			expectedCount: 5,
+			errMessage:    "",
+		},
+		{
+			name:       "User is not authenticated",
+			unreadOnly: false,
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return("")
+			},
+			errMessage: models.ErrNotAuthenticated.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+			serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			tc.mockBehavior(repoMock, providerMock, ctx)
+
+			list, err := serv.ListNotifications(ctx, pagination, tc.unreadOnly)
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, notifications, list.Notifications)
+				assert.Equal(t, tc.expectedCount, list.UnreadCount)
+
+				return
+			}
+
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
+
+func TestService_UpdateNotificationPreferences(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *Mockrepository, ctx context.Context)
+
+	user := &models.User{
+		ID:                      "315b6c09-36ff-4519-8579-492f3ae2a3be",
+		NotificationPreferences: models.NotificationPreferences{models.NotificationFollow: false},
+	}
+
+	testCases := []struct {
+		name         string
+		changes      models.NotificationPreferences
+		mockBehavior mockBehavior
+		expected     models.NotificationPreferences
+		errMessage   string
+	}{
+		{
+			name:    "Changes are merged into stored preferences",
+			changes: models.NotificationPreferences{models.NotificationReaction: false},
+			mockBehavior: func(r *Mockrepository, ctx context.Context) {
+				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(user.ID)).Return(user, nil)
+				r.EXPECT().UpdateNotificationPreferences(gomock.Eq(ctx), gomock.Eq(user.ID),
+					gomock.Eq(models.NotificationPreferences{
+						models.NotificationFollow:   false,
+						models.NotificationReaction: false,
+					})).Return(nil)
+			},
+			expected: models.NotificationPreferences{
+				models.NotificationComment:  true,
+				models.NotificationReply:    true,
+				models.NotificationReaction: false,
+				models.NotificationFollow:   false,
+				models.NotificationMention:  true,
+			},
+			errMessage: "",
+		},
+		{
+			name:         "Type is unknown",
+			changes:      models.NotificationPreferences{"digest": false},
+			mockBehavior: func(r *Mockrepository, ctx context.Context) {},
+			errMessage:   "validation failed",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+			serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			providerMock.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(user.ID)
+			tc.mockBehavior(repoMock, ctx)
+
+			preferences, err := serv.UpdateNotificationPreferences(ctx, tc.changes)
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, preferences)
+
+				return
+			}
+
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
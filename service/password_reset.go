@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+const (
+	passwordResetTokenTTL = time.Hour
+	passwordResetSubject  = "Password reset"
+	passwordResetBody     = "Somebody requested a password reset for your account.\n\n" +
+		"Use this token to set a new password: %s\n\n" +
+		"The token expires in %v. If it was not you, just ignore this message."
+)
+
+// RequestPasswordReset sends a one-time reset token to the user with such email.
+// Unknown emails are ignored, so the result does not reveal whether an account exists.
+func (s *Service) RequestPasswordReset(ctx context.Context, payload models.RequestPasswordResetPayload) error {
+	if err := payload.Validate(); err != nil {
+		return fmt.Errorf("cannot request password reset: %w", err)
+	}
+
+	user, err := s.repo.GetUserByEmail(ctx, payload.Email)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	resetToken, err := generateToken()
+	if err != nil {
+		return fmt.Errorf("error occurred while generating password reset token: %w", err)
+	}
+
+	now := time.Now()
+
+	err = s.repo.CreatePasswordResetToken(ctx, &models.PasswordResetToken{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		TokenHash: hashToken(resetToken),
+		CreatedAt: now,
+		ExpiresAt: now.Add(passwordResetTokenTTL),
+	})
+	if err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	err = s.mailer.Send(ctx, user.Email, passwordResetSubject,
+		fmt.Sprintf(passwordResetBody, resetToken, passwordResetTokenTTL))
+	if err != nil {
+		return fmt.Errorf("cannot send password reset token: %w", err)
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password using a token from RequestPasswordReset
+// and revokes all refresh tokens of the user.
+func (s *Service) ResetPassword(ctx context.Context, payload models.ResetPasswordPayload) error {
+	if err := payload.Validate(); err != nil {
+		return fmt.Errorf("cannot reset password: %w", err)
+	}
+
+	token, err := s.repo.GetPasswordResetToken(ctx, hashToken(payload.Token))
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return fmt.Errorf("password reset token is invalid: %w", models.ErrNotAuthenticated)
+		}
+
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	now := time.Now()
+
+	if token.UsedAt != nil || now.After(token.ExpiresAt) {
+		return fmt.Errorf("password reset token is used or expired: %w", models.ErrNotAuthenticated)
+	}
+
+	if err := s.repo.UsePasswordResetToken(ctx, token.ID, now); err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return fmt.Errorf("password reset token is used or expired: %w", models.ErrNotAuthenticated)
+		}
+
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	hashedPassword, err := generateHashPassword(payload.NewPassword)
+	if err != nil {
+		return fmt.Errorf("error occurred while hashing the password: %w", err)
+	}
+
+	if err := s.repo.UpdateUserPassword(ctx, token.UserID, hashedPassword, now); err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	if err := s.repo.RevokeUserRefreshTokens(ctx, token.UserID, now); err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/serhiihuberniuk/blog-api/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_RequestPasswordReset(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *Mockrepository, m *Mockmailer, ctx context.Context, email string)
+
+	user := &models.User{
+		ID:    "315b6c09-36ff-4519-8579-492f3ae2a3be",
+		Email: "email@mail.com",
+	}
+
+	testCases := []struct {
+		name         string
+		inPayload    models.RequestPasswordResetPayload
+		mockBehavior mockBehavior
+		errMessage   string
+	}{
+		{
+			name:      "Reset token is sent",
+			inPayload: models.RequestPasswordResetPayload{Email: user.Email},
+			mockBehavior: func(r *Mockrepository, m *Mockmailer, ctx context.Context, email string) {
+				var stored *models.PasswordResetToken
+
+				r.EXPECT().GetUserByEmail(gomock.Eq(ctx), gomock.Eq(email)).Return(user, nil)
+				r.EXPECT().CreatePasswordResetToken(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(_ context.Context, token *models.PasswordResetToken) error {
+						stored = token
+
+						return nil
+					})
+				m.EXPECT().Send(gomock.Eq(ctx), gomock.Eq(email), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _, body string) error {
+						assert.Equal(t, user.ID, stored.UserID)
+						assert.True(t, stored.ExpiresAt.After(time.Now()))
+
+						for _, word := range strings.Fields(body) {
+							hash := sha256.Sum256([]byte(word))
+							if hex.EncodeToString(hash[:]) == stored.TokenHash {
+								return nil
+							}
+						}
+
+						return errors.New("mail does not contain the token")
+					})
+			},
+			errMessage: "",
+		},
+		{
+			name:      "Unknown email is ignored",
+			inPayload: models.RequestPasswordResetPayload{Email: "unknown@mail.com"},
+			mockBehavior: func(r *Mockrepository, m *Mockmailer, ctx context.Context, email string) {
+				r.EXPECT().GetUserByEmail(gomock.Eq(ctx), gomock.Eq(email)).Return(nil, models.ErrNotFound)
+			},
+			errMessage: "",
+		},
+		{
+			name:      "Mailer error",
+			inPayload: models.RequestPasswordResetPayload{Email: user.Email},
+			mockBehavior: func(r *Mockrepository, m *Mockmailer, ctx context.Context, email string) {
+				r.EXPECT().GetUserByEmail(gomock.Eq(ctx), gomock.Eq(email)).Return(user, nil)
+				r.EXPECT().CreatePasswordResetToken(gomock.Eq(ctx), gomock.Any()).Return(nil)
+				m.EXPECT().Send(gomock.Eq(ctx), gomock.Eq(email), gomock.Any(), gomock.Any()).
+					Return(errors.New("smtp server is unavailable"))
+			},
+			errMessage: "smtp server is unavailable",
+		},
+		{
+			name:         "Invalid payload(email is invalid)",
+			inPayload:    models.RequestPasswordResetPayload{Email: "email"},
+			mockBehavior: func(r *Mockrepository, m *Mockmailer, ctx context.Context, email string) {},
+			errMessage:   "validation failed",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+			mailerMock := NewMockmailer(ctrl)
+
+			serv, err := service.NewService(repoMock, nil, nil, nil, mailerMock, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			tc.mockBehavior(repoMock, mailerMock, ctx, tc.inPayload.Email)
+
+			err = serv.RequestPasswordReset(ctx, tc.inPayload)
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
+
+func TestService_ResetPassword(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *Mockrepository, ctx context.Context, tokenHash string)
+
+	resetToken := "Ck1pDw0yR0n2CLnq7Gd4t9bz5o3LqjZ0m6GW2qf8s1A"
+	hash := sha256.Sum256([]byte(resetToken))
+	tokenHash := hex.EncodeToString(hash[:])
+	usedAt := time.Now().Add(-time.Minute)
+
+	storedToken := func(expiresAt time.Time, usedAt *time.Time) *models.PasswordResetToken {
+		return &models.PasswordResetToken{
+			ID:        "a4a4d1f6-0f0a-4bd4-8b1c-6f5ac2b0e0f1",
+			UserID:    "315b6c09-36ff-4519-8579-492f3ae2a3be",
+			TokenHash: tokenHash,
+			CreatedAt: time.Now().Add(-time.Minute * 10),
+			ExpiresAt: expiresAt,
+			UsedAt:    usedAt,
+		}
+	}
+
+	testCases := []struct {
+		name         string
+		inPayload    models.ResetPasswordPayload
+		mockBehavior mockBehavior
+		errMessage   string
+	}{
+		{
+			name:      "Password is reset",
+			inPayload: models.ResetPasswordPayload{Token: resetToken, NewPassword: "new password"},
+			mockBehavior: func(r *Mockrepository, ctx context.Context, tokenHash string) {
+				token := storedToken(time.Now().Add(time.Hour), nil)
+				r.EXPECT().GetPasswordResetToken(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(token, nil)
+				r.EXPECT().UsePasswordResetToken(gomock.Eq(ctx), gomock.Eq(token.ID), gomock.Any()).Return(nil)
+				r.EXPECT().UpdateUserPassword(gomock.Eq(ctx), gomock.Eq(token.UserID), gomock.Any(), gomock.Any()).
+					Return(nil)
+				r.EXPECT().RevokeUserRefreshTokens(gomock.Eq(ctx), gomock.Eq(token.UserID), gomock.Any()).Return(nil)
+			},
+			errMessage: "",
+		},
+		{
+			name:      "Token is unknown",
+			inPayload: models.ResetPasswordPayload{Token: resetToken, NewPassword: "new password"},
+			mockBehavior: func(r *Mockrepository, ctx context.Context, tokenHash string) {
+				r.EXPECT().GetPasswordResetToken(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(nil, models.ErrNotFound)
+			},
+			errMessage: models.ErrNotAuthenticated.Error(),
+		},
+		{
+			name:      "Token is already used",
+			inPayload: models.ResetPasswordPayload{Token: resetToken, NewPassword: "new password"},
+			mockBehavior: func(r *Mockrepository, ctx context.Context, tokenHash string) {
+				token := storedToken(time.Now().Add(time.Hour), &usedAt)
+				r.EXPECT().GetPasswordResetToken(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(token, nil)
+			},
+			errMessage: models.ErrNotAuthenticated.Error(),
+		},
+		{
+			name:      "Token is used concurrently",
+			inPayload: models.ResetPasswordPayload{Token: resetToken, NewPassword: "new password"},
+			mockBehavior: func(r *Mockrepository, ctx context.Context, tokenHash string) {
+				token := storedToken(time.Now().Add(time.Hour), nil)
+				r.EXPECT().GetPasswordResetToken(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(token, nil)
+				r.EXPECT().UsePasswordResetToken(gomock.Eq(ctx), gomock.Eq(token.ID), gomock.Any()).
+					Return(models.ErrNotFound)
+			},
+			errMessage: models.ErrNotAuthenticated.Error(),
+		},
+		{
+			name:      "Token is expired",
+			inPayload: models.ResetPasswordPayload{Token: resetToken, NewPassword: "new password"},
+			mockBehavior: func(r *Mockrepository, ctx context.Context, tokenHash string) {
+				token := storedToken(time.Now().Add(-time.Minute), nil)
+				r.EXPECT().GetPasswordResetToken(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(token, nil)
+			},
+			errMessage: models.ErrNotAuthenticated.Error(),
+		},
+		{
+			name:         "Invalid payload(password is too short)",
+			inPayload:    models.ResetPasswordPayload{Token: resetToken, NewPassword: "pass"},
+			mockBehavior: func(r *Mockrepository, ctx context.Context, tokenHash string) {},
+			errMessage:   "validation failed",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+
+			serv, err := service.NewService(repoMock, nil, nil, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			tc.mockBehavior(repoMock, ctx, tokenHash)
+
+			err = serv.ResetPassword(ctx, tc.inPayload)
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
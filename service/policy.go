@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+type permission string
+
+const (
+	permissionCreatePost     permission = "post:create"
+	permissionUpdatePost     permission = "post:update"
+	permissionDeletePost     permission = "post:delete"
+	permissionCreateComment  permission = "comment:create"
+	permissionUpdateComment  permission = "comment:update"
+	permissionDeleteComment  permission = "comment:delete"
+	permissionRestorePost    permission = "post:restore"
+	permissionSubmitPost     permission = "post:submit"
+	permissionPublishPost    permission = "post:publish"
+	permissionUnpublishPost  permission = "post:unpublish"
+	permissionArchivePost    permission = "post:archive"
+	permissionViewPost       permission = "post:view"
+	permissionRestoreComment permission = "comment:restore"
+	permissionReact          permission = "reaction:create"
+	permissionFollow         permission = "user:follow"
+	permissionUpdateUser     permission = "user:update"
+	permissionDeleteUser     permission = "user:delete"
+	permissionRestoreUser    permission = "user:restore"
+	permissionManageRoles    permission = "user:manage-roles"
+	permissionManageTags     permission = "tag:manage"
+)
+
+// rolePermissions lists what each role is allowed to do with objects owned by other users.
+// Owners are always allowed to manage their own objects, except for role-only permissions.
+var rolePermissions = map[models.Role][]permission{
+	models.RoleUser: {},
+	models.RoleModerator: {
+		permissionUpdateComment,
+		permissionDeleteComment,
+		permissionPublishPost,
+		permissionUnpublishPost,
+		permissionViewPost,
+	},
+	models.RoleAdmin: {
+		permissionUpdatePost,
+		permissionDeletePost,
+		permissionPublishPost,
+		permissionUnpublishPost,
+		permissionArchivePost,
+		permissionViewPost,
+		permissionUpdateComment,
+		permissionDeleteComment,
+		permissionRestorePost,
+		permissionRestoreComment,
+		permissionUpdateUser,
+		permissionDeleteUser,
+		permissionRestoreUser,
+		permissionManageRoles,
+		permissionManageTags,
+	},
+}
+
+// contentPermissions are withheld from users with unverified email when their access is read-only.
+var contentPermissions = map[permission]bool{
+	permissionCreatePost:     true,
+	permissionUpdatePost:     true,
+	permissionDeletePost:     true,
+	permissionCreateComment:  true,
+	permissionUpdateComment:  true,
+	permissionDeleteComment:  true,
+	permissionRestorePost:    true,
+	permissionRestoreComment: true,
+	permissionSubmitPost:     true,
+	permissionPublishPost:    true,
+	permissionUnpublishPost:  true,
+	permissionArchivePost:    true,
+	permissionReact:          true,
+}
+
+// roleOnlyPermissions are not granted to owners of objects. Authors cannot publish their own posts
+// without a review.
+var roleOnlyPermissions = map[permission]bool{
+	permissionPublishPost: true,
+}
+
+func (s *Service) authorize(ctx context.Context, ownerID string, p permission) error {
+	currentUserID := s.currentUserInformationProvider.GetCurrentUserID(ctx)
+	if currentUserID == "" {
+		return models.ErrNotAuthenticated
+	}
+
+	if s.unverifiedAccess == UnverifiedAccessReadOnly && contentPermissions[p] {
+		if err := s.checkEmailVerified(ctx, currentUserID); err != nil {
+			return err
+		}
+	}
+
+	if ownerID == currentUserID && !roleOnlyPermissions[p] {
+		return nil
+	}
+
+	if s.hasRolePermission(ctx, p) {
+		return nil
+	}
+
+	return models.ErrForbidden
+}
+
+func (s *Service) hasRolePermission(ctx context.Context, p permission) bool {
+	for _, allowed := range rolePermissions[s.currentUserInformationProvider.GetCurrentUserRole(ctx)] {
+		if allowed == p {
+			return true
+		}
+	}
+
+	return false
+}
@@ -10,83 +10,266 @@ import (
 )
 
 func (s *Service) CreatePost(ctx context.Context, payload models.CreatePostPayload) (string, error) {
+	now := time.Now()
+
 	post := &models.Post{
 		ID:          uuid.New().String(),
 		Title:       payload.Title,
 		Description: payload.Description,
-		CreatedAt:   time.Now(),
+		Format:      payload.Format.OrPlain(),
+		CreatedAt:   now,
 		CreatedBy:   s.currentUserInformationProvider.GetCurrentUserID(ctx),
 		Tags:        payload.Tags,
+		UpdatedAt:   now,
+		Status:      models.PostStatusDraft,
+	}
+
+	if err := s.authorize(ctx, post.CreatedBy, permissionCreatePost); err != nil {
+		return "", fmt.Errorf("cannot create post: %w", err)
+	}
+
+	tags, err := s.normalizeTags(ctx, post.Tags)
+	if err != nil {
+		return "", fmt.Errorf("cannot create post: %w", err)
 	}
 
+	post.Tags = tags
+
 	if err := post.Validate(); err != nil {
 		return "", fmt.Errorf("cannot create post: %w", err)
 	}
 
-	if err := s.repo.CreatePost(ctx, post); err != nil {
+	post.Render()
+
+	post.Mentions, err = s.resolveMentions(ctx, post.Description)
+	if err != nil {
+		return "", fmt.Errorf("cannot create post: %w", err)
+	}
+
+	err = s.savePostWithSlug(ctx, post, func() error {
+		return s.repo.CreatePost(ctx, post)
+	})
+	if err != nil {
 		return "", fmt.Errorf("cannot create post: %w", err)
 	}
 
 	return post.ID, nil
 }
 
+// GetPost returns the post with its reactions if it is visible to the current user.
 func (s *Service) GetPost(ctx context.Context, postID string) (*models.Post, error) {
+	post, err := s.getPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.countPostsReactions(ctx, []*models.Post{post}); err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+// getPost returns the post if it is visible to the current user. Posts which are not published
+// are visible to their owners and to the users who can review them.
+func (s *Service) getPost(ctx context.Context, postID string) (*models.Post, error) {
 	post, err := s.repo.GetPost(ctx, postID)
 	if err != nil {
 		return nil, fmt.Errorf("cannot get post: %w", err)
 	}
 
+	if !s.isPostVisible(ctx, post) {
+		return nil, fmt.Errorf("cannot get post: %w", models.ErrNotFound)
+	}
+
 	return post, nil
 }
 
+// UpdatePost changes the post and keeps its previous version as a revision.
+// An update which does not change anything is not recorded. Users newly mentioned in a published post
+// are notified.
 func (s *Service) UpdatePost(ctx context.Context, payload models.UpdatePostPayload) error {
-	post, err := s.GetPost(ctx, payload.PostID)
+	post, err := s.getPost(ctx, payload.PostID)
 	if err != nil {
 		return fmt.Errorf("cannot get post: %w", err)
 	}
 
-	if !s.checkCurrentUserIsOwner(ctx, post.CreatedBy) {
-		return models.ErrNotAuthenticated
+	if err := s.authorize(ctx, post.CreatedBy, permissionUpdatePost); err != nil {
+		return fmt.Errorf("cannot update post: %w", err)
+	}
+
+	previous := postRevision(post)
+	previousMentions := post.Mentions
+
+	if payload.Title != nil {
+		post.Title = *payload.Title
 	}
 
-	post.Title = payload.Title
-	post.Description = payload.Description
-	post.Tags = payload.Tags
+	if payload.Description != nil {
+		post.Description = *payload.Description
+	}
+
+	if payload.Tags != nil {
+		post.Tags, err = s.normalizeTags(ctx, *payload.Tags)
+		if err != nil {
+			return fmt.Errorf("cannot update post: %w", err)
+		}
+	}
+
+	post.Format = previous.Format
+	if payload.Format != nil {
+		post.Format = *payload.Format
+	}
 
 	if err := post.Validate(); err != nil {
 		return fmt.Errorf("cannot update post: %w", err)
 	}
 
-	if err := s.repo.UpdatePost(ctx, post); err != nil {
+	if len(previous.Diff(postRevision(post))) == 0 {
+		return nil
+	}
+
+	post.Render()
+
+	post.Mentions, err = s.resolveMentions(ctx, post.Description)
+	if err != nil {
 		return fmt.Errorf("cannot update post: %w", err)
 	}
 
+	now := time.Now()
+
+	previous.ID = uuid.New().String()
+	previous.EditedBy = s.currentUserInformationProvider.GetCurrentUserID(ctx)
+	previous.EditedAt = now
+	post.UpdatedAt = now
+
+	err = s.savePostWithSlug(ctx, post, func() error {
+		return s.repo.UpdatePost(ctx, post, previous)
+	})
+	if err != nil {
+		return fmt.Errorf("cannot update post: %w", err)
+	}
+
+	if post.Status == models.PostStatusPublished {
+		s.notifyMentions(ctx, models.AddedMentions(post.Mentions, previousMentions), post.CreatedBy, post.ID, "")
+	}
+
 	return nil
 }
 
 func (s *Service) DeletePost(ctx context.Context, postID string) error {
-	post, err := s.GetPost(ctx, postID)
+	post, err := s.getPost(ctx, postID)
 	if err != nil {
 		return fmt.Errorf("cannot get post: %w", err)
 	}
 
-	if !s.checkCurrentUserIsOwner(ctx, post.CreatedBy) {
-		return models.ErrNotAuthenticated
+	if err := s.authorize(ctx, post.CreatedBy, permissionDeletePost); err != nil {
+		return fmt.Errorf("cannot delete post: %w", err)
 	}
 
-	if err := s.repo.DeletePost(ctx, postID); err != nil {
+	if err := s.repo.DeletePost(ctx, postID, time.Now()); err != nil {
 		return fmt.Errorf("cannot delete post: %w", err)
 	}
 
 	return nil
 }
 
+// RestorePost brings back a deleted post together with the comments deleted with it.
+func (s *Service) RestorePost(ctx context.Context, postID string) error {
+	post, err := s.repo.GetDeletedPost(ctx, postID)
+	if err != nil {
+		return fmt.Errorf("cannot get deleted post: %w", err)
+	}
+
+	if err := s.authorize(ctx, post.CreatedBy, permissionRestorePost); err != nil {
+		return fmt.Errorf("cannot restore post: %w", err)
+	}
+
+	if err := s.repo.RestorePost(ctx, postID); err != nil {
+		return fmt.Errorf("cannot restore post: %w", err)
+	}
+
+	return nil
+}
+
+// ListPosts returns the page of posts visible to the current user. Posts are sorted by the creation
+// time unless other keys are given, and the total number of posts is counted when it is asked for.
 func (s *Service) ListPosts(ctx context.Context, pagination models.Pagination,
-	filter models.FilterPosts, sort models.SortPosts) ([]*models.Post, error) {
-	posts, err := s.repo.ListPosts(ctx, pagination, filter, sort)
+	filter models.FilterPosts, sort models.SortPosts) (*models.PostsPage, error) {
+	filter.Visibility = s.postVisibility(ctx)
+
+	conditions := make([]models.PostCondition, 0, len(filter.Conditions))
+
+	for _, condition := range filter.Conditions {
+		if condition.Field == models.FilterPostsByTags {
+			var err error
+
+			condition.Values, err = s.normalizeTagValues(ctx, condition.Operator, condition.Values)
+			if err != nil {
+				return nil, fmt.Errorf("cannot get posts: %w", err)
+			}
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	filter.Conditions = conditions
+
+	if err := filter.Validate(); err != nil {
+		return nil, fmt.Errorf("cannot get posts: %w", err)
+	}
+
+	if len(sort.Keys) == 0 {
+		sort = models.SortPostsBy(models.SortPostsByCreatedAt, true)
+	}
+
+	if err := sort.Validate(); err != nil {
+		return nil, fmt.Errorf("cannot get posts: %w", err)
+	}
+
+	if err := pagination.Validate(sort.SortKeys()); err != nil {
+		return nil, fmt.Errorf("cannot get posts: %w", err)
+	}
+
+	posts, err := s.repo.ListPosts(ctx, pagination.Lookahead(), filter, sort)
 	if err != nil {
 		return nil, fmt.Errorf("cannot get posts: %w", err)
 	}
 
-	return posts, nil
+	page := newPostsPage(posts, pagination, sort)
+
+	if pagination.CountTotal {
+		total, err := s.repo.CountPosts(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("cannot count posts: %w", err)
+		}
+
+		page.Total = &total
+	}
+
+	if err := s.countPostsReactions(ctx, page.Posts); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// newPostsPage returns the page of the posts fetched with the lookahead of the pagination.
+func newPostsPage(posts []*models.Post, pagination models.Pagination, sort models.SortPosts) *models.PostsPage {
+	from, to, hasPrev, hasNext := pagination.PageBounds(len(posts))
+
+	page := &models.PostsPage{
+		Posts: posts[from:to],
+		Sort:  sort,
+	}
+
+	if len(page.Posts) != 0 && hasPrev {
+		page.Prev = models.NewPostCursor(page.Posts[0], sort)
+	}
+
+	if len(page.Posts) != 0 && hasNext {
+		page.Next = models.NewPostCursor(page.Posts[len(page.Posts)-1], sort)
+	}
+
+	return page
 }
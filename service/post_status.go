@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+// SubmitPost sends the draft for review.
+func (s *Service) SubmitPost(ctx context.Context, postID string) error {
+	return s.changePostStatus(ctx, postID, models.PostStatusInReview, permissionSubmitPost)
+}
+
+// PublishPost makes the reviewed post visible to everyone. Users mentioned in the post are notified.
+func (s *Service) PublishPost(ctx context.Context, postID string) error {
+	return s.changePostStatus(ctx, postID, models.PostStatusPublished, permissionPublishPost)
+}
+
+// UnpublishPost returns the post which is published or in review back to drafts.
+func (s *Service) UnpublishPost(ctx context.Context, postID string) error {
+	return s.changePostStatus(ctx, postID, models.PostStatusDraft, permissionUnpublishPost)
+}
+
+// ArchivePost hides the published post. Archived posts cannot be published again.
+func (s *Service) ArchivePost(ctx context.Context, postID string) error {
+	return s.changePostStatus(ctx, postID, models.PostStatusArchived, permissionArchivePost)
+}
+
+func (s *Service) changePostStatus(ctx context.Context, postID string, status models.PostStatus,
+	p permission) error {
+	post, err := s.getPost(ctx, postID)
+	if err != nil {
+		return fmt.Errorf("cannot get post: %w", err)
+	}
+
+	if err := s.authorize(ctx, post.CreatedBy, p); err != nil {
+		return fmt.Errorf("cannot change status of post: %w", err)
+	}
+
+	if err := post.ValidateStatusChange(status); err != nil {
+		return fmt.Errorf("cannot change status of post: %w", err)
+	}
+
+	if err := s.repo.UpdatePostStatus(ctx, postID, status); err != nil {
+		return fmt.Errorf("cannot change status of post: %w", err)
+	}
+
+	if status == models.PostStatusPublished {
+		s.notifyMentions(ctx, post.Mentions, post.CreatedBy, post.ID, "")
+	}
+
+	return nil
+}
+
+func (s *Service) isPostVisible(ctx context.Context, post *models.Post) bool {
+	if post.Status == models.PostStatusPublished {
+		return true
+	}
+
+	if post.CreatedBy == s.currentUserInformationProvider.GetCurrentUserID(ctx) {
+		return true
+	}
+
+	return s.hasRolePermission(ctx, permissionViewPost)
+}
+
+// postVisibility limits listed posts of other users to the published ones unless the current user reviews posts.
+func (s *Service) postVisibility(ctx context.Context) models.PostVisibility {
+	if s.hasRolePermission(ctx, permissionViewPost) {
+		return models.PostVisibility{}
+	}
+
+	return models.PostVisibility{
+		OwnerID:  s.currentUserInformationProvider.GetCurrentUserID(ctx),
+		Statuses: []models.PostStatus{models.PostStatusPublished},
+	}
+}
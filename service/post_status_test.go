@@ -0,0 +1,142 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/serhiihuberniuk/blog-api/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_PublishPost(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context)
+
+	authorID := "315b6c09-36ff-4519-8579-492f3ae2a3be"
+	moderatorID := "40d2f213-8e41-4abf-ad54-410997b19401"
+	mentionedID := "7c0f6f83-45a5-4bb0-9a43-8bb0d3c3c0a4"
+
+	postWithStatus := func(status models.PostStatus) *models.Post {
+		return &models.Post{
+			ID:          "a4a4d1f6-0f0a-4bd4-8b1c-6f5ac2b0e0f1",
+			Title:       "title",
+			Description: "description",
+			CreatedBy:   authorID,
+			Status:      status,
+		}
+	}
+
+	postID := postWithStatus("").ID
+
+	testCases := []struct {
+		name         string
+		mockBehavior mockBehavior
+		errMessage   string
+	}{
+		{
+			name: "Moderator publishes post in review",
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(moderatorID).AnyTimes()
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleModerator).AnyTimes()
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(postID)).
+					Return(postWithStatus(models.PostStatusInReview), nil)
+				r.EXPECT().UpdatePostStatus(gomock.Eq(ctx), gomock.Eq(postID),
+					gomock.Eq(models.PostStatusPublished)).Return(nil)
+			},
+			errMessage: "",
+		},
+		{
+			name: "Users mentioned in published post are notified",
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				post := postWithStatus(models.PostStatusInReview)
+				post.Mentions = []models.Mention{{UserID: mentionedID, Username: "mentioned"}}
+
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(moderatorID).AnyTimes()
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleModerator).AnyTimes()
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(postID)).Return(post, nil)
+				r.EXPECT().UpdatePostStatus(gomock.Eq(ctx), gomock.Eq(postID),
+					gomock.Eq(models.PostStatusPublished)).Return(nil)
+				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(mentionedID)).Return(&models.User{ID: mentionedID}, nil)
+				r.EXPECT().CreateNotification(gomock.Eq(ctx), gomock.Any()).DoAndReturn(
+					func(_ context.Context, notification *models.Notification) error {
+						assert.Equal(t, mentionedID, notification.UserID)
+						assert.Equal(t, models.NotificationMention, notification.Type)
+						assert.Equal(t, authorID, notification.ActorID)
+						assert.Equal(t, postID, notification.PostID)
+
+						return nil
+					})
+			},
+			errMessage: "",
+		},
+		{
+			name: "Author cannot publish own post",
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(authorID).AnyTimes()
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser).AnyTimes()
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(postID)).
+					Return(postWithStatus(models.PostStatusInReview), nil)
+			},
+			errMessage: models.ErrForbidden.Error(),
+		},
+		{
+			name: "Draft cannot be published without review",
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(moderatorID).AnyTimes()
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleModerator).AnyTimes()
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(postID)).
+					Return(postWithStatus(models.PostStatusDraft), nil)
+			},
+			errMessage: "cannot change status from draft to published",
+		},
+		{
+			name: "Draft of other user is not visible",
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(moderatorID).AnyTimes()
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser).AnyTimes()
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(postID)).
+					Return(postWithStatus(models.PostStatusDraft), nil)
+			},
+			errMessage: models.ErrNotFound.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+			serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			tc.mockBehavior(repoMock, providerMock, ctx)
+
+			err = serv.PublishPost(ctx, postID)
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
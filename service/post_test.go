@@ -0,0 +1,347 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/serhiihuberniuk/blog-api/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_RestorePost(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context)
+
+	ownerID := "315b6c09-36ff-4519-8579-492f3ae2a3be"
+	otherUserID := "40d2f213-8e41-4abf-ad54-410997b19401"
+	deletedAt := time.Now().Add(-time.Hour)
+
+	post := &models.Post{
+		ID:        "a4a4d1f6-0f0a-4bd4-8b1c-6f5ac2b0e0f1",
+		Title:     "title",
+		CreatedBy: ownerID,
+		DeletedAt: &deletedAt,
+	}
+
+	testCases := []struct {
+		name         string
+		mockBehavior mockBehavior
+		errMessage   string
+	}{
+		{
+			name: "Owner restores post",
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(ownerID)
+				r.EXPECT().GetDeletedPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(post, nil)
+				r.EXPECT().RestorePost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(nil)
+			},
+			errMessage: "",
+		},
+		{
+			name: "Admin restores post of other user",
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(otherUserID)
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleAdmin)
+				r.EXPECT().GetDeletedPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(post, nil)
+				r.EXPECT().RestorePost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(nil)
+			},
+			errMessage: "",
+		},
+		{
+			name: "Moderator cannot restore post of other user",
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(otherUserID)
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleModerator)
+				r.EXPECT().GetDeletedPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(post, nil)
+			},
+			errMessage: models.ErrForbidden.Error(),
+		},
+		{
+			name: "Post is not deleted",
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				r.EXPECT().GetDeletedPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(nil, models.ErrNotFound)
+			},
+			errMessage: models.ErrNotFound.Error(),
+		},
+		{
+			name: "Error in repository layer",
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(ownerID)
+				r.EXPECT().GetDeletedPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(post, nil)
+				r.EXPECT().RestorePost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(errors.New("cannot restore post"))
+			},
+			errMessage: "cannot restore post",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+			serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			tc.mockBehavior(repoMock, providerMock, ctx)
+
+			err = serv.RestorePost(ctx, post.ID)
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
+
+func TestService_PurgeDeleted(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	repoMock := NewMockrepository(ctrl)
+	blobStoreMock := NewMockblobStore(ctrl)
+	retention := time.Hour * 24 * 30
+
+	serv, err := service.NewService(repoMock, nil, nil, nil, nil, service.UnverifiedAccessFull,
+		models.DeletedUserContentCascade, blobStoreMock, models.AttachmentConfig{})
+	if err != nil {
+		t.Log(fmt.Errorf("error occurred while init service: %w", err))
+		t.Fail()
+
+		return
+	}
+
+	before := time.Now().Add(-retention)
+
+	repoMock.EXPECT().ListPurgeableAttachments(gomock.Eq(ctx), gomock.Any()).Return([]*models.Attachment{
+		{ID: "1", BlobKey: models.AttachmentBlobKey("1")},
+		{ID: "2", BlobKey: models.AttachmentBlobKey("2")},
+	}, nil)
+	blobStoreMock.EXPECT().Delete(gomock.Eq(ctx), models.AttachmentBlobKey("1")).Return(nil)
+	blobStoreMock.EXPECT().Delete(gomock.Eq(ctx), models.AttachmentBlobKey("2")).Return(models.ErrNotFound)
+	repoMock.EXPECT().PurgeDeleted(gomock.Eq(ctx), gomock.Any()).DoAndReturn(
+		func(_ context.Context, deletedBefore time.Time) error {
+			assert.WithinDuration(t, before, deletedBefore, time.Minute)
+
+			return nil
+		})
+
+	assert.NoError(t, serv.PurgeDeleted(ctx, retention))
+}
+
+func TestService_ListPosts(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *Mockrepository, ctx context.Context)
+
+	userID := "315b6c09-36ff-4519-8579-492f3ae2a3be"
+
+	testCases := []struct {
+		name         string
+		filter       models.FilterPosts
+		mockBehavior mockBehavior
+		errMessage   string
+	}{
+		{
+			name: "Tags of filter are normalized",
+			filter: models.FilterPosts{Conditions: []models.PostCondition{
+				{Field: models.FilterPostsByCreatedBy, Operator: models.FilterOperatorEq, Values: []string{userID}},
+				{Field: models.FilterPostsByTags, Operator: models.FilterOperatorIn, Values: []string{"Golang", "API"}},
+			}},
+			mockBehavior: func(r *Mockrepository, ctx context.Context) {
+				r.EXPECT().GetTagAliases(gomock.Eq(ctx), gomock.Eq([]string{"golang", "api"})).
+					Return(map[string]string{"golang": "go"}, nil)
+				r.EXPECT().ListPosts(gomock.Eq(ctx), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ models.Pagination, filter models.FilterPosts,
+						_ models.SortPosts) ([]*models.Post, error) {
+						assert.Equal(t, []string{userID}, filter.Conditions[0].Values)
+						assert.Equal(t, []string{"go", "api"}, filter.Conditions[1].Values)
+						assert.Equal(t, userID, filter.Visibility.OwnerID)
+
+						return []*models.Post{}, nil
+					})
+			},
+			errMessage: "",
+		},
+		{
+			name: "Filter is invalid",
+			filter: models.FilterPosts{Conditions: []models.PostCondition{
+				{Field: models.FilterPostsByCreatedAt, Operator: models.FilterOperatorGte, Values: []string{"today"}},
+			}},
+			mockBehavior: func(r *Mockrepository, ctx context.Context) {},
+			errMessage:   "must be a time in RFC 3339 format",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+			providerMock.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).AnyTimes()
+			providerMock.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser).AnyTimes()
+
+			serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			tc.mockBehavior(repoMock, ctx)
+
+			_, err = serv.ListPosts(ctx, models.Pagination{}, tc.filter, models.SortPosts{})
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
+
+func TestService_ListPosts_PageInfo(t *testing.T) {
+	t.Parallel()
+
+	userID := "315b6c09-36ff-4519-8579-492f3ae2a3be"
+	createdAt := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	posts := []*models.Post{
+		{ID: "1", CreatedAt: createdAt},
+		{ID: "2", CreatedAt: createdAt.Add(time.Hour)},
+		{ID: "3", CreatedAt: createdAt.Add(2 * time.Hour)},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	repoMock := NewMockrepository(ctrl)
+	providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+	providerMock.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).AnyTimes()
+	providerMock.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser).AnyTimes()
+
+	serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+		models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+	if err != nil {
+		t.Log(fmt.Errorf("error occurred while init service: %w", err))
+		t.Fail()
+
+		return
+	}
+
+	sort := models.SortPostsBy(models.SortPostsByCreatedAt, true)
+	after := models.NewPostCursor(&models.Post{ID: "0", CreatedAt: createdAt.Add(-time.Hour)}, sort)
+
+	r := repoMock.EXPECT()
+	r.ListPosts(gomock.Eq(ctx), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, pagination models.Pagination, _ models.FilterPosts,
+			sort models.SortPosts) ([]*models.Post, error) {
+			assert.Equal(t, uint64(3), pagination.Limit)
+			assert.Equal(t, []models.SortPostsKey{{Field: models.SortPostsByCreatedAt, IsASC: true}}, sort.Keys)
+
+			return posts, nil
+		})
+	r.CountPosts(gomock.Eq(ctx), gomock.Any()).Return(7, nil)
+	r.CountReactions(gomock.Eq(ctx), gomock.Eq(models.ReactionTargetPost), gomock.Eq([]string{"1", "2"})).
+		Return(map[string]models.ReactionCounts{"1": {}, "2": {}}, nil)
+
+	page, err := serv.ListPosts(ctx, models.Pagination{Limit: 2, After: after, CountTotal: true},
+		models.FilterPosts{}, models.SortPosts{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, posts[:2], page.Posts)
+	assert.Equal(t, models.NewPostCursor(posts[0], sort), page.Prev)
+	assert.Equal(t, models.NewPostCursor(posts[1], sort), page.Next)
+	assert.Equal(t, 7, *page.Total)
+}
+
+func TestService_UpdatePost_Format(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	repoMock := NewMockrepository(ctrl)
+	providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+	ownerID := "315b6c09-36ff-4519-8579-492f3ae2a3be"
+	post := &models.Post{
+		ID:          "a4a4d1f6-0f0a-4bd4-8b1c-6f5ac2b0e0f1",
+		Title:       "Hello world",
+		Description: "Some **bold** text",
+		CreatedBy:   ownerID,
+		Status:      models.PostStatusDraft,
+		Slug:        "hello-world",
+	}
+	post.Render()
+
+	providerMock.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(ownerID).AnyTimes()
+	providerMock.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser).AnyTimes()
+	repoMock.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(post, nil)
+	repoMock.EXPECT().UpdatePost(gomock.Eq(ctx), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, updated *models.Post, _ *models.PostRevision) error {
+			assert.Equal(t, models.ContentFormatMarkdown, updated.Format)
+			assert.Equal(t, "<p>Some <strong>bold</strong> text</p>\n", updated.DescriptionHTML)
+			assert.Equal(t, 3, updated.WordCount)
+
+			return nil
+		})
+
+	serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+		models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+	if err != nil {
+		t.Log(fmt.Errorf("error occurred while init service: %w", err))
+		t.Fail()
+
+		return
+	}
+
+	format := models.ContentFormatMarkdown
+
+	err = serv.UpdatePost(ctx, models.UpdatePostPayload{
+		PostID: post.ID,
+		Format: &format,
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+// AddReaction leaves the reaction of the current user on the post or the comment and returns
+// the updated reaction counts of the object.
+func (s *Service) AddReaction(ctx context.Context, payload models.ReactionPayload) (models.ReactionCounts, error) {
+	reaction, notification, err := s.reaction(ctx, payload)
+	if err != nil {
+		return nil, fmt.Errorf("cannot add reaction: %w", err)
+	}
+
+	added, err := s.repo.AddReaction(ctx, reaction)
+	if err != nil {
+		return nil, fmt.Errorf("cannot add reaction: %w", err)
+	}
+
+	// Repeated reactions change nothing, so they are not notified again.
+	if added {
+		s.notify(ctx, notification)
+	}
+
+	return s.countReactions(ctx, reaction)
+}
+
+// RemoveReaction takes back the reaction of the current user and returns the updated reaction counts of the object.
+func (s *Service) RemoveReaction(ctx context.Context,
+	payload models.ReactionPayload) (models.ReactionCounts, error) {
+	reaction, _, err := s.reaction(ctx, payload)
+	if err != nil {
+		return nil, fmt.Errorf("cannot remove reaction: %w", err)
+	}
+
+	if err := s.repo.RemoveReaction(ctx, reaction); err != nil {
+		return nil, fmt.Errorf("cannot remove reaction: %w", err)
+	}
+
+	return s.countReactions(ctx, reaction)
+}
+
+// reaction builds the reaction of the current user and checks that its object can be seen.
+// It also returns the notification telling the owner of the object about the reaction.
+func (s *Service) reaction(ctx context.Context, payload models.ReactionPayload) (*models.Reaction,
+	*models.Notification, error) {
+	reaction := &models.Reaction{
+		TargetType: payload.TargetType,
+		TargetID:   payload.TargetID,
+		UserID:     s.currentUserInformationProvider.GetCurrentUserID(ctx),
+		Type:       payload.Type,
+		CreatedAt:  time.Now(),
+	}
+
+	if err := s.authorize(ctx, reaction.UserID, permissionReact); err != nil {
+		return nil, nil, err
+	}
+
+	if err := reaction.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	notification := &models.Notification{
+		Type:     models.NotificationReaction,
+		ActorID:  reaction.UserID,
+		Reaction: reaction.Type,
+	}
+
+	switch reaction.TargetType {
+	case models.ReactionTargetPost:
+		post, err := s.getPost(ctx, reaction.TargetID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		notification.UserID = post.CreatedBy
+		notification.PostID = post.ID
+	case models.ReactionTargetComment:
+		comment, err := s.getComment(ctx, reaction.TargetID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if _, err := s.getPost(ctx, comment.PostID); err != nil {
+			return nil, nil, fmt.Errorf("cannot get commented post: %w", err)
+		}
+
+		notification.UserID = comment.CreatedBy
+		notification.PostID = comment.PostID
+		notification.CommentID = comment.ID
+	}
+
+	return reaction, notification, nil
+}
+
+func (s *Service) countReactions(ctx context.Context, reaction *models.Reaction) (models.ReactionCounts, error) {
+	counts, err := s.repo.CountReactions(ctx, reaction.TargetType, []string{reaction.TargetID})
+	if err != nil {
+		return nil, fmt.Errorf("cannot count reactions: %w", err)
+	}
+
+	return counts[reaction.TargetID], nil
+}
+
+// countPostsReactions sets the reactions to the posts counted at once.
+func (s *Service) countPostsReactions(ctx context.Context, posts []*models.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.ID)
+	}
+
+	counts, err := s.repo.CountReactions(ctx, models.ReactionTargetPost, ids)
+	if err != nil {
+		return fmt.Errorf("cannot count reactions to posts: %w", err)
+	}
+
+	for _, post := range posts {
+		post.Reactions = counts[post.ID]
+	}
+
+	return nil
+}
+
+// countCommentsReactions sets the reactions to the comments counted at once.
+func (s *Service) countCommentsReactions(ctx context.Context, comments []*models.Comment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		ids = append(ids, comment.ID)
+	}
+
+	counts, err := s.repo.CountReactions(ctx, models.ReactionTargetComment, ids)
+	if err != nil {
+		return fmt.Errorf("cannot count reactions to comments: %w", err)
+	}
+
+	for _, comment := range comments {
+		comment.Reactions = counts[comment.ID]
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/serhiihuberniuk/blog-api/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_AddReaction(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context)
+
+	userID := "315b6c09-36ff-4519-8579-492f3ae2a3be"
+
+	post := &models.Post{
+		ID:          "a4a4d1f6-0f0a-4bd4-8b1c-6f5ac2b0e0f1",
+		Title:       "title",
+		Description: "description",
+		CreatedBy:   "40d2f213-8e41-4abf-ad54-410997b19401",
+		Status:      models.PostStatusPublished,
+	}
+
+	testCases := []struct {
+		name           string
+		payload        models.ReactionPayload
+		mockBehavior   mockBehavior
+		expectedCounts models.ReactionCounts
+		errMessage     string
+	}{
+		{
+			name: "Reaction is added to post",
+			payload: models.ReactionPayload{
+				TargetType: models.ReactionTargetPost,
+				TargetID:   post.ID,
+				Type:       models.ReactionLike,
+			},
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).AnyTimes()
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser).AnyTimes()
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(post, nil)
+				r.EXPECT().AddReaction(gomock.Eq(ctx), gomock.Any()).Return(true, nil)
+				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(post.CreatedBy)).
+					Return(&models.User{ID: post.CreatedBy}, nil)
+				r.EXPECT().CreateNotification(gomock.Eq(ctx), gomock.Any()).DoAndReturn(
+					func(_ context.Context, notification *models.Notification) error {
+						assert.Equal(t, post.CreatedBy, notification.UserID)
+						assert.Equal(t, models.NotificationReaction, notification.Type)
+						assert.Equal(t, models.ReactionLike, notification.Reaction)
+						assert.Equal(t, post.ID, notification.PostID)
+
+						return nil
+					})
+				r.EXPECT().CountReactions(gomock.Eq(ctx), gomock.Eq(models.ReactionTargetPost),
+					gomock.Eq([]string{post.ID})).
+					Return(map[string]models.ReactionCounts{post.ID: {models.ReactionLike: 2}}, nil)
+			},
+			expectedCounts: models.ReactionCounts{models.ReactionLike: 2},
+			errMessage:     "",
+		},
+		{
+			name: "Reaction is added although notification fails",
+			payload: models.ReactionPayload{
+				TargetType: models.ReactionTargetPost,
+				TargetID:   post.ID,
+				Type:       models.ReactionLike,
+			},
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).AnyTimes()
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser).AnyTimes()
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(post, nil)
+				r.EXPECT().AddReaction(gomock.Eq(ctx), gomock.Any()).Return(true, nil)
+				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(post.CreatedBy)).
+					Return(&models.User{ID: post.CreatedBy}, nil)
+				r.EXPECT().CreateNotification(gomock.Eq(ctx), gomock.Any()).Return(fmt.Errorf("some error"))
+				r.EXPECT().CountReactions(gomock.Eq(ctx), gomock.Eq(models.ReactionTargetPost),
+					gomock.Eq([]string{post.ID})).
+					Return(map[string]models.ReactionCounts{post.ID: {models.ReactionLike: 2}}, nil)
+			},
+			expectedCounts: models.ReactionCounts{models.ReactionLike: 2},
+			errMessage:     "",
+		},
+		{
+			name: "Repeated reaction is not notified",
+			payload: models.ReactionPayload{
+				TargetType: models.ReactionTargetPost,
+				TargetID:   post.ID,
+				Type:       models.ReactionLike,
+			},
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).AnyTimes()
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser).AnyTimes()
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(post, nil)
+				r.EXPECT().AddReaction(gomock.Eq(ctx), gomock.Any()).Return(false, nil)
+				r.EXPECT().CountReactions(gomock.Eq(ctx), gomock.Eq(models.ReactionTargetPost),
+					gomock.Eq([]string{post.ID})).
+					Return(map[string]models.ReactionCounts{post.ID: {models.ReactionLike: 2}}, nil)
+			},
+			expectedCounts: models.ReactionCounts{models.ReactionLike: 2},
+			errMessage:     "",
+		},
+		{
+			name: "Reaction to own comment is not notified",
+			payload: models.ReactionPayload{
+				TargetType: models.ReactionTargetComment,
+				TargetID:   "7f3c0a1e-5b2d-4c8e-9a6f-1d2e3f4a5b6c",
+				Type:       models.ReactionLove,
+			},
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).AnyTimes()
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser).AnyTimes()
+				r.EXPECT().GetComment(gomock.Eq(ctx), gomock.Eq("7f3c0a1e-5b2d-4c8e-9a6f-1d2e3f4a5b6c")).
+					Return(&models.Comment{
+						ID:        "7f3c0a1e-5b2d-4c8e-9a6f-1d2e3f4a5b6c",
+						PostID:    post.ID,
+						CreatedBy: userID,
+					}, nil)
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(post, nil)
+				r.EXPECT().AddReaction(gomock.Eq(ctx), gomock.Any()).Return(true, nil)
+				r.EXPECT().CountReactions(gomock.Eq(ctx), gomock.Eq(models.ReactionTargetComment),
+					gomock.Eq([]string{"7f3c0a1e-5b2d-4c8e-9a6f-1d2e3f4a5b6c"})).
+					Return(map[string]models.ReactionCounts{
+						"7f3c0a1e-5b2d-4c8e-9a6f-1d2e3f4a5b6c": {models.ReactionLove: 1},
+					}, nil)
+			},
+			expectedCounts: models.ReactionCounts{models.ReactionLove: 1},
+			errMessage:     "",
+		},
+		{
+			name: "Comment on unpublished post of other user is not found",
+			payload: models.ReactionPayload{
+				TargetType: models.ReactionTargetComment,
+				TargetID:   "7f3c0a1e-5b2d-4c8e-9a6f-1d2e3f4a5b6c",
+				Type:       models.ReactionLove,
+			},
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				draft := *post
+				draft.Status = models.PostStatusDraft
+
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).AnyTimes()
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser).AnyTimes()
+				r.EXPECT().GetComment(gomock.Eq(ctx), gomock.Eq("7f3c0a1e-5b2d-4c8e-9a6f-1d2e3f4a5b6c")).
+					Return(&models.Comment{
+						ID:        "7f3c0a1e-5b2d-4c8e-9a6f-1d2e3f4a5b6c",
+						PostID:    post.ID,
+						CreatedBy: post.CreatedBy,
+					}, nil)
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(&draft, nil)
+			},
+			expectedCounts: nil,
+			errMessage:     models.ErrNotFound.Error(),
+		},
+		{
+			name: "Reaction type is unknown",
+			payload: models.ReactionPayload{
+				TargetType: models.ReactionTargetPost,
+				TargetID:   post.ID,
+				Type:       "dislike",
+			},
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).AnyTimes()
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser).AnyTimes()
+			},
+			expectedCounts: nil,
+			errMessage:     "Type: must be a valid value",
+		},
+		{
+			name: "Comment does not exist",
+			payload: models.ReactionPayload{
+				TargetType: models.ReactionTargetComment,
+				TargetID:   "7f3c0a1e-5b2d-4c8e-9a6f-1d2e3f4a5b6c",
+				Type:       models.ReactionLove,
+			},
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).AnyTimes()
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser).AnyTimes()
+				r.EXPECT().GetComment(gomock.Eq(ctx), gomock.Eq("7f3c0a1e-5b2d-4c8e-9a6f-1d2e3f4a5b6c")).
+					Return(nil, models.ErrNotFound)
+			},
+			expectedCounts: nil,
+			errMessage:     models.ErrNotFound.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+			serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			tc.mockBehavior(repoMock, providerMock, ctx)
+
+			counts, err := serv.AddReaction(ctx, tc.payload)
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedCounts, counts)
+
+				return
+			}
+
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+func (s *Service) ListPostRevisions(ctx context.Context, postID string) ([]*models.PostRevision, error) {
+	if _, err := s.getPost(ctx, postID); err != nil {
+		return nil, fmt.Errorf("cannot get post: %w", err)
+	}
+
+	revisions, err := s.repo.ListPostRevisions(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get revisions of post: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (s *Service) GetPostRevision(ctx context.Context, postID, revisionID string) (*models.PostRevision, error) {
+	if _, err := s.getPost(ctx, postID); err != nil {
+		return nil, fmt.Errorf("cannot get post: %w", err)
+	}
+
+	revision, err := s.repo.GetPostRevision(ctx, revisionID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get revision of post: %w", err)
+	}
+
+	if revision.PostID != postID {
+		return nil, fmt.Errorf("cannot get revision of post: %w", models.ErrNotFound)
+	}
+
+	return revision, nil
+}
+
+// DiffPostRevisions returns the fields which were changed between two revisions of the post.
+func (s *Service) DiffPostRevisions(ctx context.Context, postID, fromRevisionID,
+	toRevisionID string) ([]models.FieldChange, error) {
+	from, err := s.GetPostRevision(ctx, postID, fromRevisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := s.GetPostRevision(ctx, postID, toRevisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return from.Diff(to), nil
+}
+
+// RollbackPost brings the post back to the revision. The replaced version is kept as a new revision.
+func (s *Service) RollbackPost(ctx context.Context, postID, revisionID string) error {
+	revision, err := s.GetPostRevision(ctx, postID, revisionID)
+	if err != nil {
+		return err
+	}
+
+	format := revision.Format.OrPlain()
+
+	return s.UpdatePost(ctx, models.UpdatePostPayload{
+		PostID:      postID,
+		Title:       &revision.Title,
+		Description: &revision.Description,
+		Tags:        &revision.Tags,
+		Format:      &format,
+	})
+}
+
+func (s *Service) ListCommentRevisions(ctx context.Context, commentID string) ([]*models.CommentRevision, error) {
+	if _, err := s.getComment(ctx, commentID); err != nil {
+		return nil, fmt.Errorf("cannot get comment: %w", err)
+	}
+
+	revisions, err := s.repo.ListCommentRevisions(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get revisions of comment: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (s *Service) GetCommentRevision(ctx context.Context, commentID,
+	revisionID string) (*models.CommentRevision, error) {
+	if _, err := s.getComment(ctx, commentID); err != nil {
+		return nil, fmt.Errorf("cannot get comment: %w", err)
+	}
+
+	revision, err := s.repo.GetCommentRevision(ctx, revisionID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get revision of comment: %w", err)
+	}
+
+	if revision.CommentID != commentID {
+		return nil, fmt.Errorf("cannot get revision of comment: %w", models.ErrNotFound)
+	}
+
+	return revision, nil
+}
+
+// DiffCommentRevisions returns the fields which were changed between two revisions of the comment.
+func (s *Service) DiffCommentRevisions(ctx context.Context, commentID, fromRevisionID,
+	toRevisionID string) ([]models.FieldChange, error) {
+	from, err := s.GetCommentRevision(ctx, commentID, fromRevisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := s.GetCommentRevision(ctx, commentID, toRevisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return from.Diff(to), nil
+}
+
+// RollbackComment brings the comment back to the revision. The replaced version is kept as a new revision.
+func (s *Service) RollbackComment(ctx context.Context, commentID, revisionID string) error {
+	revision, err := s.GetCommentRevision(ctx, commentID, revisionID)
+	if err != nil {
+		return err
+	}
+
+	format := revision.Format.OrPlain()
+
+	return s.UpdateComment(ctx, models.UpdateCommentPayload{
+		CommentID: commentID,
+		Content:   &revision.Content,
+		Format:    &format,
+	})
+}
+
+func postRevision(post *models.Post) *models.PostRevision {
+	return &models.PostRevision{
+		PostID:      post.ID,
+		Title:       post.Title,
+		Description: post.Description,
+		Tags:        post.Tags,
+		Format:      post.Format.OrPlain(),
+	}
+}
+
+func commentRevision(comment *models.Comment) *models.CommentRevision {
+	return &models.CommentRevision{
+		CommentID: comment.ID,
+		Content:   comment.Content,
+		Format:    comment.Format.OrPlain(),
+	}
+}
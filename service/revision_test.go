@@ -0,0 +1,130 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/serhiihuberniuk/blog-api/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_RollbackPost(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context)
+
+	ownerID := "315b6c09-36ff-4519-8579-492f3ae2a3be"
+	createdAt := time.Now().Add(-time.Hour)
+
+	newPost := func() *models.Post {
+		return &models.Post{
+			ID:          "a4a4d1f6-0f0a-4bd4-8b1c-6f5ac2b0e0f1",
+			Title:       "new title",
+			Description: "description",
+			Slug:        "new-title",
+			CreatedBy:   ownerID,
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt.Add(time.Minute),
+			Tags:        []string{"go"},
+			Status:      models.PostStatusPublished,
+		}
+	}
+
+	revision := &models.PostRevision{
+		ID:          "7c0f6f83-45a5-4bb0-9a43-8bb0d3c3c0a4",
+		PostID:      newPost().ID,
+		EditedBy:    ownerID,
+		EditedAt:    createdAt.Add(time.Minute),
+		Title:       "old title",
+		Description: "description",
+		Tags:        []string{"go"},
+	}
+
+	testCases := []struct {
+		name         string
+		mockBehavior mockBehavior
+		errMessage   string
+	}{
+		{
+			name: "Post is rolled back and replaced version is kept",
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(revision.PostID)).Return(newPost(), nil).Times(2)
+				r.EXPECT().GetPostRevision(gomock.Eq(ctx), gomock.Eq(revision.ID)).Return(revision, nil)
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(ownerID).Times(2)
+				r.EXPECT().GetTagAliases(gomock.Eq(ctx), gomock.Eq([]string{"go"})).Return(map[string]string{}, nil)
+				r.EXPECT().IsSlugTaken(gomock.Eq(ctx), gomock.Eq("old-title"), gomock.Eq(revision.PostID)).
+					Return(false, nil)
+				r.EXPECT().UpdatePost(gomock.Eq(ctx), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, post *models.Post, replaced *models.PostRevision) error {
+						assert.Equal(t, "old title", post.Title)
+						assert.Equal(t, "old-title", post.Slug)
+						assert.True(t, post.IsEdited())
+						assert.Equal(t, "new title", replaced.Title)
+						assert.Equal(t, ownerID, replaced.EditedBy)
+						assert.NotEmpty(t, replaced.ID)
+
+						return nil
+					})
+			},
+			errMessage: "",
+		},
+		{
+			name: "Revision belongs to other post",
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				otherRevision := *revision
+				otherRevision.PostID = "40d2f213-8e41-4abf-ad54-410997b19401"
+
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(revision.PostID)).Return(newPost(), nil)
+				r.EXPECT().GetPostRevision(gomock.Eq(ctx), gomock.Eq(revision.ID)).Return(&otherRevision, nil)
+			},
+			errMessage: models.ErrNotFound.Error(),
+		},
+		{
+			name: "Revision is not found",
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(revision.PostID)).Return(newPost(), nil)
+				r.EXPECT().GetPostRevision(gomock.Eq(ctx), gomock.Eq(revision.ID)).Return(nil, models.ErrNotFound)
+			},
+			errMessage: models.ErrNotFound.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+			serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			tc.mockBehavior(repoMock, providerMock, ctx)
+
+			err = serv.RollbackPost(ctx, revision.PostID, revision.ID)
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+// Search returns posts and comments matching the query, the most relevant first. Only posts
+// visible to the current user and comments on them are found.
+func (s *Service) Search(ctx context.Context, query models.SearchQuery,
+	pagination models.Pagination) ([]*models.SearchResult, error) {
+	query.Visibility = s.postVisibility(ctx)
+
+	if err := query.Validate(); err != nil {
+		return nil, fmt.Errorf("cannot search: %w", err)
+	}
+
+	results, err := s.repo.Search(ctx, query, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("cannot search: %w", err)
+	}
+
+	var (
+		posts    []*models.Post
+		comments []*models.Comment
+	)
+
+	for _, result := range results {
+		switch result.Target {
+		case models.SearchTargetPost:
+			result.Post, err = s.getPost(ctx, result.ID)
+			posts = append(posts, result.Post)
+		case models.SearchTargetComment:
+			result.Comment, err = s.getComment(ctx, result.ID)
+			comments = append(comments, result.Comment)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("cannot get found %s: %w", result.Target, err)
+		}
+	}
+
+	if err := s.countPostsReactions(ctx, posts); err != nil {
+		return nil, err
+	}
+
+	if err := s.countCommentsReactions(ctx, comments); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
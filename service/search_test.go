@@ -0,0 +1,118 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/serhiihuberniuk/blog-api/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Search(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context)
+
+	userID := "315b6c09-36ff-4519-8579-492f3ae2a3be"
+
+	post := &models.Post{
+		ID:          "a4a4d1f6-0f0a-4bd4-8b1c-6f5ac2b0e0f1",
+		Title:       "Golang tips",
+		Description: "description",
+		CreatedBy:   "40d2f213-8e41-4abf-ad54-410997b19401",
+		Status:      models.PostStatusPublished,
+	}
+
+	comment := &models.Comment{
+		ID:      "7f3c0a1e-5b2d-4c8e-9a6f-1d2e3f4a5b6c",
+		Content: "I like golang",
+		PostID:  post.ID,
+	}
+
+	testCases := []struct {
+		name         string
+		query        models.SearchQuery
+		mockBehavior mockBehavior
+		expected     []*models.SearchResult
+		errMessage   string
+	}{
+		{
+			name:  "Found post and comment are loaded",
+			query: models.SearchQuery{Text: "golang"},
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).AnyTimes()
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser).AnyTimes()
+				r.EXPECT().Search(gomock.Eq(ctx), gomock.Eq(models.SearchQuery{
+					Text: "golang",
+					Visibility: models.PostVisibility{
+						OwnerID:  userID,
+						Statuses: []models.PostStatus{models.PostStatusPublished},
+					},
+				}), gomock.Eq(models.Pagination{Limit: 10})).Return([]*models.SearchResult{
+					{Target: models.SearchTargetPost, ID: post.ID, Rank: 0.6},
+					{Target: models.SearchTargetComment, ID: comment.ID, Rank: 0.1},
+				}, nil)
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(post, nil)
+				r.EXPECT().CountReactions(gomock.Eq(ctx), gomock.Eq(models.ReactionTargetPost),
+					gomock.Eq([]string{post.ID})).Return(map[string]models.ReactionCounts{}, nil)
+				r.EXPECT().GetComment(gomock.Eq(ctx), gomock.Eq(comment.ID)).Return(comment, nil)
+				r.EXPECT().CountReactions(gomock.Eq(ctx), gomock.Eq(models.ReactionTargetComment),
+					gomock.Eq([]string{comment.ID})).Return(map[string]models.ReactionCounts{}, nil)
+			},
+			expected: []*models.SearchResult{
+				{Target: models.SearchTargetPost, ID: post.ID, Rank: 0.6, Post: post},
+				{Target: models.SearchTargetComment, ID: comment.ID, Rank: 0.1, Comment: comment},
+			},
+			errMessage: "",
+		},
+		{
+			name:  "Search text is empty",
+			query: models.SearchQuery{Text: ""},
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).AnyTimes()
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser).AnyTimes()
+			},
+			expected:   nil,
+			errMessage: "Text: cannot be blank",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+			serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			tc.mockBehavior(repoMock, providerMock, ctx)
+
+			results, err := serv.Search(ctx, tc.query, models.Pagination{Limit: 10})
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, results)
+
+				return
+			}
+
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
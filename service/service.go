@@ -6,6 +6,8 @@ import (
 	"context"
 	"crypto/rsa"
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/serhiihuberniuk/blog-api/models"
 	"golang.org/x/crypto/bcrypt"
@@ -15,40 +17,151 @@ type Service struct {
 	repo                           repository
 	privateKey                     *rsa.PrivateKey
 	currentUserInformationProvider currentUserInformationProvider
+	denylist                       tokenDenylist
+	mailer                         mailer
+	unverifiedAccess               UnverifiedAccess
+	deletedUserContent             models.DeletedUserContent
+	blobStore                      blobStore
+	attachments                    models.AttachmentConfig
 }
 
 type currentUserInformationProvider interface {
 	GetCurrentUserID(ctx context.Context) string
+	GetCurrentUserRole(ctx context.Context) models.Role
+	GetCurrentTokenID(ctx context.Context) string
+	GetCurrentSessionID(ctx context.Context) string
+}
+
+type tokenDenylist interface {
+	Deny(ctx context.Context, tokenID string, ttl time.Duration) error
+	IsDenied(ctx context.Context, tokenID string) (bool, error)
+}
+
+type mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+type blobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
 }
 
 type repository interface {
 	Login(ctx context.Context, email string) (*models.User, error)
+	CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error
+	GetRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, tokenID string, revokedAt time.Time) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string, revokedAt time.Time) error
+	RevokeUserRefreshTokens(ctx context.Context, userID string, revokedAt time.Time) error
+	RevokeOtherRefreshTokens(ctx context.Context, userID, familyID string, revokedAt time.Time) error
+	CreatePasswordResetToken(ctx context.Context, token *models.PasswordResetToken) error
+	GetPasswordResetToken(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error)
+	UsePasswordResetToken(ctx context.Context, tokenID string, usedAt time.Time) error
+	CreateEmailVerificationToken(ctx context.Context, token *models.EmailVerificationToken) error
+	GetEmailVerificationToken(ctx context.Context, tokenHash string) (*models.EmailVerificationToken, error)
+	UseEmailVerificationToken(ctx context.Context, tokenID string, usedAt time.Time) error
 
 	CreateUser(ctx context.Context, user *models.User) error
 	GetUser(ctx context.Context, userID string) (*models.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
+	GetUsersByUsernames(ctx context.Context, usernames []string) ([]*models.User, error)
+	IsUsernameTaken(ctx context.Context, username string) (bool, error)
+	IsEmailTaken(ctx context.Context, email string) (bool, error)
 	UpdateUser(ctx context.Context, user *models.User) error
-	DeleteUser(ctx context.Context, userID string) error
+	GetUserPassword(ctx context.Context, userID string) (string, error)
+	UpdateUserPassword(ctx context.Context, userID, password string, updatedAt time.Time) error
+	VerifyUserEmail(ctx context.Context, userID, email string, verifiedAt time.Time) error
+	UpdateUserRole(ctx context.Context, userID string, role models.Role) error
+	DeleteUser(ctx context.Context, userID string, deletedAt time.Time, content models.DeletedUserContent) error
+	GetDeletedUser(ctx context.Context, userID string) (*models.User, error)
+	RestoreUser(ctx context.Context, userID string) error
 
 	CreatePost(ctx context.Context, post *models.Post) error
 	GetPost(ctx context.Context, postID string) (*models.Post, error)
-	UpdatePost(ctx context.Context, post *models.Post) error
-	DeletePost(ctx context.Context, postID string) error
+	GetPostBySlug(ctx context.Context, slug string) (*models.Post, error)
+	IsSlugTaken(ctx context.Context, slug, postID string) (bool, error)
+	UpdatePost(ctx context.Context, post *models.Post, revision *models.PostRevision) error
+	UpdatePostStatus(ctx context.Context, postID string, status models.PostStatus) error
+	DeletePost(ctx context.Context, postID string, deletedAt time.Time) error
+	GetDeletedPost(ctx context.Context, postID string) (*models.Post, error)
+	RestorePost(ctx context.Context, postID string) error
 	ListPosts(ctx context.Context, pagination models.Pagination,
 		filter models.FilterPosts, sort models.SortPosts) ([]*models.Post, error)
+	CountPosts(ctx context.Context, filter models.FilterPosts) (int, error)
 
 	CreateComment(ctx context.Context, comment *models.Comment) error
 	GetComment(ctx context.Context, commentID string) (*models.Comment, error)
-	UpdateComment(ctx context.Context, comment *models.Comment) error
-	DeleteComment(ctx context.Context, commentID string) error
+	GetCommentThread(ctx context.Context, commentID string) (*models.CommentThread, error)
+	UpdateComment(ctx context.Context, comment *models.Comment, revision *models.CommentRevision) error
+	DeleteComment(ctx context.Context, commentID string, deletedAt time.Time) error
+	GetDeletedComment(ctx context.Context, commentID string) (*models.Comment, error)
+	RestoreComment(ctx context.Context, commentID string) error
 	ListComments(ctx context.Context, pagination models.Pagination,
 		filter models.FilterComments, sort models.SortComments) ([]*models.Comment, error)
+	CountComments(ctx context.Context, filter models.FilterComments) (int, error)
+
+	ListPostRevisions(ctx context.Context, postID string) ([]*models.PostRevision, error)
+	GetPostRevision(ctx context.Context, revisionID string) (*models.PostRevision, error)
+	ListCommentRevisions(ctx context.Context, commentID string) ([]*models.CommentRevision, error)
+	GetCommentRevision(ctx context.Context, revisionID string) (*models.CommentRevision, error)
+
+	AddReaction(ctx context.Context, reaction *models.Reaction) (bool, error)
+	RemoveReaction(ctx context.Context, reaction *models.Reaction) error
+	CountReactions(ctx context.Context, targetType models.ReactionTarget,
+		targetIDs []string) (map[string]models.ReactionCounts, error)
+
+	Follow(ctx context.Context, follow *models.Follow) (bool, error)
+	Unfollow(ctx context.Context, followerID, followeeID string) error
+	ListFollowers(ctx context.Context, userID string, pagination models.Pagination) ([]*models.User, error)
+	ListFollowing(ctx context.Context, userID string, pagination models.Pagination) ([]*models.User, error)
+
+	Search(ctx context.Context, query models.SearchQuery, pagination models.Pagination) ([]*models.SearchResult, error)
+
+	GetTag(ctx context.Context, name string) (*models.Tag, error)
+	ListTags(ctx context.Context, pagination models.Pagination) ([]*models.Tag, error)
+	AutocompleteTags(ctx context.Context, prefix string, limit uint64) ([]*models.Tag, error)
+	GetTagAliases(ctx context.Context, tags []string) (map[string]string, error)
+	RenameTag(ctx context.Context, name, newName string) ([]string, error)
+	MergeTags(ctx context.Context, from, into string) ([]string, error)
+
+	CreateAttachment(ctx context.Context, attachment *models.Attachment, quota int64) error
+	GetAttachment(ctx context.Context, attachmentID string) (*models.Attachment, error)
+	ListAttachments(ctx context.Context, postID string) ([]*models.Attachment, error)
+	DeleteAttachment(ctx context.Context, attachmentID string) error
+	SumAttachmentSizes(ctx context.Context, userID string) (int64, error)
+	ListPurgeableAttachments(ctx context.Context, deletedBefore time.Time) ([]*models.Attachment, error)
+	ListPendingAttachments(ctx context.Context, limit uint64) ([]*models.Attachment, error)
+	UpdateAttachmentVariants(ctx context.Context, attachmentID string, status models.VariantStatus,
+		variants []models.AttachmentVariant) error
+
+	CreateNotification(ctx context.Context, notification *models.Notification) error
+	ListNotifications(ctx context.Context, userID string, unreadOnly bool,
+		pagination models.Pagination) ([]*models.Notification, error)
+	CountUnreadNotifications(ctx context.Context, userID string) (int, error)
+	MarkNotificationRead(ctx context.Context, userID, notificationID string, readAt time.Time) error
+	MarkAllNotificationsRead(ctx context.Context, userID string, readAt time.Time) error
+	UpdateNotificationPreferences(ctx context.Context, userID string,
+		preferences models.NotificationPreferences) error
+
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) error
 }
 
-func NewService(r repository, privateKey *rsa.PrivateKey, p currentUserInformationProvider) (*Service, error) {
+func NewService(r repository, privateKey *rsa.PrivateKey, p currentUserInformationProvider,
+	d tokenDenylist, m mailer, unverifiedAccess UnverifiedAccess,
+	deletedUserContent models.DeletedUserContent, b blobStore,
+	attachments models.AttachmentConfig) (*Service, error) {
 	return &Service{
 		repo:                           r,
 		privateKey:                     privateKey,
 		currentUserInformationProvider: p,
+		denylist:                       d,
+		mailer:                         m,
+		unverifiedAccess:               unverifiedAccess,
+		deletedUserContent:             deletedUserContent,
+		blobStore:                      b,
+		attachments:                    attachments,
 	}, nil
 }
 
@@ -6,7 +6,9 @@ package service_test
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/serhiihuberniuk/blog-api/models"
@@ -35,6 +37,34 @@ func (m *MockcurrentUserInformationProvider) EXPECT() *MockcurrentUserInformatio
 	return m.recorder
 }
 
+// GetCurrentSessionID mocks base method.
+func (m *MockcurrentUserInformationProvider) GetCurrentSessionID(ctx context.Context) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentSessionID", ctx)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetCurrentSessionID indicates an expected call of GetCurrentSessionID.
+func (mr *MockcurrentUserInformationProviderMockRecorder) GetCurrentSessionID(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentSessionID", reflect.TypeOf((*MockcurrentUserInformationProvider)(nil).GetCurrentSessionID), ctx)
+}
+
+// GetCurrentTokenID mocks base method.
+func (m *MockcurrentUserInformationProvider) GetCurrentTokenID(ctx context.Context) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentTokenID", ctx)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetCurrentTokenID indicates an expected call of GetCurrentTokenID.
+func (mr *MockcurrentUserInformationProviderMockRecorder) GetCurrentTokenID(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentTokenID", reflect.TypeOf((*MockcurrentUserInformationProvider)(nil).GetCurrentTokenID), ctx)
+}
+
 // GetCurrentUserID mocks base method.
 func (m *MockcurrentUserInformationProvider) GetCurrentUserID(ctx context.Context) string {
 	m.ctrl.T.Helper()
@@ -49,6 +79,175 @@ func (mr *MockcurrentUserInformationProviderMockRecorder) GetCurrentUserID(ctx i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentUserID", reflect.TypeOf((*MockcurrentUserInformationProvider)(nil).GetCurrentUserID), ctx)
 }
 
+// GetCurrentUserRole mocks base method.
+func (m *MockcurrentUserInformationProvider) GetCurrentUserRole(ctx context.Context) models.Role {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentUserRole", ctx)
+	ret0, _ := ret[0].(models.Role)
+	return ret0
+}
+
+// GetCurrentUserRole indicates an expected call of GetCurrentUserRole.
+func (mr *MockcurrentUserInformationProviderMockRecorder) GetCurrentUserRole(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentUserRole", reflect.TypeOf((*MockcurrentUserInformationProvider)(nil).GetCurrentUserRole), ctx)
+}
+
+// MocktokenDenylist is a mock of tokenDenylist interface.
+type MocktokenDenylist struct {
+	ctrl     *gomock.Controller
+	recorder *MocktokenDenylistMockRecorder
+}
+
+// MocktokenDenylistMockRecorder is the mock recorder for MocktokenDenylist.
+type MocktokenDenylistMockRecorder struct {
+	mock *MocktokenDenylist
+}
+
+// NewMocktokenDenylist creates a new mock instance.
+func NewMocktokenDenylist(ctrl *gomock.Controller) *MocktokenDenylist {
+	mock := &MocktokenDenylist{ctrl: ctrl}
+	mock.recorder = &MocktokenDenylistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktokenDenylist) EXPECT() *MocktokenDenylistMockRecorder {
+	return m.recorder
+}
+
+// Deny mocks base method.
+func (m *MocktokenDenylist) Deny(ctx context.Context, tokenID string, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deny", ctx, tokenID, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deny indicates an expected call of Deny.
+func (mr *MocktokenDenylistMockRecorder) Deny(ctx, tokenID, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deny", reflect.TypeOf((*MocktokenDenylist)(nil).Deny), ctx, tokenID, ttl)
+}
+
+// IsDenied mocks base method.
+func (m *MocktokenDenylist) IsDenied(ctx context.Context, tokenID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsDenied", ctx, tokenID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsDenied indicates an expected call of IsDenied.
+func (mr *MocktokenDenylistMockRecorder) IsDenied(ctx, tokenID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDenied", reflect.TypeOf((*MocktokenDenylist)(nil).IsDenied), ctx, tokenID)
+}
+
+// Mockmailer is a mock of mailer interface.
+type Mockmailer struct {
+	ctrl     *gomock.Controller
+	recorder *MockmailerMockRecorder
+}
+
+// MockmailerMockRecorder is the mock recorder for Mockmailer.
+type MockmailerMockRecorder struct {
+	mock *Mockmailer
+}
+
+// NewMockmailer creates a new mock instance.
+func NewMockmailer(ctrl *gomock.Controller) *Mockmailer {
+	mock := &Mockmailer{ctrl: ctrl}
+	mock.recorder = &MockmailerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockmailer) EXPECT() *MockmailerMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *Mockmailer) Send(ctx context.Context, to, subject, body string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, to, subject, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockmailerMockRecorder) Send(ctx, to, subject, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*Mockmailer)(nil).Send), ctx, to, subject, body)
+}
+
+// MockblobStore is a mock of blobStore interface.
+type MockblobStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockblobStoreMockRecorder
+}
+
+// MockblobStoreMockRecorder is the mock recorder for MockblobStore.
+type MockblobStoreMockRecorder struct {
+	mock *MockblobStore
+}
+
+// NewMockblobStore creates a new mock instance.
+func NewMockblobStore(ctrl *gomock.Controller) *MockblobStore {
+	mock := &MockblobStore{ctrl: ctrl}
+	mock.recorder = &MockblobStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockblobStore) EXPECT() *MockblobStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockblobStore) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockblobStoreMockRecorder) Delete(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockblobStore)(nil).Delete), ctx, key)
+}
+
+// Get mocks base method.
+func (m *MockblobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockblobStoreMockRecorder) Get(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockblobStore)(nil).Get), ctx, key)
+}
+
+// Put mocks base method.
+func (m *MockblobStore) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, key, r, contentType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockblobStoreMockRecorder) Put(ctx, key, r, contentType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockblobStore)(nil).Put), ctx, key, r, contentType)
+}
+
 // Mockrepository is a mock of repository interface.
 type Mockrepository struct {
 	ctrl     *gomock.Controller
@@ -72,6 +271,110 @@ func (m *Mockrepository) EXPECT() *MockrepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddReaction mocks base method.
+func (m *Mockrepository) AddReaction(ctx context.Context, reaction *models.Reaction) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReaction", ctx, reaction)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReaction indicates an expected call of AddReaction.
+func (mr *MockrepositoryMockRecorder) AddReaction(ctx, reaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReaction", reflect.TypeOf((*Mockrepository)(nil).AddReaction), ctx, reaction)
+}
+
+// AutocompleteTags mocks base method.
+func (m *Mockrepository) AutocompleteTags(ctx context.Context, prefix string, limit uint64) ([]*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AutocompleteTags", ctx, prefix, limit)
+	ret0, _ := ret[0].([]*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AutocompleteTags indicates an expected call of AutocompleteTags.
+func (mr *MockrepositoryMockRecorder) AutocompleteTags(ctx, prefix, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutocompleteTags", reflect.TypeOf((*Mockrepository)(nil).AutocompleteTags), ctx, prefix, limit)
+}
+
+// CountComments mocks base method.
+func (m *Mockrepository) CountComments(ctx context.Context, filter models.FilterComments) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountComments", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountComments indicates an expected call of CountComments.
+func (mr *MockrepositoryMockRecorder) CountComments(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountComments", reflect.TypeOf((*Mockrepository)(nil).CountComments), ctx, filter)
+}
+
+// CountPosts mocks base method.
+func (m *Mockrepository) CountPosts(ctx context.Context, filter models.FilterPosts) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPosts", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPosts indicates an expected call of CountPosts.
+func (mr *MockrepositoryMockRecorder) CountPosts(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPosts", reflect.TypeOf((*Mockrepository)(nil).CountPosts), ctx, filter)
+}
+
+// CountReactions mocks base method.
+func (m *Mockrepository) CountReactions(ctx context.Context, targetType models.ReactionTarget, targetIDs []string) (map[string]models.ReactionCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountReactions", ctx, targetType, targetIDs)
+	ret0, _ := ret[0].(map[string]models.ReactionCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountReactions indicates an expected call of CountReactions.
+func (mr *MockrepositoryMockRecorder) CountReactions(ctx, targetType, targetIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountReactions", reflect.TypeOf((*Mockrepository)(nil).CountReactions), ctx, targetType, targetIDs)
+}
+
+// CountUnreadNotifications mocks base method.
+func (m *Mockrepository) CountUnreadNotifications(ctx context.Context, userID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnreadNotifications", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnreadNotifications indicates an expected call of CountUnreadNotifications.
+func (mr *MockrepositoryMockRecorder) CountUnreadNotifications(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnreadNotifications", reflect.TypeOf((*Mockrepository)(nil).CountUnreadNotifications), ctx, userID)
+}
+
+// CreateAttachment mocks base method.
+func (m *Mockrepository) CreateAttachment(ctx context.Context, attachment *models.Attachment, quota int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttachment", ctx, attachment, quota)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAttachment indicates an expected call of CreateAttachment.
+func (mr *MockrepositoryMockRecorder) CreateAttachment(ctx, attachment, quota interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttachment", reflect.TypeOf((*Mockrepository)(nil).CreateAttachment), ctx, attachment, quota)
+}
+
 // CreateComment mocks base method.
 func (m *Mockrepository) CreateComment(ctx context.Context, comment *models.Comment) error {
 	m.ctrl.T.Helper()
@@ -86,6 +389,48 @@ func (mr *MockrepositoryMockRecorder) CreateComment(ctx, comment interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*Mockrepository)(nil).CreateComment), ctx, comment)
 }
 
+// CreateEmailVerificationToken mocks base method.
+func (m *Mockrepository) CreateEmailVerificationToken(ctx context.Context, token *models.EmailVerificationToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmailVerificationToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEmailVerificationToken indicates an expected call of CreateEmailVerificationToken.
+func (mr *MockrepositoryMockRecorder) CreateEmailVerificationToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmailVerificationToken", reflect.TypeOf((*Mockrepository)(nil).CreateEmailVerificationToken), ctx, token)
+}
+
+// CreateNotification mocks base method.
+func (m *Mockrepository) CreateNotification(ctx context.Context, notification *models.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotification", ctx, notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNotification indicates an expected call of CreateNotification.
+func (mr *MockrepositoryMockRecorder) CreateNotification(ctx, notification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotification", reflect.TypeOf((*Mockrepository)(nil).CreateNotification), ctx, notification)
+}
+
+// CreatePasswordResetToken mocks base method.
+func (m *Mockrepository) CreatePasswordResetToken(ctx context.Context, token *models.PasswordResetToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordResetToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePasswordResetToken indicates an expected call of CreatePasswordResetToken.
+func (mr *MockrepositoryMockRecorder) CreatePasswordResetToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordResetToken", reflect.TypeOf((*Mockrepository)(nil).CreatePasswordResetToken), ctx, token)
+}
+
 // CreatePost mocks base method.
 func (m *Mockrepository) CreatePost(ctx context.Context, post *models.Post) error {
 	m.ctrl.T.Helper()
@@ -100,6 +445,20 @@ func (mr *MockrepositoryMockRecorder) CreatePost(ctx, post interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePost", reflect.TypeOf((*Mockrepository)(nil).CreatePost), ctx, post)
 }
 
+// CreateRefreshToken mocks base method.
+func (m *Mockrepository) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefreshToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRefreshToken indicates an expected call of CreateRefreshToken.
+func (mr *MockrepositoryMockRecorder) CreateRefreshToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*Mockrepository)(nil).CreateRefreshToken), ctx, token)
+}
+
 // CreateUser mocks base method.
 func (m *Mockrepository) CreateUser(ctx context.Context, user *models.User) error {
 	m.ctrl.T.Helper()
@@ -114,46 +473,90 @@ func (mr *MockrepositoryMockRecorder) CreateUser(ctx, user interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*Mockrepository)(nil).CreateUser), ctx, user)
 }
 
+// DeleteAttachment mocks base method.
+func (m *Mockrepository) DeleteAttachment(ctx context.Context, attachmentID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, attachmentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockrepositoryMockRecorder) DeleteAttachment(ctx, attachmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*Mockrepository)(nil).DeleteAttachment), ctx, attachmentID)
+}
+
 // DeleteComment mocks base method.
-func (m *Mockrepository) DeleteComment(ctx context.Context, commentID string) error {
+func (m *Mockrepository) DeleteComment(ctx context.Context, commentID string, deletedAt time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteComment", ctx, commentID)
+	ret := m.ctrl.Call(m, "DeleteComment", ctx, commentID, deletedAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteComment indicates an expected call of DeleteComment.
-func (mr *MockrepositoryMockRecorder) DeleteComment(ctx, commentID interface{}) *gomock.Call {
+func (mr *MockrepositoryMockRecorder) DeleteComment(ctx, commentID, deletedAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*Mockrepository)(nil).DeleteComment), ctx, commentID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*Mockrepository)(nil).DeleteComment), ctx, commentID, deletedAt)
 }
 
 // DeletePost mocks base method.
-func (m *Mockrepository) DeletePost(ctx context.Context, postID string) error {
+func (m *Mockrepository) DeletePost(ctx context.Context, postID string, deletedAt time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePost", ctx, postID)
+	ret := m.ctrl.Call(m, "DeletePost", ctx, postID, deletedAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeletePost indicates an expected call of DeletePost.
-func (mr *MockrepositoryMockRecorder) DeletePost(ctx, postID interface{}) *gomock.Call {
+func (mr *MockrepositoryMockRecorder) DeletePost(ctx, postID, deletedAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePost", reflect.TypeOf((*Mockrepository)(nil).DeletePost), ctx, postID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePost", reflect.TypeOf((*Mockrepository)(nil).DeletePost), ctx, postID, deletedAt)
 }
 
 // DeleteUser mocks base method.
-func (m *Mockrepository) DeleteUser(ctx context.Context, userID string) error {
+func (m *Mockrepository) DeleteUser(ctx context.Context, userID string, deletedAt time.Time, content models.DeletedUserContent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", ctx, userID)
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, userID, deletedAt, content)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockrepositoryMockRecorder) DeleteUser(ctx, userID interface{}) *gomock.Call {
+func (mr *MockrepositoryMockRecorder) DeleteUser(ctx, userID, deletedAt, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*Mockrepository)(nil).DeleteUser), ctx, userID, deletedAt, content)
+}
+
+// Follow mocks base method.
+func (m *Mockrepository) Follow(ctx context.Context, follow *models.Follow) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Follow", ctx, follow)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Follow indicates an expected call of Follow.
+func (mr *MockrepositoryMockRecorder) Follow(ctx, follow interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Follow", reflect.TypeOf((*Mockrepository)(nil).Follow), ctx, follow)
+}
+
+// GetAttachment mocks base method.
+func (m *Mockrepository) GetAttachment(ctx context.Context, attachmentID string) (*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachment", ctx, attachmentID)
+	ret0, _ := ret[0].(*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockrepositoryMockRecorder) GetAttachment(ctx, attachmentID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*Mockrepository)(nil).DeleteUser), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*Mockrepository)(nil).GetAttachment), ctx, attachmentID)
 }
 
 // GetComment mocks base method.
@@ -171,107 +574,797 @@ func (mr *MockrepositoryMockRecorder) GetComment(ctx, commentID interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComment", reflect.TypeOf((*Mockrepository)(nil).GetComment), ctx, commentID)
 }
 
-// GetPost mocks base method.
-func (m *Mockrepository) GetPost(ctx context.Context, postID string) (*models.Post, error) {
+// GetCommentRevision mocks base method.
+func (m *Mockrepository) GetCommentRevision(ctx context.Context, revisionID string) (*models.CommentRevision, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPost", ctx, postID)
-	ret0, _ := ret[0].(*models.Post)
+	ret := m.ctrl.Call(m, "GetCommentRevision", ctx, revisionID)
+	ret0, _ := ret[0].(*models.CommentRevision)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPost indicates an expected call of GetPost.
-func (mr *MockrepositoryMockRecorder) GetPost(ctx, postID interface{}) *gomock.Call {
+// GetCommentRevision indicates an expected call of GetCommentRevision.
+func (mr *MockrepositoryMockRecorder) GetCommentRevision(ctx, revisionID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPost", reflect.TypeOf((*Mockrepository)(nil).GetPost), ctx, postID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentRevision", reflect.TypeOf((*Mockrepository)(nil).GetCommentRevision), ctx, revisionID)
 }
 
-// GetUser mocks base method.
-func (m *Mockrepository) GetUser(ctx context.Context, userID string) (*models.User, error) {
+// GetCommentThread mocks base method.
+func (m *Mockrepository) GetCommentThread(ctx context.Context, commentID string) (*models.CommentThread, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", ctx, userID)
-	ret0, _ := ret[0].(*models.User)
+	ret := m.ctrl.Call(m, "GetCommentThread", ctx, commentID)
+	ret0, _ := ret[0].(*models.CommentThread)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockrepositoryMockRecorder) GetUser(ctx, userID interface{}) *gomock.Call {
+// GetCommentThread indicates an expected call of GetCommentThread.
+func (mr *MockrepositoryMockRecorder) GetCommentThread(ctx, commentID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*Mockrepository)(nil).GetUser), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentThread", reflect.TypeOf((*Mockrepository)(nil).GetCommentThread), ctx, commentID)
 }
 
-// ListComments mocks base method.
-func (m *Mockrepository) ListComments(ctx context.Context, pagination models.Pagination, filter models.FilterComments, sort models.SortComments) ([]*models.Comment, error) {
+// GetDeletedComment mocks base method.
+func (m *Mockrepository) GetDeletedComment(ctx context.Context, commentID string) (*models.Comment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListComments", ctx, pagination, filter, sort)
-	ret0, _ := ret[0].([]*models.Comment)
+	ret := m.ctrl.Call(m, "GetDeletedComment", ctx, commentID)
+	ret0, _ := ret[0].(*models.Comment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListComments indicates an expected call of ListComments.
-func (mr *MockrepositoryMockRecorder) ListComments(ctx, pagination, filter, sort interface{}) *gomock.Call {
+// GetDeletedComment indicates an expected call of GetDeletedComment.
+func (mr *MockrepositoryMockRecorder) GetDeletedComment(ctx, commentID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComments", reflect.TypeOf((*Mockrepository)(nil).ListComments), ctx, pagination, filter, sort)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedComment", reflect.TypeOf((*Mockrepository)(nil).GetDeletedComment), ctx, commentID)
 }
 
-// ListPosts mocks base method.
-func (m *Mockrepository) ListPosts(ctx context.Context, pagination models.Pagination, filter models.FilterPosts, sort models.SortPosts) ([]*models.Post, error) {
+// GetDeletedPost mocks base method.
+func (m *Mockrepository) GetDeletedPost(ctx context.Context, postID string) (*models.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPosts", ctx, pagination, filter, sort)
-	ret0, _ := ret[0].([]*models.Post)
+	ret := m.ctrl.Call(m, "GetDeletedPost", ctx, postID)
+	ret0, _ := ret[0].(*models.Post)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPosts indicates an expected call of ListPosts.
-func (mr *MockrepositoryMockRecorder) ListPosts(ctx, pagination, filter, sort interface{}) *gomock.Call {
+// GetDeletedPost indicates an expected call of GetDeletedPost.
+func (mr *MockrepositoryMockRecorder) GetDeletedPost(ctx, postID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPosts", reflect.TypeOf((*Mockrepository)(nil).ListPosts), ctx, pagination, filter, sort)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedPost", reflect.TypeOf((*Mockrepository)(nil).GetDeletedPost), ctx, postID)
 }
 
-// Login mocks base method.
-func (m *Mockrepository) Login(ctx context.Context, email string) (*models.User, error) {
+// GetDeletedUser mocks base method.
+func (m *Mockrepository) GetDeletedUser(ctx context.Context, userID string) (*models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", ctx, email)
+	ret := m.ctrl.Call(m, "GetDeletedUser", ctx, userID)
 	ret0, _ := ret[0].(*models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Login indicates an expected call of Login.
-func (mr *MockrepositoryMockRecorder) Login(ctx, email interface{}) *gomock.Call {
+// GetDeletedUser indicates an expected call of GetDeletedUser.
+func (mr *MockrepositoryMockRecorder) GetDeletedUser(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*Mockrepository)(nil).Login), ctx, email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedUser", reflect.TypeOf((*Mockrepository)(nil).GetDeletedUser), ctx, userID)
 }
 
-// UpdateComment mocks base method.
-func (m *Mockrepository) UpdateComment(ctx context.Context, comment *models.Comment) error {
+// GetEmailVerificationToken mocks base method.
+func (m *Mockrepository) GetEmailVerificationToken(ctx context.Context, tokenHash string) (*models.EmailVerificationToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateComment", ctx, comment)
+	ret := m.ctrl.Call(m, "GetEmailVerificationToken", ctx, tokenHash)
+	ret0, _ := ret[0].(*models.EmailVerificationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmailVerificationToken indicates an expected call of GetEmailVerificationToken.
+func (mr *MockrepositoryMockRecorder) GetEmailVerificationToken(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailVerificationToken", reflect.TypeOf((*Mockrepository)(nil).GetEmailVerificationToken), ctx, tokenHash)
+}
+
+// GetPasswordResetToken mocks base method.
+func (m *Mockrepository) GetPasswordResetToken(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordResetToken", ctx, tokenHash)
+	ret0, _ := ret[0].(*models.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordResetToken indicates an expected call of GetPasswordResetToken.
+func (mr *MockrepositoryMockRecorder) GetPasswordResetToken(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordResetToken", reflect.TypeOf((*Mockrepository)(nil).GetPasswordResetToken), ctx, tokenHash)
+}
+
+// GetPost mocks base method.
+func (m *Mockrepository) GetPost(ctx context.Context, postID string) (*models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPost", ctx, postID)
+	ret0, _ := ret[0].(*models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPost indicates an expected call of GetPost.
+func (mr *MockrepositoryMockRecorder) GetPost(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPost", reflect.TypeOf((*Mockrepository)(nil).GetPost), ctx, postID)
+}
+
+// GetPostBySlug mocks base method.
+func (m *Mockrepository) GetPostBySlug(ctx context.Context, slug string) (*models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostBySlug", ctx, slug)
+	ret0, _ := ret[0].(*models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostBySlug indicates an expected call of GetPostBySlug.
+func (mr *MockrepositoryMockRecorder) GetPostBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostBySlug", reflect.TypeOf((*Mockrepository)(nil).GetPostBySlug), ctx, slug)
+}
+
+// GetPostRevision mocks base method.
+func (m *Mockrepository) GetPostRevision(ctx context.Context, revisionID string) (*models.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostRevision", ctx, revisionID)
+	ret0, _ := ret[0].(*models.PostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostRevision indicates an expected call of GetPostRevision.
+func (mr *MockrepositoryMockRecorder) GetPostRevision(ctx, revisionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostRevision", reflect.TypeOf((*Mockrepository)(nil).GetPostRevision), ctx, revisionID)
+}
+
+// GetRefreshToken mocks base method.
+func (m *Mockrepository) GetRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefreshToken", ctx, tokenHash)
+	ret0, _ := ret[0].(*models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefreshToken indicates an expected call of GetRefreshToken.
+func (mr *MockrepositoryMockRecorder) GetRefreshToken(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshToken", reflect.TypeOf((*Mockrepository)(nil).GetRefreshToken), ctx, tokenHash)
+}
+
+// GetTag mocks base method.
+func (m *Mockrepository) GetTag(ctx context.Context, name string) (*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTag", ctx, name)
+	ret0, _ := ret[0].(*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTag indicates an expected call of GetTag.
+func (mr *MockrepositoryMockRecorder) GetTag(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTag", reflect.TypeOf((*Mockrepository)(nil).GetTag), ctx, name)
+}
+
+// GetTagAliases mocks base method.
+func (m *Mockrepository) GetTagAliases(ctx context.Context, tags []string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagAliases", ctx, tags)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagAliases indicates an expected call of GetTagAliases.
+func (mr *MockrepositoryMockRecorder) GetTagAliases(ctx, tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagAliases", reflect.TypeOf((*Mockrepository)(nil).GetTagAliases), ctx, tags)
+}
+
+// GetUser mocks base method.
+func (m *Mockrepository) GetUser(ctx context.Context, userID string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, userID)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockrepositoryMockRecorder) GetUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*Mockrepository)(nil).GetUser), ctx, userID)
+}
+
+// GetUserByEmail mocks base method.
+func (m *Mockrepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockrepositoryMockRecorder) GetUserByEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*Mockrepository)(nil).GetUserByEmail), ctx, email)
+}
+
+// GetUserByUsername mocks base method.
+func (m *Mockrepository) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsername", ctx, username)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsername indicates an expected call of GetUserByUsername.
+func (mr *MockrepositoryMockRecorder) GetUserByUsername(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*Mockrepository)(nil).GetUserByUsername), ctx, username)
+}
+
+// GetUserPassword mocks base method.
+func (m *Mockrepository) GetUserPassword(ctx context.Context, userID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPassword", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPassword indicates an expected call of GetUserPassword.
+func (mr *MockrepositoryMockRecorder) GetUserPassword(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPassword", reflect.TypeOf((*Mockrepository)(nil).GetUserPassword), ctx, userID)
+}
+
+// GetUsersByUsernames mocks base method.
+func (m *Mockrepository) GetUsersByUsernames(ctx context.Context, usernames []string) ([]*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByUsernames", ctx, usernames)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByUsernames indicates an expected call of GetUsersByUsernames.
+func (mr *MockrepositoryMockRecorder) GetUsersByUsernames(ctx, usernames interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByUsernames", reflect.TypeOf((*Mockrepository)(nil).GetUsersByUsernames), ctx, usernames)
+}
+
+// IsEmailTaken mocks base method.
+func (m *Mockrepository) IsEmailTaken(ctx context.Context, email string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEmailTaken", ctx, email)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsEmailTaken indicates an expected call of IsEmailTaken.
+func (mr *MockrepositoryMockRecorder) IsEmailTaken(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEmailTaken", reflect.TypeOf((*Mockrepository)(nil).IsEmailTaken), ctx, email)
+}
+
+// IsSlugTaken mocks base method.
+func (m *Mockrepository) IsSlugTaken(ctx context.Context, slug, postID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSlugTaken", ctx, slug, postID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSlugTaken indicates an expected call of IsSlugTaken.
+func (mr *MockrepositoryMockRecorder) IsSlugTaken(ctx, slug, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSlugTaken", reflect.TypeOf((*Mockrepository)(nil).IsSlugTaken), ctx, slug, postID)
+}
+
+// IsUsernameTaken mocks base method.
+func (m *Mockrepository) IsUsernameTaken(ctx context.Context, username string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUsernameTaken", ctx, username)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsUsernameTaken indicates an expected call of IsUsernameTaken.
+func (mr *MockrepositoryMockRecorder) IsUsernameTaken(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUsernameTaken", reflect.TypeOf((*Mockrepository)(nil).IsUsernameTaken), ctx, username)
+}
+
+// ListAttachments mocks base method.
+func (m *Mockrepository) ListAttachments(ctx context.Context, postID string) ([]*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttachments", ctx, postID)
+	ret0, _ := ret[0].([]*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttachments indicates an expected call of ListAttachments.
+func (mr *MockrepositoryMockRecorder) ListAttachments(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttachments", reflect.TypeOf((*Mockrepository)(nil).ListAttachments), ctx, postID)
+}
+
+// ListCommentRevisions mocks base method.
+func (m *Mockrepository) ListCommentRevisions(ctx context.Context, commentID string) ([]*models.CommentRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCommentRevisions", ctx, commentID)
+	ret0, _ := ret[0].([]*models.CommentRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCommentRevisions indicates an expected call of ListCommentRevisions.
+func (mr *MockrepositoryMockRecorder) ListCommentRevisions(ctx, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCommentRevisions", reflect.TypeOf((*Mockrepository)(nil).ListCommentRevisions), ctx, commentID)
+}
+
+// ListComments mocks base method.
+func (m *Mockrepository) ListComments(ctx context.Context, pagination models.Pagination, filter models.FilterComments, sort models.SortComments) ([]*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComments", ctx, pagination, filter, sort)
+	ret0, _ := ret[0].([]*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListComments indicates an expected call of ListComments.
+func (mr *MockrepositoryMockRecorder) ListComments(ctx, pagination, filter, sort interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComments", reflect.TypeOf((*Mockrepository)(nil).ListComments), ctx, pagination, filter, sort)
+}
+
+// ListFollowers mocks base method.
+func (m *Mockrepository) ListFollowers(ctx context.Context, userID string, pagination models.Pagination) ([]*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowers", ctx, userID, pagination)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFollowers indicates an expected call of ListFollowers.
+func (mr *MockrepositoryMockRecorder) ListFollowers(ctx, userID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowers", reflect.TypeOf((*Mockrepository)(nil).ListFollowers), ctx, userID, pagination)
+}
+
+// ListFollowing mocks base method.
+func (m *Mockrepository) ListFollowing(ctx context.Context, userID string, pagination models.Pagination) ([]*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowing", ctx, userID, pagination)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFollowing indicates an expected call of ListFollowing.
+func (mr *MockrepositoryMockRecorder) ListFollowing(ctx, userID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowing", reflect.TypeOf((*Mockrepository)(nil).ListFollowing), ctx, userID, pagination)
+}
+
+// ListNotifications mocks base method.
+func (m *Mockrepository) ListNotifications(ctx context.Context, userID string, unreadOnly bool, pagination models.Pagination) ([]*models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotifications", ctx, userID, unreadOnly, pagination)
+	ret0, _ := ret[0].([]*models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotifications indicates an expected call of ListNotifications.
+func (mr *MockrepositoryMockRecorder) ListNotifications(ctx, userID, unreadOnly, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotifications", reflect.TypeOf((*Mockrepository)(nil).ListNotifications), ctx, userID, unreadOnly, pagination)
+}
+
+// ListPendingAttachments mocks base method.
+func (m *Mockrepository) ListPendingAttachments(ctx context.Context, limit uint64) ([]*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingAttachments", ctx, limit)
+	ret0, _ := ret[0].([]*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingAttachments indicates an expected call of ListPendingAttachments.
+func (mr *MockrepositoryMockRecorder) ListPendingAttachments(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingAttachments", reflect.TypeOf((*Mockrepository)(nil).ListPendingAttachments), ctx, limit)
+}
+
+// ListPostRevisions mocks base method.
+func (m *Mockrepository) ListPostRevisions(ctx context.Context, postID string) ([]*models.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPostRevisions", ctx, postID)
+	ret0, _ := ret[0].([]*models.PostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPostRevisions indicates an expected call of ListPostRevisions.
+func (mr *MockrepositoryMockRecorder) ListPostRevisions(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPostRevisions", reflect.TypeOf((*Mockrepository)(nil).ListPostRevisions), ctx, postID)
+}
+
+// ListPosts mocks base method.
+func (m *Mockrepository) ListPosts(ctx context.Context, pagination models.Pagination, filter models.FilterPosts, sort models.SortPosts) ([]*models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPosts", ctx, pagination, filter, sort)
+	ret0, _ := ret[0].([]*models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPosts indicates an expected call of ListPosts.
+func (mr *MockrepositoryMockRecorder) ListPosts(ctx, pagination, filter, sort interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPosts", reflect.TypeOf((*Mockrepository)(nil).ListPosts), ctx, pagination, filter, sort)
+}
+
+// ListPurgeableAttachments mocks base method.
+func (m *Mockrepository) ListPurgeableAttachments(ctx context.Context, deletedBefore time.Time) ([]*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurgeableAttachments", ctx, deletedBefore)
+	ret0, _ := ret[0].([]*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPurgeableAttachments indicates an expected call of ListPurgeableAttachments.
+func (mr *MockrepositoryMockRecorder) ListPurgeableAttachments(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurgeableAttachments", reflect.TypeOf((*Mockrepository)(nil).ListPurgeableAttachments), ctx, deletedBefore)
+}
+
+// ListTags mocks base method.
+func (m *Mockrepository) ListTags(ctx context.Context, pagination models.Pagination) ([]*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", ctx, pagination)
+	ret0, _ := ret[0].([]*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockrepositoryMockRecorder) ListTags(ctx, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*Mockrepository)(nil).ListTags), ctx, pagination)
+}
+
+// Login mocks base method.
+func (m *Mockrepository) Login(ctx context.Context, email string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx, email)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockrepositoryMockRecorder) Login(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*Mockrepository)(nil).Login), ctx, email)
+}
+
+// MarkAllNotificationsRead mocks base method.
+func (m *Mockrepository) MarkAllNotificationsRead(ctx context.Context, userID string, readAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllNotificationsRead", ctx, userID, readAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllNotificationsRead indicates an expected call of MarkAllNotificationsRead.
+func (mr *MockrepositoryMockRecorder) MarkAllNotificationsRead(ctx, userID, readAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllNotificationsRead", reflect.TypeOf((*Mockrepository)(nil).MarkAllNotificationsRead), ctx, userID, readAt)
+}
+
+// MarkNotificationRead mocks base method.
+func (m *Mockrepository) MarkNotificationRead(ctx context.Context, userID, notificationID string, readAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationRead", ctx, userID, notificationID, readAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationRead indicates an expected call of MarkNotificationRead.
+func (mr *MockrepositoryMockRecorder) MarkNotificationRead(ctx, userID, notificationID, readAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*Mockrepository)(nil).MarkNotificationRead), ctx, userID, notificationID, readAt)
+}
+
+// MergeTags mocks base method.
+func (m *Mockrepository) MergeTags(ctx context.Context, from, into string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeTags", ctx, from, into)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeTags indicates an expected call of MergeTags.
+func (mr *MockrepositoryMockRecorder) MergeTags(ctx, from, into interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTags", reflect.TypeOf((*Mockrepository)(nil).MergeTags), ctx, from, into)
+}
+
+// PurgeDeleted mocks base method.
+func (m *Mockrepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, deletedBefore)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockrepositoryMockRecorder) PurgeDeleted(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*Mockrepository)(nil).PurgeDeleted), ctx, deletedBefore)
+}
+
+// RemoveReaction mocks base method.
+func (m *Mockrepository) RemoveReaction(ctx context.Context, reaction *models.Reaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReaction", ctx, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveReaction indicates an expected call of RemoveReaction.
+func (mr *MockrepositoryMockRecorder) RemoveReaction(ctx, reaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReaction", reflect.TypeOf((*Mockrepository)(nil).RemoveReaction), ctx, reaction)
+}
+
+// RenameTag mocks base method.
+func (m *Mockrepository) RenameTag(ctx context.Context, name, newName string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTag", ctx, name, newName)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockrepositoryMockRecorder) RenameTag(ctx, name, newName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*Mockrepository)(nil).RenameTag), ctx, name, newName)
+}
+
+// RestoreComment mocks base method.
+func (m *Mockrepository) RestoreComment(ctx context.Context, commentID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreComment", ctx, commentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreComment indicates an expected call of RestoreComment.
+func (mr *MockrepositoryMockRecorder) RestoreComment(ctx, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreComment", reflect.TypeOf((*Mockrepository)(nil).RestoreComment), ctx, commentID)
+}
+
+// RestorePost mocks base method.
+func (m *Mockrepository) RestorePost(ctx context.Context, postID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestorePost", ctx, postID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestorePost indicates an expected call of RestorePost.
+func (mr *MockrepositoryMockRecorder) RestorePost(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestorePost", reflect.TypeOf((*Mockrepository)(nil).RestorePost), ctx, postID)
+}
+
+// RestoreUser mocks base method.
+func (m *Mockrepository) RestoreUser(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockrepositoryMockRecorder) RestoreUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*Mockrepository)(nil).RestoreUser), ctx, userID)
+}
+
+// RevokeOtherRefreshTokens mocks base method.
+func (m *Mockrepository) RevokeOtherRefreshTokens(ctx context.Context, userID, familyID string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOtherRefreshTokens", ctx, userID, familyID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeOtherRefreshTokens indicates an expected call of RevokeOtherRefreshTokens.
+func (mr *MockrepositoryMockRecorder) RevokeOtherRefreshTokens(ctx, userID, familyID, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOtherRefreshTokens", reflect.TypeOf((*Mockrepository)(nil).RevokeOtherRefreshTokens), ctx, userID, familyID, revokedAt)
+}
+
+// RevokeRefreshToken mocks base method.
+func (m *Mockrepository) RevokeRefreshToken(ctx context.Context, tokenID string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshToken", ctx, tokenID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshToken indicates an expected call of RevokeRefreshToken.
+func (mr *MockrepositoryMockRecorder) RevokeRefreshToken(ctx, tokenID, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshToken", reflect.TypeOf((*Mockrepository)(nil).RevokeRefreshToken), ctx, tokenID, revokedAt)
+}
+
+// RevokeRefreshTokenFamily mocks base method.
+func (m *Mockrepository) RevokeRefreshTokenFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshTokenFamily", ctx, familyID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshTokenFamily indicates an expected call of RevokeRefreshTokenFamily.
+func (mr *MockrepositoryMockRecorder) RevokeRefreshTokenFamily(ctx, familyID, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshTokenFamily", reflect.TypeOf((*Mockrepository)(nil).RevokeRefreshTokenFamily), ctx, familyID, revokedAt)
+}
+
+// RevokeUserRefreshTokens mocks base method.
+func (m *Mockrepository) RevokeUserRefreshTokens(ctx context.Context, userID string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserRefreshTokens", ctx, userID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserRefreshTokens indicates an expected call of RevokeUserRefreshTokens.
+func (mr *MockrepositoryMockRecorder) RevokeUserRefreshTokens(ctx, userID, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserRefreshTokens", reflect.TypeOf((*Mockrepository)(nil).RevokeUserRefreshTokens), ctx, userID, revokedAt)
+}
+
+// Search mocks base method.
+func (m *Mockrepository) Search(ctx context.Context, query models.SearchQuery, pagination models.Pagination) ([]*models.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, pagination)
+	ret0, _ := ret[0].([]*models.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockrepositoryMockRecorder) Search(ctx, query, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*Mockrepository)(nil).Search), ctx, query, pagination)
+}
+
+// SumAttachmentSizes mocks base method.
+func (m *Mockrepository) SumAttachmentSizes(ctx context.Context, userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumAttachmentSizes", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumAttachmentSizes indicates an expected call of SumAttachmentSizes.
+func (mr *MockrepositoryMockRecorder) SumAttachmentSizes(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumAttachmentSizes", reflect.TypeOf((*Mockrepository)(nil).SumAttachmentSizes), ctx, userID)
+}
+
+// Unfollow mocks base method.
+func (m *Mockrepository) Unfollow(ctx context.Context, followerID, followeeID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unfollow", ctx, followerID, followeeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unfollow indicates an expected call of Unfollow.
+func (mr *MockrepositoryMockRecorder) Unfollow(ctx, followerID, followeeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unfollow", reflect.TypeOf((*Mockrepository)(nil).Unfollow), ctx, followerID, followeeID)
+}
+
+// UpdateAttachmentVariants mocks base method.
+func (m *Mockrepository) UpdateAttachmentVariants(ctx context.Context, attachmentID string, status models.VariantStatus, variants []models.AttachmentVariant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAttachmentVariants", ctx, attachmentID, status, variants)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAttachmentVariants indicates an expected call of UpdateAttachmentVariants.
+func (mr *MockrepositoryMockRecorder) UpdateAttachmentVariants(ctx, attachmentID, status, variants interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAttachmentVariants", reflect.TypeOf((*Mockrepository)(nil).UpdateAttachmentVariants), ctx, attachmentID, status, variants)
+}
+
+// UpdateComment mocks base method.
+func (m *Mockrepository) UpdateComment(ctx context.Context, comment *models.Comment, revision *models.CommentRevision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", ctx, comment, revision)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateComment indicates an expected call of UpdateComment.
-func (mr *MockrepositoryMockRecorder) UpdateComment(ctx, comment interface{}) *gomock.Call {
+func (mr *MockrepositoryMockRecorder) UpdateComment(ctx, comment, revision interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*Mockrepository)(nil).UpdateComment), ctx, comment)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*Mockrepository)(nil).UpdateComment), ctx, comment, revision)
+}
+
+// UpdateNotificationPreferences mocks base method.
+func (m *Mockrepository) UpdateNotificationPreferences(ctx context.Context, userID string, preferences models.NotificationPreferences) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotificationPreferences", ctx, userID, preferences)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNotificationPreferences indicates an expected call of UpdateNotificationPreferences.
+func (mr *MockrepositoryMockRecorder) UpdateNotificationPreferences(ctx, userID, preferences interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationPreferences", reflect.TypeOf((*Mockrepository)(nil).UpdateNotificationPreferences), ctx, userID, preferences)
 }
 
 // UpdatePost mocks base method.
-func (m *Mockrepository) UpdatePost(ctx context.Context, post *models.Post) error {
+func (m *Mockrepository) UpdatePost(ctx context.Context, post *models.Post, revision *models.PostRevision) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePost", ctx, post)
+	ret := m.ctrl.Call(m, "UpdatePost", ctx, post, revision)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdatePost indicates an expected call of UpdatePost.
-func (mr *MockrepositoryMockRecorder) UpdatePost(ctx, post interface{}) *gomock.Call {
+func (mr *MockrepositoryMockRecorder) UpdatePost(ctx, post, revision interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePost", reflect.TypeOf((*Mockrepository)(nil).UpdatePost), ctx, post)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePost", reflect.TypeOf((*Mockrepository)(nil).UpdatePost), ctx, post, revision)
+}
+
+// UpdatePostStatus mocks base method.
+func (m *Mockrepository) UpdatePostStatus(ctx context.Context, postID string, status models.PostStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePostStatus", ctx, postID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePostStatus indicates an expected call of UpdatePostStatus.
+func (mr *MockrepositoryMockRecorder) UpdatePostStatus(ctx, postID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePostStatus", reflect.TypeOf((*Mockrepository)(nil).UpdatePostStatus), ctx, postID, status)
 }
 
 // UpdateUser mocks base method.
@@ -287,3 +1380,73 @@ func (mr *MockrepositoryMockRecorder) UpdateUser(ctx, user interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*Mockrepository)(nil).UpdateUser), ctx, user)
 }
+
+// UpdateUserPassword mocks base method.
+func (m *Mockrepository) UpdateUserPassword(ctx context.Context, userID, password string, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPassword", ctx, userID, password, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPassword indicates an expected call of UpdateUserPassword.
+func (mr *MockrepositoryMockRecorder) UpdateUserPassword(ctx, userID, password, updatedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*Mockrepository)(nil).UpdateUserPassword), ctx, userID, password, updatedAt)
+}
+
+// UpdateUserRole mocks base method.
+func (m *Mockrepository) UpdateUserRole(ctx context.Context, userID string, role models.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", ctx, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockrepositoryMockRecorder) UpdateUserRole(ctx, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*Mockrepository)(nil).UpdateUserRole), ctx, userID, role)
+}
+
+// UseEmailVerificationToken mocks base method.
+func (m *Mockrepository) UseEmailVerificationToken(ctx context.Context, tokenID string, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseEmailVerificationToken", ctx, tokenID, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseEmailVerificationToken indicates an expected call of UseEmailVerificationToken.
+func (mr *MockrepositoryMockRecorder) UseEmailVerificationToken(ctx, tokenID, usedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseEmailVerificationToken", reflect.TypeOf((*Mockrepository)(nil).UseEmailVerificationToken), ctx, tokenID, usedAt)
+}
+
+// UsePasswordResetToken mocks base method.
+func (m *Mockrepository) UsePasswordResetToken(ctx context.Context, tokenID string, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsePasswordResetToken", ctx, tokenID, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UsePasswordResetToken indicates an expected call of UsePasswordResetToken.
+func (mr *MockrepositoryMockRecorder) UsePasswordResetToken(ctx, tokenID, usedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsePasswordResetToken", reflect.TypeOf((*Mockrepository)(nil).UsePasswordResetToken), ctx, tokenID, usedAt)
+}
+
+// VerifyUserEmail mocks base method.
+func (m *Mockrepository) VerifyUserEmail(ctx context.Context, userID, email string, verifiedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyUserEmail", ctx, userID, email, verifiedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyUserEmail indicates an expected call of VerifyUserEmail.
+func (mr *MockrepositoryMockRecorder) VerifyUserEmail(ctx, userID, email, verifiedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyUserEmail", reflect.TypeOf((*Mockrepository)(nil).VerifyUserEmail), ctx, userID, email, verifiedAt)
+}
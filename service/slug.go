@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+// GetPostBySlug returns the post with its reactions by its current or previous slug
+// if it is visible to the current user.
+func (s *Service) GetPostBySlug(ctx context.Context, slug string) (*models.Post, error) {
+	post, err := s.repo.GetPostBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get post: %w", err)
+	}
+
+	if !s.isPostVisible(ctx, post) {
+		return nil, fmt.Errorf("cannot get post: %w", models.ErrNotFound)
+	}
+
+	if err := s.countPostsReactions(ctx, []*models.Post{post}); err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+// GetPostByIDOrSlug returns the post referenced either by its ID or by its slug.
+func (s *Service) GetPostByIDOrSlug(ctx context.Context, ref string) (*models.Post, error) {
+	if models.IsPostID(ref) {
+		return s.GetPost(ctx, ref)
+	}
+
+	return s.GetPostBySlug(ctx, ref)
+}
+
+// maxSlugCandidates limits the numbered slugs tried for the title of a post.
+const maxSlugCandidates = 100
+
+// savePostWithSlug chooses the slug for the title of the post and saves the post with it. The current slug
+// is kept while it is made of the same title, otherwise the first slug which is not taken by other posts
+// is chosen. The next slug is tried if another post takes the chosen one before the post is saved.
+func (s *Service) savePostWithSlug(ctx context.Context, post *models.Post, save func() error) error {
+	base := models.Slugify(post.Title)
+
+	if post.Slug != "" && models.HasSlugBase(post.Slug, base) {
+		return save()
+	}
+
+	for n := 1; n <= maxSlugCandidates; n++ {
+		slug := models.SlugCandidate(base, n)
+
+		taken, err := s.repo.IsSlugTaken(ctx, slug, post.ID)
+		if err != nil {
+			return fmt.Errorf("cannot check slug: %w", err)
+		}
+
+		if taken {
+			continue
+		}
+
+		post.Slug = slug
+
+		if err := save(); !errors.Is(err, models.ErrSlugTaken) {
+			return err
+		}
+	}
+
+	return fmt.Errorf("cannot choose slug: %w", models.ErrSlugTaken)
+}
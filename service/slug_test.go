@@ -0,0 +1,191 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/serhiihuberniuk/blog-api/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_CreatePost_Slug(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	repoMock := NewMockrepository(ctrl)
+	providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+	providerMock.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return("315b6c09-36ff-4519-8579-492f3ae2a3be").AnyTimes()
+	providerMock.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser).AnyTimes()
+	gomock.InOrder(
+		repoMock.EXPECT().IsSlugTaken(gomock.Eq(ctx), gomock.Eq("cafe-au-lait"), gomock.Any()).Return(true, nil),
+		repoMock.EXPECT().IsSlugTaken(gomock.Eq(ctx), gomock.Eq("cafe-au-lait-2"), gomock.Any()).Return(true, nil),
+		repoMock.EXPECT().IsSlugTaken(gomock.Eq(ctx), gomock.Eq("cafe-au-lait-3"), gomock.Any()).Return(false, nil),
+	)
+	repoMock.EXPECT().CreatePost(gomock.Eq(ctx), gomock.Any()).DoAndReturn(
+		func(_ context.Context, post *models.Post) error {
+			assert.Equal(t, "cafe-au-lait-3", post.Slug)
+
+			return nil
+		})
+
+	serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+		models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+	if err != nil {
+		t.Log(fmt.Errorf("error occurred while init service: %w", err))
+		t.Fail()
+
+		return
+	}
+
+	_, err = serv.CreatePost(ctx, models.CreatePostPayload{
+		Title:       "Café au lait",
+		Description: "description",
+	})
+	assert.NoError(t, err)
+}
+
+func TestService_CreatePost_SlugTakenConcurrently(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	repoMock := NewMockrepository(ctrl)
+	providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+	providerMock.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return("315b6c09-36ff-4519-8579-492f3ae2a3be").AnyTimes()
+	providerMock.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser).AnyTimes()
+	gomock.InOrder(
+		repoMock.EXPECT().IsSlugTaken(gomock.Eq(ctx), gomock.Eq("cafe-au-lait"), gomock.Any()).Return(false, nil),
+		repoMock.EXPECT().CreatePost(gomock.Eq(ctx), gomock.Any()).DoAndReturn(
+			func(_ context.Context, post *models.Post) error {
+				assert.Equal(t, "cafe-au-lait", post.Slug)
+
+				return fmt.Errorf("cannot create post: %w", models.ErrSlugTaken)
+			}),
+		repoMock.EXPECT().IsSlugTaken(gomock.Eq(ctx), gomock.Eq("cafe-au-lait-2"), gomock.Any()).Return(false, nil),
+		repoMock.EXPECT().CreatePost(gomock.Eq(ctx), gomock.Any()).DoAndReturn(
+			func(_ context.Context, post *models.Post) error {
+				assert.Equal(t, "cafe-au-lait-2", post.Slug)
+
+				return nil
+			}),
+	)
+
+	serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+		models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+	if err != nil {
+		t.Log(fmt.Errorf("error occurred while init service: %w", err))
+		t.Fail()
+
+		return
+	}
+
+	_, err = serv.CreatePost(ctx, models.CreatePostPayload{
+		Title:       "Café au lait",
+		Description: "description",
+	})
+	assert.NoError(t, err)
+}
+
+func TestService_GetPostByIDOrSlug(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *Mockrepository, ctx context.Context)
+
+	userID := "315b6c09-36ff-4519-8579-492f3ae2a3be"
+	post := models.Post{
+		ID:        "a4a4d1f6-0f0a-4bd4-8b1c-6f5ac2b0e0f1",
+		Title:     "Hello world",
+		CreatedBy: "40d2f213-8e41-4abf-ad54-410997b19401",
+		CreatedAt: time.Now(),
+		Status:    models.PostStatusPublished,
+		Slug:      "hello-world",
+	}
+
+	testCases := []struct {
+		name         string
+		ref          string
+		mockBehavior mockBehavior
+		errMessage   string
+	}{
+		{
+			name: "Post is found by ID",
+			ref:  post.ID,
+			mockBehavior: func(r *Mockrepository, ctx context.Context) {
+				published := post
+				r.EXPECT().GetPost(gomock.Eq(ctx), gomock.Eq(post.ID)).Return(&published, nil)
+				r.EXPECT().CountReactions(gomock.Eq(ctx), gomock.Eq(models.ReactionTargetPost),
+					gomock.Eq([]string{post.ID})).Return(map[string]models.ReactionCounts{}, nil)
+			},
+			errMessage: "",
+		},
+		{
+			name: "Post is found by previous slug",
+			ref:  "hello",
+			mockBehavior: func(r *Mockrepository, ctx context.Context) {
+				published := post
+				r.EXPECT().GetPostBySlug(gomock.Eq(ctx), gomock.Eq("hello")).Return(&published, nil)
+				r.EXPECT().CountReactions(gomock.Eq(ctx), gomock.Eq(models.ReactionTargetPost),
+					gomock.Eq([]string{post.ID})).Return(map[string]models.ReactionCounts{}, nil)
+			},
+			errMessage: "",
+		},
+		{
+			name: "Draft of other user is not found by slug",
+			ref:  "hello-world",
+			mockBehavior: func(r *Mockrepository, ctx context.Context) {
+				draft := post
+				draft.Status = models.PostStatusDraft
+				r.EXPECT().GetPostBySlug(gomock.Eq(ctx), gomock.Eq("hello-world")).Return(&draft, nil)
+			},
+			errMessage: models.ErrNotFound.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+			providerMock.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).AnyTimes()
+			providerMock.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser).AnyTimes()
+			tc.mockBehavior(repoMock, ctx)
+
+			serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			got, err := serv.GetPostByIDOrSlug(ctx, tc.ref)
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, post.ID, got.ID)
+
+				return
+			}
+
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+// ListTags returns tags of published posts, the most used first.
+func (s *Service) ListTags(ctx context.Context, pagination models.Pagination) ([]*models.Tag, error) {
+	tags, err := s.repo.ListTags(ctx, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// AutocompleteTags returns tags of published posts starting with the normalized prefix, the most used first.
+func (s *Service) AutocompleteTags(ctx context.Context, prefix string, limit uint64) ([]*models.Tag, error) {
+	prefix = models.NormalizeTag(prefix)
+	if prefix == "" {
+		return []*models.Tag{}, nil
+	}
+
+	tags, err := s.repo.AutocompleteTags(ctx, prefix, limit)
+	if err != nil {
+		return nil, fmt.Errorf("cannot autocomplete tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// RenameTag renames the tag on all posts. Posts tagged with the old name later get the new one.
+func (s *Service) RenameTag(ctx context.Context, payload models.RenameTagPayload) error {
+	if err := s.authorize(ctx, "", permissionManageTags); err != nil {
+		return fmt.Errorf("cannot rename tag: %w", err)
+	}
+
+	payload.Name = models.NormalizeTag(payload.Name)
+	payload.NewName = models.NormalizeTag(payload.NewName)
+
+	if err := payload.Validate(); err != nil {
+		return fmt.Errorf("cannot rename tag: %w", err)
+	}
+
+	if _, err := s.repo.GetTag(ctx, payload.Name); err != nil {
+		return fmt.Errorf("cannot get tag: %w", err)
+	}
+
+	_, err := s.repo.GetTag(ctx, payload.NewName)
+	if err == nil {
+		return fmt.Errorf("cannot rename tag: %w", validation.Errors{
+			"name": fmt.Errorf("tag %s already exists, merge the tags instead", payload.NewName),
+		})
+	}
+
+	if !errors.Is(err, models.ErrNotFound) {
+		return fmt.Errorf("cannot get tag: %w", err)
+	}
+
+	if _, err := s.repo.RenameTag(ctx, payload.Name, payload.NewName); err != nil {
+		return fmt.Errorf("cannot rename tag: %w", err)
+	}
+
+	return nil
+}
+
+// MergeTags replaces the tag with the other one on all posts. Posts tagged with the merged tag later
+// get the other one.
+func (s *Service) MergeTags(ctx context.Context, payload models.MergeTagsPayload) error {
+	if err := s.authorize(ctx, "", permissionManageTags); err != nil {
+		return fmt.Errorf("cannot merge tags: %w", err)
+	}
+
+	payload.From = models.NormalizeTag(payload.From)
+	payload.Into = models.NormalizeTag(payload.Into)
+
+	if err := payload.Validate(); err != nil {
+		return fmt.Errorf("cannot merge tags: %w", err)
+	}
+
+	for _, name := range []string{payload.From, payload.Into} {
+		if _, err := s.repo.GetTag(ctx, name); err != nil {
+			return fmt.Errorf("cannot get tag: %w", err)
+		}
+	}
+
+	if _, err := s.repo.MergeTags(ctx, payload.From, payload.Into); err != nil {
+		return fmt.Errorf("cannot merge tags: %w", err)
+	}
+
+	return nil
+}
+
+// normalizeTags normalizes the tags and replaces the aliases left by merged and renamed tags.
+func (s *Service) normalizeTags(ctx context.Context, tags []string) ([]string, error) {
+	tags = models.NormalizeTags(tags)
+	if len(tags) == 0 {
+		return tags, nil
+	}
+
+	aliases, err := s.repo.GetTagAliases(ctx, tags)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get aliases of tags: %w", err)
+	}
+
+	if len(aliases) == 0 {
+		return tags, nil
+	}
+
+	for i, tag := range tags {
+		if replacement, ok := aliases[tag]; ok {
+			tags[i] = replacement
+		}
+	}
+
+	return models.NormalizeTags(tags), nil
+}
+
+// normalizeTagValues normalizes the tags compared with the posts by the operator. Prefixes of tags
+// are not replaced by aliases.
+func (s *Service) normalizeTagValues(ctx context.Context, operator models.FilterOperator,
+	values []string) ([]string, error) {
+	if operator != models.FilterOperatorPrefix {
+		return s.normalizeTags(ctx, values)
+	}
+
+	normalized := make([]string, 0, len(values))
+	for _, value := range values {
+		normalized = append(normalized, models.NormalizeTag(value))
+	}
+
+	return normalized, nil
+}
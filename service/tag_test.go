@@ -0,0 +1,155 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/serhiihuberniuk/blog-api/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_RenameTag(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context)
+
+	userID := "315b6c09-36ff-4519-8579-492f3ae2a3be"
+
+	testCases := []struct {
+		name         string
+		payload      models.RenameTagPayload
+		mockBehavior mockBehavior
+		errMessage   string
+	}{
+		{
+			name: "Tag is renamed by admin",
+			payload: models.RenameTagPayload{
+				Name:    "Golang",
+				NewName: " Go ",
+			},
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).AnyTimes()
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleAdmin).AnyTimes()
+				r.EXPECT().GetTag(gomock.Eq(ctx), gomock.Eq("golang")).Return(&models.Tag{Name: "golang"}, nil)
+				r.EXPECT().GetTag(gomock.Eq(ctx), gomock.Eq("go")).Return(nil, models.ErrNotFound)
+				r.EXPECT().RenameTag(gomock.Eq(ctx), gomock.Eq("golang"), gomock.Eq("go")).Return([]string{}, nil)
+			},
+			errMessage: "",
+		},
+		{
+			name: "User is not allowed to rename tags",
+			payload: models.RenameTagPayload{
+				Name:    "golang",
+				NewName: "go",
+			},
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).AnyTimes()
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleModerator).AnyTimes()
+			},
+			errMessage: models.ErrForbidden.Error(),
+		},
+		{
+			name: "Tag with new name already exists",
+			payload: models.RenameTagPayload{
+				Name:    "golang",
+				NewName: "go",
+			},
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).AnyTimes()
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleAdmin).AnyTimes()
+				r.EXPECT().GetTag(gomock.Eq(ctx), gomock.Eq("golang")).Return(&models.Tag{Name: "golang"}, nil)
+				r.EXPECT().GetTag(gomock.Eq(ctx), gomock.Eq("go")).Return(&models.Tag{Name: "go"}, nil)
+			},
+			errMessage: "merge the tags instead",
+		},
+		{
+			name: "New name is the same after normalization",
+			payload: models.RenameTagPayload{
+				Name:    "go",
+				NewName: "GO",
+			},
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).AnyTimes()
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleAdmin).AnyTimes()
+			},
+			errMessage: "tag is already named go",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+			serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while init service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			tc.mockBehavior(repoMock, providerMock, ctx)
+
+			err = serv.RenameTag(ctx, tc.payload)
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
+
+func TestService_CreatePost_Tags(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	repoMock := NewMockrepository(ctrl)
+	providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+	providerMock.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return("315b6c09-36ff-4519-8579-492f3ae2a3be").AnyTimes()
+	providerMock.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser).AnyTimes()
+	repoMock.EXPECT().GetTagAliases(gomock.Eq(ctx), gomock.Eq([]string{"golang", "go", "api"})).
+		Return(map[string]string{"golang": "go"}, nil)
+	repoMock.EXPECT().IsSlugTaken(gomock.Eq(ctx), gomock.Eq("title"), gomock.Any()).Return(false, nil)
+	repoMock.EXPECT().CreatePost(gomock.Eq(ctx), gomock.Any()).DoAndReturn(
+		func(_ context.Context, post *models.Post) error {
+			assert.Equal(t, []string{"go", "api"}, post.Tags)
+
+			return nil
+		})
+
+	serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+		models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+	if err != nil {
+		t.Log(fmt.Errorf("error occurred while init service: %w", err))
+		t.Fail()
+
+		return
+	}
+
+	_, err = serv.CreatePost(ctx, models.CreatePostPayload{
+		Title:       "title",
+		Description: "description",
+		Tags:        []string{"Golang", "go ", "API"},
+	})
+	assert.NoError(t, err)
+}
@@ -2,11 +2,15 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"time"
 
+	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/google/uuid"
 	"github.com/serhiihuberniuk/blog-api/models"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func (s *Service) CreateUser(ctx context.Context, payload models.CreateUserPayload) (string, error) {
@@ -15,15 +19,28 @@ func (s *Service) CreateUser(ctx context.Context, payload models.CreateUserPaylo
 	user := &models.User{
 		ID:        uuid.New().String(),
 		Name:      payload.Name,
+		Username:  models.NormalizeUsername(payload.Username),
 		Email:     payload.Email,
 		CreatedAt: now,
 		UpdatedAt: now,
 		Password:  payload.Password,
+		Role:      models.RoleUser,
 	}
 	if err := user.Validate(); err != nil {
 		return "", fmt.Errorf("cannot create user: %w", err)
 	}
 
+	taken, err := s.repo.IsUsernameTaken(ctx, user.Username)
+	if err != nil {
+		return "", fmt.Errorf("cannot check username: %w", err)
+	}
+
+	if taken {
+		return "", fmt.Errorf("cannot create user: %w", validation.Errors{
+			"username": fmt.Errorf("username %s is already taken", user.Username),
+		})
+	}
+
 	hashedPassword, err := generateHashPassword(payload.Password)
 	if err != nil {
 		return "", fmt.Errorf("error occurred while hashing the password: %w", err)
@@ -35,6 +52,12 @@ func (s *Service) CreateUser(ctx context.Context, payload models.CreateUserPaylo
 		return "", fmt.Errorf("error occurred in repository layer: %w", err)
 	}
 
+	// The user is already created, so a failed email must not fail the request. The user can ask
+	// to send the verification again.
+	if err := s.sendEmailVerification(ctx, user.ID, user.Email); err != nil {
+		log.Printf("cannot send email verification to user %s: %v", user.ID, err)
+	}
+
 	return user.ID, nil
 }
 
@@ -44,30 +67,172 @@ func (s *Service) GetUser(ctx context.Context, userID string) (*models.User, err
 		return nil, fmt.Errorf("error occurred in repository layer: %w", err)
 	}
 
+	s.hideEmails(ctx, user)
+
 	return user, nil
 }
 
+// GetUserByUsername returns the user by the username. Usernames are not case-sensitive.
+func (s *Service) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	user, err := s.repo.GetUserByUsername(ctx, models.NormalizeUsername(username))
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	s.hideEmails(ctx, user)
+
+	return user, nil
+}
+
+// hideEmails clears the current and the pending email of users other than the current one, so addresses are
+// disclosed only to their owners and to those who can update users.
+func (s *Service) hideEmails(ctx context.Context, users ...*models.User) {
+	currentUserID := s.currentUserInformationProvider.GetCurrentUserID(ctx)
+
+	others := make([]*models.User, 0, len(users))
+
+	for _, user := range users {
+		if user.ID != currentUserID {
+			others = append(others, user)
+		}
+	}
+
+	if len(others) == 0 || s.hasRolePermission(ctx, permissionUpdateUser) {
+		return
+	}
+
+	for _, user := range others {
+		user.Email = ""
+		user.PendingEmail = ""
+	}
+}
+
 func (s *Service) UpdateUser(ctx context.Context, payload models.UpdateUserPayload) error {
-	user, err := s.GetUser(ctx, s.currentUserInformationProvider.GetCurrentUserID(ctx))
+	userID := payload.UserID
+	if userID == "" {
+		userID = s.currentUserInformationProvider.GetCurrentUserID(ctx)
+	}
+
+	user, err := s.repo.GetUser(ctx, userID)
 	if err != nil {
 		return fmt.Errorf("error occurred whole getting user with such id, %w", err)
 	}
 
-	user.Name = payload.Name
-	user.Email = payload.Email
-	user.UpdatedAt = time.Now()
-	user.Password = payload.Password
+	if err = s.authorize(ctx, user.ID, permissionUpdateUser); err != nil {
+		return fmt.Errorf("cannot update user: %w", err)
+	}
 
-	if err = user.Validate(); err != nil {
+	if err = payload.Validate(); err != nil {
 		return fmt.Errorf("error occurred while validation: %w", err)
 	}
 
-	user.Password, err = generateHashPassword(payload.Password)
+	updatedUser := *user
+	updatedUser.UpdatedAt = time.Now()
+
+	if payload.Name != nil {
+		updatedUser.Name = *payload.Name
+	}
+
+	// New email is applied only after it is confirmed. Sending back the current one cancels the pending change.
+	emailChanged := payload.Email != nil && *payload.Email != user.Email
+	if payload.Email != nil {
+		updatedUser.PendingEmail = ""
+	}
+
+	if emailChanged {
+		updatedUser.PendingEmail = *payload.Email
+	}
+
+	if err = updatedUser.ValidateProfile(); err != nil {
+		return fmt.Errorf("cannot update user: %w", err)
+	}
+
+	if emailChanged {
+		taken, err := s.repo.IsEmailTaken(ctx, updatedUser.PendingEmail)
+		if err != nil {
+			return fmt.Errorf("cannot check email: %w", err)
+		}
+
+		if taken {
+			return fmt.Errorf("cannot update user: %w", validation.Errors{
+				"email": fmt.Errorf("email %s is already taken", updatedUser.PendingEmail),
+			})
+		}
+	}
+
+	if err = s.repo.UpdateUser(ctx, &updatedUser); err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	// The pending email is already saved, so a failed email must not fail the request. The user can ask
+	// to send the verification again.
+	if emailChanged {
+		if err = s.sendEmailVerification(ctx, updatedUser.ID, updatedUser.PendingEmail); err != nil {
+			log.Printf("cannot send verification of new email to user %s: %v", updatedUser.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// ChangePassword sets a new password of the current user if the old one matches.
+// Sessions other than the current one are revoked.
+func (s *Service) ChangePassword(ctx context.Context, oldPassword, newPassword string) error {
+	userID := s.currentUserInformationProvider.GetCurrentUserID(ctx)
+	if userID == "" {
+		return models.ErrNotAuthenticated
+	}
+
+	payload := models.ChangePasswordPayload{
+		OldPassword: oldPassword,
+		NewPassword: newPassword,
+	}
+	if err := payload.Validate(); err != nil {
+		return fmt.Errorf("cannot change password: %w", err)
+	}
+
+	hashedPassword, err := s.repo.GetUserPassword(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	if err = bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(oldPassword)); err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return fmt.Errorf("old password is wrong: %w", models.ErrNotAuthenticated)
+		}
+
+		return fmt.Errorf("error occurred while checking the password, %w", err)
+	}
+
+	hashedPassword, err = generateHashPassword(newPassword)
 	if err != nil {
 		return fmt.Errorf("error occurred while hashing the password: %w", err)
 	}
 
-	if err = s.repo.UpdateUser(ctx, user); err != nil {
+	now := time.Now()
+
+	if err = s.repo.UpdateUserPassword(ctx, userID, hashedPassword, now); err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	err = s.repo.RevokeOtherRefreshTokens(ctx, userID, s.currentUserInformationProvider.GetCurrentSessionID(ctx), now)
+	if err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) UpdateUserRole(ctx context.Context, payload models.UpdateUserRolePayload) error {
+	if err := s.authorize(ctx, "", permissionManageRoles); err != nil {
+		return fmt.Errorf("cannot update role of user: %w", err)
+	}
+
+	if err := payload.Validate(); err != nil {
+		return fmt.Errorf("cannot update role of user: %w", err)
+	}
+
+	if err := s.repo.UpdateUserRole(ctx, payload.UserID, payload.Role); err != nil {
 		return fmt.Errorf("error occurred in repository layer: %w", err)
 	}
 
@@ -75,9 +240,61 @@ func (s *Service) UpdateUser(ctx context.Context, payload models.UpdateUserPaylo
 }
 
 func (s *Service) DeleteUser(ctx context.Context) error {
-	if err := s.repo.DeleteUser(ctx, s.currentUserInformationProvider.GetCurrentUserID(ctx)); err != nil {
+	return s.DeleteUserByID(ctx, s.currentUserInformationProvider.GetCurrentUserID(ctx))
+}
+
+// DeleteUserByID deletes the user. Posts and comments of the user are deleted or anonymized
+// according to the configured policy.
+func (s *Service) DeleteUserByID(ctx context.Context, userID string) error {
+	if err := s.authorize(ctx, userID, permissionDeleteUser); err != nil {
+		return fmt.Errorf("cannot delete user: %w", err)
+	}
+
+	if userID == models.DeletedUserID {
+		return fmt.Errorf("cannot delete tombstone user: %w", models.ErrForbidden)
+	}
+
+	now := time.Now()
+
+	if err := s.repo.DeleteUser(ctx, userID, now, s.deletedUserContent); err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	if err := s.repo.RevokeUserRefreshTokens(ctx, userID, now); err != nil {
+		return fmt.Errorf("error occurred in repository layer: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) RestoreUser(ctx context.Context, userID string) error {
+	if _, err := s.repo.GetDeletedUser(ctx, userID); err != nil {
+		return fmt.Errorf("cannot get deleted user: %w", err)
+	}
+
+	if err := s.authorize(ctx, userID, permissionRestoreUser); err != nil {
+		return fmt.Errorf("cannot restore user: %w", err)
+	}
+
+	if err := s.repo.RestoreUser(ctx, userID); err != nil {
 		return fmt.Errorf("error occurred in repository layer: %w", err)
 	}
 
 	return nil
 }
+
+// PurgeDeleted permanently removes users, posts and comments which were deleted longer than retention ago,
+// together with files attached to the posts.
+func (s *Service) PurgeDeleted(ctx context.Context, retention time.Duration) error {
+	deletedBefore := time.Now().Add(-retention)
+
+	if err := s.purgeAttachments(ctx, deletedBefore); err != nil {
+		return fmt.Errorf("cannot purge deleted objects: %w", err)
+	}
+
+	if err := s.repo.PurgeDeleted(ctx, deletedBefore); err != nil {
+		return fmt.Errorf("cannot purge deleted objects: %w", err)
+	}
+
+	return nil
+}
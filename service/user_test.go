@@ -11,62 +11,90 @@ import (
 	"github.com/serhiihuberniuk/blog-api/models"
 	"github.com/serhiihuberniuk/blog-api/service"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestService_GetUser(t *testing.T) {
 	t.Parallel()
 
-	type mockBehavior func(r *Mockrepository, ctx context.Context, userID string, user *models.User)
-
-	user := &models.User{
-		ID:        "315b6c09-36ff-4519-8579-492f3ae2a3be",
-		Name:      "name",
-		Email:     "email@email.com",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-		Password:  "hashedPassword",
+	type mockBehavior func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context,
+		userID string)
+
+	newUser := func() *models.User {
+		return &models.User{
+			ID:           "315b6c09-36ff-4519-8579-492f3ae2a3be",
+			Name:         "name",
+			Email:        "email@email.com",
+			PendingEmail: "new@email.com",
+			CreatedAt:    time.Now(),
+			UpdatedAt:    time.Now(),
+			Password:     "hashedPassword",
+		}
 	}
+	otherUserID := "40d2f213-8e41-4abf-ad54-410997b19401"
 
 	testCases := []struct {
-		name         string
-		inUserId     string
-		inCtx        context.Context
-		mockBehavior mockBehavior
-		expectedUser *models.User
-		errMessage   string
+		name          string
+		inUserId      string
+		mockBehavior  mockBehavior
+		expectedEmail string
+		expectedNil   bool
+		errMessage    string
 	}{
 		{
-			name:     "User is gotten",
-			inUserId: user.ID,
-			inCtx:    context.Background(),
-			mockBehavior: func(r *Mockrepository, ctx context.Context, userID string, user *models.User) {
-				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(userID)).
-					Return(user, nil)
+			name:     "User gets own emails",
+			inUserId: newUser().ID,
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context,
+				userID string) {
+				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(userID)).Return(newUser(), nil)
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID)
 			},
-			expectedUser: user,
-			errMessage:   "",
+			expectedEmail: "email@email.com",
+			errMessage:    "",
+		},
+		{
+			name:     "Emails are hidden from other users",
+			inUserId: newUser().ID,
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context,
+				userID string) {
+				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(userID)).Return(newUser(), nil)
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(otherUserID)
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleUser)
+			},
+			expectedEmail: "",
+			errMessage:    "",
+		},
+		{
+			name:     "Admin gets emails of other users",
+			inUserId: newUser().ID,
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context,
+				userID string) {
+				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(userID)).Return(newUser(), nil)
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(otherUserID)
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleAdmin)
+			},
+			expectedEmail: "email@email.com",
+			errMessage:    "",
 		},
 		{
 			name:     "Error in repository layer",
-			inUserId: user.ID,
-			inCtx:    context.Background(),
-			mockBehavior: func(r *Mockrepository, ctx context.Context, userID string, user *models.User) {
+			inUserId: newUser().ID,
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context,
+				userID string) {
 				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(userID)).
 					Return(nil, errors.New("cannot get user"))
 			},
-			expectedUser: nil,
-			errMessage:   "cannot get user",
+			errMessage: "cannot get user",
 		},
 		{
 			name:     "User is not found",
 			inUserId: "Invalid userID",
-			inCtx:    context.Background(),
-			mockBehavior: func(r *Mockrepository, ctx context.Context, userID string, user *models.User) {
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context,
+				userID string) {
 				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(userID)).
 					Return(nil, models.ErrNotFound)
 			},
-			expectedUser: nil,
-			errMessage:   models.ErrNotFound.Error(),
+			errMessage: models.ErrNotFound.Error(),
 		},
 	}
 
@@ -78,10 +106,13 @@ func TestService_GetUser(t *testing.T) {
 
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
+
+			ctx := context.Background()
 			mockRepo := NewMockrepository(ctrl)
 			mockProvider := NewMockcurrentUserInformationProvider(ctrl)
 
-			serv, err := service.NewService(mockRepo, nil, mockProvider)
+			serv, err := service.NewService(mockRepo, nil, mockProvider, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
 			if err != nil {
 				t.Log(err)
 				t.Fail()
@@ -89,12 +120,20 @@ func TestService_GetUser(t *testing.T) {
 				return
 			}
 
-			tc.mockBehavior(mockRepo, tc.inCtx, tc.inUserId, tc.expectedUser)
+			tc.mockBehavior(mockRepo, mockProvider, ctx, tc.inUserId)
 
-			gotUser, err := serv.GetUser(tc.inCtx, tc.inUserId)
+			gotUser, err := serv.GetUser(ctx, tc.inUserId)
 			if tc.errMessage == "" {
-				assert.NoError(t, err)
-				assert.Equal(t, tc.expectedUser, gotUser)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				assert.Equal(t, tc.inUserId, gotUser.ID)
+				assert.Equal(t, tc.expectedEmail, gotUser.Email)
+
+				if tc.expectedEmail == "" {
+					assert.Empty(t, gotUser.PendingEmail)
+				}
 
 				return
 			}
@@ -127,7 +166,9 @@ func TestService_DeleteUser(t *testing.T) {
 			name:  "User is deleted",
 			inCtx: ctxWithUserId,
 			repoMockBehavior: func(r *Mockrepository, ctx context.Context, userId string) {
-				r.EXPECT().DeleteUser(gomock.Eq(ctx), gomock.Eq(userId)).Return(nil)
+				r.EXPECT().DeleteUser(gomock.Eq(ctx), gomock.Eq(userId), gomock.Any(),
+					gomock.Eq(models.DeletedUserContentCascade)).Return(nil)
+				r.EXPECT().RevokeUserRefreshTokens(gomock.Eq(ctx), gomock.Eq(userId), gomock.Any()).Return(nil)
 			},
 			providerMockBehavior: func(p *MockcurrentUserInformationProvider, ctx context.Context, userId string) {
 				p.EXPECT().GetCurrentUserID(gomock.Eq(ctxWithUserId)).Return(userId).AnyTimes()
@@ -139,7 +180,8 @@ func TestService_DeleteUser(t *testing.T) {
 			name:  "Error in repository layer",
 			inCtx: ctxWithUserId,
 			repoMockBehavior: func(r *Mockrepository, ctx context.Context, userId string) {
-				r.EXPECT().DeleteUser(gomock.Eq(ctx), gomock.Eq(userId)).
+				r.EXPECT().DeleteUser(gomock.Eq(ctx), gomock.Eq(userId), gomock.Any(),
+					gomock.Eq(models.DeletedUserContentCascade)).
 					Return(errors.New("cannot delete user"))
 			},
 			providerMockBehavior: func(p *MockcurrentUserInformationProvider, ctx context.Context, userId string) {
@@ -152,7 +194,8 @@ func TestService_DeleteUser(t *testing.T) {
 			name:  "User is not found",
 			inCtx: ctxWithUserId,
 			repoMockBehavior: func(r *Mockrepository, ctx context.Context, userId string) {
-				r.EXPECT().DeleteUser(gomock.Eq(ctx), gomock.Eq(userId)).Return(models.ErrNotFound)
+				r.EXPECT().DeleteUser(gomock.Eq(ctx), gomock.Eq(userId), gomock.Any(),
+					gomock.Eq(models.DeletedUserContentCascade)).Return(models.ErrNotFound)
 			},
 			providerMockBehavior: func(p *MockcurrentUserInformationProvider, ctx context.Context, userId string) {
 				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userId).AnyTimes()
@@ -161,16 +204,24 @@ func TestService_DeleteUser(t *testing.T) {
 			errMessage:                 models.ErrNotFound.Error(),
 		},
 		{
-			name:  "Context is empty",
-			inCtx: nil,
-			repoMockBehavior: func(r *Mockrepository, ctx context.Context, userId string) {
-				r.EXPECT().DeleteUser(gomock.Eq(ctx), gomock.Eq(userId)).Return(models.ErrNotFound)
+			name:             "Tombstone user cannot be deleted",
+			inCtx:            ctxWithUserId,
+			repoMockBehavior: func(r *Mockrepository, ctx context.Context, userId string) {},
+			providerMockBehavior: func(p *MockcurrentUserInformationProvider, ctx context.Context, userId string) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userId).AnyTimes()
 			},
+			expectedUserIdFromProvider: models.DeletedUserID,
+			errMessage:                 models.ErrForbidden.Error(),
+		},
+		{
+			name:             "Context is empty",
+			inCtx:            nil,
+			repoMockBehavior: func(r *Mockrepository, ctx context.Context, userId string) {},
 			providerMockBehavior: func(p *MockcurrentUserInformationProvider, ctx context.Context, userId string) {
-				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userId).Times(2)
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userId).Times(3)
 			},
 			expectedUserIdFromProvider: "",
-			errMessage:                 models.ErrNotFound.Error(),
+			errMessage:                 models.ErrNotAuthenticated.Error(),
 		},
 	}
 
@@ -184,7 +235,8 @@ func TestService_DeleteUser(t *testing.T) {
 			defer ctrl.Finish()
 			repoMock := NewMockrepository(ctrl)
 			providerMock := NewMockcurrentUserInformationProvider(ctrl)
-			serv, err := service.NewService(repoMock, nil, providerMock)
+			serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
 			if err != nil {
 				t.Log(fmt.Errorf("error occurred while initialization of service: %w", err))
 				t.Fail()
@@ -211,11 +263,12 @@ func TestService_CreateUser(t *testing.T) {
 
 	input := &models.User{
 		Name:     "name",
+		Username: "username",
 		Email:    "email@mail.com",
 		Password: "password",
 	}
 
-	type mockBehavior func(r *Mockrepository, ctx context.Context)
+	type mockBehavior func(r *Mockrepository, m *Mockmailer, ctx context.Context)
 
 	testCases := []struct {
 		name         string
@@ -230,12 +283,35 @@ func TestService_CreateUser(t *testing.T) {
 			inCtx: context.Background(),
 			inPayload: models.CreateUserPayload{
 				Name:     input.Name,
+				Username: input.Username,
+				Email:    input.Email,
+				Password: input.Password,
+			},
+			mockBehavior: func(r *Mockrepository, m *Mockmailer, ctx context.Context) {
+				r.EXPECT().IsUsernameTaken(gomock.Eq(ctx), gomock.Eq(input.Username)).Return(false, nil)
+				r.EXPECT().CreateUser(gomock.Eq(ctx), gomock.Any()).
+					Return(nil)
+				r.EXPECT().CreateEmailVerificationToken(gomock.Eq(ctx), gomock.Any()).Return(nil)
+				m.EXPECT().Send(gomock.Eq(ctx), gomock.Eq(input.Email), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			errMessage: "",
+		},
+		{
+			name:  "User is created although verification email is not sent",
+			inCtx: context.Background(),
+			inPayload: models.CreateUserPayload{
+				Name:     input.Name,
+				Username: input.Username,
 				Email:    input.Email,
 				Password: input.Password,
 			},
-			mockBehavior: func(r *Mockrepository, ctx context.Context) {
+			mockBehavior: func(r *Mockrepository, m *Mockmailer, ctx context.Context) {
+				r.EXPECT().IsUsernameTaken(gomock.Eq(ctx), gomock.Eq(input.Username)).Return(false, nil)
 				r.EXPECT().CreateUser(gomock.Eq(ctx), gomock.Any()).
 					Return(nil)
+				r.EXPECT().CreateEmailVerificationToken(gomock.Eq(ctx), gomock.Any()).Return(nil)
+				m.EXPECT().Send(gomock.Eq(ctx), gomock.Eq(input.Email), gomock.Any(), gomock.Any()).
+					Return(errors.New("smtp server is unavailable"))
 			},
 			errMessage: "",
 		},
@@ -244,23 +320,40 @@ func TestService_CreateUser(t *testing.T) {
 			inCtx: context.Background(),
 			inPayload: models.CreateUserPayload{
 				Name:     input.Name,
+				Username: input.Username,
 				Email:    input.Email,
 				Password: input.Password,
 			},
-			mockBehavior: func(r *Mockrepository, ctx context.Context) {
+			mockBehavior: func(r *Mockrepository, m *Mockmailer, ctx context.Context) {
+				r.EXPECT().IsUsernameTaken(gomock.Eq(ctx), gomock.Eq(input.Username)).Return(false, nil)
 				r.EXPECT().CreateUser(gomock.Eq(ctx), gomock.Any()).
 					Return(errors.New("cannot create user"))
 			},
 			errMessage: "cannot create user",
 		},
+		{
+			name:  "Username is taken",
+			inCtx: context.Background(),
+			inPayload: models.CreateUserPayload{
+				Name:     input.Name,
+				Username: "UserName",
+				Email:    input.Email,
+				Password: input.Password,
+			},
+			mockBehavior: func(r *Mockrepository, m *Mockmailer, ctx context.Context) {
+				r.EXPECT().IsUsernameTaken(gomock.Eq(ctx), gomock.Eq(input.Username)).Return(true, nil)
+			},
+			errMessage: "username username is already taken",
+		},
 		{
 			name:  "Invalid payload(name is empty)",
 			inCtx: context.Background(),
 			inPayload: models.CreateUserPayload{
+				Username: input.Username,
 				Email:    input.Email,
 				Password: input.Password,
 			},
-			mockBehavior: func(r *Mockrepository, ctx context.Context) {},
+			mockBehavior: func(r *Mockrepository, m *Mockmailer, ctx context.Context) {},
 			errMessage:   "validation failed",
 		},
 	}
@@ -276,8 +369,10 @@ func TestService_CreateUser(t *testing.T) {
 
 			repoMock := NewMockrepository(ctrl)
 			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+			mailerMock := NewMockmailer(ctrl)
 
-			serv, err := service.NewService(repoMock, nil, providerMock)
+			serv, err := service.NewService(repoMock, nil, providerMock, nil, mailerMock, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
 			if err != nil {
 				t.Log(fmt.Errorf("error occurred while initialization of service: %w", err))
 				t.Fail()
@@ -285,7 +380,7 @@ func TestService_CreateUser(t *testing.T) {
 				return
 			}
 
-			tc.mockBehavior(repoMock, tc.inCtx)
+			tc.mockBehavior(repoMock, mailerMock, tc.inCtx)
 			userID, err := serv.CreateUser(tc.inCtx, tc.inPayload)
 			if tc.errMessage == "" {
 				assert.NoError(t, err)
@@ -303,14 +398,20 @@ func TestService_UpdateUser(t *testing.T) {
 
 	validUserId := "315b6c09-36ff-4519-8579-492f3ae2a3be"
 	input := &models.User{
+		ID:       validUserId,
 		Name:     "name",
+		Username: "username",
 		Email:    "email@email.com",
 		Password: "password",
 	}
 	ctxWithUserId := context.WithValue(context.Background(), "userID", validUserId)
+	newName := "new name"
+	newEmail := "new@email.com"
+	emptyEmail := ""
 
 	type repoMockBehaviorUpdate func(r *Mockrepository, ctx context.Context, userId string, user *models.User)
 	type providerMockBehavior func(r *MockcurrentUserInformationProvider, ctx context.Context, userID string)
+	type mailerMockBehavior func(m *Mockmailer, ctx context.Context)
 
 	testCases := []struct {
 		name                       string
@@ -318,6 +419,7 @@ func TestService_UpdateUser(t *testing.T) {
 		inPayload                  models.UpdateUserPayload
 		repoMockBehaviorUpdate     repoMockBehaviorUpdate
 		providerMockBehavior       providerMockBehavior
+		mailerMockBehavior         mailerMockBehavior
 		expectedUserIdFromProvider string
 		expectedUserFromGetUser    *models.User
 		errMessage                 string
@@ -326,28 +428,118 @@ func TestService_UpdateUser(t *testing.T) {
 			name:  "User updated",
 			inCtx: ctxWithUserId,
 			inPayload: models.UpdateUserPayload{
-				Name:     input.Name,
-				Email:    input.Email,
-				Password: input.Password,
+				Name:  &input.Name,
+				Email: &input.Email,
 			},
 			providerMockBehavior: func(r *MockcurrentUserInformationProvider, ctx context.Context, userID string) {
-				r.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).Times(2)
+				r.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).Times(3)
+			},
+			repoMockBehaviorUpdate: func(r *Mockrepository, ctx context.Context, userID string, user *models.User) {
+				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(userID)).Return(user, nil)
+				r.EXPECT().UpdateUser(gomock.Eq(ctx), gomock.Any()).Return(nil)
+			},
+			expectedUserFromGetUser:    input,
+			expectedUserIdFromProvider: validUserId,
+			errMessage:                 "",
+		},
+		{
+			name:  "Only name is updated",
+			inCtx: ctxWithUserId,
+			inPayload: models.UpdateUserPayload{
+				Name: &newName,
+			},
+			providerMockBehavior: func(r *MockcurrentUserInformationProvider, ctx context.Context, userID string) {
+				r.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).Times(3)
+			},
+			repoMockBehaviorUpdate: func(r *Mockrepository, ctx context.Context, userID string, user *models.User) {
+				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(userID)).Return(user, nil)
+				r.EXPECT().UpdateUser(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(_ context.Context, updated *models.User) error {
+						assert.Equal(t, newName, updated.Name)
+						assert.Equal(t, user.Email, updated.Email)
+
+						return nil
+					})
+			},
+			expectedUserFromGetUser:    input,
+			expectedUserIdFromProvider: validUserId,
+			errMessage:                 "",
+		},
+		{
+			name:  "Email change is pending until verified",
+			inCtx: ctxWithUserId,
+			inPayload: models.UpdateUserPayload{
+				Name:  &input.Name,
+				Email: &newEmail,
+			},
+			providerMockBehavior: func(r *MockcurrentUserInformationProvider, ctx context.Context, userID string) {
+				r.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).Times(3)
+			},
+			repoMockBehaviorUpdate: func(r *Mockrepository, ctx context.Context, userID string, user *models.User) {
+				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(userID)).Return(user, nil)
+				r.EXPECT().IsEmailTaken(gomock.Eq(ctx), gomock.Eq(newEmail)).Return(false, nil)
+				r.EXPECT().UpdateUser(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(_ context.Context, updated *models.User) error {
+						assert.Equal(t, user.Email, updated.Email)
+						assert.Equal(t, newEmail, updated.PendingEmail)
+
+						return nil
+					})
+				r.EXPECT().CreateEmailVerificationToken(gomock.Eq(ctx), gomock.Any()).Return(nil)
+			},
+			mailerMockBehavior: func(m *Mockmailer, ctx context.Context) {
+				m.EXPECT().Send(gomock.Eq(ctx), gomock.Eq(newEmail), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedUserFromGetUser:    input,
+			expectedUserIdFromProvider: validUserId,
+			errMessage:                 "",
+		},
+		{
+			name:  "Email change is kept if verification cannot be sent",
+			inCtx: ctxWithUserId,
+			inPayload: models.UpdateUserPayload{
+				Email: &newEmail,
+			},
+			providerMockBehavior: func(r *MockcurrentUserInformationProvider, ctx context.Context, userID string) {
+				r.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).Times(3)
 			},
 			repoMockBehaviorUpdate: func(r *Mockrepository, ctx context.Context, userID string, user *models.User) {
 				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(userID)).Return(user, nil)
+				r.EXPECT().IsEmailTaken(gomock.Eq(ctx), gomock.Eq(newEmail)).Return(false, nil)
 				r.EXPECT().UpdateUser(gomock.Eq(ctx), gomock.Any()).Return(nil)
+				r.EXPECT().CreateEmailVerificationToken(gomock.Eq(ctx), gomock.Any()).Return(nil)
+			},
+			mailerMockBehavior: func(m *Mockmailer, ctx context.Context) {
+				m.EXPECT().Send(gomock.Eq(ctx), gomock.Eq(newEmail), gomock.Any(), gomock.Any()).
+					Return(errors.New("smtp server is unavailable"))
 			},
 			expectedUserFromGetUser:    input,
 			expectedUserIdFromProvider: validUserId,
 			errMessage:                 "",
 		},
+		{
+			name:  "Email is taken by other user",
+			inCtx: ctxWithUserId,
+			inPayload: models.UpdateUserPayload{
+				Email: &newEmail,
+			},
+			providerMockBehavior: func(r *MockcurrentUserInformationProvider, ctx context.Context, userID string) {
+				r.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).Times(3)
+			},
+			repoMockBehaviorUpdate: func(r *Mockrepository, ctx context.Context, userID string, user *models.User) {
+				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(userID)).Return(user, nil)
+				r.EXPECT().IsEmailTaken(gomock.Eq(ctx), gomock.Eq(newEmail)).Return(true, nil)
+			},
+			expectedUserFromGetUser:    input,
+			expectedUserIdFromProvider: validUserId,
+			errMessage:                 "is already taken",
+		},
 		{
 			name:  "Context is empty",
 			inCtx: nil,
 			inPayload: models.UpdateUserPayload{
-				Name:     input.Name,
-				Email:    input.Email,
-				Password: input.Password,
+				Name:  &input.Name,
+				Email: &input.Email,
 			},
 			providerMockBehavior: func(r *MockcurrentUserInformationProvider, ctx context.Context, userID string) {
 				r.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return("").Times(2)
@@ -363,9 +555,8 @@ func TestService_UpdateUser(t *testing.T) {
 			name:  "Repository error while getting user",
 			inCtx: ctxWithUserId,
 			inPayload: models.UpdateUserPayload{
-				Name:     input.Name,
-				Email:    input.Email,
-				Password: input.Password,
+				Name:  &input.Name,
+				Email: &input.Email,
 			},
 			providerMockBehavior: func(r *MockcurrentUserInformationProvider, ctx context.Context, userID string) {
 				r.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).Times(2)
@@ -382,11 +573,11 @@ func TestService_UpdateUser(t *testing.T) {
 			name:  "Invalid payload(email empty)",
 			inCtx: ctxWithUserId,
 			inPayload: models.UpdateUserPayload{
-				Name:     input.Name,
-				Password: input.Password,
+				Name:  &input.Name,
+				Email: &emptyEmail,
 			},
 			providerMockBehavior: func(r *MockcurrentUserInformationProvider, ctx context.Context, userID string) {
-				r.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).Times(2)
+				r.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).Times(3)
 			},
 			repoMockBehaviorUpdate: func(r *Mockrepository, ctx context.Context, userID string, user *models.User) {
 				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(userID)).Return(user, nil)
@@ -399,12 +590,11 @@ func TestService_UpdateUser(t *testing.T) {
 			name:  "Repository error while updating user",
 			inCtx: ctxWithUserId,
 			inPayload: models.UpdateUserPayload{
-				Name:     input.Name,
-				Email:    input.Email,
-				Password: input.Password,
+				Name:  &input.Name,
+				Email: &input.Email,
 			},
 			providerMockBehavior: func(r *MockcurrentUserInformationProvider, ctx context.Context, userID string) {
-				r.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).Times(2)
+				r.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID).Times(3)
 			},
 			repoMockBehaviorUpdate: func(r *Mockrepository, ctx context.Context, userID string, user *models.User) {
 				r.EXPECT().GetUser(gomock.Eq(ctx), gomock.Eq(userID)).Return(user, nil)
@@ -427,8 +617,10 @@ func TestService_UpdateUser(t *testing.T) {
 
 			repoMock := NewMockrepository(ctrl)
 			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+			mailerMock := NewMockmailer(ctrl)
 
-			serv, err := service.NewService(repoMock, nil, providerMock)
+			serv, err := service.NewService(repoMock, nil, providerMock, nil, mailerMock, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
 			if err != nil {
 				t.Log(fmt.Errorf("error occurred while initialization of service: %w", err))
 				t.Fail()
@@ -439,6 +631,10 @@ func TestService_UpdateUser(t *testing.T) {
 			tc.providerMockBehavior(providerMock, tc.inCtx, tc.expectedUserIdFromProvider)
 			tc.repoMockBehaviorUpdate(repoMock, tc.inCtx, providerMock.GetCurrentUserID(tc.inCtx), tc.expectedUserFromGetUser)
 
+			if tc.mailerMockBehavior != nil {
+				tc.mailerMockBehavior(mailerMock, tc.inCtx)
+			}
+
 			err = serv.UpdateUser(tc.inCtx, tc.inPayload)
 			if tc.errMessage == "" {
 				assert.NoError(t, err)
@@ -450,3 +646,246 @@ func TestService_UpdateUser(t *testing.T) {
 		})
 	}
 }
+
+func TestService_ChangePassword(t *testing.T) {
+	t.Parallel()
+
+	type mockBehavior func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context)
+
+	userID := "315b6c09-36ff-4519-8579-492f3ae2a3be"
+	sessionID := "8a3c3b1e-3f5e-4c1a-9f53-2d3f7f1f0c1d"
+	oldPassword := "old password"
+	newPassword := "new password"
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(oldPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Log(fmt.Errorf("error occurred while running test: %w", err))
+		t.Fail()
+
+		return
+	}
+
+	testCases := []struct {
+		name          string
+		inOldPassword string
+		inNewPassword string
+		mockBehavior  mockBehavior
+		errMessage    string
+	}{
+		{
+			name:          "Password changed",
+			inOldPassword: oldPassword,
+			inNewPassword: newPassword,
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID)
+				p.EXPECT().GetCurrentSessionID(gomock.Eq(ctx)).Return(sessionID)
+				r.EXPECT().GetUserPassword(gomock.Eq(ctx), gomock.Eq(userID)).Return(string(hashedPassword), nil)
+				r.EXPECT().UpdateUserPassword(gomock.Eq(ctx), gomock.Eq(userID), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, password string, _ time.Time) error {
+						return bcrypt.CompareHashAndPassword([]byte(password), []byte(newPassword))
+					})
+				r.EXPECT().RevokeOtherRefreshTokens(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(sessionID),
+					gomock.Any()).Return(nil)
+			},
+			errMessage: "",
+		},
+		{
+			name:          "Old password is wrong",
+			inOldPassword: "wrong password",
+			inNewPassword: newPassword,
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID)
+				r.EXPECT().GetUserPassword(gomock.Eq(ctx), gomock.Eq(userID)).Return(string(hashedPassword), nil)
+			},
+			errMessage: models.ErrNotAuthenticated.Error(),
+		},
+		{
+			name:          "Not authenticated",
+			inOldPassword: oldPassword,
+			inNewPassword: newPassword,
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return("")
+			},
+			errMessage: models.ErrNotAuthenticated.Error(),
+		},
+		{
+			name:          "Invalid payload(new password is too short)",
+			inOldPassword: oldPassword,
+			inNewPassword: "pass",
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID)
+			},
+			errMessage: "validation failed",
+		},
+		{
+			name:          "Repository error while updating password",
+			inOldPassword: oldPassword,
+			inNewPassword: newPassword,
+			mockBehavior: func(r *Mockrepository, p *MockcurrentUserInformationProvider, ctx context.Context) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(userID)
+				r.EXPECT().GetUserPassword(gomock.Eq(ctx), gomock.Eq(userID)).Return(string(hashedPassword), nil)
+				r.EXPECT().UpdateUserPassword(gomock.Eq(ctx), gomock.Eq(userID), gomock.Any(), gomock.Any()).
+					Return(errors.New("cannot update password"))
+			},
+			errMessage: "cannot update password",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			repoMock := NewMockrepository(ctrl)
+			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+
+			serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while initialization of service: %w", err))
+				t.Fail()
+
+				return
+			}
+
+			tc.mockBehavior(repoMock, providerMock, ctx)
+
+			err = serv.ChangePassword(ctx, tc.inOldPassword, tc.inNewPassword)
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
+
+func TestService_UpdateUserRole(t *testing.T) {
+	t.Parallel()
+
+	adminId := "40d2f213-8e41-4abf-ad54-410997b19401"
+	targetUserId := "7f1c1f9e-0a55-4b3b-9a2f-1a6d7e0b6a10"
+	ctx := context.Background()
+
+	type repoMockBehavior func(r *Mockrepository, payload models.UpdateUserRolePayload)
+
+	type providerMockBehavior func(p *MockcurrentUserInformationProvider)
+
+	testCases := []struct {
+		name                 string
+		payload              models.UpdateUserRolePayload
+		repoMockBehavior     repoMockBehavior
+		providerMockBehavior providerMockBehavior
+		errMessage           string
+	}{
+		{
+			name: "Role updated by admin",
+			payload: models.UpdateUserRolePayload{
+				UserID: targetUserId,
+				Role:   models.RoleModerator,
+			},
+			repoMockBehavior: func(r *Mockrepository, payload models.UpdateUserRolePayload) {
+				r.EXPECT().UpdateUserRole(gomock.Eq(ctx), gomock.Eq(payload.UserID), gomock.Eq(payload.Role)).
+					Return(nil)
+			},
+			providerMockBehavior: func(p *MockcurrentUserInformationProvider) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(adminId)
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleAdmin)
+			},
+			errMessage: "",
+		},
+		{
+			name: "Moderator is not allowed to manage roles",
+			payload: models.UpdateUserRolePayload{
+				UserID: targetUserId,
+				Role:   models.RoleAdmin,
+			},
+			repoMockBehavior: func(r *Mockrepository, payload models.UpdateUserRolePayload) {},
+			providerMockBehavior: func(p *MockcurrentUserInformationProvider) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(adminId)
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleModerator)
+			},
+			errMessage: models.ErrForbidden.Error(),
+		},
+		{
+			name: "User is not authenticated",
+			payload: models.UpdateUserRolePayload{
+				UserID: targetUserId,
+				Role:   models.RoleAdmin,
+			},
+			repoMockBehavior: func(r *Mockrepository, payload models.UpdateUserRolePayload) {},
+			providerMockBehavior: func(p *MockcurrentUserInformationProvider) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return("")
+			},
+			errMessage: models.ErrNotAuthenticated.Error(),
+		},
+		{
+			name: "Invalid role",
+			payload: models.UpdateUserRolePayload{
+				UserID: targetUserId,
+				Role:   "superuser",
+			},
+			repoMockBehavior: func(r *Mockrepository, payload models.UpdateUserRolePayload) {},
+			providerMockBehavior: func(p *MockcurrentUserInformationProvider) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(adminId)
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleAdmin)
+			},
+			errMessage: "Role: must be a valid value",
+		},
+		{
+			name: "User is not found",
+			payload: models.UpdateUserRolePayload{
+				UserID: targetUserId,
+				Role:   models.RoleUser,
+			},
+			repoMockBehavior: func(r *Mockrepository, payload models.UpdateUserRolePayload) {
+				r.EXPECT().UpdateUserRole(gomock.Eq(ctx), gomock.Eq(payload.UserID), gomock.Eq(payload.Role)).
+					Return(models.ErrNotFound)
+			},
+			providerMockBehavior: func(p *MockcurrentUserInformationProvider) {
+				p.EXPECT().GetCurrentUserID(gomock.Eq(ctx)).Return(adminId)
+				p.EXPECT().GetCurrentUserRole(gomock.Eq(ctx)).Return(models.RoleAdmin)
+			},
+			errMessage: models.ErrNotFound.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repoMock := NewMockrepository(ctrl)
+			providerMock := NewMockcurrentUserInformationProvider(ctrl)
+			serv, err := service.NewService(repoMock, nil, providerMock, nil, nil, service.UnverifiedAccessFull,
+				models.DeletedUserContentCascade, nil, models.AttachmentConfig{})
+			if err != nil {
+				t.Log(fmt.Errorf("error occurred while initialization of service: %w", err))
+				t.Fail()
+
+				return
+			}
+			tc.providerMockBehavior(providerMock)
+			tc.repoMockBehavior(repoMock, tc.payload)
+
+			err = serv.UpdateUserRole(ctx, tc.payload)
+			if tc.errMessage == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.Contains(t, err.Error(), tc.errMessage)
+		})
+	}
+}
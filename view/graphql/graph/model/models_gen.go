@@ -8,41 +8,111 @@ import (
 	"strconv"
 )
 
+type Attachment struct {
+	ID            string               `json:"id"`
+	PostID        string               `json:"postID"`
+	CreatedBy     string               `json:"createdBy"`
+	CreatedAt     string               `json:"createdAt"`
+	FileName      string               `json:"fileName"`
+	ContentType   string               `json:"contentType"`
+	Size          int                  `json:"size"`
+	URL           string               `json:"url"`
+	VariantStatus *VariantStatus       `json:"variantStatus"`
+	Variants      []*AttachmentVariant `json:"variants"`
+}
+
+type AttachmentVariant struct {
+	Name        string `json:"name"`
+	ContentType string `json:"contentType"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	Size        int    `json:"size"`
+	URL         string `json:"url"`
+}
+
+type ChangePasswordInput struct {
+	OldPassword string `json:"oldPassword"`
+	NewPassword string `json:"newPassword"`
+}
+
 type Comment struct {
+	ID          string           `json:"id"`
+	Content     string           `json:"content"`
+	CreatedBy   *User            `json:"createdBy"`
+	AuthorID    string           `json:"authorID"`
+	CreatedAt   string           `json:"createdAt"`
+	Post        *Post            `json:"post"`
+	PostID      string           `json:"postID"`
+	UpdatedAt   string           `json:"updatedAt"`
+	Edited      bool             `json:"edited"`
+	ParentID    *string          `json:"parentID"`
+	Replies     []*Comment       `json:"replies"`
+	Reactions   []*ReactionCount `json:"reactions"`
+	Format      ContentFormat    `json:"format"`
+	ContentHTML string           `json:"contentHtml"`
+	WordCount   int              `json:"wordCount"`
+	ReadingTime int              `json:"readingTime"`
+	Mentions    []*Mention       `json:"mentions"`
+}
+
+type CommentConnection struct {
+	Edges      []*CommentEdge `json:"edges"`
+	PageInfo   *PageInfo      `json:"pageInfo"`
+	TotalCount *int           `json:"totalCount"`
+}
+
+type CommentEdge struct {
+	Cursor string   `json:"cursor"`
+	Node   *Comment `json:"node"`
+}
+
+type CommentRevision struct {
 	ID        string `json:"id"`
+	CommentID string `json:"commentID"`
+	EditedBy  string `json:"editedBy"`
+	EditedAt  string `json:"editedAt"`
 	Content   string `json:"content"`
-	CreatedBy *User  `json:"createdBy"`
-	AuthorID  string `json:"authorID"`
-	CreatedAt string `json:"createdAt"`
-	Post      *Post  `json:"post"`
-	PostID    string `json:"postID"`
 }
 
 type CreateCommentInput struct {
-	Content string `json:"content"`
-	PostID  string `json:"postId"`
+	Content  string         `json:"content"`
+	PostID   string         `json:"postId"`
+	ParentID *string        `json:"parentId"`
+	Format   *ContentFormat `json:"format"`
 }
 
 type CreatePostInput struct {
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Tags        []string `json:"tags"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Tags        []string       `json:"tags"`
+	Format      *ContentFormat `json:"format"`
 }
 
 type CreateUserInput struct {
 	Name     string `json:"name"`
+	Username string `json:"username"`
 	Email    string `json:"email"`
 	Password string `json:"password"`
 }
 
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
 type FilterCommentsInput struct {
-	Field FilterCommentsField `json:"field"`
-	Value string              `json:"value"`
+	Field    FilterCommentsField `json:"field"`
+	Operator *FilterOperator     `json:"operator"`
+	Value    *string             `json:"value"`
+	Values   []string            `json:"values"`
 }
 
 type FilterPostInput struct {
-	Field FilterPostsField `json:"field"`
-	Value string           `json:"value"`
+	Field    FilterPostsField `json:"field"`
+	Operator *FilterOperator  `json:"operator"`
+	Value    *string          `json:"value"`
+	Values   []string         `json:"values"`
 }
 
 type LoginInput struct {
@@ -50,21 +120,112 @@ type LoginInput struct {
 	Password string `json:"password"`
 }
 
+type Mention struct {
+	UserID   string `json:"userID"`
+	Username string `json:"username"`
+}
+
+type Notification struct {
+	ID        string           `json:"id"`
+	Type      NotificationType `json:"type"`
+	ActorID   string           `json:"actorID"`
+	PostID    *string          `json:"postID"`
+	CommentID *string          `json:"commentID"`
+	Reaction  *ReactionType    `json:"reaction"`
+	CreatedAt string           `json:"createdAt"`
+	Read      bool             `json:"read"`
+	ReadAt    *string          `json:"readAt"`
+}
+
+type NotificationList struct {
+	Notifications []*Notification `json:"notifications"`
+	UnreadCount   int             `json:"unreadCount"`
+}
+
+type NotificationPreference struct {
+	Type    NotificationType `json:"type"`
+	Enabled bool             `json:"enabled"`
+}
+
+type NotificationPreferenceInput struct {
+	Type    NotificationType `json:"type"`
+	Enabled bool             `json:"enabled"`
+}
+
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
+}
+
 type PaginationInput struct {
 	Limit  int `json:"limit"`
 	Offset int `json:"offset"`
 }
 
 type Post struct {
+	ID              string           `json:"id"`
+	Title           string           `json:"title"`
+	Description     string           `json:"description"`
+	CreatedBy       *User            `json:"createdBy"`
+	AuthorID        string           `json:"authorID"`
+	CreatedAt       string           `json:"createdAt"`
+	Tags            []string         `json:"tags"`
+	UpdatedAt       string           `json:"updatedAt"`
+	Edited          bool             `json:"edited"`
+	Status          PostStatus       `json:"status"`
+	Slug            string           `json:"slug"`
+	Reactions       []*ReactionCount `json:"reactions"`
+	CommentCount    int              `json:"commentCount"`
+	LastCommentedAt *string          `json:"lastCommentedAt"`
+	Format          ContentFormat    `json:"format"`
+	DescriptionHTML string           `json:"descriptionHtml"`
+	WordCount       int              `json:"wordCount"`
+	ReadingTime     int              `json:"readingTime"`
+	Mentions        []*Mention       `json:"mentions"`
+}
+
+type PostConnection struct {
+	Edges      []*PostEdge `json:"edges"`
+	PageInfo   *PageInfo   `json:"pageInfo"`
+	TotalCount *int        `json:"totalCount"`
+}
+
+type PostEdge struct {
+	Cursor string `json:"cursor"`
+	Node   *Post  `json:"node"`
+}
+
+type PostRevision struct {
 	ID          string   `json:"id"`
+	PostID      string   `json:"postID"`
+	EditedBy    string   `json:"editedBy"`
+	EditedAt    string   `json:"editedAt"`
 	Title       string   `json:"title"`
 	Description string   `json:"description"`
-	CreatedBy   *User    `json:"createdBy"`
-	AuthorID    string   `json:"authorID"`
-	CreatedAt   string   `json:"createdAt"`
 	Tags        []string `json:"tags"`
 }
 
+type ReactionCount struct {
+	Type  ReactionType `json:"type"`
+	Count int          `json:"count"`
+}
+
+type ResetPasswordInput struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+type SearchResult struct {
+	Target  SearchTarget `json:"target"`
+	ID      string       `json:"id"`
+	Rank    float64      `json:"rank"`
+	Snippet string       `json:"snippet"`
+	Post    *Post        `json:"post"`
+	Comment *Comment     `json:"comment"`
+}
+
 type SortCommentsInput struct {
 	Field SortCommentsField `json:"field"`
 	IsAsc bool              `json:"isAsc"`
@@ -75,29 +236,85 @@ type SortPostsInput struct {
 	IsAsc bool           `json:"isAsc"`
 }
 
+type Tag struct {
+	Name      string `json:"name"`
+	PostCount int    `json:"postCount"`
+}
+
+type Tokens struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken"`
+}
+
 type UpdateCommentInput struct {
-	Content string `json:"content"`
+	Content *string        `json:"content"`
+	Format  *ContentFormat `json:"format"`
 }
 
 type UpdatePostInput struct {
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Tags        []string `json:"tags"`
+	Title       *string        `json:"title"`
+	Description *string        `json:"description"`
+	Tags        []string       `json:"tags"`
+	Format      *ContentFormat `json:"format"`
 }
 
 type UpdateUserInput struct {
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Name  *string `json:"name"`
+	Email *string `json:"email"`
 }
 
 type User struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	Email     string `json:"email"`
-	CreatedAt string `json:"createdAt"`
-	UpdatedAt string `json:"updatedAt"`
-	Password  string `json:"password"`
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	Username      string  `json:"username"`
+	Email         string  `json:"email"`
+	CreatedAt     string  `json:"createdAt"`
+	UpdatedAt     string  `json:"updatedAt"`
+	Password      string  `json:"password"`
+	Role          Role    `json:"role"`
+	EmailVerified bool    `json:"emailVerified"`
+	PendingEmail  *string `json:"pendingEmail"`
+}
+
+type ContentFormat string
+
+const (
+	ContentFormatPlain    ContentFormat = "PLAIN"
+	ContentFormatMarkdown ContentFormat = "MARKDOWN"
+)
+
+var AllContentFormat = []ContentFormat{
+	ContentFormatPlain,
+	ContentFormatMarkdown,
+}
+
+func (e ContentFormat) IsValid() bool {
+	switch e {
+	case ContentFormatPlain, ContentFormatMarkdown:
+		return true
+	}
+	return false
+}
+
+func (e ContentFormat) String() string {
+	return string(e)
+}
+
+func (e *ContentFormat) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ContentFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ContentFormat", str)
+	}
+	return nil
+}
+
+func (e ContentFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
 type FilterCommentsField string
@@ -143,23 +360,74 @@ func (e FilterCommentsField) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type FilterOperator string
+
+const (
+	FilterOperatorEq     FilterOperator = "EQ"
+	FilterOperatorIn     FilterOperator = "IN"
+	FilterOperatorPrefix FilterOperator = "PREFIX"
+	FilterOperatorGte    FilterOperator = "GTE"
+	FilterOperatorLte    FilterOperator = "LTE"
+)
+
+var AllFilterOperator = []FilterOperator{
+	FilterOperatorEq,
+	FilterOperatorIn,
+	FilterOperatorPrefix,
+	FilterOperatorGte,
+	FilterOperatorLte,
+}
+
+func (e FilterOperator) IsValid() bool {
+	switch e {
+	case FilterOperatorEq, FilterOperatorIn, FilterOperatorPrefix, FilterOperatorGte, FilterOperatorLte:
+		return true
+	}
+	return false
+}
+
+func (e FilterOperator) String() string {
+	return string(e)
+}
+
+func (e *FilterOperator) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FilterOperator(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FilterOperator", str)
+	}
+	return nil
+}
+
+func (e FilterOperator) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type FilterPostsField string
 
 const (
 	FilterPostsFieldCreatedBy FilterPostsField = "CREATED_BY"
 	FilterPostsFieldTitle     FilterPostsField = "TITLE"
 	FilterPostsFieldTags      FilterPostsField = "TAGS"
+	FilterPostsFieldStatus    FilterPostsField = "STATUS"
+	FilterPostsFieldCreatedAt FilterPostsField = "CREATED_AT"
 )
 
 var AllFilterPostsField = []FilterPostsField{
 	FilterPostsFieldCreatedBy,
 	FilterPostsFieldTitle,
 	FilterPostsFieldTags,
+	FilterPostsFieldStatus,
+	FilterPostsFieldCreatedAt,
 }
 
 func (e FilterPostsField) IsValid() bool {
 	switch e {
-	case FilterPostsFieldCreatedBy, FilterPostsFieldTitle, FilterPostsFieldTags:
+	case FilterPostsFieldCreatedBy, FilterPostsFieldTitle, FilterPostsFieldTags, FilterPostsFieldStatus, FilterPostsFieldCreatedAt:
 		return true
 	}
 	return false
@@ -186,19 +454,289 @@ func (e FilterPostsField) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type NotificationType string
+
+const (
+	NotificationTypeComment  NotificationType = "COMMENT"
+	NotificationTypeReply    NotificationType = "REPLY"
+	NotificationTypeReaction NotificationType = "REACTION"
+	NotificationTypeFollow   NotificationType = "FOLLOW"
+	NotificationTypeMention  NotificationType = "MENTION"
+)
+
+var AllNotificationType = []NotificationType{
+	NotificationTypeComment,
+	NotificationTypeReply,
+	NotificationTypeReaction,
+	NotificationTypeFollow,
+	NotificationTypeMention,
+}
+
+func (e NotificationType) IsValid() bool {
+	switch e {
+	case NotificationTypeComment, NotificationTypeReply, NotificationTypeReaction, NotificationTypeFollow, NotificationTypeMention:
+		return true
+	}
+	return false
+}
+
+func (e NotificationType) String() string {
+	return string(e)
+}
+
+func (e *NotificationType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NotificationType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid NotificationType", str)
+	}
+	return nil
+}
+
+func (e NotificationType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type PostStatus string
+
+const (
+	PostStatusDraft     PostStatus = "DRAFT"
+	PostStatusInReview  PostStatus = "IN_REVIEW"
+	PostStatusPublished PostStatus = "PUBLISHED"
+	PostStatusArchived  PostStatus = "ARCHIVED"
+)
+
+var AllPostStatus = []PostStatus{
+	PostStatusDraft,
+	PostStatusInReview,
+	PostStatusPublished,
+	PostStatusArchived,
+}
+
+func (e PostStatus) IsValid() bool {
+	switch e {
+	case PostStatusDraft, PostStatusInReview, PostStatusPublished, PostStatusArchived:
+		return true
+	}
+	return false
+}
+
+func (e PostStatus) String() string {
+	return string(e)
+}
+
+func (e *PostStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PostStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PostStatus", str)
+	}
+	return nil
+}
+
+func (e PostStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ReactionTarget string
+
+const (
+	ReactionTargetPost    ReactionTarget = "POST"
+	ReactionTargetComment ReactionTarget = "COMMENT"
+)
+
+var AllReactionTarget = []ReactionTarget{
+	ReactionTargetPost,
+	ReactionTargetComment,
+}
+
+func (e ReactionTarget) IsValid() bool {
+	switch e {
+	case ReactionTargetPost, ReactionTargetComment:
+		return true
+	}
+	return false
+}
+
+func (e ReactionTarget) String() string {
+	return string(e)
+}
+
+func (e *ReactionTarget) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReactionTarget(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReactionTarget", str)
+	}
+	return nil
+}
+
+func (e ReactionTarget) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ReactionType string
+
+const (
+	ReactionTypeLike  ReactionType = "LIKE"
+	ReactionTypeLove  ReactionType = "LOVE"
+	ReactionTypeLaugh ReactionType = "LAUGH"
+	ReactionTypeWow   ReactionType = "WOW"
+	ReactionTypeSad   ReactionType = "SAD"
+	ReactionTypeAngry ReactionType = "ANGRY"
+)
+
+var AllReactionType = []ReactionType{
+	ReactionTypeLike,
+	ReactionTypeLove,
+	ReactionTypeLaugh,
+	ReactionTypeWow,
+	ReactionTypeSad,
+	ReactionTypeAngry,
+}
+
+func (e ReactionType) IsValid() bool {
+	switch e {
+	case ReactionTypeLike, ReactionTypeLove, ReactionTypeLaugh, ReactionTypeWow, ReactionTypeSad, ReactionTypeAngry:
+		return true
+	}
+	return false
+}
+
+func (e ReactionType) String() string {
+	return string(e)
+}
+
+func (e *ReactionType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReactionType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReactionType", str)
+	}
+	return nil
+}
+
+func (e ReactionType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type Role string
+
+const (
+	RoleUser      Role = "USER"
+	RoleModerator Role = "MODERATOR"
+	RoleAdmin     Role = "ADMIN"
+)
+
+var AllRole = []Role{
+	RoleUser,
+	RoleModerator,
+	RoleAdmin,
+}
+
+func (e Role) IsValid() bool {
+	switch e {
+	case RoleUser, RoleModerator, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+func (e Role) String() string {
+	return string(e)
+}
+
+func (e *Role) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Role(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Role", str)
+	}
+	return nil
+}
+
+func (e Role) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type SearchTarget string
+
+const (
+	SearchTargetPost    SearchTarget = "POST"
+	SearchTargetComment SearchTarget = "COMMENT"
+)
+
+var AllSearchTarget = []SearchTarget{
+	SearchTargetPost,
+	SearchTargetComment,
+}
+
+func (e SearchTarget) IsValid() bool {
+	switch e {
+	case SearchTargetPost, SearchTargetComment:
+		return true
+	}
+	return false
+}
+
+func (e SearchTarget) String() string {
+	return string(e)
+}
+
+func (e *SearchTarget) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SearchTarget(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SearchTarget", str)
+	}
+	return nil
+}
+
+func (e SearchTarget) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type SortCommentsField string
 
 const (
-	SortCommentsFieldCreatedAt SortCommentsField = "CREATED_AT"
+	SortCommentsFieldCreatedAt     SortCommentsField = "CREATED_AT"
+	SortCommentsFieldUpdatedAt     SortCommentsField = "UPDATED_AT"
+	SortCommentsFieldReactionCount SortCommentsField = "REACTION_COUNT"
 )
 
 var AllSortCommentsField = []SortCommentsField{
 	SortCommentsFieldCreatedAt,
+	SortCommentsFieldUpdatedAt,
+	SortCommentsFieldReactionCount,
 }
 
 func (e SortCommentsField) IsValid() bool {
 	switch e {
-	case SortCommentsFieldCreatedAt:
+	case SortCommentsFieldCreatedAt, SortCommentsFieldUpdatedAt, SortCommentsFieldReactionCount:
 		return true
 	}
 	return false
@@ -228,18 +766,24 @@ func (e SortCommentsField) MarshalGQL(w io.Writer) {
 type SortPostsField string
 
 const (
-	SortPostsFieldCreatedAt SortPostsField = "CREATED_AT"
-	SortPostsFieldTitle     SortPostsField = "TITLE"
+	SortPostsFieldCreatedAt     SortPostsField = "CREATED_AT"
+	SortPostsFieldTitle         SortPostsField = "TITLE"
+	SortPostsFieldUpdatedAt     SortPostsField = "UPDATED_AT"
+	SortPostsFieldCommentCount  SortPostsField = "COMMENT_COUNT"
+	SortPostsFieldReactionCount SortPostsField = "REACTION_COUNT"
 )
 
 var AllSortPostsField = []SortPostsField{
 	SortPostsFieldCreatedAt,
 	SortPostsFieldTitle,
+	SortPostsFieldUpdatedAt,
+	SortPostsFieldCommentCount,
+	SortPostsFieldReactionCount,
 }
 
 func (e SortPostsField) IsValid() bool {
 	switch e {
-	case SortPostsFieldCreatedAt, SortPostsFieldTitle:
+	case SortPostsFieldCreatedAt, SortPostsFieldTitle, SortPostsFieldUpdatedAt, SortPostsFieldCommentCount, SortPostsFieldReactionCount:
 		return true
 	}
 	return false
@@ -265,3 +809,46 @@ func (e *SortPostsField) UnmarshalGQL(v interface{}) error {
 func (e SortPostsField) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+type VariantStatus string
+
+const (
+	VariantStatusPending VariantStatus = "PENDING"
+	VariantStatusReady   VariantStatus = "READY"
+	VariantStatusFailed  VariantStatus = "FAILED"
+)
+
+var AllVariantStatus = []VariantStatus{
+	VariantStatusPending,
+	VariantStatusReady,
+	VariantStatusFailed,
+}
+
+func (e VariantStatus) IsValid() bool {
+	switch e {
+	case VariantStatusPending, VariantStatusReady, VariantStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e VariantStatus) String() string {
+	return string(e)
+}
+
+func (e *VariantStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = VariantStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid VariantStatus", str)
+	}
+	return nil
+}
+
+func (e VariantStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
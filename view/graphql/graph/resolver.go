@@ -2,6 +2,7 @@ package graph
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/serhiihuberniuk/blog-api/models"
@@ -12,19 +13,21 @@ import (
 // This file will not be regenerated automatically.
 //
 // It serves as dependency injection for your app, add any dependencies you require here.
-const (
-	maxLimit = 50
-)
 
 var (
 	allowedFilterPostsFields = map[model.FilterPostsField]models.FilterPostsByField{
 		model.FilterPostsFieldCreatedBy: models.FilterPostsByCreatedBy,
 		model.FilterPostsFieldTags:      models.FilterPostsByTags,
 		model.FilterPostsFieldTitle:     models.FilterPostsByTitle,
+		model.FilterPostsFieldStatus:    models.FilterPostsByStatus,
+		model.FilterPostsFieldCreatedAt: models.FilterPostsByCreatedAt,
 	}
 	allowedSortPostsFields = map[model.SortPostsField]models.SortPostsByField{
-		model.SortPostsFieldCreatedAt: models.SortPostsByCreatedAt,
-		model.SortPostsFieldTitle:     models.SortPostsByTitle,
+		model.SortPostsFieldCreatedAt:     models.SortPostsByCreatedAt,
+		model.SortPostsFieldTitle:         models.SortPostsByTitle,
+		model.SortPostsFieldUpdatedAt:     models.SortPostsByUpdatedAt,
+		model.SortPostsFieldCommentCount:  models.SortPostsByCommentCount,
+		model.SortPostsFieldReactionCount: models.SortPostsByReactionCount,
 	}
 	allowedFilterCommentsFields = map[model.FilterCommentsField]models.FilterCommentsByField{
 		model.FilterCommentsFieldCreatedAt: models.FilterCommentsByCreatedAt,
@@ -32,22 +35,103 @@ var (
 		model.FilterCommentsFieldPostID:    models.FilterCommentsByPost,
 	}
 	allowedSortCommentsFields = map[model.SortCommentsField]models.SortCommentsByField{
-		model.SortCommentsFieldCreatedAt: models.SortCommentByCreatedAt,
+		model.SortCommentsFieldCreatedAt:     models.SortCommentByCreatedAt,
+		model.SortCommentsFieldUpdatedAt:     models.SortCommentByUpdatedAt,
+		model.SortCommentsFieldReactionCount: models.SortCommentByReactionCount,
+	}
+	allowedFilterOperators = map[model.FilterOperator]models.FilterOperator{
+		model.FilterOperatorEq:     models.FilterOperatorEq,
+		model.FilterOperatorIn:     models.FilterOperatorIn,
+		model.FilterOperatorPrefix: models.FilterOperatorPrefix,
+		model.FilterOperatorGte:    models.FilterOperatorGte,
+		model.FilterOperatorLte:    models.FilterOperatorLte,
+	}
+	allowedRoles = map[model.Role]models.Role{
+		model.RoleUser:      models.RoleUser,
+		model.RoleModerator: models.RoleModerator,
+		model.RoleAdmin:     models.RoleAdmin,
+	}
+	graphqlRoles = map[models.Role]model.Role{
+		models.RoleUser:      model.RoleUser,
+		models.RoleModerator: model.RoleModerator,
+		models.RoleAdmin:     model.RoleAdmin,
+	}
+	graphqlPostStatuses = map[models.PostStatus]model.PostStatus{
+		models.PostStatusDraft:     model.PostStatusDraft,
+		models.PostStatusInReview:  model.PostStatusInReview,
+		models.PostStatusPublished: model.PostStatusPublished,
+		models.PostStatusArchived:  model.PostStatusArchived,
+	}
+	graphqlReactionTypes = map[models.ReactionType]model.ReactionType{
+		models.ReactionLike:  model.ReactionTypeLike,
+		models.ReactionLove:  model.ReactionTypeLove,
+		models.ReactionLaugh: model.ReactionTypeLaugh,
+		models.ReactionWow:   model.ReactionTypeWow,
+		models.ReactionSad:   model.ReactionTypeSad,
+		models.ReactionAngry: model.ReactionTypeAngry,
+	}
+	allowedNotificationTypes = map[model.NotificationType]models.NotificationType{
+		model.NotificationTypeComment:  models.NotificationComment,
+		model.NotificationTypeReply:    models.NotificationReply,
+		model.NotificationTypeReaction: models.NotificationReaction,
+		model.NotificationTypeFollow:   models.NotificationFollow,
+		model.NotificationTypeMention:  models.NotificationMention,
+	}
+	graphqlNotificationTypes = map[models.NotificationType]model.NotificationType{
+		models.NotificationComment:  model.NotificationTypeComment,
+		models.NotificationReply:    model.NotificationTypeReply,
+		models.NotificationReaction: model.NotificationTypeReaction,
+		models.NotificationFollow:   model.NotificationTypeFollow,
+		models.NotificationMention:  model.NotificationTypeMention,
+	}
+	graphqlVariantStatuses = map[models.VariantStatus]model.VariantStatus{
+		models.VariantStatusPending: model.VariantStatusPending,
+		models.VariantStatusReady:   model.VariantStatusReady,
+		models.VariantStatusFailed:  model.VariantStatusFailed,
+	}
+	allowedSearchTargets = map[model.SearchTarget]models.SearchTarget{
+		model.SearchTargetPost:    models.SearchTargetPost,
+		model.SearchTargetComment: models.SearchTargetComment,
+	}
+	graphqlSearchTargets = map[models.SearchTarget]model.SearchTarget{
+		models.SearchTargetPost:    model.SearchTargetPost,
+		models.SearchTargetComment: model.SearchTargetComment,
+	}
+	allowedContentFormats = map[model.ContentFormat]models.ContentFormat{
+		model.ContentFormatPlain:    models.ContentFormatPlain,
+		model.ContentFormatMarkdown: models.ContentFormatMarkdown,
+	}
+	graphqlContentFormats = map[models.ContentFormat]model.ContentFormat{
+		models.ContentFormatPlain:    model.ContentFormatPlain,
+		models.ContentFormatMarkdown: model.ContentFormatMarkdown,
+	}
+	allowedReactionTargets = map[model.ReactionTarget]models.ReactionTarget{
+		model.ReactionTargetPost:    models.ReactionTargetPost,
+		model.ReactionTargetComment: models.ReactionTargetComment,
+	}
+	allowedReactionTypes = map[model.ReactionType]models.ReactionType{
+		model.ReactionTypeLike:  models.ReactionLike,
+		model.ReactionTypeLove:  models.ReactionLove,
+		model.ReactionTypeLaugh: models.ReactionLaugh,
+		model.ReactionTypeWow:   models.ReactionWow,
+		model.ReactionTypeSad:   models.ReactionSad,
+		model.ReactionTypeAngry: models.ReactionAngry,
 	}
 )
 
 type Resolver struct {
 	service                        service
 	currentUserInformationProvider currentUserInformationProvider
+	maxLimit                       uint64
 }
 
 type currentUserInformationProvider interface {
 	GetCurrentUserID(ctx context.Context) string
 }
 
-func getPaginationParams(paginationInput *model.PaginationInput) models.Pagination {
+func (r *Resolver) getPaginationParams(paginationInput *model.PaginationInput) models.Pagination {
 	pagination := models.Pagination{
-		Limit:  maxLimit,
+		Limit:  r.maxLimit,
 		Offset: 0,
 	}
 
@@ -57,41 +141,434 @@ func getPaginationParams(paginationInput *model.PaginationInput) models.Paginati
 			Offset: uint64(paginationInput.Offset),
 		}
 
-		if pagination.Limit > maxLimit {
-			pagination.Limit = maxLimit
+		if pagination.Limit > r.maxLimit {
+			pagination.Limit = r.maxLimit
 		}
 	}
 
 	return pagination
 }
 
+// pageLimit returns the limit of the page, which is the maximal one if the given limit is missing, not positive
+// or too big.
+func (r *Resolver) pageLimit(limit *int) uint64 {
+	if limit == nil || *limit <= 0 || uint64(*limit) > r.maxLimit {
+		return r.maxLimit
+	}
+
+	return uint64(*limit)
+}
+
+// getConnectionParams returns the pagination of a connection. The connection is paged forward with first and after,
+// or backward with last and before. The total count is asked for only when it is selected.
+func (r *Resolver) getConnectionParams(ctx context.Context, first *int, after *string,
+	last *int, before *string) (models.Pagination, error) {
+	var (
+		pagination models.Pagination
+		err        error
+	)
+
+	if after != nil {
+		if pagination.After, err = models.ParseCursor(*after); err != nil {
+			return models.Pagination{}, err
+		}
+	}
+
+	if before != nil {
+		if pagination.Before, err = models.ParseCursor(*before); err != nil {
+			return models.Pagination{}, err
+		}
+	}
+
+	pagination.Limit = r.pageLimit(first)
+	if pagination.Before != nil && last != nil {
+		pagination.Limit = r.pageLimit(last)
+	}
+
+	for _, field := range graphql.CollectAllFields(ctx) {
+		if field == "totalCount" {
+			pagination.CountTotal = true
+		}
+	}
+
+	return pagination, nil
+}
+
+// postConnection returns the connection of the posts of the page.
+func (r *Resolver) postConnection(ctx context.Context, page *models.PostsPage) (*model.PostConnection, error) {
+	edges := make([]*model.PostEdge, 0, len(page.Posts))
+
+	for _, post := range page.Posts {
+		node, err := r.Query().GetPost(ctx, post.ID)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get post: %w", err)
+		}
+
+		edges = append(edges, &model.PostEdge{
+			Cursor: models.NewPostCursor(post, page.Sort).String(),
+			Node:   node,
+		})
+	}
+
+	var start, end *models.Cursor
+	if len(page.Posts) != 0 {
+		start = models.NewPostCursor(page.Posts[0], page.Sort)
+		end = models.NewPostCursor(page.Posts[len(page.Posts)-1], page.Sort)
+	}
+
+	return &model.PostConnection{
+		Edges:      edges,
+		PageInfo:   pageInfoModel(page.PageInfo, start, end),
+		TotalCount: page.Total,
+	}, nil
+}
+
+func pageInfoModel(pageInfo models.PageInfo, start, end *models.Cursor) *model.PageInfo {
+	out := &model.PageInfo{
+		HasNextPage:     pageInfo.Next != nil,
+		HasPreviousPage: pageInfo.Prev != nil,
+	}
+
+	if start != nil {
+		startCursor, endCursor := start.String(), end.String()
+		out.StartCursor, out.EndCursor = &startCursor, &endCursor
+	}
+
+	return out
+}
+
+func postsFilterParams(filters []*model.FilterPostInput) models.FilterPosts {
+	filterPosts := models.FilterPosts{}
+
+	for _, filter := range filters {
+		if filter == nil || !filter.Field.IsValid() {
+			continue
+		}
+
+		operator, values := filterConditionParams(filter.Operator, filter.Value, filter.Values)
+
+		filterPosts.Conditions = append(filterPosts.Conditions, models.PostCondition{
+			Field:    allowedFilterPostsFields[filter.Field],
+			Operator: operator,
+			Values:   values,
+		})
+	}
+
+	return filterPosts
+}
+
+// postsSortParams returns the sort by the keys in order. A single key can be given instead of the list.
+func postsSortParams(sortPostsInput []*model.SortPostsInput) models.SortPosts {
+	sortPosts := models.SortPosts{}
+
+	for _, key := range sortPostsInput {
+		if key == nil || !key.Field.IsValid() {
+			continue
+		}
+
+		sortPosts.Keys = append(sortPosts.Keys, models.SortPostsKey{
+			Field: allowedSortPostsFields[key.Field],
+			IsASC: key.IsAsc,
+		})
+	}
+
+	return sortPosts
+}
+
+func commentsFilterParams(filters []*model.FilterCommentsInput) models.FilterComments {
+	filterComments := models.FilterComments{}
+
+	for _, filter := range filters {
+		if filter == nil || !filter.Field.IsValid() {
+			continue
+		}
+
+		operator, values := filterConditionParams(filter.Operator, filter.Value, filter.Values)
+
+		filterComments.Conditions = append(filterComments.Conditions, models.CommentCondition{
+			Field:    allowedFilterCommentsFields[filter.Field],
+			Operator: operator,
+			Values:   values,
+		})
+	}
+
+	return filterComments
+}
+
+// commentsSortParams returns the sort by the keys in order. A single key can be given instead of the list.
+func commentsSortParams(sortCommentsInput []*model.SortCommentsInput) models.SortComments {
+	sortComments := models.SortComments{}
+
+	for _, key := range sortCommentsInput {
+		if key == nil || !key.Field.IsValid() {
+			continue
+		}
+
+		sortComments.Keys = append(sortComments.Keys, models.SortCommentsKey{
+			Field: allowedSortCommentsFields[key.Field],
+			IsASC: key.IsAsc,
+		})
+	}
+
+	return sortComments
+}
+
 type service interface {
-	Login(ctx context.Context, payload models.LoginPayload) (string, error)
+	Login(ctx context.Context, payload models.LoginPayload) (*models.Tokens, error)
+	Refresh(ctx context.Context, payload models.RefreshPayload) (*models.Tokens, error)
+	Logout(ctx context.Context, payload models.LogoutPayload) error
+	RequestPasswordReset(ctx context.Context, payload models.RequestPasswordResetPayload) error
+	ResetPassword(ctx context.Context, payload models.ResetPasswordPayload) error
+	VerifyEmail(ctx context.Context, payload models.VerifyEmailPayload) error
+	ResendEmailVerification(ctx context.Context) error
 
 	CreateUser(ctx context.Context, payload models.CreateUserPayload) (string, error)
 	GetUser(ctx context.Context, userID string) (*models.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
 	UpdateUser(ctx context.Context, payload models.UpdateUserPayload) error
+	ChangePassword(ctx context.Context, oldPassword, newPassword string) error
+	UpdateUserRole(ctx context.Context, payload models.UpdateUserRolePayload) error
 	DeleteUser(ctx context.Context) error
+	DeleteUserByID(ctx context.Context, userID string) error
+	RestoreUser(ctx context.Context, userID string) error
+
+	Follow(ctx context.Context, userID string) error
+	Unfollow(ctx context.Context, userID string) error
+	ListFollowers(ctx context.Context, userID string, pagination models.Pagination) ([]*models.User, error)
+	ListFollowing(ctx context.Context, userID string, pagination models.Pagination) ([]*models.User, error)
+	Feed(ctx context.Context, pagination models.Pagination) (*models.PostsPage, error)
+
+	ListNotifications(ctx context.Context, pagination models.Pagination,
+		unreadOnly bool) (*models.NotificationList, error)
+	MarkNotificationRead(ctx context.Context, notificationID string) error
+	MarkAllNotificationsRead(ctx context.Context) error
+	GetNotificationPreferences(ctx context.Context) (models.NotificationPreferences, error)
+	UpdateNotificationPreferences(ctx context.Context,
+		changes models.NotificationPreferences) (models.NotificationPreferences, error)
 
 	CreatePost(ctx context.Context, payload models.CreatePostPayload) (string, error)
 	GetPost(ctx context.Context, postID string) (*models.Post, error)
+	GetPostByIDOrSlug(ctx context.Context, ref string) (*models.Post, error)
 	UpdatePost(ctx context.Context, payload models.UpdatePostPayload) error
 	DeletePost(ctx context.Context, postID string) error
+	RestorePost(ctx context.Context, postID string) error
+	SubmitPost(ctx context.Context, postID string) error
+	PublishPost(ctx context.Context, postID string) error
+	UnpublishPost(ctx context.Context, postID string) error
+	ArchivePost(ctx context.Context, postID string) error
 	ListPosts(ctx context.Context, pagination models.Pagination,
-		filter models.FilterPosts, sort models.SortPosts) ([]*models.Post, error)
+		filter models.FilterPosts, sort models.SortPosts) (*models.PostsPage, error)
 
 	CreateComment(ctx context.Context, payload models.CreateCommentPayload) (string, error)
 	GetComment(ctx context.Context, commentID string) (*models.Comment, error)
+	ListReplies(ctx context.Context, commentID string) ([]*models.CommentThread, error)
 	UpdateComment(ctx context.Context, payload models.UpdateCommentPayload) error
 	DeleteComment(ctx context.Context, commentId string) error
+	RestoreComment(ctx context.Context, commentID string) error
 	ListComments(ctx context.Context, pagination models.Pagination,
-		filter models.FilterComments, sort models.SortComments) ([]*models.Comment, error)
+		filter models.FilterComments, sort models.SortComments) (*models.CommentsPage, error)
+
+	Search(ctx context.Context, query models.SearchQuery, pagination models.Pagination) ([]*models.SearchResult, error)
+
+	ListTags(ctx context.Context, pagination models.Pagination) ([]*models.Tag, error)
+	AutocompleteTags(ctx context.Context, prefix string, limit uint64) ([]*models.Tag, error)
+	RenameTag(ctx context.Context, payload models.RenameTagPayload) error
+	MergeTags(ctx context.Context, payload models.MergeTagsPayload) error
+
+	AddReaction(ctx context.Context, payload models.ReactionPayload) (models.ReactionCounts, error)
+	RemoveReaction(ctx context.Context, payload models.ReactionPayload) (models.ReactionCounts, error)
+
+	ListPostRevisions(ctx context.Context, postID string) ([]*models.PostRevision, error)
+	GetPostRevision(ctx context.Context, postID, revisionID string) (*models.PostRevision, error)
+	DiffPostRevisions(ctx context.Context, postID, fromRevisionID, toRevisionID string) ([]models.FieldChange, error)
+	RollbackPost(ctx context.Context, postID, revisionID string) error
+	ListCommentRevisions(ctx context.Context, commentID string) ([]*models.CommentRevision, error)
+	GetCommentRevision(ctx context.Context, commentID, revisionID string) (*models.CommentRevision, error)
+	DiffCommentRevisions(ctx context.Context, commentID, fromRevisionID,
+		toRevisionID string) ([]models.FieldChange, error)
+	RollbackComment(ctx context.Context, commentID, revisionID string) error
+
+	CreateAttachment(ctx context.Context, payload models.CreateAttachmentPayload) (*models.Attachment, error)
+	GetAttachment(ctx context.Context, attachmentID string) (*models.Attachment, error)
+	ListAttachments(ctx context.Context, postID string) ([]*models.Attachment, error)
+	DeleteAttachment(ctx context.Context, attachmentID string) error
+}
+
+func attachmentModel(attachment *models.Attachment) *model.Attachment {
+	out := &model.Attachment{
+		ID:          attachment.ID,
+		PostID:      attachment.PostID,
+		CreatedBy:   attachment.CreatedBy,
+		CreatedAt:   attachment.CreatedAt.String(),
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        int(attachment.Size),
+		URL:         attachment.URL,
+		Variants:    make([]*model.AttachmentVariant, 0, len(attachment.Variants)),
+	}
+
+	if status, ok := graphqlVariantStatuses[attachment.VariantStatus]; ok {
+		out.VariantStatus = &status
+	}
+
+	for _, variant := range attachment.Variants {
+		out.Variants = append(out.Variants, &model.AttachmentVariant{
+			Name:        variant.Name,
+			ContentType: variant.ContentType,
+			Width:       variant.Width,
+			Height:      variant.Height,
+			Size:        int(variant.Size),
+			URL:         variant.URL,
+		})
+	}
+
+	return out
+}
+
+func postRevisionModel(revision *models.PostRevision) *model.PostRevision {
+	return &model.PostRevision{
+		ID:          revision.ID,
+		PostID:      revision.PostID,
+		EditedBy:    revision.EditedBy,
+		EditedAt:    revision.EditedAt.String(),
+		Title:       revision.Title,
+		Description: revision.Description,
+		Tags:        revision.Tags,
+	}
+}
+
+func commentRevisionModel(revision *models.CommentRevision) *model.CommentRevision {
+	return &model.CommentRevision{
+		ID:        revision.ID,
+		CommentID: revision.CommentID,
+		EditedBy:  revision.EditedBy,
+		EditedAt:  revision.EditedAt.String(),
+		Content:   revision.Content,
+	}
+}
+
+func fieldChangesModel(changes []models.FieldChange) []*model.FieldChange {
+	out := make([]*model.FieldChange, 0, len(changes))
+	for _, change := range changes {
+		out = append(out, &model.FieldChange{
+			Field: change.Field,
+			From:  change.From,
+			To:    change.To,
+		})
+	}
+
+	return out
+}
+
+// contentFormatModel returns the format of the content given in the input, or no format if it is not given.
+func contentFormatModel(format *model.ContentFormat) models.ContentFormat {
+	if format == nil {
+		return ""
+	}
+
+	return allowedContentFormats[*format]
+}
+
+// contentFormatToUpdate returns the format of the content if it is given in the input.
+func contentFormatToUpdate(format *model.ContentFormat) *models.ContentFormat {
+	if format == nil {
+		return nil
+	}
+
+	f := allowedContentFormats[*format]
+
+	return &f
+}
+
+func notificationModel(notification *models.Notification) *model.Notification {
+	out := &model.Notification{
+		ID:        notification.ID,
+		Type:      graphqlNotificationTypes[notification.Type],
+		ActorID:   notification.ActorID,
+		CreatedAt: notification.CreatedAt.String(),
+		Read:      notification.IsRead(),
+	}
+
+	if notification.PostID != "" {
+		out.PostID = &notification.PostID
+	}
+
+	if notification.CommentID != "" {
+		out.CommentID = &notification.CommentID
+	}
+
+	if reaction, ok := graphqlReactionTypes[notification.Reaction]; ok {
+		out.Reaction = &reaction
+	}
+
+	if notification.ReadAt != nil {
+		readAt := notification.ReadAt.String()
+		out.ReadAt = &readAt
+	}
+
+	return out
+}
+
+// notificationPreferencesModel lists the preferences in the order of the notification types.
+func notificationPreferencesModel(preferences models.NotificationPreferences) []*model.NotificationPreference {
+	out := make([]*model.NotificationPreference, 0, len(model.AllNotificationType))
+
+	for _, notificationType := range model.AllNotificationType {
+		out = append(out, &model.NotificationPreference{
+			Type:    notificationType,
+			Enabled: preferences.Enabled(allowedNotificationTypes[notificationType]),
+		})
+	}
+
+	return out
+}
+
+func reactionCountsModel(counts models.ReactionCounts) []*model.ReactionCount {
+	out := make([]*model.ReactionCount, 0, len(allowedReactionTypes))
+
+	for _, reactionType := range model.AllReactionType {
+		out = append(out, &model.ReactionCount{
+			Type:  reactionType,
+			Count: counts[allowedReactionTypes[reactionType]],
+		})
+	}
+
+	return out
 }
 
-func NewResolverConfig(s service, p currentUserInformationProvider) generated.Config {
+func mentionsModel(mentions []models.Mention) []*model.Mention {
+	out := make([]*model.Mention, 0, len(mentions))
+
+	for _, mention := range mentions {
+		out = append(out, &model.Mention{
+			UserID:   mention.UserID,
+			Username: mention.Username,
+		})
+	}
+
+	return out
+}
+
+func (r *Resolver) usersModel(ctx context.Context, users []*models.User) ([]*model.User, error) {
+	out := make([]*model.User, 0, len(users))
+
+	for _, user := range users {
+		u, err := r.Query().GetUser(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get user: %w", err)
+		}
+
+		out = append(out, u)
+	}
+
+	return out, nil
+}
+
+func NewResolverConfig(s service, p currentUserInformationProvider, maxLimit uint64) generated.Config {
 	r := &Resolver{
 		service:                        s,
 		currentUserInformationProvider: p,
+		maxLimit:                       maxLimit,
 	}
 
 	resolverConfig := generated.Config{
@@ -110,3 +587,32 @@ func NewResolverConfig(s service, p currentUserInformationProvider) generated.Co
 
 	return resolverConfig
 }
+
+func tagsModel(tags []*models.Tag) []*model.Tag {
+	out := make([]*model.Tag, 0, len(tags))
+
+	for _, tag := range tags {
+		out = append(out, &model.Tag{
+			Name:      tag.Name,
+			PostCount: tag.PostCount,
+		})
+	}
+
+	return out
+}
+
+// filterConditionParams returns the operator and the values of a filter input. The operator is EQ
+// when it is not given, and the values are taken from the single value when the list is empty.
+func filterConditionParams(operator *model.FilterOperator, value *string,
+	values []string) (models.FilterOperator, []string) {
+	out := models.FilterOperatorEq
+	if operator != nil {
+		out = allowedFilterOperators[*operator]
+	}
+
+	if len(values) == 0 && value != nil {
+		values = []string{*value}
+	}
+
+	return out, values
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/serhiihuberniuk/blog-api/models"
 	"github.com/serhiihuberniuk/blog-api/view/graphql/graph/generated"
 	"github.com/serhiihuberniuk/blog-api/view/graphql/graph/model"
@@ -30,21 +31,116 @@ func (r *commentResolver) Post(ctx context.Context, obj *model.Comment) (*model.
 	return post, nil
 }
 
-func (r *mutationResolver) Login(ctx context.Context, loginInput model.LoginInput) (string, error) {
-	token, err := r.service.Login(ctx, models.LoginPayload{
+func (r *commentResolver) Replies(ctx context.Context, obj *model.Comment) ([]*model.Comment, error) {
+	replies, err := r.service.ListReplies(ctx, obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get replies to comment: %w", err)
+	}
+
+	out := make([]*model.Comment, 0, len(replies))
+
+	for _, reply := range replies {
+		comment, err := r.Query().GetComment(ctx, reply.Comment.ID)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get reply: %w", err)
+		}
+
+		out = append(out, comment)
+	}
+
+	return out, nil
+}
+
+func (r *mutationResolver) Login(ctx context.Context, loginInput model.LoginInput) (*model.Tokens, error) {
+	tokens, err := r.service.Login(ctx, models.LoginPayload{
 		Email:    loginInput.Email,
 		Password: loginInput.Password,
 	})
 	if err != nil {
-		return "", fmt.Errorf("authentification failed: %w", err)
+		return nil, fmt.Errorf("authentification failed: %w", err)
+	}
+
+	return &model.Tokens{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	}, nil
+}
+
+func (r *mutationResolver) Refresh(ctx context.Context, refreshToken string) (*model.Tokens, error) {
+	tokens, err := r.service.Refresh(ctx, models.RefreshPayload{
+		RefreshToken: refreshToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot refresh tokens: %w", err)
+	}
+
+	return &model.Tokens{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	}, nil
+}
+
+func (r *mutationResolver) Logout(ctx context.Context, refreshToken string) (bool, error) {
+	if err := r.service.Logout(ctx, models.LogoutPayload{
+		RefreshToken: refreshToken,
+	}); err != nil {
+		return false, fmt.Errorf("cannot logout: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) ChangePassword(ctx context.Context, input model.ChangePasswordInput) (bool, error) {
+	if err := r.service.ChangePassword(ctx, input.OldPassword, input.NewPassword); err != nil {
+		return false, fmt.Errorf("cannot change password: %w", err)
 	}
 
-	return token, nil
+	return true, nil
+}
+
+func (r *mutationResolver) RequestPasswordReset(ctx context.Context, email string) (bool, error) {
+	if err := r.service.RequestPasswordReset(ctx, models.RequestPasswordResetPayload{
+		Email: email,
+	}); err != nil {
+		return false, fmt.Errorf("cannot request password reset: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) ResetPassword(ctx context.Context, input model.ResetPasswordInput) (bool, error) {
+	if err := r.service.ResetPassword(ctx, models.ResetPasswordPayload{
+		Token:       input.Token,
+		NewPassword: input.Password,
+	}); err != nil {
+		return false, fmt.Errorf("cannot reset password: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) VerifyEmail(ctx context.Context, token string) (bool, error) {
+	if err := r.service.VerifyEmail(ctx, models.VerifyEmailPayload{
+		Token: token,
+	}); err != nil {
+		return false, fmt.Errorf("cannot verify email: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) ResendEmailVerification(ctx context.Context) (bool, error) {
+	if err := r.service.ResendEmailVerification(ctx); err != nil {
+		return false, fmt.Errorf("cannot resend email verification: %w", err)
+	}
+
+	return true, nil
 }
 
 func (r *mutationResolver) CreateUser(ctx context.Context, input model.CreateUserInput) (*model.User, error) {
 	userId, err := r.service.CreateUser(ctx, models.CreateUserPayload{
 		Name:     input.Name,
+		Username: input.Username,
 		Email:    input.Email,
 		Password: input.Password,
 	})
@@ -57,20 +153,45 @@ func (r *mutationResolver) CreateUser(ctx context.Context, input model.CreateUse
 		return nil, fmt.Errorf("cannot get created user:%w", err)
 	}
 
+	// The new user is not signed in yet, so the email is not given back by the service.
+	user.Email = input.Email
+
 	return user, nil
 }
 
-func (r *mutationResolver) UpdateUser(ctx context.Context, input model.UpdateUserInput) (*model.User, error) {
+func (r *mutationResolver) UpdateUser(ctx context.Context, id *string, input model.UpdateUserInput) (*model.User, error) {
+	userID := r.currentUserInformationProvider.GetCurrentUserID(ctx)
+	if id != nil {
+		userID = *id
+	}
+
 	err := r.service.UpdateUser(ctx, models.UpdateUserPayload{
-		Name:     input.Name,
-		Email:    input.Email,
-		Password: input.Password,
+		UserID: userID,
+		Name:   input.Name,
+		Email:  input.Email,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("cannot update user: %w", err)
 	}
 
-	user, err := r.Query().GetUser(ctx, r.currentUserInformationProvider.GetCurrentUserID(ctx))
+	user, err := r.Query().GetUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get updated user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (r *mutationResolver) UpdateUserRole(ctx context.Context, id string, role model.Role) (*model.User, error) {
+	err := r.service.UpdateUserRole(ctx, models.UpdateUserRolePayload{
+		UserID: id,
+		Role:   allowedRoles[role],
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot update role of user: %w", err)
+	}
+
+	user, err := r.Query().GetUser(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("cannot get updated user: %w", err)
 	}
@@ -86,10 +207,48 @@ func (r *mutationResolver) DeleteUser(ctx context.Context) (bool, error) {
 	return true, nil
 }
 
+func (r *mutationResolver) DeleteUserByID(ctx context.Context, id string) (bool, error) {
+	if err := r.service.DeleteUserByID(ctx, id); err != nil {
+		return false, fmt.Errorf("cannot dalete user: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) RestoreUser(ctx context.Context, id string) (*model.User, error) {
+	if err := r.service.RestoreUser(ctx, id); err != nil {
+		return nil, fmt.Errorf("cannot restore user: %w", err)
+	}
+
+	user, err := r.Query().GetUser(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get restored user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (r *mutationResolver) Follow(ctx context.Context, id string) (bool, error) {
+	if err := r.service.Follow(ctx, id); err != nil {
+		return false, fmt.Errorf("cannot follow user: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) Unfollow(ctx context.Context, id string) (bool, error) {
+	if err := r.service.Unfollow(ctx, id); err != nil {
+		return false, fmt.Errorf("cannot unfollow user: %w", err)
+	}
+
+	return true, nil
+}
+
 func (r *mutationResolver) CreatePost(ctx context.Context, input model.CreatePostInput) (*model.Post, error) {
 	postId, err := r.service.CreatePost(ctx, models.CreatePostPayload{
 		Title:       input.Title,
 		Description: input.Description,
+		Format:      contentFormatModel(input.Format),
 		Tags:        input.Tags,
 	})
 	if err != nil {
@@ -105,11 +264,17 @@ func (r *mutationResolver) CreatePost(ctx context.Context, input model.CreatePos
 }
 
 func (r *mutationResolver) UpdatePost(ctx context.Context, id string, input model.UpdatePostInput) (*model.Post, error) {
+	var tags *[]string
+	if input.Tags != nil {
+		tags = &input.Tags
+	}
+
 	err := r.service.UpdatePost(ctx, models.UpdatePostPayload{
 		PostID:      id,
 		Title:       input.Title,
 		Description: input.Description,
-		Tags:        input.Tags,
+		Format:      contentFormatToUpdate(input.Format),
+		Tags:        tags,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("cannot update post: %w", err)
@@ -131,10 +296,146 @@ func (r *mutationResolver) DeletePost(ctx context.Context, id string) (bool, err
 	return true, nil
 }
 
+func (r *mutationResolver) RestorePost(ctx context.Context, id string) (*model.Post, error) {
+	if err := r.service.RestorePost(ctx, id); err != nil {
+		return nil, fmt.Errorf("cannot restore post: %w", err)
+	}
+
+	post, err := r.Query().GetPost(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get restored post: %w", err)
+	}
+
+	return post, nil
+}
+
+func (r *mutationResolver) SubmitPost(ctx context.Context, id string) (*model.Post, error) {
+	if err := r.service.SubmitPost(ctx, id); err != nil {
+		return nil, fmt.Errorf("cannot submit post: %w", err)
+	}
+
+	post, err := r.Query().GetPost(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get submitted post: %w", err)
+	}
+
+	return post, nil
+}
+
+func (r *mutationResolver) PublishPost(ctx context.Context, id string) (*model.Post, error) {
+	if err := r.service.PublishPost(ctx, id); err != nil {
+		return nil, fmt.Errorf("cannot publish post: %w", err)
+	}
+
+	post, err := r.Query().GetPost(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get published post: %w", err)
+	}
+
+	return post, nil
+}
+
+func (r *mutationResolver) UnpublishPost(ctx context.Context, id string) (*model.Post, error) {
+	if err := r.service.UnpublishPost(ctx, id); err != nil {
+		return nil, fmt.Errorf("cannot unpublish post: %w", err)
+	}
+
+	post, err := r.Query().GetPost(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get unpublished post: %w", err)
+	}
+
+	return post, nil
+}
+
+func (r *mutationResolver) ArchivePost(ctx context.Context, id string) (*model.Post, error) {
+	if err := r.service.ArchivePost(ctx, id); err != nil {
+		return nil, fmt.Errorf("cannot archive post: %w", err)
+	}
+
+	post, err := r.Query().GetPost(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get archived post: %w", err)
+	}
+
+	return post, nil
+}
+
+func (r *mutationResolver) RollbackPost(ctx context.Context, id string, revisionID string) (*model.Post, error) {
+	if err := r.service.RollbackPost(ctx, id, revisionID); err != nil {
+		return nil, fmt.Errorf("cannot rollback post: %w", err)
+	}
+
+	post, err := r.Query().GetPost(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get rolled back post: %w", err)
+	}
+
+	return post, nil
+}
+
+func (r *mutationResolver) UploadAttachment(ctx context.Context, postID string, file graphql.Upload) (*model.Attachment, error) {
+	attachment, err := r.service.CreateAttachment(ctx, models.CreateAttachmentPayload{
+		PostID:   postID,
+		FileName: file.Filename,
+		Content:  file.File,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot upload attachment: %w", err)
+	}
+
+	return attachmentModel(attachment), nil
+}
+
+func (r *mutationResolver) DeleteAttachment(ctx context.Context, id string) (bool, error) {
+	if err := r.service.DeleteAttachment(ctx, id); err != nil {
+		return false, fmt.Errorf("cannot delete attachment: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) MarkNotificationRead(ctx context.Context, id string) (bool, error) {
+	if err := r.service.MarkNotificationRead(ctx, id); err != nil {
+		return false, fmt.Errorf("cannot mark notification as read: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) MarkAllNotificationsRead(ctx context.Context) (bool, error) {
+	if err := r.service.MarkAllNotificationsRead(ctx); err != nil {
+		return false, fmt.Errorf("cannot mark notifications as read: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) UpdateNotificationPreferences(ctx context.Context, preferences []*model.NotificationPreferenceInput) ([]*model.NotificationPreference, error) {
+	changes := make(models.NotificationPreferences, len(preferences))
+	for _, preference := range preferences {
+		changes[allowedNotificationTypes[preference.Type]] = preference.Enabled
+	}
+
+	updated, err := r.service.UpdateNotificationPreferences(ctx, changes)
+	if err != nil {
+		return nil, fmt.Errorf("cannot update notification preferences: %w", err)
+	}
+
+	return notificationPreferencesModel(updated), nil
+}
+
 func (r *mutationResolver) CreateComment(ctx context.Context, input model.CreateCommentInput) (*model.Comment, error) {
+	var parentID string
+	if input.ParentID != nil {
+		parentID = *input.ParentID
+	}
+
 	commentID, err := r.service.CreateComment(ctx, models.CreateCommentPayload{
-		Content: input.Content,
-		PostID:  input.PostID,
+		Content:  input.Content,
+		Format:   contentFormatModel(input.Format),
+		PostID:   input.PostID,
+		ParentID: parentID,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("cannot create comment: %w", err)
@@ -152,6 +453,7 @@ func (r *mutationResolver) UpdateComment(ctx context.Context, id string, input m
 	err := r.service.UpdateComment(ctx, models.UpdateCommentPayload{
 		CommentID: id,
 		Content:   input.Content,
+		Format:    contentFormatToUpdate(input.Format),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("cannot update comment: %w", err)
@@ -173,6 +475,82 @@ func (r *mutationResolver) DeleteComment(ctx context.Context, id string) (bool,
 	return true, nil
 }
 
+func (r *mutationResolver) RestoreComment(ctx context.Context, id string) (*model.Comment, error) {
+	if err := r.service.RestoreComment(ctx, id); err != nil {
+		return nil, fmt.Errorf("cannot restore comment: %w", err)
+	}
+
+	comment, err := r.Query().GetComment(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get restored comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+func (r *mutationResolver) RollbackComment(ctx context.Context, id string, revisionID string) (*model.Comment, error) {
+	if err := r.service.RollbackComment(ctx, id, revisionID); err != nil {
+		return nil, fmt.Errorf("cannot rollback comment: %w", err)
+	}
+
+	comment, err := r.Query().GetComment(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get rolled back comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+func (r *mutationResolver) RenameTag(ctx context.Context, name string, newName string) (bool, error) {
+	err := r.service.RenameTag(ctx, models.RenameTagPayload{
+		Name:    name,
+		NewName: newName,
+	})
+	if err != nil {
+		return false, fmt.Errorf("cannot rename tag: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) MergeTags(ctx context.Context, from string, into string) (bool, error) {
+	err := r.service.MergeTags(ctx, models.MergeTagsPayload{
+		From: from,
+		Into: into,
+	})
+	if err != nil {
+		return false, fmt.Errorf("cannot merge tags: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) AddReaction(ctx context.Context, target model.ReactionTarget, id string, typeArg model.ReactionType) ([]*model.ReactionCount, error) {
+	counts, err := r.service.AddReaction(ctx, models.ReactionPayload{
+		TargetType: allowedReactionTargets[target],
+		TargetID:   id,
+		Type:       allowedReactionTypes[typeArg],
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot add reaction: %w", err)
+	}
+
+	return reactionCountsModel(counts), nil
+}
+
+func (r *mutationResolver) RemoveReaction(ctx context.Context, target model.ReactionTarget, id string, typeArg model.ReactionType) ([]*model.ReactionCount, error) {
+	counts, err := r.service.RemoveReaction(ctx, models.ReactionPayload{
+		TargetType: allowedReactionTargets[target],
+		TargetID:   id,
+		Type:       allowedReactionTypes[typeArg],
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot remove reaction: %w", err)
+	}
+
+	return reactionCountsModel(counts), nil
+}
+
 func (r *postResolver) CreatedBy(ctx context.Context, obj *model.Post) (*model.User, error) {
 	user, err := r.Query().GetUser(ctx, obj.AuthorID)
 	if err != nil {
@@ -188,28 +566,64 @@ func (r *queryResolver) GetUser(ctx context.Context, id string) (*model.User, er
 		return nil, fmt.Errorf("cannot get user: %w", err)
 	}
 
+	var pendingEmail *string
+	if user.PendingEmail != "" {
+		pendingEmail = &user.PendingEmail
+	}
+
 	return &model.User{
 		ID:        user.ID,
 		Name:      user.Name,
+		Username:  user.Username,
 		Email:     user.Email,
 		CreatedAt: user.CreatedAt.String(),
 		UpdatedAt: user.UpdatedAt.String(),
+		Role:      graphqlRoles[user.Role],
+
+		EmailVerified: user.IsEmailVerified(),
+		PendingEmail:  pendingEmail,
 	}, nil
 }
 
+func (r *queryResolver) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
+	user, err := r.service.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get user: %w", err)
+	}
+
+	return r.Query().GetUser(ctx, user.ID)
+}
+
 func (r *queryResolver) GetPost(ctx context.Context, id string) (*model.Post, error) {
-	post, err := r.service.GetPost(ctx, id)
+	post, err := r.service.GetPostByIDOrSlug(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("cannot get post: %w", err)
 	}
 
 	out := &model.Post{
-		ID:          post.ID,
-		Title:       post.Title,
-		Description: post.Description,
-		AuthorID:    post.CreatedBy,
-		CreatedAt:   post.CreatedAt.String(),
-		Tags:        post.Tags,
+		ID:           post.ID,
+		Title:        post.Title,
+		Description:  post.Description,
+		AuthorID:     post.CreatedBy,
+		CreatedAt:    post.CreatedAt.String(),
+		Tags:         post.Tags,
+		UpdatedAt:    post.UpdatedAt.String(),
+		Edited:       post.IsEdited(),
+		Status:       graphqlPostStatuses[post.Status],
+		Slug:         post.Slug,
+		Reactions:    reactionCountsModel(post.Reactions),
+		CommentCount: post.CommentCount,
+
+		Format:          graphqlContentFormats[post.Format.OrPlain()],
+		DescriptionHTML: post.DescriptionHTML,
+		WordCount:       post.WordCount,
+		ReadingTime:     post.ReadingTime(),
+		Mentions:        mentionsModel(post.Mentions),
+	}
+
+	if post.LastCommentedAt != nil {
+		lastCommentedAt := post.LastCommentedAt.String()
+		out.LastCommentedAt = &lastCommentedAt
 	}
 
 	out.CreatedBy, err = r.Post().CreatedBy(ctx, out)
@@ -232,6 +646,16 @@ func (r *queryResolver) GetComment(ctx context.Context, id string) (*model.Comme
 		AuthorID:  comment.CreatedBy,
 		CreatedAt: comment.CreatedAt.String(),
 		PostID:    comment.PostID,
+		UpdatedAt: comment.UpdatedAt.String(),
+		Edited:    comment.IsEdited(),
+		ParentID:  comment.ParentID,
+		Reactions: reactionCountsModel(comment.Reactions),
+
+		Format:      graphqlContentFormats[comment.Format.OrPlain()],
+		ContentHTML: comment.ContentHTML,
+		WordCount:   comment.WordCount,
+		ReadingTime: comment.ReadingTime(),
+		Mentions:    mentionsModel(comment.Mentions),
 	}
 
 	out.CreatedBy, err = r.Comment().CreatedBy(ctx, out)
@@ -247,39 +671,110 @@ func (r *queryResolver) GetComment(ctx context.Context, id string) (*model.Comme
 	return out, nil
 }
 
-func (r *queryResolver) ListPosts(ctx context.Context, paginationInput *model.PaginationInput, filterPostsInput *model.FilterPostInput, sortPostsInput *model.SortPostsInput) ([]*model.Post, error) {
-	pagination := getPaginationParams(paginationInput)
+func (r *queryResolver) ListFollowers(ctx context.Context, id string, paginationInput *model.PaginationInput) ([]*model.User, error) {
+	users, err := r.service.ListFollowers(ctx, id, r.getPaginationParams(paginationInput))
+	if err != nil {
+		return nil, fmt.Errorf("cannot get followers: %w", err)
+	}
 
-	filterPost := models.FilterPosts{}
+	return r.usersModel(ctx, users)
+}
 
-	if filterPostsInput != nil {
-		if filterPostsInput.Field.IsValid() {
-			filterPost = models.FilterPosts{
-				Field: allowedFilterPostsFields[filterPostsInput.Field],
-				Value: filterPostsInput.Value,
-			}
-		}
+func (r *queryResolver) ListFollowing(ctx context.Context, id string, paginationInput *model.PaginationInput) ([]*model.User, error) {
+	users, err := r.service.ListFollowing(ctx, id, r.getPaginationParams(paginationInput))
+	if err != nil {
+		return nil, fmt.Errorf("cannot get followed users: %w", err)
 	}
 
-	sortPost := models.SortPosts{
-		IsASC: true,
+	return r.usersModel(ctx, users)
+}
+
+func (r *queryResolver) Feed(ctx context.Context, first *int, after *string, last *int, before *string) (*model.PostConnection, error) {
+	pagination, err := r.getConnectionParams(ctx, first, after, last, before)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get feed: %w", err)
 	}
 
-	if sortPostsInput != nil {
-		if sortPostsInput.Field.IsValid() {
-			sortPost.SortByField = allowedSortPostsFields[sortPostsInput.Field]
-			sortPost.IsASC = sortPostsInput.IsAsc
+	page, err := r.service.Feed(ctx, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get feed: %w", err)
+	}
+
+	return r.postConnection(ctx, page)
+}
+
+func (r *queryResolver) Search(ctx context.Context, query string, targets []model.SearchTarget, paginationInput *model.PaginationInput) ([]*model.SearchResult, error) {
+	searchQuery := models.SearchQuery{
+		Text: query,
+	}
+
+	for _, target := range targets {
+		searchQuery.Targets = append(searchQuery.Targets, allowedSearchTargets[target])
+	}
+
+	results, err := r.service.Search(ctx, searchQuery, r.getPaginationParams(paginationInput))
+	if err != nil {
+		return nil, fmt.Errorf("cannot search: %w", err)
+	}
+
+	out := make([]*model.SearchResult, 0, len(results))
+
+	for _, result := range results {
+		found := &model.SearchResult{
+			Target:  graphqlSearchTargets[result.Target],
+			ID:      result.ID,
+			Rank:    result.Rank,
+			Snippet: result.Snippet,
+		}
+
+		switch result.Target {
+		case models.SearchTargetPost:
+			found.Post, err = r.Query().GetPost(ctx, result.ID)
+		case models.SearchTargetComment:
+			found.Comment, err = r.Query().GetComment(ctx, result.ID)
 		}
+
+		if err != nil {
+			return nil, fmt.Errorf("cannot get found %s: %w", result.Target, err)
+		}
+
+		out = append(out, found)
+	}
+
+	return out, nil
+}
+
+func (r *queryResolver) ListTags(ctx context.Context, paginationInput *model.PaginationInput) ([]*model.Tag, error) {
+	tags, err := r.service.ListTags(ctx, r.getPaginationParams(paginationInput))
+	if err != nil {
+		return nil, fmt.Errorf("cannot get tags: %w", err)
 	}
 
-	posts, err := r.service.ListPosts(ctx, pagination, filterPost, sortPost)
+	return tagsModel(tags), nil
+}
+
+func (r *queryResolver) AutocompleteTags(ctx context.Context, prefix string, limit *int) ([]*model.Tag, error) {
+	tags, err := r.service.AutocompleteTags(ctx, prefix, r.pageLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("cannot autocomplete tags: %w", err)
+	}
+
+	return tagsModel(tags), nil
+}
+
+func (r *queryResolver) ListPosts(ctx context.Context, paginationInput *model.PaginationInput, filterPostsInput *model.FilterPostInput, sortPostsInput []*model.SortPostsInput, filters []*model.FilterPostInput) ([]*model.Post, error) {
+	pagination := r.getPaginationParams(paginationInput)
+
+	page, err := r.service.ListPosts(ctx, pagination,
+		postsFilterParams(append([]*model.FilterPostInput{filterPostsInput}, filters...)),
+		postsSortParams(sortPostsInput))
 	if err != nil {
 		return nil, fmt.Errorf("cannot get list of posts: %w", err)
 	}
 
-	listPosts := make([]*model.Post, 0, len(posts))
+	listPosts := make([]*model.Post, 0, len(page.Posts))
 
-	for _, post := range posts {
+	for _, post := range page.Posts {
 		out, err := r.Query().GetPost(ctx, post.ID)
 		if err != nil {
 			return nil, fmt.Errorf("cannot get post, %w", err)
@@ -291,48 +786,195 @@ func (r *queryResolver) ListPosts(ctx context.Context, paginationInput *model.Pa
 	return listPosts, nil
 }
 
-func (r *queryResolver) ListComments(ctx context.Context, paginationInput *model.PaginationInput, filterCommentsInput *model.FilterCommentsInput, sortCommentsInput *model.SortCommentsInput) ([]*model.Comment, error) {
-	pagination := getPaginationParams(paginationInput)
+func (r *queryResolver) ListComments(ctx context.Context, paginationInput *model.PaginationInput, filterCommentsInput *model.FilterCommentsInput, sortCommentsInput []*model.SortCommentsInput, filters []*model.FilterCommentsInput) ([]*model.Comment, error) {
+	pagination := r.getPaginationParams(paginationInput)
 
-	filterComments := models.FilterComments{}
+	page, err := r.service.ListComments(ctx, pagination,
+		commentsFilterParams(append([]*model.FilterCommentsInput{filterCommentsInput}, filters...)),
+		commentsSortParams(sortCommentsInput))
+	if err != nil {
+		return nil, fmt.Errorf("cannot get list of comments: %w", err)
+	}
 
-	if filterCommentsInput != nil {
-		if filterCommentsInput.Field.IsValid() {
-			filterComments = models.FilterComments{
-				Field: allowedFilterCommentsFields[filterCommentsInput.Field],
-				Value: filterCommentsInput.Value,
-			}
+	listComments := make([]*model.Comment, 0, len(page.Comments))
+
+	for _, comment := range page.Comments {
+		out, err := r.Query().GetComment(ctx, comment.ID)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get comment: %w", err)
 		}
+
+		listComments = append(listComments, out)
 	}
 
-	sortComments := models.SortComments{
-		IsASC: true,
+	return listComments, nil
+}
+
+func (r *queryResolver) Posts(ctx context.Context, first *int, after *string, last *int, before *string, filters []*model.FilterPostInput, sort []*model.SortPostsInput) (*model.PostConnection, error) {
+	pagination, err := r.getConnectionParams(ctx, first, after, last, before)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get posts: %w", err)
 	}
 
-	if sortCommentsInput != nil {
-		if sortCommentsInput.Field.IsValid() {
-			sortComments.Field = allowedSortCommentsFields[sortCommentsInput.Field]
-			sortComments.IsASC = sortCommentsInput.IsAsc
-		}
+	page, err := r.service.ListPosts(ctx, pagination, postsFilterParams(filters), postsSortParams(sort))
+	if err != nil {
+		return nil, fmt.Errorf("cannot get posts: %w", err)
 	}
 
-	comments, err := r.service.ListComments(ctx, pagination, filterComments, sortComments)
+	return r.postConnection(ctx, page)
+}
+
+func (r *queryResolver) Comments(ctx context.Context, first *int, after *string, last *int, before *string, filters []*model.FilterCommentsInput, sort []*model.SortCommentsInput) (*model.CommentConnection, error) {
+	pagination, err := r.getConnectionParams(ctx, first, after, last, before)
 	if err != nil {
-		return nil, fmt.Errorf("cannot get list of comments: %w", err)
+		return nil, fmt.Errorf("cannot get comments: %w", err)
 	}
 
-	listComments := make([]*model.Comment, 0, len(comments))
+	page, err := r.service.ListComments(ctx, pagination, commentsFilterParams(filters), commentsSortParams(sort))
+	if err != nil {
+		return nil, fmt.Errorf("cannot get comments: %w", err)
+	}
 
-	for _, comment := range comments {
-		out, err := r.Query().GetComment(ctx, comment.ID)
+	edges := make([]*model.CommentEdge, 0, len(page.Comments))
+
+	for _, comment := range page.Comments {
+		node, err := r.Query().GetComment(ctx, comment.ID)
 		if err != nil {
 			return nil, fmt.Errorf("cannot get comment: %w", err)
 		}
 
-		listComments = append(listComments, out)
+		edges = append(edges, &model.CommentEdge{
+			Cursor: models.NewCommentCursor(comment, page.Sort).String(),
+			Node:   node,
+		})
 	}
 
-	return listComments, nil
+	var start, end *models.Cursor
+	if len(page.Comments) != 0 {
+		start = models.NewCommentCursor(page.Comments[0], page.Sort)
+		end = models.NewCommentCursor(page.Comments[len(page.Comments)-1], page.Sort)
+	}
+
+	return &model.CommentConnection{
+		Edges:      edges,
+		PageInfo:   pageInfoModel(page.PageInfo, start, end),
+		TotalCount: page.Total,
+	}, nil
+}
+
+func (r *queryResolver) ListPostRevisions(ctx context.Context, postID string) ([]*model.PostRevision, error) {
+	revisions, err := r.service.ListPostRevisions(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get revisions of post: %w", err)
+	}
+
+	out := make([]*model.PostRevision, 0, len(revisions))
+	for _, revision := range revisions {
+		out = append(out, postRevisionModel(revision))
+	}
+
+	return out, nil
+}
+
+func (r *queryResolver) GetPostRevision(ctx context.Context, postID string, id string) (*model.PostRevision, error) {
+	revision, err := r.service.GetPostRevision(ctx, postID, id)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get revision of post: %w", err)
+	}
+
+	return postRevisionModel(revision), nil
+}
+
+func (r *queryResolver) DiffPostRevisions(ctx context.Context, postID string, from string, to string) ([]*model.FieldChange, error) {
+	changes, err := r.service.DiffPostRevisions(ctx, postID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("cannot compare revisions of post: %w", err)
+	}
+
+	return fieldChangesModel(changes), nil
+}
+
+func (r *queryResolver) ListCommentRevisions(ctx context.Context, commentID string) ([]*model.CommentRevision, error) {
+	revisions, err := r.service.ListCommentRevisions(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get revisions of comment: %w", err)
+	}
+
+	out := make([]*model.CommentRevision, 0, len(revisions))
+	for _, revision := range revisions {
+		out = append(out, commentRevisionModel(revision))
+	}
+
+	return out, nil
+}
+
+func (r *queryResolver) GetCommentRevision(ctx context.Context, commentID string, id string) (*model.CommentRevision, error) {
+	revision, err := r.service.GetCommentRevision(ctx, commentID, id)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get revision of comment: %w", err)
+	}
+
+	return commentRevisionModel(revision), nil
+}
+
+func (r *queryResolver) DiffCommentRevisions(ctx context.Context, commentID string, from string, to string) ([]*model.FieldChange, error) {
+	changes, err := r.service.DiffCommentRevisions(ctx, commentID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("cannot compare revisions of comment: %w", err)
+	}
+
+	return fieldChangesModel(changes), nil
+}
+
+func (r *queryResolver) GetAttachment(ctx context.Context, id string) (*model.Attachment, error) {
+	attachment, err := r.service.GetAttachment(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get attachment: %w", err)
+	}
+
+	return attachmentModel(attachment), nil
+}
+
+func (r *queryResolver) ListAttachments(ctx context.Context, postID string) ([]*model.Attachment, error) {
+	attachments, err := r.service.ListAttachments(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get attachments of post: %w", err)
+	}
+
+	out := make([]*model.Attachment, 0, len(attachments))
+	for _, attachment := range attachments {
+		out = append(out, attachmentModel(attachment))
+	}
+
+	return out, nil
+}
+
+func (r *queryResolver) Notifications(ctx context.Context, unreadOnly *bool, paginationInput *model.PaginationInput) (*model.NotificationList, error) {
+	list, err := r.service.ListNotifications(ctx, r.getPaginationParams(paginationInput),
+		unreadOnly != nil && *unreadOnly)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get notifications: %w", err)
+	}
+
+	out := &model.NotificationList{
+		Notifications: make([]*model.Notification, 0, len(list.Notifications)),
+		UnreadCount:   list.UnreadCount,
+	}
+
+	for _, notification := range list.Notifications {
+		out.Notifications = append(out.Notifications, notificationModel(notification))
+	}
+
+	return out, nil
+}
+
+func (r *queryResolver) NotificationPreferences(ctx context.Context) ([]*model.NotificationPreference, error) {
+	preferences, err := r.service.GetNotificationPreferences(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get notification preferences: %w", err)
+	}
+
+	return notificationPreferencesModel(preferences), nil
 }
 
 // Comment returns generated.CommentResolver implementation.
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/serhiihuberniuk/blog-api/models"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -12,8 +13,12 @@ import (
 )
 
 var publicAccess = map[string]bool{
-	"/grpc.BlogApi/Login":      true,
-	"/grpc.BlogApi/CreateUser": true,
+	"/grpc.BlogApi/Login":                true,
+	"/grpc.BlogApi/Refresh":              true,
+	"/grpc.BlogApi/RequestPasswordReset": true,
+	"/grpc.BlogApi/ResetPassword":        true,
+	"/grpc.BlogApi/VerifyEmail":          true,
+	"/grpc.BlogApi/CreateUser":           true,
 }
 
 type AuthInterceptor struct {
@@ -46,11 +51,14 @@ func NewAuthInterceptor(s service, p currentUserInformationProvider) *AuthInterc
 }
 
 type service interface {
-	ParseToken(tokenString string) (string, error)
+	ParseToken(ctx context.Context, tokenString string) (*models.AuthInfo, error)
 }
 
 type currentUserInformationProvider interface {
 	SetCurrentUserID(ctx context.Context, userID string) context.Context
+	SetCurrentUserRole(ctx context.Context, role models.Role) context.Context
+	SetCurrentTokenID(ctx context.Context, tokenID string) context.Context
+	SetCurrentSessionID(ctx context.Context, sessionID string) context.Context
 }
 
 func (i *AuthInterceptor) UnaryAuthInterceptor(ctx context.Context,
@@ -61,49 +69,54 @@ func (i *AuthInterceptor) UnaryAuthInterceptor(ctx context.Context,
 		return handler(ctx, req)
 	}
 
-	userID, err := i.auth(ctx)
+	authInfo, err := i.auth(ctx)
 	if err != nil {
 		return nil, status.Error(codes.Unauthenticated, codes.Unauthenticated.String())
 	}
 
-	ctx = i.currentUserInformationProvider.SetCurrentUserID(ctx, userID)
-
-	return handler(ctx, req)
+	return handler(i.setAuthInfo(ctx, authInfo), req)
 }
 
 func (i *AuthInterceptor) StreamAuthInterceptor(srv interface{},
 	ss grpc.ServerStream,
 	_ *grpc.StreamServerInfo,
 	handler grpc.StreamHandler) error {
-	userID, err := i.auth(ss.Context())
+	authInfo, err := i.auth(ss.Context())
 	if err != nil {
 		return status.Error(codes.Unauthenticated, codes.Unauthenticated.String())
 	}
 
 	wrappedServerStream := wrapServerStream(ss)
-	wrappedServerStream.wrappedContext = i.currentUserInformationProvider.
-		SetCurrentUserID(wrappedServerStream.wrappedContext, userID)
+	wrappedServerStream.wrappedContext = i.setAuthInfo(wrappedServerStream.wrappedContext, authInfo)
 
 	return handler(srv, wrappedServerStream)
 }
 
-func (i *AuthInterceptor) auth(ctx context.Context) (string, error) {
+func (i *AuthInterceptor) auth(ctx context.Context) (*models.AuthInfo, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return "", errors.New("metadata is empty")
+		return nil, errors.New("metadata is empty")
 	}
 
 	values := md["authorization"]
 	if len(values) == 0 {
-		return "", errors.New("authorization token is not provided")
+		return nil, errors.New("authorization token is not provided")
 	}
 
 	token := values[0]
 
-	userID, err := i.service.ParseToken(token)
+	authInfo, err := i.service.ParseToken(ctx, token)
 	if err != nil {
-		return "", fmt.Errorf("cannot parse token: %w", err)
+		return nil, fmt.Errorf("cannot parse token: %w", err)
 	}
 
-	return userID, nil
+	return authInfo, nil
+}
+
+func (i *AuthInterceptor) setAuthInfo(ctx context.Context, authInfo *models.AuthInfo) context.Context {
+	ctx = i.currentUserInformationProvider.SetCurrentUserID(ctx, authInfo.UserID)
+	ctx = i.currentUserInformationProvider.SetCurrentUserRole(ctx, authInfo.Role)
+	ctx = i.currentUserInformationProvider.SetCurrentTokenID(ctx, authInfo.TokenID)
+
+	return i.currentUserInformationProvider.SetCurrentSessionID(ctx, authInfo.SessionID)
 }
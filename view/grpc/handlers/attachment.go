@@ -0,0 +1,144 @@
+package grpcHandlers
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/serhiihuberniuk/blog-api/view/grpc/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// UploadAttachment takes the information about the file from the first message of the stream and passes
+// the chunks of the following ones to the service as they arrive.
+func (h *Handlers) UploadAttachment(stream pb.BlogApi_UploadAttachmentServer) error {
+	request, err := stream.Recv()
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "attachment info is missing")
+	}
+
+	info := request.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "first message must carry attachment info")
+	}
+
+	content := &chunkReader{stream: stream}
+
+	attachment, err := h.service.CreateAttachment(stream.Context(), models.CreateAttachmentPayload{
+		PostID:   info.GetPostId(),
+		FileName: info.GetFileName(),
+		Content:  content,
+	})
+	if err != nil {
+		if content.err != nil {
+			return content.err
+		}
+
+		return errorStatusGrpc(err)
+	}
+
+	return stream.SendAndClose(attachmentResponse(attachment))
+}
+
+func (h *Handlers) GetAttachment(ctx context.Context,
+	request *pb.GetAttachmentRequest) (*pb.GetAttachmentResponse, error) {
+	attachment, err := h.service.GetAttachment(ctx, request.GetId())
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return attachmentResponse(attachment), nil
+}
+
+func (h *Handlers) ListAttachments(ctx context.Context,
+	request *pb.ListAttachmentsRequest) (*pb.ListAttachmentsResponse, error) {
+	attachments, err := h.service.ListAttachments(ctx, request.GetPostId())
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	response := make([]*pb.GetAttachmentResponse, 0, len(attachments))
+	for _, attachment := range attachments {
+		response = append(response, attachmentResponse(attachment))
+	}
+
+	return &pb.ListAttachmentsResponse{
+		Attachments: response,
+	}, nil
+}
+
+func (h *Handlers) DeleteAttachment(ctx context.Context,
+	request *pb.DeleteAttachmentRequest) (*pb.DeleteAttachmentResponse, error) {
+	if err := h.service.DeleteAttachment(ctx, request.GetId()); err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.DeleteAttachmentResponse{}, nil
+}
+
+// chunkReader reads the content of the file from the chunks of the upload stream.
+type chunkReader struct {
+	stream pb.BlogApi_UploadAttachmentServer
+	chunk  []byte
+	// err is the status to return if the stream is broken, rather than the error of the service.
+	err error
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		request, err := r.stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return 0, io.EOF
+		}
+
+		if err != nil {
+			r.err = err
+
+			return 0, err
+		}
+
+		if request.GetInfo() != nil {
+			r.err = status.Error(codes.InvalidArgument, "attachment info is sent twice")
+
+			return 0, r.err
+		}
+
+		r.chunk = request.GetChunk()
+	}
+
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+
+	return n, nil
+}
+
+func attachmentResponse(attachment *models.Attachment) *pb.GetAttachmentResponse {
+	out := &pb.GetAttachmentResponse{
+		Id:            attachment.ID,
+		PostId:        attachment.PostID,
+		CreatedBy:     attachment.CreatedBy,
+		CreatedAt:     timestamppb.New(attachment.CreatedAt),
+		FileName:      attachment.FileName,
+		ContentType:   attachment.ContentType,
+		Size:          attachment.Size,
+		Url:           attachment.URL,
+		VariantStatus: string(attachment.VariantStatus),
+		Variants:      make([]*pb.AttachmentVariant, 0, len(attachment.Variants)),
+	}
+
+	for _, variant := range attachment.Variants {
+		out.Variants = append(out.Variants, &pb.AttachmentVariant{
+			Name:        variant.Name,
+			ContentType: variant.ContentType,
+			Width:       int32(variant.Width),
+			Height:      int32(variant.Height),
+			Size:        variant.Size,
+			Url:         variant.URL,
+		})
+	}
+
+	return out
+}
@@ -8,7 +8,7 @@ import (
 )
 
 func (h *Handlers) Login(ctx context.Context, request *pb.LoginRequest) (*pb.LoginResponse, error) {
-	token, err := h.service.Login(ctx, viewmodels.LoginPayload{
+	tokens, err := h.service.Login(ctx, viewmodels.LoginPayload{
 		Email:    request.GetEmail(),
 		Password: request.GetPassword(),
 	})
@@ -17,6 +17,86 @@ func (h *Handlers) Login(ctx context.Context, request *pb.LoginRequest) (*pb.Log
 	}
 
 	return &pb.LoginResponse{
-		Token: token,
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 	}, nil
 }
+
+func (h *Handlers) Refresh(ctx context.Context, request *pb.RefreshRequest) (*pb.RefreshResponse, error) {
+	tokens, err := h.service.Refresh(ctx, viewmodels.RefreshPayload{
+		RefreshToken: request.GetRefreshToken(),
+	})
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.RefreshResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	}, nil
+}
+
+func (h *Handlers) Logout(ctx context.Context, request *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	err := h.service.Logout(ctx, viewmodels.LogoutPayload{
+		RefreshToken: request.GetRefreshToken(),
+	})
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.LogoutResponse{}, nil
+}
+
+func (h *Handlers) ChangePassword(ctx context.Context,
+	request *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
+	if err := h.service.ChangePassword(ctx, request.GetOldPassword(), request.GetNewPassword()); err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.ChangePasswordResponse{}, nil
+}
+
+func (h *Handlers) RequestPasswordReset(ctx context.Context,
+	request *pb.RequestPasswordResetRequest) (*pb.RequestPasswordResetResponse, error) {
+	err := h.service.RequestPasswordReset(ctx, viewmodels.RequestPasswordResetPayload{
+		Email: request.GetEmail(),
+	})
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.RequestPasswordResetResponse{}, nil
+}
+
+func (h *Handlers) ResetPassword(ctx context.Context,
+	request *pb.ResetPasswordRequest) (*pb.ResetPasswordResponse, error) {
+	err := h.service.ResetPassword(ctx, viewmodels.ResetPasswordPayload{
+		Token:       request.GetToken(),
+		NewPassword: request.GetPassword(),
+	})
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.ResetPasswordResponse{}, nil
+}
+
+func (h *Handlers) VerifyEmail(ctx context.Context, request *pb.VerifyEmailRequest) (*pb.VerifyEmailResponse, error) {
+	err := h.service.VerifyEmail(ctx, viewmodels.VerifyEmailPayload{
+		Token: request.GetToken(),
+	})
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.VerifyEmailResponse{}, nil
+}
+
+func (h *Handlers) ResendEmailVerification(ctx context.Context,
+	_ *pb.ResendEmailVerificationRequest) (*pb.ResendEmailVerificationResponse, error) {
+	if err := h.service.ResendEmailVerification(ctx); err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.ResendEmailVerificationResponse{}, nil
+}
@@ -16,16 +16,20 @@ var (
 		pb.ListCommentsRequest_Filter_CREATED_BY:    models.FilterCommentsByAuthor,
 	}
 	allowedSortCommentsFields = map[pb.ListCommentsRequest_Sort_Field]models.SortCommentsByField{
-		pb.ListCommentsRequest_Sort_UNKNOWN_FIELD: "",
-		pb.ListCommentsRequest_Sort_CREATED_AT:    models.SortCommentByCreatedAt,
+		pb.ListCommentsRequest_Sort_UNKNOWN_FIELD:  "",
+		pb.ListCommentsRequest_Sort_CREATED_AT:     models.SortCommentByCreatedAt,
+		pb.ListCommentsRequest_Sort_UPDATED_AT:     models.SortCommentByUpdatedAt,
+		pb.ListCommentsRequest_Sort_REACTION_COUNT: models.SortCommentByReactionCount,
 	}
 )
 
 func (h *Handlers) CreateComment(ctx context.Context,
 	request *pb.CreateCommentRequest) (*pb.CreateCommentResponse, error) {
 	commentID, err := h.service.CreateComment(ctx, models.CreateCommentPayload{
-		Content: request.GetContent(),
-		PostID:  request.GetPostId(),
+		Content:  request.GetContent(),
+		Format:   models.ContentFormat(request.GetFormat()),
+		PostID:   request.GetPostId(),
+		ParentID: request.GetParentId(),
 	})
 	if err != nil {
 		return nil, errorStatusGrpc(err)
@@ -37,11 +41,20 @@ func (h *Handlers) CreateComment(ctx context.Context,
 	}
 
 	return &pb.CreateCommentResponse{
-		Id:        comment.ID,
-		Content:   comment.Content,
-		CreatedBy: comment.CreatedBy,
-		CreatedAt: timestamppb.New(comment.CreatedAt),
-		PostId:    comment.PostID,
+		Id:          comment.ID,
+		Content:     comment.Content,
+		CreatedBy:   comment.CreatedBy,
+		CreatedAt:   timestamppb.New(comment.CreatedAt),
+		PostId:      comment.PostID,
+		UpdatedAt:   timestamppb.New(comment.UpdatedAt),
+		Edited:      comment.IsEdited(),
+		ParentId:    parentID(comment),
+		Reactions:   reactionsResponse(comment.Reactions),
+		Format:      string(comment.Format),
+		ContentHtml: comment.ContentHTML,
+		WordCount:   int32(comment.WordCount),
+		ReadingTime: int32(comment.ReadingTime()),
+		Mentions:    mentionsResponse(comment.Mentions),
 	}, nil
 }
 
@@ -51,20 +64,39 @@ func (h *Handlers) GetComment(ctx context.Context, request *pb.GetCommentRequest
 		return nil, errorStatusGrpc(err)
 	}
 
-	return &pb.GetCommentResponse{
-		Id:        comment.ID,
-		Content:   comment.Content,
-		CreatedBy: comment.CreatedBy,
-		CreatedAt: timestamppb.New(comment.CreatedAt),
-		PostId:    comment.PostID,
-	}, nil
+	out := &pb.GetCommentResponse{
+		Id:          comment.ID,
+		Content:     comment.Content,
+		CreatedBy:   comment.CreatedBy,
+		CreatedAt:   timestamppb.New(comment.CreatedAt),
+		PostId:      comment.PostID,
+		UpdatedAt:   timestamppb.New(comment.UpdatedAt),
+		Edited:      comment.IsEdited(),
+		ParentId:    parentID(comment),
+		Reactions:   reactionsResponse(comment.Reactions),
+		Format:      string(comment.Format),
+		ContentHtml: comment.ContentHTML,
+		WordCount:   int32(comment.WordCount),
+		ReadingTime: int32(comment.ReadingTime()),
+		Mentions:    mentionsResponse(comment.Mentions),
+	}
+
+	applyContentView(request.GetContentView(), &out.Content, &out.ContentHtml)
+
+	return out, nil
 }
 
 func (h *Handlers) UpdateComment(ctx context.Context,
 	request *pb.UpdateCommentRequest) (*pb.UpdateCommentResponse, error) {
-	err := h.service.UpdateComment(ctx, models.UpdateCommentPayload{
+	fields, err := fieldsToUpdate(request, request.GetUpdateMask())
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.service.UpdateComment(ctx, models.UpdateCommentPayload{
 		CommentID: request.GetId(),
-		Content:   request.GetContent(),
+		Content:   stringToUpdate(fields, "content", request.GetContent()),
+		Format:    contentFormatToUpdate(fields, "format", request.GetFormat()),
 	})
 	if err != nil {
 		return nil, errorStatusGrpc(err)
@@ -76,11 +108,20 @@ func (h *Handlers) UpdateComment(ctx context.Context,
 	}
 
 	return &pb.UpdateCommentResponse{
-		Id:        comment.ID,
-		Content:   comment.Content,
-		CreatedBy: comment.CreatedBy,
-		CreatedAt: timestamppb.New(comment.CreatedAt),
-		PostId:    comment.PostID,
+		Id:          comment.ID,
+		Content:     comment.Content,
+		CreatedBy:   comment.CreatedBy,
+		CreatedAt:   timestamppb.New(comment.CreatedAt),
+		PostId:      comment.PostID,
+		UpdatedAt:   timestamppb.New(comment.UpdatedAt),
+		Edited:      comment.IsEdited(),
+		ParentId:    parentID(comment),
+		Reactions:   reactionsResponse(comment.Reactions),
+		Format:      string(comment.Format),
+		ContentHtml: comment.ContentHTML,
+		WordCount:   int32(comment.WordCount),
+		ReadingTime: int32(comment.ReadingTime()),
+		Mentions:    mentionsResponse(comment.Mentions),
 	}, nil
 }
 
@@ -93,46 +134,155 @@ func (h *Handlers) DeleteComment(ctx context.Context,
 	return &pb.DeleteCommentResponse{}, nil
 }
 
+func (h *Handlers) RestoreComment(ctx context.Context,
+	request *pb.RestoreCommentRequest) (*pb.RestoreCommentResponse, error) {
+	if err := h.service.RestoreComment(ctx, request.GetId()); err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	comment, err := h.service.GetComment(ctx, request.GetId())
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.RestoreCommentResponse{
+		Id:          comment.ID,
+		Content:     comment.Content,
+		CreatedBy:   comment.CreatedBy,
+		CreatedAt:   timestamppb.New(comment.CreatedAt),
+		PostId:      comment.PostID,
+		UpdatedAt:   timestamppb.New(comment.UpdatedAt),
+		Edited:      comment.IsEdited(),
+		ParentId:    parentID(comment),
+		Reactions:   reactionsResponse(comment.Reactions),
+		Format:      string(comment.Format),
+		ContentHtml: comment.ContentHTML,
+		WordCount:   int32(comment.WordCount),
+		ReadingTime: int32(comment.ReadingTime()),
+		Mentions:    mentionsResponse(comment.Mentions),
+	}, nil
+}
+
 func (h *Handlers) ListComments(ctx context.Context,
 	request *pb.ListCommentsRequest) (*pb.ListCommentsResponse, error) {
-	pagination := getPaginationParam(request.GetPagination())
+	pagination, err := h.getKeysetPaginationParam(request.GetPagination())
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
 
 	filter := models.FilterComments{}
 
-	if request.GetFilter() != nil {
-		filter = models.FilterComments{
-			Field: allowedFilterCommentsFields[request.GetFilter().GetField()],
-			Value: request.GetFilter().GetValue(),
+	for _, f := range append([]*pb.ListCommentsRequest_Filter{request.GetFilter()}, request.GetFilters()...) {
+		if f.GetField() == pb.ListCommentsRequest_Filter_UNKNOWN_FIELD {
+			continue
 		}
+
+		filter.Conditions = append(filter.Conditions, models.CommentCondition{
+			Field:    allowedFilterCommentsFields[f.GetField()],
+			Operator: allowedFilterOperators[f.GetOperator()],
+			Values:   filterValues(f.GetValue(), f.GetValues()),
+		})
 	}
 
 	sort := models.SortComments{}
 
-	if request.GetSort() != nil {
-		sort = models.SortComments{
-			Field: allowedSortCommentsFields[request.GetSort().GetField()],
-			IsASC: request.GetSort().GetIsAsc(),
+	for _, s := range append([]*pb.ListCommentsRequest_Sort{request.GetSort()}, request.GetSorts()...) {
+		if s.GetField() == pb.ListCommentsRequest_Sort_UNKNOWN_FIELD {
+			continue
 		}
+
+		sort.Keys = append(sort.Keys, models.SortCommentsKey{
+			Field: allowedSortCommentsFields[s.GetField()],
+			IsASC: s.GetIsAsc(),
+		})
 	}
 
-	comments, err := h.service.ListComments(ctx, pagination, filter, sort)
+	page, err := h.service.ListComments(ctx, pagination, filter, sort)
 	if err != nil {
 		return nil, errorStatusGrpc(err)
 	}
 
-	var outs pb.ListCommentsResponse
+	outs := pb.ListCommentsResponse{
+		PageInfo: pageInfoResponse(page.PageInfo),
+	}
 
-	for _, comment := range comments {
+	for _, comment := range page.Comments {
 		out := pb.GetCommentResponse{
-			Id:        comment.ID,
-			Content:   comment.Content,
-			CreatedBy: comment.CreatedBy,
-			CreatedAt: timestamppb.New(comment.CreatedAt),
-			PostId:    comment.PostID,
+			Id:          comment.ID,
+			Content:     comment.Content,
+			CreatedBy:   comment.CreatedBy,
+			CreatedAt:   timestamppb.New(comment.CreatedAt),
+			PostId:      comment.PostID,
+			UpdatedAt:   timestamppb.New(comment.UpdatedAt),
+			Edited:      comment.IsEdited(),
+			ParentId:    parentID(comment),
+			Reactions:   reactionsResponse(comment.Reactions),
+			Format:      string(comment.Format),
+			ContentHtml: comment.ContentHTML,
+			WordCount:   int32(comment.WordCount),
+			ReadingTime: int32(comment.ReadingTime()),
+			Mentions:    mentionsResponse(comment.Mentions),
 		}
 
+		applyContentView(request.GetContentView(), &out.Content, &out.ContentHtml)
+
 		outs.Comments = append(outs.Comments, &out)
 	}
 
 	return &outs, nil
 }
+
+func (h *Handlers) ListReplies(ctx context.Context,
+	request *pb.ListRepliesRequest) (*pb.ListRepliesResponse, error) {
+	replies, err := h.service.ListReplies(ctx, request.GetCommentId())
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.ListRepliesResponse{
+		Replies: commentRepliesResponse(replies, request.GetContentView()),
+	}, nil
+}
+
+func commentRepliesResponse(replies []*models.CommentThread, view pb.ContentView) []*pb.CommentReply {
+	response := make([]*pb.CommentReply, 0, len(replies))
+
+	for _, reply := range replies {
+		comment := reply.Comment
+
+		out := &pb.GetCommentResponse{
+			Id:          comment.ID,
+			Content:     comment.Content,
+			CreatedBy:   comment.CreatedBy,
+			CreatedAt:   timestamppb.New(comment.CreatedAt),
+			PostId:      comment.PostID,
+			UpdatedAt:   timestamppb.New(comment.UpdatedAt),
+			Edited:      comment.IsEdited(),
+			ParentId:    parentID(comment),
+			Reactions:   reactionsResponse(comment.Reactions),
+			Format:      string(comment.Format),
+			ContentHtml: comment.ContentHTML,
+			WordCount:   int32(comment.WordCount),
+			ReadingTime: int32(comment.ReadingTime()),
+			Mentions:    mentionsResponse(comment.Mentions),
+		}
+
+		applyContentView(view, &out.Content, &out.ContentHtml)
+
+		response = append(response, &pb.CommentReply{
+			Comment: out,
+			Replies: commentRepliesResponse(reply.Replies, view),
+		})
+	}
+
+	return response
+}
+
+// parentID returns the ID of the comment the comment replies to, or an empty string for top-level comments.
+func parentID(comment *models.Comment) string {
+	if comment.ParentID == nil {
+		return ""
+	}
+
+	return *comment.ParentID
+}
@@ -0,0 +1,114 @@
+package grpcHandlers
+
+import (
+	"context"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/serhiihuberniuk/blog-api/view/grpc/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *Handlers) Follow(ctx context.Context, request *pb.FollowRequest) (*pb.FollowResponse, error) {
+	if err := h.service.Follow(ctx, request.GetUserId()); err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.FollowResponse{}, nil
+}
+
+func (h *Handlers) Unfollow(ctx context.Context, request *pb.UnfollowRequest) (*pb.UnfollowResponse, error) {
+	if err := h.service.Unfollow(ctx, request.GetUserId()); err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.UnfollowResponse{}, nil
+}
+
+func (h *Handlers) ListFollowers(ctx context.Context,
+	request *pb.ListFollowersRequest) (*pb.ListFollowersResponse, error) {
+	users, err := h.service.ListFollowers(ctx, request.GetUserId(), h.getPaginationParam(request.GetPagination()))
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.ListFollowersResponse{
+		Users: usersResponse(users),
+	}, nil
+}
+
+func (h *Handlers) ListFollowing(ctx context.Context,
+	request *pb.ListFollowingRequest) (*pb.ListFollowingResponse, error) {
+	users, err := h.service.ListFollowing(ctx, request.GetUserId(), h.getPaginationParam(request.GetPagination()))
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.ListFollowingResponse{
+		Users: usersResponse(users),
+	}, nil
+}
+
+func (h *Handlers) Feed(ctx context.Context, request *pb.FeedRequest) (*pb.FeedResponse, error) {
+	pagination, err := h.getKeysetPaginationParam(request.GetPagination())
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	feed, err := h.service.Feed(ctx, pagination)
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	posts := make([]*pb.GetPostResponse, 0, len(feed.Posts))
+	for _, post := range feed.Posts {
+		out := &pb.GetPostResponse{
+			Id:              post.ID,
+			Title:           post.Title,
+			Description:     post.Description,
+			CreatedBy:       post.CreatedBy,
+			CreatedAt:       timestamppb.New(post.CreatedAt),
+			Tags:            post.Tags,
+			UpdatedAt:       timestamppb.New(post.UpdatedAt),
+			Edited:          post.IsEdited(),
+			Status:          string(post.Status),
+			Slug:            post.Slug,
+			Reactions:       reactionsResponse(post.Reactions),
+			Format:          string(post.Format),
+			DescriptionHtml: post.DescriptionHTML,
+			WordCount:       int32(post.WordCount),
+			ReadingTime:     int32(post.ReadingTime()),
+			Mentions:        mentionsResponse(post.Mentions),
+			CommentCount:    int32(post.CommentCount),
+			LastCommentedAt: timestampOrNil(post.LastCommentedAt),
+		}
+
+		applyContentView(request.GetContentView(), &out.Description, &out.DescriptionHtml)
+
+		posts = append(posts, out)
+	}
+
+	return &pb.FeedResponse{
+		Posts:    posts,
+		PageInfo: pageInfoResponse(feed.PageInfo),
+	}, nil
+}
+
+func usersResponse(users []*models.User) []*pb.GetUserResponse {
+	out := make([]*pb.GetUserResponse, 0, len(users))
+	for _, user := range users {
+		out = append(out, &pb.GetUserResponse{
+			Id:        user.ID,
+			Name:      user.Name,
+			Username:  user.Username,
+			Email:     user.Email,
+			CreatedAt: timestamppb.New(user.CreatedAt),
+			UpdatedAt: timestamppb.New(user.UpdatedAt),
+			Role:      pbRoles[user.Role],
+
+			EmailVerified: user.IsEmailVerified(),
+			PendingEmail:  user.PendingEmail,
+		})
+	}
+
+	return out
+}
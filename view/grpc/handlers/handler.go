@@ -9,18 +9,17 @@ import (
 	"github.com/serhiihuberniuk/blog-api/view/grpc/pb"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
-const maxLimit = 50
-
-func getPaginationParam(p *pb.Pagination) models.Pagination {
+func (h *Handlers) getPaginationParam(p *pb.Pagination) models.Pagination {
 	pagination := models.Pagination{}
 
 	if p != nil {
-		limit := p.GetLimit()
-		if limit <= 0 || limit > maxLimit {
-			limit = maxLimit
-		}
+		limit := h.pageLimit(p.GetLimit())
 
 		offset := p.GetOffset()
 		if offset < 0 {
@@ -36,6 +35,135 @@ func getPaginationParam(p *pb.Pagination) models.Pagination {
 	return pagination
 }
 
+// getKeysetPaginationParam returns the pagination together with the cursors and the request to count objects.
+func (h *Handlers) getKeysetPaginationParam(p *pb.Pagination) (models.Pagination, error) {
+	pagination := h.getPaginationParam(p)
+	pagination.CountTotal = p.GetCountTotal()
+
+	var err error
+
+	if pagination.After, err = models.ParseCursor(p.GetAfter()); err != nil {
+		return models.Pagination{}, err
+	}
+
+	if pagination.Before, err = models.ParseCursor(p.GetBefore()); err != nil {
+		return models.Pagination{}, err
+	}
+
+	return pagination, nil
+}
+
+// pageLimit returns the limit of the page, which is the maximal one if the given limit is not positive or too big.
+func (h *Handlers) pageLimit(limit int32) int32 {
+	if limit <= 0 || uint64(limit) > h.maxLimit {
+		return int32(h.maxLimit)
+	}
+
+	return limit
+}
+
+func pageInfoResponse(pageInfo models.PageInfo) *pb.PageInfo {
+	out := &pb.PageInfo{
+		NextCursor: pageInfo.Next.String(),
+		PrevCursor: pageInfo.Prev.String(),
+		HasNext:    pageInfo.Next != nil,
+		HasPrev:    pageInfo.Prev != nil,
+	}
+
+	if pageInfo.Total != nil {
+		out.TotalCount = wrapperspb.Int32(int32(*pageInfo.Total))
+	}
+
+	return out
+}
+
+var allowedFilterOperators = map[pb.FilterOperator]models.FilterOperator{
+	pb.FilterOperator_FILTER_OPERATOR_EQ:     models.FilterOperatorEq,
+	pb.FilterOperator_FILTER_OPERATOR_IN:     models.FilterOperatorIn,
+	pb.FilterOperator_FILTER_OPERATOR_PREFIX: models.FilterOperatorPrefix,
+	pb.FilterOperator_FILTER_OPERATOR_GTE:    models.FilterOperatorGte,
+	pb.FilterOperator_FILTER_OPERATOR_LTE:    models.FilterOperatorLte,
+}
+
+var allowedContentViews = map[pb.ContentView]models.ContentView{
+	pb.ContentView_CONTENT_VIEW_BOTH: models.ContentViewBoth,
+	pb.ContentView_CONTENT_VIEW_RAW:  models.ContentViewRaw,
+	pb.ContentView_CONTENT_VIEW_HTML: models.ContentViewHTML,
+}
+
+// applyContentView clears the representations of the content which are not selected by the view.
+func applyContentView(view pb.ContentView, raw, html *string) {
+	if !allowedContentViews[view].ShowsRaw() {
+		*raw = ""
+	}
+
+	if !allowedContentViews[view].ShowsHTML() {
+		*html = ""
+	}
+}
+
+// filterValues returns the values of a filter, which are given either in the single value or in the list.
+func filterValues(value string, values []string) []string {
+	if len(values) != 0 {
+		return values
+	}
+
+	return []string{value}
+}
+
+// fieldsToUpdate returns names of the request fields which should be applied by an update.
+// If the mask is empty, all fields with non-default values are applied.
+func fieldsToUpdate(request proto.Message, mask *fieldmaskpb.FieldMask) (map[string]bool, error) {
+	fields := make(map[string]bool)
+
+	if len(mask.GetPaths()) == 0 {
+		request.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+			fields[string(fd.Name())] = true
+
+			return true
+		})
+
+		return fields, nil
+	}
+
+	if !mask.IsValid(request) {
+		return nil, status.Error(codes.InvalidArgument, "update mask contains unknown fields")
+	}
+
+	for _, path := range mask.GetPaths() {
+		fields[path] = true
+	}
+
+	return fields, nil
+}
+
+func stringToUpdate(fields map[string]bool, name, value string) *string {
+	if !fields[name] {
+		return nil
+	}
+
+	return &value
+}
+
+// contentFormatToUpdate returns the format of the content if it should be applied by an update.
+func contentFormatToUpdate(fields map[string]bool, name, value string) *models.ContentFormat {
+	if !fields[name] {
+		return nil
+	}
+
+	format := models.ContentFormat(value)
+
+	return &format
+}
+
+func stringsToUpdate(fields map[string]bool, name string, value []string) *[]string {
+	if !fields[name] {
+		return nil
+	}
+
+	return &value
+}
+
 func errorStatusGrpc(err error) error {
 	if errors.Is(err, models.ErrNotFound) {
 		return status.Error(codes.NotFound, codes.NotFound.String())
@@ -45,6 +173,14 @@ func errorStatusGrpc(err error) error {
 		return status.Error(codes.Unauthenticated, codes.Unauthenticated.String())
 	}
 
+	if errors.Is(err, models.ErrForbidden) {
+		return status.Error(codes.PermissionDenied, codes.PermissionDenied.String())
+	}
+
+	if errors.Is(err, models.ErrTooLarge) || errors.Is(err, models.ErrQuotaExceeded) {
+		return status.Error(codes.ResourceExhausted, codes.ResourceExhausted.String())
+	}
+
 	if errors.As(err, &validation.Errors{}) {
 		return status.Error(codes.InvalidArgument, codes.InvalidArgument.String())
 	}
@@ -55,6 +191,7 @@ func errorStatusGrpc(err error) error {
 type Handlers struct {
 	service                        service
 	currentUserInformationProvider currentUserInformationProvider
+	maxLimit                       uint64
 }
 
 type currentUserInformationProvider interface {
@@ -62,31 +199,90 @@ type currentUserInformationProvider interface {
 }
 
 type service interface {
-	Login(ctx context.Context, payload models.LoginPayload) (string, error)
+	Login(ctx context.Context, payload models.LoginPayload) (*models.Tokens, error)
+	Refresh(ctx context.Context, payload models.RefreshPayload) (*models.Tokens, error)
+	Logout(ctx context.Context, payload models.LogoutPayload) error
+	RequestPasswordReset(ctx context.Context, payload models.RequestPasswordResetPayload) error
+	ResetPassword(ctx context.Context, payload models.ResetPasswordPayload) error
+	VerifyEmail(ctx context.Context, payload models.VerifyEmailPayload) error
+	ResendEmailVerification(ctx context.Context) error
 
 	CreateUser(ctx context.Context, payload models.CreateUserPayload) (string, error)
 	GetUser(ctx context.Context, userID string) (*models.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
 	UpdateUser(ctx context.Context, payload models.UpdateUserPayload) error
+	ChangePassword(ctx context.Context, oldPassword, newPassword string) error
+	UpdateUserRole(ctx context.Context, payload models.UpdateUserRolePayload) error
 	DeleteUser(ctx context.Context) error
+	DeleteUserByID(ctx context.Context, userID string) error
+	RestoreUser(ctx context.Context, userID string) error
+
+	Follow(ctx context.Context, userID string) error
+	Unfollow(ctx context.Context, userID string) error
+	ListFollowers(ctx context.Context, userID string, pagination models.Pagination) ([]*models.User, error)
+	ListFollowing(ctx context.Context, userID string, pagination models.Pagination) ([]*models.User, error)
+	Feed(ctx context.Context, pagination models.Pagination) (*models.PostsPage, error)
+
+	ListNotifications(ctx context.Context, pagination models.Pagination,
+		unreadOnly bool) (*models.NotificationList, error)
+	MarkNotificationRead(ctx context.Context, notificationID string) error
+	MarkAllNotificationsRead(ctx context.Context) error
+	GetNotificationPreferences(ctx context.Context) (models.NotificationPreferences, error)
+	UpdateNotificationPreferences(ctx context.Context,
+		changes models.NotificationPreferences) (models.NotificationPreferences, error)
 
 	CreatePost(ctx context.Context, payload models.CreatePostPayload) (string, error)
 	GetPost(ctx context.Context, postID string) (*models.Post, error)
+	GetPostByIDOrSlug(ctx context.Context, ref string) (*models.Post, error)
 	UpdatePost(ctx context.Context, payload models.UpdatePostPayload) error
 	DeletePost(ctx context.Context, postID string) error
+	RestorePost(ctx context.Context, postID string) error
+	SubmitPost(ctx context.Context, postID string) error
+	PublishPost(ctx context.Context, postID string) error
+	UnpublishPost(ctx context.Context, postID string) error
+	ArchivePost(ctx context.Context, postID string) error
 	ListPosts(ctx context.Context, pagination models.Pagination,
-		filter models.FilterPosts, sort models.SortPosts) ([]*models.Post, error)
+		filter models.FilterPosts, sort models.SortPosts) (*models.PostsPage, error)
 
 	CreateComment(ctx context.Context, payload models.CreateCommentPayload) (string, error)
 	GetComment(ctx context.Context, commentID string) (*models.Comment, error)
+	ListReplies(ctx context.Context, commentID string) ([]*models.CommentThread, error)
 	UpdateComment(ctx context.Context, payload models.UpdateCommentPayload) error
 	DeleteComment(ctx context.Context, commentID string) error
+	RestoreComment(ctx context.Context, commentID string) error
 	ListComments(ctx context.Context, pagination models.Pagination,
-		filter models.FilterComments, sort models.SortComments) ([]*models.Comment, error)
+		filter models.FilterComments, sort models.SortComments) (*models.CommentsPage, error)
+
+	Search(ctx context.Context, query models.SearchQuery, pagination models.Pagination) ([]*models.SearchResult, error)
+
+	ListTags(ctx context.Context, pagination models.Pagination) ([]*models.Tag, error)
+	AutocompleteTags(ctx context.Context, prefix string, limit uint64) ([]*models.Tag, error)
+	RenameTag(ctx context.Context, payload models.RenameTagPayload) error
+	MergeTags(ctx context.Context, payload models.MergeTagsPayload) error
+
+	AddReaction(ctx context.Context, payload models.ReactionPayload) (models.ReactionCounts, error)
+	RemoveReaction(ctx context.Context, payload models.ReactionPayload) (models.ReactionCounts, error)
+
+	ListPostRevisions(ctx context.Context, postID string) ([]*models.PostRevision, error)
+	GetPostRevision(ctx context.Context, postID, revisionID string) (*models.PostRevision, error)
+	DiffPostRevisions(ctx context.Context, postID, fromRevisionID, toRevisionID string) ([]models.FieldChange, error)
+	RollbackPost(ctx context.Context, postID, revisionID string) error
+	ListCommentRevisions(ctx context.Context, commentID string) ([]*models.CommentRevision, error)
+	GetCommentRevision(ctx context.Context, commentID, revisionID string) (*models.CommentRevision, error)
+	DiffCommentRevisions(ctx context.Context, commentID, fromRevisionID,
+		toRevisionID string) ([]models.FieldChange, error)
+	RollbackComment(ctx context.Context, commentID, revisionID string) error
+
+	CreateAttachment(ctx context.Context, payload models.CreateAttachmentPayload) (*models.Attachment, error)
+	GetAttachment(ctx context.Context, attachmentID string) (*models.Attachment, error)
+	ListAttachments(ctx context.Context, postID string) ([]*models.Attachment, error)
+	DeleteAttachment(ctx context.Context, attachmentID string) error
 }
 
-func NewGrpcHandlers(s service, p currentUserInformationProvider) *Handlers {
+func NewGrpcHandlers(s service, p currentUserInformationProvider, maxLimit uint64) *Handlers {
 	return &Handlers{
 		service:                        s,
 		currentUserInformationProvider: p,
+		maxLimit:                       maxLimit,
 	}
 }
@@ -0,0 +1,18 @@
+package grpcHandlers
+
+import (
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/serhiihuberniuk/blog-api/view/grpc/pb"
+)
+
+func mentionsResponse(mentions []models.Mention) []*pb.Mention {
+	out := make([]*pb.Mention, 0, len(mentions))
+	for _, mention := range mentions {
+		out = append(out, &pb.Mention{
+			UserId:   mention.UserID,
+			Username: mention.Username,
+		})
+	}
+
+	return out
+}
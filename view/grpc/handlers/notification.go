@@ -0,0 +1,96 @@
+package grpcHandlers
+
+import (
+	"context"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/serhiihuberniuk/blog-api/view/grpc/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *Handlers) ListNotifications(ctx context.Context,
+	request *pb.ListNotificationsRequest) (*pb.ListNotificationsResponse, error) {
+	list, err := h.service.ListNotifications(ctx, h.getPaginationParam(request.GetPagination()),
+		request.GetUnreadOnly())
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	notifications := make([]*pb.Notification, 0, len(list.Notifications))
+	for _, notification := range list.Notifications {
+		out := &pb.Notification{
+			Id:        notification.ID,
+			Type:      string(notification.Type),
+			ActorId:   notification.ActorID,
+			PostId:    notification.PostID,
+			CommentId: notification.CommentID,
+			Reaction:  string(notification.Reaction),
+			CreatedAt: timestamppb.New(notification.CreatedAt),
+		}
+
+		if notification.ReadAt != nil {
+			out.ReadAt = timestamppb.New(*notification.ReadAt)
+		}
+
+		notifications = append(notifications, out)
+	}
+
+	return &pb.ListNotificationsResponse{
+		Notifications: notifications,
+		UnreadCount:   int32(list.UnreadCount),
+	}, nil
+}
+
+func (h *Handlers) MarkNotificationRead(ctx context.Context,
+	request *pb.MarkNotificationReadRequest) (*pb.MarkNotificationReadResponse, error) {
+	if err := h.service.MarkNotificationRead(ctx, request.GetId()); err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.MarkNotificationReadResponse{}, nil
+}
+
+func (h *Handlers) MarkAllNotificationsRead(ctx context.Context,
+	_ *pb.MarkAllNotificationsReadRequest) (*pb.MarkAllNotificationsReadResponse, error) {
+	if err := h.service.MarkAllNotificationsRead(ctx); err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.MarkAllNotificationsReadResponse{}, nil
+}
+
+func (h *Handlers) GetNotificationPreferences(ctx context.Context,
+	_ *pb.GetNotificationPreferencesRequest) (*pb.NotificationPreferencesResponse, error) {
+	preferences, err := h.service.GetNotificationPreferences(ctx)
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return notificationPreferencesResponse(preferences), nil
+}
+
+func (h *Handlers) UpdateNotificationPreferences(ctx context.Context,
+	request *pb.UpdateNotificationPreferencesRequest) (*pb.NotificationPreferencesResponse, error) {
+	changes := make(models.NotificationPreferences, len(request.GetPreferences()))
+	for t, enabled := range request.GetPreferences() {
+		changes[models.NotificationType(t)] = enabled
+	}
+
+	preferences, err := h.service.UpdateNotificationPreferences(ctx, changes)
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return notificationPreferencesResponse(preferences), nil
+}
+
+func notificationPreferencesResponse(preferences models.NotificationPreferences) *pb.NotificationPreferencesResponse {
+	out := make(map[string]bool, len(preferences))
+	for t, enabled := range preferences {
+		out[string(t)] = enabled
+	}
+
+	return &pb.NotificationPreferencesResponse{
+		Preferences: out,
+	}
+}
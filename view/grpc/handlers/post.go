@@ -2,6 +2,7 @@ package grpcHandlers
 
 import (
 	"context"
+	"time"
 
 	"github.com/serhiihuberniuk/blog-api/models"
 	"github.com/serhiihuberniuk/blog-api/view/grpc/pb"
@@ -14,12 +15,17 @@ var (
 		pb.ListPostsRequest_Filter_CREATED_BY:    models.FilterPostsByCreatedBy,
 		pb.ListPostsRequest_Filter_TITLE:         models.FilterPostsByTitle,
 		pb.ListPostsRequest_Filter_TAGS:          models.FilterPostsByTags,
+		pb.ListPostsRequest_Filter_STATUS:        models.FilterPostsByStatus,
+		pb.ListPostsRequest_Filter_CREATED_AT:    models.FilterPostsByCreatedAt,
 	}
 
 	allowedSortPostsFields = map[pb.ListPostsRequest_Sort_Field]models.SortPostsByField{
-		pb.ListPostsRequest_Sort_UNKNOWN_FIELD: "",
-		pb.ListPostsRequest_Sort_CREATED_AT:    models.SortPostsByCreatedAt,
-		pb.ListPostsRequest_Sort_TITLE:         models.SortPostsByTitle,
+		pb.ListPostsRequest_Sort_UNKNOWN_FIELD:  "",
+		pb.ListPostsRequest_Sort_CREATED_AT:     models.SortPostsByCreatedAt,
+		pb.ListPostsRequest_Sort_TITLE:          models.SortPostsByTitle,
+		pb.ListPostsRequest_Sort_UPDATED_AT:     models.SortPostsByUpdatedAt,
+		pb.ListPostsRequest_Sort_COMMENT_COUNT:  models.SortPostsByCommentCount,
+		pb.ListPostsRequest_Sort_REACTION_COUNT: models.SortPostsByReactionCount,
 	}
 )
 
@@ -27,6 +33,7 @@ func (h *Handlers) CreatePost(ctx context.Context, request *pb.CreatePostRequest
 	postID, err := h.service.CreatePost(ctx, models.CreatePostPayload{
 		Title:       request.GetTitle(),
 		Description: request.GetDescription(),
+		Format:      models.ContentFormat(request.GetFormat()),
 		Tags:        request.GetTags(),
 	})
 	if err != nil {
@@ -39,37 +46,71 @@ func (h *Handlers) CreatePost(ctx context.Context, request *pb.CreatePostRequest
 	}
 
 	return &pb.CreatePostResponse{
-		Id:          post.ID,
-		Title:       post.Title,
-		Description: post.Description,
-		CreatedBy:   post.CreatedBy,
-		CreatedAt:   timestamppb.New(post.CreatedAt),
-		Tags:        post.Tags,
+		Id:              post.ID,
+		Title:           post.Title,
+		Description:     post.Description,
+		CreatedBy:       post.CreatedBy,
+		CreatedAt:       timestamppb.New(post.CreatedAt),
+		Tags:            post.Tags,
+		UpdatedAt:       timestamppb.New(post.UpdatedAt),
+		Edited:          post.IsEdited(),
+		Status:          string(post.Status),
+		Slug:            post.Slug,
+		Reactions:       reactionsResponse(post.Reactions),
+		Format:          string(post.Format),
+		DescriptionHtml: post.DescriptionHTML,
+		WordCount:       int32(post.WordCount),
+		ReadingTime:     int32(post.ReadingTime()),
+		Mentions:        mentionsResponse(post.Mentions),
+		CommentCount:    int32(post.CommentCount),
+		LastCommentedAt: timestampOrNil(post.LastCommentedAt),
 	}, nil
 }
 
 func (h *Handlers) GetPost(ctx context.Context, request *pb.GetPostRequest) (*pb.GetPostResponse, error) {
-	post, err := h.service.GetPost(ctx, request.GetId())
+	post, err := h.service.GetPostByIDOrSlug(ctx, request.GetId())
 	if err != nil {
 		return nil, errorStatusGrpc(err)
 	}
 
-	return &pb.GetPostResponse{
-		Id:          post.ID,
-		Title:       post.Title,
-		Description: post.Description,
-		CreatedBy:   post.CreatedBy,
-		CreatedAt:   timestamppb.New(post.CreatedAt),
-		Tags:        post.Tags,
-	}, nil
+	out := &pb.GetPostResponse{
+		Id:              post.ID,
+		Title:           post.Title,
+		Description:     post.Description,
+		CreatedBy:       post.CreatedBy,
+		CreatedAt:       timestamppb.New(post.CreatedAt),
+		Tags:            post.Tags,
+		UpdatedAt:       timestamppb.New(post.UpdatedAt),
+		Edited:          post.IsEdited(),
+		Status:          string(post.Status),
+		Slug:            post.Slug,
+		Reactions:       reactionsResponse(post.Reactions),
+		Format:          string(post.Format),
+		DescriptionHtml: post.DescriptionHTML,
+		WordCount:       int32(post.WordCount),
+		ReadingTime:     int32(post.ReadingTime()),
+		Mentions:        mentionsResponse(post.Mentions),
+		CommentCount:    int32(post.CommentCount),
+		LastCommentedAt: timestampOrNil(post.LastCommentedAt),
+	}
+
+	applyContentView(request.GetContentView(), &out.Description, &out.DescriptionHtml)
+
+	return out, nil
 }
 
 func (h *Handlers) UpdatePost(ctx context.Context, request *pb.UpdatePostRequest) (*pb.UpdatePostResponse, error) {
-	err := h.service.UpdatePost(ctx, models.UpdatePostPayload{
+	fields, err := fieldsToUpdate(request, request.GetUpdateMask())
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.service.UpdatePost(ctx, models.UpdatePostPayload{
 		PostID:      request.GetId(),
-		Title:       request.GetTitle(),
-		Description: request.GetDescription(),
-		Tags:        request.GetTags(),
+		Title:       stringToUpdate(fields, "title", request.GetTitle()),
+		Description: stringToUpdate(fields, "description", request.GetDescription()),
+		Format:      contentFormatToUpdate(fields, "format", request.GetFormat()),
+		Tags:        stringsToUpdate(fields, "tags", request.GetTags()),
 	})
 	if err != nil {
 		return nil, errorStatusGrpc(err)
@@ -81,12 +122,24 @@ func (h *Handlers) UpdatePost(ctx context.Context, request *pb.UpdatePostRequest
 	}
 
 	return &pb.UpdatePostResponse{
-		Id:          post.ID,
-		Title:       post.Title,
-		Description: post.Description,
-		CreatedBy:   post.CreatedBy,
-		CreatedAt:   timestamppb.New(post.CreatedAt),
-		Tags:        post.Tags,
+		Id:              post.ID,
+		Title:           post.Title,
+		Description:     post.Description,
+		CreatedBy:       post.CreatedBy,
+		CreatedAt:       timestamppb.New(post.CreatedAt),
+		Tags:            post.Tags,
+		UpdatedAt:       timestamppb.New(post.UpdatedAt),
+		Edited:          post.IsEdited(),
+		Status:          string(post.Status),
+		Slug:            post.Slug,
+		Reactions:       reactionsResponse(post.Reactions),
+		Format:          string(post.Format),
+		DescriptionHtml: post.DescriptionHTML,
+		WordCount:       int32(post.WordCount),
+		ReadingTime:     int32(post.ReadingTime()),
+		Mentions:        mentionsResponse(post.Mentions),
+		CommentCount:    int32(post.CommentCount),
+		LastCommentedAt: timestampOrNil(post.LastCommentedAt),
 	}, nil
 }
 
@@ -98,47 +151,116 @@ func (h *Handlers) DeletePost(ctx context.Context, request *pb.DeletePostRequest
 	return &pb.DeletePostResponse{}, nil
 }
 
+func (h *Handlers) RestorePost(ctx context.Context, request *pb.RestorePostRequest) (*pb.RestorePostResponse, error) {
+	if err := h.service.RestorePost(ctx, request.GetId()); err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	post, err := h.service.GetPost(ctx, request.GetId())
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.RestorePostResponse{
+		Id:              post.ID,
+		Title:           post.Title,
+		Description:     post.Description,
+		CreatedBy:       post.CreatedBy,
+		CreatedAt:       timestamppb.New(post.CreatedAt),
+		Tags:            post.Tags,
+		UpdatedAt:       timestamppb.New(post.UpdatedAt),
+		Edited:          post.IsEdited(),
+		Status:          string(post.Status),
+		Slug:            post.Slug,
+		Reactions:       reactionsResponse(post.Reactions),
+		Format:          string(post.Format),
+		DescriptionHtml: post.DescriptionHTML,
+		WordCount:       int32(post.WordCount),
+		ReadingTime:     int32(post.ReadingTime()),
+		Mentions:        mentionsResponse(post.Mentions),
+		CommentCount:    int32(post.CommentCount),
+		LastCommentedAt: timestampOrNil(post.LastCommentedAt),
+	}, nil
+}
+
 func (h *Handlers) ListPosts(ctx context.Context,
 	request *pb.ListPostsRequest) (*pb.ListPostsResponse, error) {
-	pagination := getPaginationParam(request.GetPagination())
+	pagination, err := h.getKeysetPaginationParam(request.GetPagination())
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
 
 	filter := models.FilterPosts{}
 
-	if request.GetFilter() != nil {
-		filter = models.FilterPosts{
-			Field: allowedFilterPostsFields[request.GetFilter().GetField()],
-			Value: request.GetFilter().Value,
+	for _, f := range append([]*pb.ListPostsRequest_Filter{request.GetFilter()}, request.GetFilters()...) {
+		if f.GetField() == pb.ListPostsRequest_Filter_UNKNOWN_FIELD {
+			continue
 		}
+
+		filter.Conditions = append(filter.Conditions, models.PostCondition{
+			Field:    allowedFilterPostsFields[f.GetField()],
+			Operator: allowedFilterOperators[f.GetOperator()],
+			Values:   filterValues(f.GetValue(), f.GetValues()),
+		})
 	}
 
 	sort := models.SortPosts{}
 
-	if request.GetSort() != nil {
-		sort = models.SortPosts{
-			SortByField: allowedSortPostsFields[request.GetSort().GetField()],
-			IsASC:       request.GetSort().GetIsAsc(),
+	for _, s := range append([]*pb.ListPostsRequest_Sort{request.GetSort()}, request.GetSorts()...) {
+		if s.GetField() == pb.ListPostsRequest_Sort_UNKNOWN_FIELD {
+			continue
 		}
+
+		sort.Keys = append(sort.Keys, models.SortPostsKey{
+			Field: allowedSortPostsFields[s.GetField()],
+			IsASC: s.GetIsAsc(),
+		})
 	}
 
-	posts, err := h.service.ListPosts(ctx, pagination, filter, sort)
+	page, err := h.service.ListPosts(ctx, pagination, filter, sort)
 	if err != nil {
 		return nil, errorStatusGrpc(err)
 	}
 
-	var outs pb.ListPostsResponse
+	outs := pb.ListPostsResponse{
+		PageInfo: pageInfoResponse(page.PageInfo),
+	}
 
-	for _, post := range posts {
+	for _, post := range page.Posts {
 		out := pb.GetPostResponse{
-			Id:          post.ID,
-			Title:       post.Title,
-			Description: post.Description,
-			CreatedAt:   timestamppb.New(post.CreatedAt),
-			CreatedBy:   post.CreatedBy,
-			Tags:        post.Tags,
+			Id:              post.ID,
+			Title:           post.Title,
+			Description:     post.Description,
+			CreatedAt:       timestamppb.New(post.CreatedAt),
+			CreatedBy:       post.CreatedBy,
+			Tags:            post.Tags,
+			UpdatedAt:       timestamppb.New(post.UpdatedAt),
+			Edited:          post.IsEdited(),
+			Status:          string(post.Status),
+			Slug:            post.Slug,
+			Reactions:       reactionsResponse(post.Reactions),
+			Format:          string(post.Format),
+			DescriptionHtml: post.DescriptionHTML,
+			WordCount:       int32(post.WordCount),
+			ReadingTime:     int32(post.ReadingTime()),
+			Mentions:        mentionsResponse(post.Mentions),
+			CommentCount:    int32(post.CommentCount),
+			LastCommentedAt: timestampOrNil(post.LastCommentedAt),
 		}
 
+		applyContentView(request.GetContentView(), &out.Description, &out.DescriptionHtml)
+
 		outs.Posts = append(outs.Posts, &out)
 	}
 
 	return &outs, nil
 }
+
+// timestampOrNil converts the optional time, leaving the field unset when there is no time.
+func timestampOrNil(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,61 @@
+package grpcHandlers
+
+import (
+	"context"
+
+	"github.com/serhiihuberniuk/blog-api/view/grpc/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *Handlers) SubmitPost(ctx context.Context,
+	request *pb.ChangePostStatusRequest) (*pb.ChangePostStatusResponse, error) {
+	return h.changePostStatus(ctx, request.GetId(), h.service.SubmitPost)
+}
+
+func (h *Handlers) PublishPost(ctx context.Context,
+	request *pb.ChangePostStatusRequest) (*pb.ChangePostStatusResponse, error) {
+	return h.changePostStatus(ctx, request.GetId(), h.service.PublishPost)
+}
+
+func (h *Handlers) UnpublishPost(ctx context.Context,
+	request *pb.ChangePostStatusRequest) (*pb.ChangePostStatusResponse, error) {
+	return h.changePostStatus(ctx, request.GetId(), h.service.UnpublishPost)
+}
+
+func (h *Handlers) ArchivePost(ctx context.Context,
+	request *pb.ChangePostStatusRequest) (*pb.ChangePostStatusResponse, error) {
+	return h.changePostStatus(ctx, request.GetId(), h.service.ArchivePost)
+}
+
+func (h *Handlers) changePostStatus(ctx context.Context, postID string,
+	change func(ctx context.Context, postID string) error) (*pb.ChangePostStatusResponse, error) {
+	if err := change(ctx, postID); err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	post, err := h.service.GetPost(ctx, postID)
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.ChangePostStatusResponse{
+		Id:              post.ID,
+		Title:           post.Title,
+		Description:     post.Description,
+		CreatedBy:       post.CreatedBy,
+		CreatedAt:       timestamppb.New(post.CreatedAt),
+		Tags:            post.Tags,
+		UpdatedAt:       timestamppb.New(post.UpdatedAt),
+		Edited:          post.IsEdited(),
+		Status:          string(post.Status),
+		Slug:            post.Slug,
+		Reactions:       reactionsResponse(post.Reactions),
+		Format:          string(post.Format),
+		DescriptionHtml: post.DescriptionHTML,
+		WordCount:       int32(post.WordCount),
+		ReadingTime:     int32(post.ReadingTime()),
+		Mentions:        mentionsResponse(post.Mentions),
+		CommentCount:    int32(post.CommentCount),
+		LastCommentedAt: timestampOrNil(post.LastCommentedAt),
+	}, nil
+}
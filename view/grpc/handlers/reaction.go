@@ -0,0 +1,53 @@
+package grpcHandlers
+
+import (
+	"context"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/serhiihuberniuk/blog-api/view/grpc/pb"
+)
+
+var allowedReactionTargets = map[pb.ReactionRequest_Target]models.ReactionTarget{
+	pb.ReactionRequest_UNKNOWN_TARGET: "",
+	pb.ReactionRequest_POST:           models.ReactionTargetPost,
+	pb.ReactionRequest_COMMENT:        models.ReactionTargetComment,
+}
+
+func (h *Handlers) AddReaction(ctx context.Context, request *pb.ReactionRequest) (*pb.ReactionResponse, error) {
+	counts, err := h.service.AddReaction(ctx, reactionPayload(request))
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.ReactionResponse{
+		Reactions: reactionsResponse(counts),
+	}, nil
+}
+
+func (h *Handlers) RemoveReaction(ctx context.Context, request *pb.ReactionRequest) (*pb.ReactionResponse, error) {
+	counts, err := h.service.RemoveReaction(ctx, reactionPayload(request))
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.ReactionResponse{
+		Reactions: reactionsResponse(counts),
+	}, nil
+}
+
+func reactionPayload(request *pb.ReactionRequest) models.ReactionPayload {
+	return models.ReactionPayload{
+		TargetType: allowedReactionTargets[request.GetTarget()],
+		TargetID:   request.GetTargetId(),
+		Type:       models.ReactionType(request.GetType()),
+	}
+}
+
+func reactionsResponse(counts models.ReactionCounts) map[string]int32 {
+	out := make(map[string]int32, len(models.ReactionTypes))
+	for _, reactionType := range models.ReactionTypes {
+		out[string(reactionType)] = int32(counts[reactionType])
+	}
+
+	return out
+}
@@ -0,0 +1,186 @@
+package grpcHandlers
+
+import (
+	"context"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/serhiihuberniuk/blog-api/view/grpc/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *Handlers) ListPostRevisions(ctx context.Context,
+	request *pb.ListPostRevisionsRequest) (*pb.ListPostRevisionsResponse, error) {
+	revisions, err := h.service.ListPostRevisions(ctx, request.GetPostId())
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	response := make([]*pb.GetPostRevisionResponse, 0, len(revisions))
+	for _, revision := range revisions {
+		response = append(response, postRevisionResponse(revision))
+	}
+
+	return &pb.ListPostRevisionsResponse{
+		Revisions: response,
+	}, nil
+}
+
+func (h *Handlers) GetPostRevision(ctx context.Context,
+	request *pb.GetPostRevisionRequest) (*pb.GetPostRevisionResponse, error) {
+	revision, err := h.service.GetPostRevision(ctx, request.GetPostId(), request.GetId())
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return postRevisionResponse(revision), nil
+}
+
+func (h *Handlers) DiffPostRevisions(ctx context.Context,
+	request *pb.DiffPostRevisionsRequest) (*pb.DiffPostRevisionsResponse, error) {
+	changes, err := h.service.DiffPostRevisions(ctx, request.GetPostId(),
+		request.GetFromRevisionId(), request.GetToRevisionId())
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.DiffPostRevisionsResponse{
+		Changes: fieldChangesResponse(changes),
+	}, nil
+}
+
+func (h *Handlers) RollbackPost(ctx context.Context,
+	request *pb.RollbackPostRequest) (*pb.RollbackPostResponse, error) {
+	if err := h.service.RollbackPost(ctx, request.GetPostId(), request.GetRevisionId()); err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	post, err := h.service.GetPost(ctx, request.GetPostId())
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.RollbackPostResponse{
+		Id:              post.ID,
+		Title:           post.Title,
+		Description:     post.Description,
+		CreatedBy:       post.CreatedBy,
+		CreatedAt:       timestamppb.New(post.CreatedAt),
+		Tags:            post.Tags,
+		UpdatedAt:       timestamppb.New(post.UpdatedAt),
+		Edited:          post.IsEdited(),
+		Status:          string(post.Status),
+		Slug:            post.Slug,
+		Reactions:       reactionsResponse(post.Reactions),
+		Format:          string(post.Format),
+		DescriptionHtml: post.DescriptionHTML,
+		WordCount:       int32(post.WordCount),
+		ReadingTime:     int32(post.ReadingTime()),
+		Mentions:        mentionsResponse(post.Mentions),
+		CommentCount:    int32(post.CommentCount),
+		LastCommentedAt: timestampOrNil(post.LastCommentedAt),
+	}, nil
+}
+
+func (h *Handlers) ListCommentRevisions(ctx context.Context,
+	request *pb.ListCommentRevisionsRequest) (*pb.ListCommentRevisionsResponse, error) {
+	revisions, err := h.service.ListCommentRevisions(ctx, request.GetCommentId())
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	response := make([]*pb.GetCommentRevisionResponse, 0, len(revisions))
+	for _, revision := range revisions {
+		response = append(response, commentRevisionResponse(revision))
+	}
+
+	return &pb.ListCommentRevisionsResponse{
+		Revisions: response,
+	}, nil
+}
+
+func (h *Handlers) GetCommentRevision(ctx context.Context,
+	request *pb.GetCommentRevisionRequest) (*pb.GetCommentRevisionResponse, error) {
+	revision, err := h.service.GetCommentRevision(ctx, request.GetCommentId(), request.GetId())
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return commentRevisionResponse(revision), nil
+}
+
+func (h *Handlers) DiffCommentRevisions(ctx context.Context,
+	request *pb.DiffCommentRevisionsRequest) (*pb.DiffCommentRevisionsResponse, error) {
+	changes, err := h.service.DiffCommentRevisions(ctx, request.GetCommentId(),
+		request.GetFromRevisionId(), request.GetToRevisionId())
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.DiffCommentRevisionsResponse{
+		Changes: fieldChangesResponse(changes),
+	}, nil
+}
+
+func (h *Handlers) RollbackComment(ctx context.Context,
+	request *pb.RollbackCommentRequest) (*pb.RollbackCommentResponse, error) {
+	if err := h.service.RollbackComment(ctx, request.GetCommentId(), request.GetRevisionId()); err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	comment, err := h.service.GetComment(ctx, request.GetCommentId())
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.RollbackCommentResponse{
+		Id:          comment.ID,
+		Content:     comment.Content,
+		CreatedBy:   comment.CreatedBy,
+		CreatedAt:   timestamppb.New(comment.CreatedAt),
+		PostId:      comment.PostID,
+		UpdatedAt:   timestamppb.New(comment.UpdatedAt),
+		Edited:      comment.IsEdited(),
+		ParentId:    parentID(comment),
+		Reactions:   reactionsResponse(comment.Reactions),
+		Format:      string(comment.Format),
+		ContentHtml: comment.ContentHTML,
+		WordCount:   int32(comment.WordCount),
+		ReadingTime: int32(comment.ReadingTime()),
+		Mentions:    mentionsResponse(comment.Mentions),
+	}, nil
+}
+
+func postRevisionResponse(revision *models.PostRevision) *pb.GetPostRevisionResponse {
+	return &pb.GetPostRevisionResponse{
+		Id:          revision.ID,
+		PostId:      revision.PostID,
+		EditedBy:    revision.EditedBy,
+		EditedAt:    timestamppb.New(revision.EditedAt),
+		Title:       revision.Title,
+		Description: revision.Description,
+		Tags:        revision.Tags,
+	}
+}
+
+func commentRevisionResponse(revision *models.CommentRevision) *pb.GetCommentRevisionResponse {
+	return &pb.GetCommentRevisionResponse{
+		Id:        revision.ID,
+		CommentId: revision.CommentID,
+		EditedBy:  revision.EditedBy,
+		EditedAt:  timestamppb.New(revision.EditedAt),
+		Content:   revision.Content,
+	}
+}
+
+func fieldChangesResponse(changes []models.FieldChange) []*pb.FieldChange {
+	response := make([]*pb.FieldChange, 0, len(changes))
+	for _, change := range changes {
+		response = append(response, &pb.FieldChange{
+			Field: change.Field,
+			From:  change.From,
+			To:    change.To,
+		})
+	}
+
+	return response
+}
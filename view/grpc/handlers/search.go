@@ -0,0 +1,96 @@
+package grpcHandlers
+
+import (
+	"context"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/serhiihuberniuk/blog-api/view/grpc/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	allowedSearchTargets = map[pb.SearchTarget]models.SearchTarget{
+		pb.SearchTarget_UNKNOWN_SEARCH_TARGET: "",
+		pb.SearchTarget_SEARCH_TARGET_POST:    models.SearchTargetPost,
+		pb.SearchTarget_SEARCH_TARGET_COMMENT: models.SearchTargetComment,
+	}
+
+	pbSearchTargets = map[models.SearchTarget]pb.SearchTarget{
+		models.SearchTargetPost:    pb.SearchTarget_SEARCH_TARGET_POST,
+		models.SearchTargetComment: pb.SearchTarget_SEARCH_TARGET_COMMENT,
+	}
+)
+
+func (h *Handlers) Search(ctx context.Context, request *pb.SearchRequest) (*pb.SearchResponse, error) {
+	query := models.SearchQuery{
+		Text: request.GetQuery(),
+	}
+
+	for _, target := range request.GetTargets() {
+		query.Targets = append(query.Targets, allowedSearchTargets[target])
+	}
+
+	results, err := h.service.Search(ctx, query, h.getPaginationParam(request.GetPagination()))
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	out := make([]*pb.SearchResult, 0, len(results))
+
+	for _, result := range results {
+		r := &pb.SearchResult{
+			Target:  pbSearchTargets[result.Target],
+			Id:      result.ID,
+			Rank:    result.Rank,
+			Snippet: result.Snippet,
+		}
+
+		if post := result.Post; post != nil {
+			r.Post = &pb.GetPostResponse{
+				Id:              post.ID,
+				Title:           post.Title,
+				Description:     post.Description,
+				CreatedBy:       post.CreatedBy,
+				CreatedAt:       timestamppb.New(post.CreatedAt),
+				Tags:            post.Tags,
+				UpdatedAt:       timestamppb.New(post.UpdatedAt),
+				Edited:          post.IsEdited(),
+				Status:          string(post.Status),
+				Slug:            post.Slug,
+				Reactions:       reactionsResponse(post.Reactions),
+				Format:          string(post.Format),
+				DescriptionHtml: post.DescriptionHTML,
+				WordCount:       int32(post.WordCount),
+				ReadingTime:     int32(post.ReadingTime()),
+				Mentions:        mentionsResponse(post.Mentions),
+				CommentCount:    int32(post.CommentCount),
+				LastCommentedAt: timestampOrNil(post.LastCommentedAt),
+			}
+		}
+
+		if comment := result.Comment; comment != nil {
+			r.Comment = &pb.GetCommentResponse{
+				Id:          comment.ID,
+				Content:     comment.Content,
+				CreatedBy:   comment.CreatedBy,
+				CreatedAt:   timestamppb.New(comment.CreatedAt),
+				PostId:      comment.PostID,
+				UpdatedAt:   timestamppb.New(comment.UpdatedAt),
+				Edited:      comment.IsEdited(),
+				ParentId:    parentID(comment),
+				Reactions:   reactionsResponse(comment.Reactions),
+				Format:      string(comment.Format),
+				ContentHtml: comment.ContentHTML,
+				WordCount:   int32(comment.WordCount),
+				ReadingTime: int32(comment.ReadingTime()),
+				Mentions:    mentionsResponse(comment.Mentions),
+			}
+		}
+
+		out = append(out, r)
+	}
+
+	return &pb.SearchResponse{
+		Results: out,
+	}, nil
+}
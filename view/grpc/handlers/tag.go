@@ -0,0 +1,71 @@
+package grpcHandlers
+
+import (
+	"context"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+	"github.com/serhiihuberniuk/blog-api/view/grpc/pb"
+)
+
+func (h *Handlers) ListTags(ctx context.Context, request *pb.ListTagsRequest) (*pb.ListTagsResponse, error) {
+	tags, err := h.service.ListTags(ctx, h.getPaginationParam(request.GetPagination()))
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.ListTagsResponse{
+		Tags: tagsResponse(tags),
+	}, nil
+}
+
+func (h *Handlers) AutocompleteTags(ctx context.Context,
+	request *pb.AutocompleteTagsRequest) (*pb.AutocompleteTagsResponse, error) {
+	limit := request.GetLimit()
+	limit = h.pageLimit(limit)
+
+	tags, err := h.service.AutocompleteTags(ctx, request.GetPrefix(), uint64(limit))
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.AutocompleteTagsResponse{
+		Tags: tagsResponse(tags),
+	}, nil
+}
+
+func (h *Handlers) RenameTag(ctx context.Context, request *pb.RenameTagRequest) (*pb.RenameTagResponse, error) {
+	err := h.service.RenameTag(ctx, models.RenameTagPayload{
+		Name:    request.GetName(),
+		NewName: request.GetNewName(),
+	})
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.RenameTagResponse{}, nil
+}
+
+func (h *Handlers) MergeTags(ctx context.Context, request *pb.MergeTagsRequest) (*pb.MergeTagsResponse, error) {
+	err := h.service.MergeTags(ctx, models.MergeTagsPayload{
+		From: request.GetFrom(),
+		Into: request.GetInto(),
+	})
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.MergeTagsResponse{}, nil
+}
+
+func tagsResponse(tags []*models.Tag) []*pb.Tag {
+	outs := make([]*pb.Tag, 0, len(tags))
+
+	for _, tag := range tags {
+		outs = append(outs, &pb.Tag{
+			Name:      tag.Name,
+			PostCount: int32(tag.PostCount),
+		})
+	}
+
+	return outs
+}
@@ -8,9 +8,25 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+var (
+	allowedRoles = map[pb.Role]models.Role{
+		pb.Role_UNKNOWN_ROLE: "",
+		pb.Role_USER:         models.RoleUser,
+		pb.Role_MODERATOR:    models.RoleModerator,
+		pb.Role_ADMIN:        models.RoleAdmin,
+	}
+
+	pbRoles = map[models.Role]pb.Role{
+		models.RoleUser:      pb.Role_USER,
+		models.RoleModerator: pb.Role_MODERATOR,
+		models.RoleAdmin:     pb.Role_ADMIN,
+	}
+)
+
 func (h *Handlers) CreateUser(ctx context.Context, request *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
 	userID, err := h.service.CreateUser(ctx, models.CreateUserPayload{
 		Name:     request.GetName(),
+		Username: request.GetUsername(),
 		Email:    request.GetEmail(),
 		Password: request.GetPassword(),
 	})
@@ -23,12 +39,18 @@ func (h *Handlers) CreateUser(ctx context.Context, request *pb.CreateUserRequest
 		return nil, errorStatusGrpc(err)
 	}
 
+	// The new user is not signed in yet, so the email is not given back by the service.
 	return &pb.CreateUserResponse{
 		Id:        user.ID,
 		Name:      user.Name,
-		Email:     user.Email,
+		Username:  user.Username,
+		Email:     request.GetEmail(),
 		CreatedAt: timestamppb.New(user.CreatedAt),
 		UpdatedAt: timestamppb.New(user.UpdatedAt),
+		Role:      pbRoles[user.Role],
+
+		EmailVerified: user.IsEmailVerified(),
+		PendingEmail:  user.PendingEmail,
 	}, nil
 }
 
@@ -41,23 +63,59 @@ func (h *Handlers) GetUser(ctx context.Context, request *pb.GetUserRequest) (*pb
 	return &pb.GetUserResponse{
 		Id:        user.ID,
 		Name:      user.Name,
+		Username:  user.Username,
 		Email:     user.Email,
 		CreatedAt: timestamppb.New(user.CreatedAt),
 		UpdatedAt: timestamppb.New(user.UpdatedAt),
+		Role:      pbRoles[user.Role],
+
+		EmailVerified: user.IsEmailVerified(),
+		PendingEmail:  user.PendingEmail,
+	}, nil
+}
+
+func (h *Handlers) GetUserByUsername(ctx context.Context,
+	request *pb.GetUserByUsernameRequest) (*pb.GetUserResponse, error) {
+	user, err := h.service.GetUserByUsername(ctx, request.GetUsername())
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.GetUserResponse{
+		Id:        user.ID,
+		Name:      user.Name,
+		Username:  user.Username,
+		Email:     user.Email,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+		Role:      pbRoles[user.Role],
+
+		EmailVerified: user.IsEmailVerified(),
+		PendingEmail:  user.PendingEmail,
 	}, nil
 }
 
 func (h *Handlers) UpdateUser(ctx context.Context, request *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
-	err := h.service.UpdateUser(ctx, models.UpdateUserPayload{
-		Name:     request.GetName(),
-		Email:    request.GetEmail(),
-		Password: request.GetPassword(),
+	userID := request.GetId()
+	if userID == "" {
+		userID = h.currentUserInformationProvider.GetCurrentUserID(ctx)
+	}
+
+	fields, err := fieldsToUpdate(request, request.GetUpdateMask())
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.service.UpdateUser(ctx, models.UpdateUserPayload{
+		UserID: userID,
+		Name:   stringToUpdate(fields, "name", request.GetName()),
+		Email:  stringToUpdate(fields, "email", request.GetEmail()),
 	})
 	if err != nil {
 		return nil, errorStatusGrpc(err)
 	}
 
-	user, err := h.service.GetUser(ctx, h.currentUserInformationProvider.GetCurrentUserID(ctx))
+	user, err := h.service.GetUser(ctx, userID)
 	if err != nil {
 		return nil, errorStatusGrpc(err)
 	}
@@ -65,9 +123,43 @@ func (h *Handlers) UpdateUser(ctx context.Context, request *pb.UpdateUserRequest
 	return &pb.UpdateUserResponse{
 		Id:        user.ID,
 		Name:      user.Name,
+		Username:  user.Username,
 		Email:     user.Email,
 		CreatedAt: timestamppb.New(user.CreatedAt),
 		UpdatedAt: timestamppb.New(user.UpdatedAt),
+		Role:      pbRoles[user.Role],
+
+		EmailVerified: user.IsEmailVerified(),
+		PendingEmail:  user.PendingEmail,
+	}, nil
+}
+
+func (h *Handlers) UpdateUserRole(ctx context.Context,
+	request *pb.UpdateUserRoleRequest) (*pb.UpdateUserRoleResponse, error) {
+	err := h.service.UpdateUserRole(ctx, models.UpdateUserRolePayload{
+		UserID: request.GetId(),
+		Role:   allowedRoles[request.GetRole()],
+	})
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	user, err := h.service.GetUser(ctx, request.GetId())
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.UpdateUserRoleResponse{
+		Id:        user.ID,
+		Name:      user.Name,
+		Username:  user.Username,
+		Email:     user.Email,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+		Role:      pbRoles[user.Role],
+
+		EmailVerified: user.IsEmailVerified(),
+		PendingEmail:  user.PendingEmail,
 	}, nil
 }
 
@@ -78,3 +170,36 @@ func (h *Handlers) DeleteUser(ctx context.Context, _ *pb.DeleteUserRequest) (*pb
 
 	return &pb.DeleteUserResponse{}, nil
 }
+
+func (h *Handlers) DeleteUserById(ctx context.Context,
+	request *pb.DeleteUserByIdRequest) (*pb.DeleteUserByIdResponse, error) {
+	if err := h.service.DeleteUserByID(ctx, request.GetId()); err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.DeleteUserByIdResponse{}, nil
+}
+
+func (h *Handlers) RestoreUser(ctx context.Context, request *pb.RestoreUserRequest) (*pb.RestoreUserResponse, error) {
+	if err := h.service.RestoreUser(ctx, request.GetId()); err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	user, err := h.service.GetUser(ctx, request.GetId())
+	if err != nil {
+		return nil, errorStatusGrpc(err)
+	}
+
+	return &pb.RestoreUserResponse{
+		Id:        user.ID,
+		Name:      user.Name,
+		Username:  user.Username,
+		Email:     user.Email,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+		Role:      pbRoles[user.Role],
+
+		EmailVerified: user.IsEmailVerified(),
+		PendingEmail:  user.PendingEmail,
+	}, nil
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/serhiihuberniuk/blog-api/models"
+	viewmodels "github.com/serhiihuberniuk/blog-api/view/rest/models"
+)
+
+// attachmentFormField is the field of the multipart form the uploaded file is sent in.
+const attachmentFormField = "file"
+
+// UploadAttachment streams the file from the multipart form straight to the service without buffering it.
+func (h *Handlers) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		http.Error(w, "cannot read multipart form", http.StatusBadRequest)
+
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			http.Error(w, "file is missing in form", http.StatusBadRequest)
+
+			return
+		}
+
+		if err != nil {
+			http.Error(w, "cannot read multipart form", http.StatusBadRequest)
+
+			return
+		}
+
+		if part.FormName() != attachmentFormField || part.FileName() == "" {
+			continue
+		}
+
+		attachment, err := h.service.CreateAttachment(r.Context(), models.CreateAttachmentPayload{
+			PostID:   mux.Vars(r)["id"],
+			FileName: part.FileName(),
+			Content:  part,
+		})
+		if err != nil {
+			errorStatusHttp(w, err)
+
+			return
+		}
+
+		if !encodeIntoJson(w, attachmentResponse(attachment)) {
+			return
+		}
+
+		return
+	}
+}
+
+func (h *Handlers) ListAttachments(w http.ResponseWriter, r *http.Request) {
+	attachments, err := h.service.ListAttachments(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	outs := make([]viewmodels.GetAttachmentResponse, 0)
+
+	for _, attachment := range attachments {
+		outs = append(outs, attachmentResponse(attachment))
+	}
+
+	if !encodeIntoJson(w, outs) {
+		return
+	}
+}
+
+func (h *Handlers) GetAttachment(w http.ResponseWriter, r *http.Request) {
+	attachment, err := h.service.GetAttachment(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	if !encodeIntoJson(w, attachmentResponse(attachment)) {
+		return
+	}
+}
+
+// GetAttachmentContent streams the file. The stored media type is served as is, so browsers are told not to
+// guess another one.
+func (h *Handlers) GetAttachmentContent(w http.ResponseWriter, r *http.Request) {
+	attachment, content, err := h.service.OpenAttachment(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline",
+		map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	_, _ = io.Copy(w, content)
+}
+
+// GetAttachmentVariant streams the variant of the attached image.
+func (h *Handlers) GetAttachmentVariant(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	variant, content, err := h.service.OpenAttachmentVariant(r.Context(), vars["id"], vars["name"])
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", variant.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(variant.Size, 10))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	_, _ = io.Copy(w, content)
+}
+
+func (h *Handlers) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteAttachment(r.Context(), mux.Vars(r)["id"]); err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func attachmentResponse(attachment *models.Attachment) viewmodels.GetAttachmentResponse {
+	out := viewmodels.GetAttachmentResponse{
+		ID:            attachment.ID,
+		PostID:        attachment.PostID,
+		CreatedBy:     attachment.CreatedBy,
+		CreatedAt:     attachment.CreatedAt,
+		FileName:      attachment.FileName,
+		ContentType:   attachment.ContentType,
+		Size:          attachment.Size,
+		URL:           attachment.URL,
+		VariantStatus: string(attachment.VariantStatus),
+		Variants:      make([]viewmodels.AttachmentVariantResponse, 0, len(attachment.Variants)),
+	}
+
+	for _, variant := range attachment.Variants {
+		out.Variants = append(out.Variants, viewmodels.AttachmentVariantResponse{
+			Name:        variant.Name,
+			ContentType: variant.ContentType,
+			Width:       variant.Width,
+			Height:      variant.Height,
+			Size:        variant.Size,
+			URL:         variant.URL,
+		})
+	}
+
+	return out
+}
@@ -14,19 +14,151 @@ func (h *Handlers) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := h.service.Login(r.Context(), models.LoginPayload{
+	tokens, err := h.service.Login(r.Context(), models.LoginPayload{
 		Email:    in.Email,
 		Password: in.Password,
 	})
 	if err != nil {
 		errorStatusHttp(w, err)
+
+		return
 	}
 
 	out := &viewmodels.LoginResponse{
-		Token: token,
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 	}
 
 	if !encodeIntoJson(w, out) {
 		return
 	}
 }
+
+func (h *Handlers) Refresh(w http.ResponseWriter, r *http.Request) {
+	var in viewmodels.RefreshRequest
+
+	if !decodeFromJson(w, r, &in) {
+		return
+	}
+
+	tokens, err := h.service.Refresh(r.Context(), models.RefreshPayload{
+		RefreshToken: in.RefreshToken,
+	})
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	out := &viewmodels.LoginResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	}
+
+	if !encodeIntoJson(w, out) {
+		return
+	}
+}
+
+func (h *Handlers) Logout(w http.ResponseWriter, r *http.Request) {
+	var in viewmodels.LogoutRequest
+
+	if !decodeFromJson(w, r, &in) {
+		return
+	}
+
+	err := h.service.Logout(r.Context(), models.LogoutPayload{
+		RefreshToken: in.RefreshToken,
+	})
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handlers) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	var in viewmodels.ChangePasswordRequest
+
+	if !decodeFromJson(w, r, &in) {
+		return
+	}
+
+	if err := h.service.ChangePassword(r.Context(), in.OldPassword, in.NewPassword); err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handlers) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var in viewmodels.RequestPasswordResetRequest
+
+	if !decodeFromJson(w, r, &in) {
+		return
+	}
+
+	err := h.service.RequestPasswordReset(r.Context(), models.RequestPasswordResetPayload{
+		Email: in.Email,
+	})
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handlers) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var in viewmodels.ResetPasswordRequest
+
+	if !decodeFromJson(w, r, &in) {
+		return
+	}
+
+	err := h.service.ResetPassword(r.Context(), models.ResetPasswordPayload{
+		Token:       in.Token,
+		NewPassword: in.Password,
+	})
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handlers) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var in viewmodels.VerifyEmailRequest
+
+	if !decodeFromJson(w, r, &in) {
+		return
+	}
+
+	err := h.service.VerifyEmail(r.Context(), models.VerifyEmailPayload{
+		Token: in.Token,
+	})
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handlers) ResendEmailVerification(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.ResendEmailVerification(r.Context()); err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
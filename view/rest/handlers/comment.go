@@ -16,7 +16,9 @@ var (
 	}
 
 	allowedSortCommentsFields = map[string]models.SortCommentsByField{
-		string(models.SortCommentByCreatedAt): models.SortCommentByCreatedAt,
+		string(models.SortCommentByCreatedAt):     models.SortCommentByCreatedAt,
+		string(models.SortCommentByUpdatedAt):     models.SortCommentByUpdatedAt,
+		string(models.SortCommentByReactionCount): models.SortCommentByReactionCount,
 	}
 )
 
@@ -28,8 +30,10 @@ func (h *Handlers) CreateComment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	commentID, err := h.service.CreateComment(r.Context(), models.CreateCommentPayload{
-		Content: in.Content,
-		PostID:  in.PostID,
+		Content:  in.Content,
+		Format:   models.ContentFormat(in.Format),
+		PostID:   in.PostID,
+		ParentID: in.ParentID,
 	})
 	if err != nil {
 		errorStatusHttp(w, err)
@@ -45,11 +49,20 @@ func (h *Handlers) CreateComment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	out := viewmodels.GetCommentResponse{
-		ID:        comment.ID,
-		Content:   comment.Content,
-		CreatedAt: comment.CreatedAt,
-		CreatedBy: comment.CreatedBy,
-		PostID:    comment.PostID,
+		ID:          comment.ID,
+		Content:     comment.Content,
+		CreatedAt:   comment.CreatedAt,
+		CreatedBy:   comment.CreatedBy,
+		PostID:      comment.PostID,
+		UpdatedAt:   comment.UpdatedAt,
+		Edited:      comment.IsEdited(),
+		ParentID:    comment.ParentID,
+		Reactions:   reactionsResponse(comment.Reactions),
+		Format:      string(comment.Format),
+		ContentHTML: comment.ContentHTML,
+		WordCount:   comment.WordCount,
+		ReadingTime: comment.ReadingTime(),
+		Mentions:    mentionsResponse(comment.Mentions),
 	}
 
 	if !encodeIntoJson(w, out) {
@@ -58,6 +71,13 @@ func (h *Handlers) CreateComment(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handlers) GetComment(w http.ResponseWriter, r *http.Request) {
+	view, err := contentView(r)
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
 	commentID := mux.Vars(r)["id"]
 
 	comment, err := h.service.GetComment(r.Context(), commentID)
@@ -68,14 +88,43 @@ func (h *Handlers) GetComment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	out := viewmodels.GetCommentResponse{
-		ID:        comment.ID,
-		Content:   comment.Content,
-		CreatedAt: comment.CreatedAt,
-		CreatedBy: comment.CreatedBy,
-		PostID:    comment.PostID,
+		ID:          comment.ID,
+		Content:     comment.Content,
+		CreatedAt:   comment.CreatedAt,
+		CreatedBy:   comment.CreatedBy,
+		PostID:      comment.PostID,
+		UpdatedAt:   comment.UpdatedAt,
+		Edited:      comment.IsEdited(),
+		ParentID:    comment.ParentID,
+		Reactions:   reactionsResponse(comment.Reactions),
+		Format:      string(comment.Format),
+		ContentHTML: comment.ContentHTML,
+		WordCount:   comment.WordCount,
+		ReadingTime: comment.ReadingTime(),
+		Mentions:    mentionsResponse(comment.Mentions),
 	}
 
-	if !encodeIntoJson(w, out) {
+	if !encodeIntoJson(w, commentContentView(out, view)) {
+		return
+	}
+}
+
+func (h *Handlers) ListReplies(w http.ResponseWriter, r *http.Request) {
+	view, err := contentView(r)
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	replies, err := h.service.ListReplies(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	if !encodeIntoJson(w, commentRepliesResponse(replies, view)) {
 		return
 	}
 }
@@ -92,6 +141,7 @@ func (h *Handlers) UpdateComment(w http.ResponseWriter, r *http.Request) {
 	err := h.service.UpdateComment(r.Context(), models.UpdateCommentPayload{
 		CommentID: commentID,
 		Content:   in.Content,
+		Format:    contentFormat(in.Format),
 	})
 	if err != nil {
 		errorStatusHttp(w, err)
@@ -107,11 +157,20 @@ func (h *Handlers) UpdateComment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	out := viewmodels.GetCommentResponse{
-		ID:        comment.ID,
-		Content:   comment.Content,
-		CreatedAt: comment.CreatedAt,
-		CreatedBy: comment.CreatedBy,
-		PostID:    comment.PostID,
+		ID:          comment.ID,
+		Content:     comment.Content,
+		CreatedAt:   comment.CreatedAt,
+		CreatedBy:   comment.CreatedBy,
+		PostID:      comment.PostID,
+		UpdatedAt:   comment.UpdatedAt,
+		Edited:      comment.IsEdited(),
+		ParentID:    comment.ParentID,
+		Reactions:   reactionsResponse(comment.Reactions),
+		Format:      string(comment.Format),
+		ContentHTML: comment.ContentHTML,
+		WordCount:   comment.WordCount,
+		ReadingTime: comment.ReadingTime(),
+		Mentions:    mentionsResponse(comment.Mentions),
 	}
 
 	if !encodeIntoJson(w, out) {
@@ -131,8 +190,46 @@ func (h *Handlers) DeleteComment(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+func (h *Handlers) RestoreComment(w http.ResponseWriter, r *http.Request) {
+	commentID := mux.Vars(r)["id"]
+
+	if err := h.service.RestoreComment(r.Context(), commentID); err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	comment, err := h.service.GetComment(r.Context(), commentID)
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	out := viewmodels.GetCommentResponse{
+		ID:          comment.ID,
+		Content:     comment.Content,
+		CreatedAt:   comment.CreatedAt,
+		CreatedBy:   comment.CreatedBy,
+		PostID:      comment.PostID,
+		UpdatedAt:   comment.UpdatedAt,
+		Edited:      comment.IsEdited(),
+		ParentID:    comment.ParentID,
+		Reactions:   reactionsResponse(comment.Reactions),
+		Format:      string(comment.Format),
+		ContentHTML: comment.ContentHTML,
+		WordCount:   comment.WordCount,
+		ReadingTime: comment.ReadingTime(),
+		Mentions:    mentionsResponse(comment.Mentions),
+	}
+
+	if !encodeIntoJson(w, out) {
+		return
+	}
+}
+
 func (h *Handlers) GetListOfComments(w http.ResponseWriter, r *http.Request) {
-	queryParams, err := GetQueryParams(r, func(s string) bool {
+	queryParams, err := h.GetQueryParams(r, func(s string) bool {
 		_, ok := allowedFilterCommentsFields[s]
 
 		return ok
@@ -147,37 +244,119 @@ func (h *Handlers) GetListOfComments(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	comments, err := h.service.ListComments(r.Context(), models.Pagination{
-		Limit:  uint64(queryParams.limit),
-		Offset: uint64(queryParams.offset),
-	}, models.FilterComments{
-		Field: models.FilterCommentsByField(queryParams.filterByField),
-		Value: queryParams.filterValue,
-	}, models.SortComments{
-		Field: models.SortCommentsByField(queryParams.sortByField),
-		IsASC: queryParams.isAsc,
-	})
+	view, err := contentView(r)
 	if err != nil {
 		errorStatusHttp(w, err)
 
 		return
 	}
 
-	outs := make([]viewmodels.GetCommentResponse, 0)
+	page, err := h.service.ListComments(r.Context(), queryParams.pagination(), filterComments(queryParams.filters), sortComments(queryParams.sortKeys))
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	outs := make([]viewmodels.GetCommentResponse, 0, len(page.Comments))
 
-	for _, comment := range comments {
+	for _, comment := range page.Comments {
 		out := viewmodels.GetCommentResponse{
-			ID:        comment.ID,
-			Content:   comment.Content,
-			CreatedAt: comment.CreatedAt,
-			CreatedBy: comment.CreatedBy,
-			PostID:    comment.PostID,
+			ID:          comment.ID,
+			Content:     comment.Content,
+			CreatedAt:   comment.CreatedAt,
+			CreatedBy:   comment.CreatedBy,
+			PostID:      comment.PostID,
+			UpdatedAt:   comment.UpdatedAt,
+			Edited:      comment.IsEdited(),
+			ParentID:    comment.ParentID,
+			Reactions:   reactionsResponse(comment.Reactions),
+			Format:      string(comment.Format),
+			ContentHTML: comment.ContentHTML,
+			WordCount:   comment.WordCount,
+			ReadingTime: comment.ReadingTime(),
+			Mentions:    mentionsResponse(comment.Mentions),
 		}
 
-		outs = append(outs, out)
+		outs = append(outs, commentContentView(out, view))
 	}
 
+	setPageHeaders(w, r, page.PageInfo)
+
 	if !encodeIntoJson(w, outs) {
 		return
 	}
 }
+
+func commentRepliesResponse(replies []*models.CommentThread,
+	view models.ContentView) []viewmodels.CommentReplyResponse {
+	outs := make([]viewmodels.CommentReplyResponse, 0, len(replies))
+
+	for _, reply := range replies {
+		comment := reply.Comment
+
+		outs = append(outs, viewmodels.CommentReplyResponse{
+			GetCommentResponse: commentContentView(viewmodels.GetCommentResponse{
+				ID:          comment.ID,
+				Content:     comment.Content,
+				CreatedAt:   comment.CreatedAt,
+				CreatedBy:   comment.CreatedBy,
+				PostID:      comment.PostID,
+				UpdatedAt:   comment.UpdatedAt,
+				Edited:      comment.IsEdited(),
+				ParentID:    comment.ParentID,
+				Reactions:   reactionsResponse(comment.Reactions),
+				Format:      string(comment.Format),
+				ContentHTML: comment.ContentHTML,
+				WordCount:   comment.WordCount,
+				ReadingTime: comment.ReadingTime(),
+				Mentions:    mentionsResponse(comment.Mentions),
+			}, view),
+			Replies: commentRepliesResponse(reply.Replies, view),
+		})
+	}
+
+	return outs
+}
+
+// commentContentView leaves out the representations of the content which are not selected by the view.
+func commentContentView(out viewmodels.GetCommentResponse, view models.ContentView) viewmodels.GetCommentResponse {
+	if !view.ShowsRaw() {
+		out.Content = ""
+	}
+
+	if !view.ShowsHTML() {
+		out.ContentHTML = ""
+	}
+
+	return out
+}
+
+func filterComments(filters []filterParam) models.FilterComments {
+	conditions := make([]models.CommentCondition, 0, len(filters))
+
+	for _, filter := range filters {
+		conditions = append(conditions, models.CommentCondition{
+			Field:    allowedFilterCommentsFields[filter.field],
+			Operator: models.FilterOperator(filter.operator),
+			Values:   filter.values,
+		})
+	}
+
+	return models.FilterComments{
+		Conditions: conditions,
+	}
+}
+
+func sortComments(keys []models.SortKey) models.SortComments {
+	sort := models.SortComments{}
+
+	for _, key := range keys {
+		sort.Keys = append(sort.Keys, models.SortCommentsKey{
+			Field: allowedSortCommentsFields[key.Field],
+			IsASC: key.IsASC,
+		})
+	}
+
+	return sort
+}
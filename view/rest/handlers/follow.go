@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/serhiihuberniuk/blog-api/models"
+	viewmodels "github.com/serhiihuberniuk/blog-api/view/rest/models"
+)
+
+func (h *Handlers) Follow(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Follow(r.Context(), mux.Vars(r)["id"]); err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+}
+
+func (h *Handlers) Unfollow(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Unfollow(r.Context(), mux.Vars(r)["id"]); err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+}
+
+func (h *Handlers) ListFollowers(w http.ResponseWriter, r *http.Request) {
+	h.listFollowUsers(w, r, h.service.ListFollowers)
+}
+
+func (h *Handlers) ListFollowing(w http.ResponseWriter, r *http.Request) {
+	h.listFollowUsers(w, r, h.service.ListFollowing)
+}
+
+// listFollowUsers writes the page of users related to the user from the path.
+func (h *Handlers) listFollowUsers(w http.ResponseWriter, r *http.Request,
+	list func(ctx context.Context, userID string, pagination models.Pagination) ([]*models.User, error)) {
+	queryParams, err := h.GetQueryParams(r, func(string) bool { return false }, func(string) bool { return false })
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	users, err := list(r.Context(), mux.Vars(r)["id"], models.Pagination{
+		Limit:  uint64(queryParams.limit),
+		Offset: uint64(queryParams.offset),
+	})
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	outs := make([]viewmodels.GetUserResponse, 0, len(users))
+
+	for _, user := range users {
+		outs = append(outs, viewmodels.GetUserResponse{
+			ID:        user.ID,
+			Name:      user.Name,
+			Username:  user.Username,
+			Email:     user.Email,
+			CreatedAt: user.CreatedAt,
+			UpdatedAt: user.UpdatedAt,
+			Role:      string(user.Role),
+
+			EmailVerified: user.IsEmailVerified(),
+			PendingEmail:  user.PendingEmail,
+		})
+	}
+
+	if !encodeIntoJson(w, outs) {
+		return
+	}
+}
+
+func (h *Handlers) Feed(w http.ResponseWriter, r *http.Request) {
+	noFields := func(string) bool {
+		return false
+	}
+
+	queryParams, err := h.GetQueryParams(r, noFields, noFields)
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	view, err := contentView(r)
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	page, err := h.service.Feed(r.Context(), queryParams.pagination())
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	outs := make([]viewmodels.GetPostResponse, 0, len(page.Posts))
+
+	for _, post := range page.Posts {
+		outs = append(outs, postContentView(viewmodels.GetPostResponse{
+			ID:              post.ID,
+			Title:           post.Title,
+			Description:     post.Description,
+			CreatedAt:       post.CreatedAt,
+			CreatedBy:       post.CreatedBy,
+			Tags:            post.Tags,
+			UpdatedAt:       post.UpdatedAt,
+			Edited:          post.IsEdited(),
+			Status:          string(post.Status),
+			Slug:            post.Slug,
+			Reactions:       reactionsResponse(post.Reactions),
+			Format:          string(post.Format),
+			DescriptionHTML: post.DescriptionHTML,
+			WordCount:       post.WordCount,
+			ReadingTime:     post.ReadingTime(),
+			Mentions:        mentionsResponse(post.Mentions),
+			CommentCount:    post.CommentCount,
+			LastCommentedAt: post.LastCommentedAt,
+		}, view))
+	}
+
+	setPageHeaders(w, r, page.PageInfo)
+
+	if !encodeIntoJson(w, outs) {
+		return
+	}
+}
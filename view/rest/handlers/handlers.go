@@ -7,22 +7,42 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/serhiihuberniuk/blog-api/models"
 )
 
-const maxLimit = 50
-
 type queryParam struct {
-	filterByField string
-	filterValue   string
-	sortByField   string
-	isAsc         bool
-	limit         int
-	offset        int
+	filters    []filterParam
+	sortKeys   []models.SortKey
+	limit      int
+	offset     int
+	after      *models.Cursor
+	before     *models.Cursor
+	countTotal bool
+}
+
+func (q *queryParam) pagination() models.Pagination {
+	return models.Pagination{
+		Limit:      uint64(q.limit),
+		Offset:     uint64(q.offset),
+		After:      q.after,
+		Before:     q.before,
+		CountTotal: q.countTotal,
+	}
+}
+
+// filterParam is a condition of a filter given in the query as filter=<field>:<operator>:<value>.
+// Values of the "in" operator are separated with commas.
+type filterParam struct {
+	field    string
+	operator string
+	values   []string
 }
 
 func errorStatusHttp(w http.ResponseWriter, err error) {
@@ -38,6 +58,18 @@ func errorStatusHttp(w http.ResponseWriter, err error) {
 		return
 	}
 
+	if errors.Is(err, models.ErrForbidden) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+
+		return
+	}
+
+	if errors.Is(err, models.ErrTooLarge) || errors.Is(err, models.ErrQuotaExceeded) {
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+
+		return
+	}
+
 	if errors.As(err, &validation.Errors{}) {
 		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 
@@ -69,7 +101,7 @@ func encodeIntoJson(w http.ResponseWriter, a interface{}) bool {
 	return true
 }
 
-func GetQueryParams(r *http.Request, allowFilterFn, allowSortFn func(string) bool) (*queryParam, error) {
+func (h *Handlers) GetQueryParams(r *http.Request, allowFilterFn, allowSortFn func(string) bool) (*queryParam, error) {
 	vars := r.URL.Query()
 
 	var err error
@@ -77,16 +109,27 @@ func GetQueryParams(r *http.Request, allowFilterFn, allowSortFn func(string) boo
 	queryParams := queryParam{}
 
 	if v := vars.Get("filter-field"); v != "" && allowFilterFn(v) {
-		queryParams.filterByField = v
-		queryParams.filterValue = vars.Get("filter-value")
+		queryParams.filters = append(queryParams.filters, filterParam{
+			field:    v,
+			operator: string(models.FilterOperatorEq),
+			values:   []string{vars.Get("filter-value")},
+		})
 	}
 
-	if v := vars.Get("sort-field"); v != "" && allowSortFn(v) {
-		queryParams.sortByField = v
+	for _, v := range vars["filter"] {
+		filter, err := parseFilterParam(v, allowFilterFn)
+		if err != nil {
+			return nil, err
+		}
+
+		queryParams.filters = append(queryParams.filters, filter)
 	}
 
-	if vars.Get("is-asc") != "false" {
-		queryParams.isAsc = true
+	if v := vars.Get("sort-field"); v != "" {
+		queryParams.sortKeys, err = parseSortParam(v, vars.Get("is-asc") != "false", allowSortFn)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	queryParams.limit, err = strconv.Atoi(vars.Get("limit"))
@@ -94,9 +137,7 @@ func GetQueryParams(r *http.Request, allowFilterFn, allowSortFn func(string) boo
 		return nil, fmt.Errorf("cannot convert limit into int: %w", err)
 	}
 
-	if queryParams.limit <= 0 || queryParams.limit > maxLimit {
-		queryParams.limit = maxLimit
-	}
+	queryParams.limit = h.pageLimit(queryParams.limit)
 
 	queryParams.offset = 0
 	if vars.Get("offset") != "" {
@@ -110,31 +151,223 @@ func GetQueryParams(r *http.Request, allowFilterFn, allowSortFn func(string) boo
 		queryParams.offset = 0
 	}
 
+	if queryParams.after, err = models.ParseCursor(vars.Get("after")); err != nil {
+		return nil, err
+	}
+
+	if queryParams.before, err = models.ParseCursor(vars.Get("before")); err != nil {
+		return nil, err
+	}
+
+	queryParams.countTotal = vars.Get("count") == "true"
+
 	return &queryParams, nil
 }
 
+// pageLimit returns the limit of the page, which is the maximal one if the given limit is not positive or too big.
+func (h *Handlers) pageLimit(limit int) int {
+	if limit <= 0 || uint64(limit) > h.maxLimit {
+		return int(h.maxLimit)
+	}
+
+	return limit
+}
+
+// contentView returns the view of the content selected in the content query parameter.
+// Both the raw text and the HTML are returned when the view is not given.
+func contentView(r *http.Request) (models.ContentView, error) {
+	view := models.ContentView(r.URL.Query().Get("content"))
+
+	err := validation.Validate(view, validation.In(models.ContentViewRaw, models.ContentViewHTML, models.ContentViewBoth))
+	if err != nil {
+		return "", validation.Errors{
+			"content": err,
+		}
+	}
+
+	return view, nil
+}
+
+// contentFormat returns the format of the content given in the request, if any.
+func contentFormat(format *string) *models.ContentFormat {
+	if format == nil {
+		return nil
+	}
+
+	f := models.ContentFormat(*format)
+
+	return &f
+}
+
+// setPageHeaders links the neighbouring pages in the Link header
+// and writes the total number of objects into the X-Total-Count header.
+func setPageHeaders(w http.ResponseWriter, r *http.Request, pageInfo models.PageInfo) {
+	var links []string
+
+	if pageInfo.Next != nil {
+		links = append(links, pageLink(r, "after", pageInfo.Next, "next"))
+	}
+
+	if pageInfo.Prev != nil {
+		links = append(links, pageLink(r, "before", pageInfo.Prev, "prev"))
+	}
+
+	if len(links) != 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+
+	if pageInfo.Total != nil {
+		w.Header().Set("X-Total-Count", strconv.Itoa(*pageInfo.Total))
+	}
+}
+
+// pageLink returns the link to the page selected by the cursor, keeping the rest of the request query.
+func pageLink(r *http.Request, param string, cursor *models.Cursor, rel string) string {
+	query := r.URL.Query()
+	query.Del("offset")
+	query.Del("after")
+	query.Del("before")
+	query.Set(param, cursor.String())
+
+	link := url.URL{
+		Path:     r.URL.Path,
+		RawQuery: query.Encode(),
+	}
+
+	return fmt.Sprintf("<%s>; rel=%q", link.String(), rel)
+}
+
+func parseFilterParam(v string, allowFilterFn func(string) bool) (filterParam, error) {
+	parts := strings.SplitN(v, ":", 3)
+	if len(parts) != 3 {
+		return filterParam{}, validation.Errors{
+			"filter": fmt.Errorf("%q must be formatted as <field>:<operator>:<value>", v),
+		}
+	}
+
+	if !allowFilterFn(parts[0]) {
+		return filterParam{}, validation.Errors{
+			"filter": fmt.Errorf("cannot filter by %q", parts[0]),
+		}
+	}
+
+	filter := filterParam{
+		field:    parts[0],
+		operator: parts[1],
+		values:   []string{parts[2]},
+	}
+
+	if filter.operator == string(models.FilterOperatorIn) {
+		filter.values = strings.Split(parts[2], ",")
+	}
+
+	return filter, nil
+}
+
+// parseSortParam parses the keys given in the query as sort-field=<field>[:asc|:desc],...
+// Keys without the direction are sorted in the direction of is-asc. Unknown fields are skipped.
+func parseSortParam(v string, isAsc bool, allowSortFn func(string) bool) ([]models.SortKey, error) {
+	var keys []models.SortKey
+
+	for _, s := range strings.Split(v, ",") {
+		key, err := models.ParseSortKey(s, isAsc)
+		if err != nil {
+			return nil, validation.Errors{
+				"sort-field": err,
+			}
+		}
+
+		if allowSortFn(key.Field) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys, nil
+}
+
 type service interface {
-	Login(ctx context.Context, payload models.LoginPayload) (string, error)
-	ParseToken(tokenString string) (string, error)
+	Login(ctx context.Context, payload models.LoginPayload) (*models.Tokens, error)
+	Refresh(ctx context.Context, payload models.RefreshPayload) (*models.Tokens, error)
+	Logout(ctx context.Context, payload models.LogoutPayload) error
+	RequestPasswordReset(ctx context.Context, payload models.RequestPasswordResetPayload) error
+	ResetPassword(ctx context.Context, payload models.ResetPasswordPayload) error
+	VerifyEmail(ctx context.Context, payload models.VerifyEmailPayload) error
+	ResendEmailVerification(ctx context.Context) error
+	ParseToken(ctx context.Context, tokenString string) (*models.AuthInfo, error)
 
 	CreateUser(ctx context.Context, payload models.CreateUserPayload) (string, error)
 	GetUser(ctx context.Context, userID string) (*models.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
 	UpdateUser(ctx context.Context, payload models.UpdateUserPayload) error
+	ChangePassword(ctx context.Context, oldPassword, newPassword string) error
+	UpdateUserRole(ctx context.Context, payload models.UpdateUserRolePayload) error
 	DeleteUser(ctx context.Context) error
+	DeleteUserByID(ctx context.Context, userID string) error
+	RestoreUser(ctx context.Context, userID string) error
+
+	Follow(ctx context.Context, userID string) error
+	Unfollow(ctx context.Context, userID string) error
+	ListFollowers(ctx context.Context, userID string, pagination models.Pagination) ([]*models.User, error)
+	ListFollowing(ctx context.Context, userID string, pagination models.Pagination) ([]*models.User, error)
+	Feed(ctx context.Context, pagination models.Pagination) (*models.PostsPage, error)
+
+	ListNotifications(ctx context.Context, pagination models.Pagination,
+		unreadOnly bool) (*models.NotificationList, error)
+	MarkNotificationRead(ctx context.Context, notificationID string) error
+	MarkAllNotificationsRead(ctx context.Context) error
+	GetNotificationPreferences(ctx context.Context) (models.NotificationPreferences, error)
+	UpdateNotificationPreferences(ctx context.Context,
+		changes models.NotificationPreferences) (models.NotificationPreferences, error)
 
 	CreatePost(ctx context.Context, payload models.CreatePostPayload) (string, error)
 	GetPost(ctx context.Context, postID string) (*models.Post, error)
+	GetPostBySlug(ctx context.Context, slug string) (*models.Post, error)
 	UpdatePost(ctx context.Context, payload models.UpdatePostPayload) error
 	DeletePost(ctx context.Context, postID string) error
+	RestorePost(ctx context.Context, postID string) error
+	SubmitPost(ctx context.Context, postID string) error
+	PublishPost(ctx context.Context, postID string) error
+	UnpublishPost(ctx context.Context, postID string) error
+	ArchivePost(ctx context.Context, postID string) error
 	ListPosts(ctx context.Context, pagination models.Pagination,
-		filter models.FilterPosts, sort models.SortPosts) ([]*models.Post, error)
+		filter models.FilterPosts, sort models.SortPosts) (*models.PostsPage, error)
 
 	CreateComment(ctx context.Context, payload models.CreateCommentPayload) (string, error)
 	GetComment(ctx context.Context, commentID string) (*models.Comment, error)
+	ListReplies(ctx context.Context, commentID string) ([]*models.CommentThread, error)
 	UpdateComment(ctx context.Context, payload models.UpdateCommentPayload) error
 	DeleteComment(ctx context.Context, commentId string) error
+	RestoreComment(ctx context.Context, commentID string) error
 	ListComments(ctx context.Context, pagination models.Pagination,
-		filter models.FilterComments, sort models.SortComments) ([]*models.Comment, error)
+		filter models.FilterComments, sort models.SortComments) (*models.CommentsPage, error)
+
+	Search(ctx context.Context, query models.SearchQuery, pagination models.Pagination) ([]*models.SearchResult, error)
+
+	ListTags(ctx context.Context, pagination models.Pagination) ([]*models.Tag, error)
+	AutocompleteTags(ctx context.Context, prefix string, limit uint64) ([]*models.Tag, error)
+	RenameTag(ctx context.Context, payload models.RenameTagPayload) error
+	MergeTags(ctx context.Context, payload models.MergeTagsPayload) error
+
+	AddReaction(ctx context.Context, payload models.ReactionPayload) (models.ReactionCounts, error)
+	RemoveReaction(ctx context.Context, payload models.ReactionPayload) (models.ReactionCounts, error)
+
+	ListPostRevisions(ctx context.Context, postID string) ([]*models.PostRevision, error)
+	GetPostRevision(ctx context.Context, postID, revisionID string) (*models.PostRevision, error)
+	DiffPostRevisions(ctx context.Context, postID, fromRevisionID, toRevisionID string) ([]models.FieldChange, error)
+	RollbackPost(ctx context.Context, postID, revisionID string) error
+	ListCommentRevisions(ctx context.Context, commentID string) ([]*models.CommentRevision, error)
+	GetCommentRevision(ctx context.Context, commentID, revisionID string) (*models.CommentRevision, error)
+	DiffCommentRevisions(ctx context.Context, commentID, fromRevisionID,
+		toRevisionID string) ([]models.FieldChange, error)
+	RollbackComment(ctx context.Context, commentID, revisionID string) error
+
+	CreateAttachment(ctx context.Context, payload models.CreateAttachmentPayload) (*models.Attachment, error)
+	GetAttachment(ctx context.Context, attachmentID string) (*models.Attachment, error)
+	OpenAttachment(ctx context.Context, attachmentID string) (*models.Attachment, io.ReadCloser, error)
+	OpenAttachmentVariant(ctx context.Context, attachmentID, name string) (*models.AttachmentVariant,
+		io.ReadCloser, error)
+	ListAttachments(ctx context.Context, postID string) ([]*models.Attachment, error)
+	DeleteAttachment(ctx context.Context, attachmentID string) error
 }
 
 type authMiddleware interface {
@@ -149,12 +382,14 @@ type Handlers struct {
 	service                        service
 	authMiddleware                 authMiddleware
 	currentUserInformationProvider currentUserInformationProvider
+	maxLimit                       uint64
 }
 
-func NewRestHandlers(s service, m authMiddleware, p currentUserInformationProvider) *Handlers {
+func NewRestHandlers(s service, m authMiddleware, p currentUserInformationProvider, maxLimit uint64) *Handlers {
 	return &Handlers{
 		service:                        s,
 		authMiddleware:                 m,
 		currentUserInformationProvider: p,
+		maxLimit:                       maxLimit,
 	}
 }
@@ -6,6 +6,7 @@ package handlers_test
 
 import (
 	context "context"
+	io "io"
 	http "net/http"
 	reflect "reflect"
 
@@ -36,6 +37,79 @@ func (m *Mockservice) EXPECT() *MockserviceMockRecorder {
 	return m.recorder
 }
 
+// AddReaction mocks base method.
+func (m *Mockservice) AddReaction(ctx context.Context, payload models.ReactionPayload) (models.ReactionCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReaction", ctx, payload)
+	ret0, _ := ret[0].(models.ReactionCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReaction indicates an expected call of AddReaction.
+func (mr *MockserviceMockRecorder) AddReaction(ctx, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReaction", reflect.TypeOf((*Mockservice)(nil).AddReaction), ctx, payload)
+}
+
+// ArchivePost mocks base method.
+func (m *Mockservice) ArchivePost(ctx context.Context, postID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchivePost", ctx, postID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ArchivePost indicates an expected call of ArchivePost.
+func (mr *MockserviceMockRecorder) ArchivePost(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchivePost", reflect.TypeOf((*Mockservice)(nil).ArchivePost), ctx, postID)
+}
+
+// AutocompleteTags mocks base method.
+func (m *Mockservice) AutocompleteTags(ctx context.Context, prefix string, limit uint64) ([]*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AutocompleteTags", ctx, prefix, limit)
+	ret0, _ := ret[0].([]*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AutocompleteTags indicates an expected call of AutocompleteTags.
+func (mr *MockserviceMockRecorder) AutocompleteTags(ctx, prefix, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutocompleteTags", reflect.TypeOf((*Mockservice)(nil).AutocompleteTags), ctx, prefix, limit)
+}
+
+// ChangePassword mocks base method.
+func (m *Mockservice) ChangePassword(ctx context.Context, oldPassword, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, oldPassword, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockserviceMockRecorder) ChangePassword(ctx, oldPassword, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*Mockservice)(nil).ChangePassword), ctx, oldPassword, newPassword)
+}
+
+// CreateAttachment mocks base method.
+func (m *Mockservice) CreateAttachment(ctx context.Context, payload models.CreateAttachmentPayload) (*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttachment", ctx, payload)
+	ret0, _ := ret[0].(*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAttachment indicates an expected call of CreateAttachment.
+func (mr *MockserviceMockRecorder) CreateAttachment(ctx, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttachment", reflect.TypeOf((*Mockservice)(nil).CreateAttachment), ctx, payload)
+}
+
 // CreateComment mocks base method.
 func (m *Mockservice) CreateComment(ctx context.Context, payload models.CreateCommentPayload) (string, error) {
 	m.ctrl.T.Helper()
@@ -81,6 +155,20 @@ func (mr *MockserviceMockRecorder) CreateUser(ctx, payload interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*Mockservice)(nil).CreateUser), ctx, payload)
 }
 
+// DeleteAttachment mocks base method.
+func (m *Mockservice) DeleteAttachment(ctx context.Context, attachmentID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, attachmentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockserviceMockRecorder) DeleteAttachment(ctx, attachmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*Mockservice)(nil).DeleteAttachment), ctx, attachmentID)
+}
+
 // DeleteComment mocks base method.
 func (m *Mockservice) DeleteComment(ctx context.Context, commentId string) error {
 	m.ctrl.T.Helper()
@@ -123,6 +211,94 @@ func (mr *MockserviceMockRecorder) DeleteUser(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*Mockservice)(nil).DeleteUser), ctx)
 }
 
+// DeleteUserByID mocks base method.
+func (m *Mockservice) DeleteUserByID(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserByID", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserByID indicates an expected call of DeleteUserByID.
+func (mr *MockserviceMockRecorder) DeleteUserByID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserByID", reflect.TypeOf((*Mockservice)(nil).DeleteUserByID), ctx, userID)
+}
+
+// DiffCommentRevisions mocks base method.
+func (m *Mockservice) DiffCommentRevisions(ctx context.Context, commentID, fromRevisionID, toRevisionID string) ([]models.FieldChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffCommentRevisions", ctx, commentID, fromRevisionID, toRevisionID)
+	ret0, _ := ret[0].([]models.FieldChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffCommentRevisions indicates an expected call of DiffCommentRevisions.
+func (mr *MockserviceMockRecorder) DiffCommentRevisions(ctx, commentID, fromRevisionID, toRevisionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffCommentRevisions", reflect.TypeOf((*Mockservice)(nil).DiffCommentRevisions), ctx, commentID, fromRevisionID, toRevisionID)
+}
+
+// DiffPostRevisions mocks base method.
+func (m *Mockservice) DiffPostRevisions(ctx context.Context, postID, fromRevisionID, toRevisionID string) ([]models.FieldChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffPostRevisions", ctx, postID, fromRevisionID, toRevisionID)
+	ret0, _ := ret[0].([]models.FieldChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffPostRevisions indicates an expected call of DiffPostRevisions.
+func (mr *MockserviceMockRecorder) DiffPostRevisions(ctx, postID, fromRevisionID, toRevisionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffPostRevisions", reflect.TypeOf((*Mockservice)(nil).DiffPostRevisions), ctx, postID, fromRevisionID, toRevisionID)
+}
+
+// Feed mocks base method.
+func (m *Mockservice) Feed(ctx context.Context, pagination models.Pagination) (*models.PostsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Feed", ctx, pagination)
+	ret0, _ := ret[0].(*models.PostsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Feed indicates an expected call of Feed.
+func (mr *MockserviceMockRecorder) Feed(ctx, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Feed", reflect.TypeOf((*Mockservice)(nil).Feed), ctx, pagination)
+}
+
+// Follow mocks base method.
+func (m *Mockservice) Follow(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Follow", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Follow indicates an expected call of Follow.
+func (mr *MockserviceMockRecorder) Follow(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Follow", reflect.TypeOf((*Mockservice)(nil).Follow), ctx, userID)
+}
+
+// GetAttachment mocks base method.
+func (m *Mockservice) GetAttachment(ctx context.Context, attachmentID string) (*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachment", ctx, attachmentID)
+	ret0, _ := ret[0].(*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockserviceMockRecorder) GetAttachment(ctx, attachmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*Mockservice)(nil).GetAttachment), ctx, attachmentID)
+}
+
 // GetComment mocks base method.
 func (m *Mockservice) GetComment(ctx context.Context, commentID string) (*models.Comment, error) {
 	m.ctrl.T.Helper()
@@ -138,6 +314,36 @@ func (mr *MockserviceMockRecorder) GetComment(ctx, commentID interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComment", reflect.TypeOf((*Mockservice)(nil).GetComment), ctx, commentID)
 }
 
+// GetCommentRevision mocks base method.
+func (m *Mockservice) GetCommentRevision(ctx context.Context, commentID, revisionID string) (*models.CommentRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentRevision", ctx, commentID, revisionID)
+	ret0, _ := ret[0].(*models.CommentRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentRevision indicates an expected call of GetCommentRevision.
+func (mr *MockserviceMockRecorder) GetCommentRevision(ctx, commentID, revisionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentRevision", reflect.TypeOf((*Mockservice)(nil).GetCommentRevision), ctx, commentID, revisionID)
+}
+
+// GetNotificationPreferences mocks base method.
+func (m *Mockservice) GetNotificationPreferences(ctx context.Context) (models.NotificationPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationPreferences", ctx)
+	ret0, _ := ret[0].(models.NotificationPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationPreferences indicates an expected call of GetNotificationPreferences.
+func (mr *MockserviceMockRecorder) GetNotificationPreferences(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationPreferences", reflect.TypeOf((*Mockservice)(nil).GetNotificationPreferences), ctx)
+}
+
 // GetPost mocks base method.
 func (m *Mockservice) GetPost(ctx context.Context, postID string) (*models.Post, error) {
 	m.ctrl.T.Helper()
@@ -153,6 +359,36 @@ func (mr *MockserviceMockRecorder) GetPost(ctx, postID interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPost", reflect.TypeOf((*Mockservice)(nil).GetPost), ctx, postID)
 }
 
+// GetPostBySlug mocks base method.
+func (m *Mockservice) GetPostBySlug(ctx context.Context, slug string) (*models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostBySlug", ctx, slug)
+	ret0, _ := ret[0].(*models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostBySlug indicates an expected call of GetPostBySlug.
+func (mr *MockserviceMockRecorder) GetPostBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostBySlug", reflect.TypeOf((*Mockservice)(nil).GetPostBySlug), ctx, slug)
+}
+
+// GetPostRevision mocks base method.
+func (m *Mockservice) GetPostRevision(ctx context.Context, postID, revisionID string) (*models.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostRevision", ctx, postID, revisionID)
+	ret0, _ := ret[0].(*models.PostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostRevision indicates an expected call of GetPostRevision.
+func (mr *MockserviceMockRecorder) GetPostRevision(ctx, postID, revisionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostRevision", reflect.TypeOf((*Mockservice)(nil).GetPostRevision), ctx, postID, revisionID)
+}
+
 // GetUser mocks base method.
 func (m *Mockservice) GetUser(ctx context.Context, userID string) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -168,11 +404,56 @@ func (mr *MockserviceMockRecorder) GetUser(ctx, userID interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*Mockservice)(nil).GetUser), ctx, userID)
 }
 
+// GetUserByUsername mocks base method.
+func (m *Mockservice) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsername", ctx, username)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsername indicates an expected call of GetUserByUsername.
+func (mr *MockserviceMockRecorder) GetUserByUsername(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*Mockservice)(nil).GetUserByUsername), ctx, username)
+}
+
+// ListAttachments mocks base method.
+func (m *Mockservice) ListAttachments(ctx context.Context, postID string) ([]*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttachments", ctx, postID)
+	ret0, _ := ret[0].([]*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttachments indicates an expected call of ListAttachments.
+func (mr *MockserviceMockRecorder) ListAttachments(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttachments", reflect.TypeOf((*Mockservice)(nil).ListAttachments), ctx, postID)
+}
+
+// ListCommentRevisions mocks base method.
+func (m *Mockservice) ListCommentRevisions(ctx context.Context, commentID string) ([]*models.CommentRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCommentRevisions", ctx, commentID)
+	ret0, _ := ret[0].([]*models.CommentRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCommentRevisions indicates an expected call of ListCommentRevisions.
+func (mr *MockserviceMockRecorder) ListCommentRevisions(ctx, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCommentRevisions", reflect.TypeOf((*Mockservice)(nil).ListCommentRevisions), ctx, commentID)
+}
+
 // ListComments mocks base method.
-func (m *Mockservice) ListComments(ctx context.Context, pagination models.Pagination, filter models.FilterComments, sort models.SortComments) ([]*models.Comment, error) {
+func (m *Mockservice) ListComments(ctx context.Context, pagination models.Pagination, filter models.FilterComments, sort models.SortComments) (*models.CommentsPage, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListComments", ctx, pagination, filter, sort)
-	ret0, _ := ret[0].([]*models.Comment)
+	ret0, _ := ret[0].(*models.CommentsPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -183,11 +464,71 @@ func (mr *MockserviceMockRecorder) ListComments(ctx, pagination, filter, sort in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComments", reflect.TypeOf((*Mockservice)(nil).ListComments), ctx, pagination, filter, sort)
 }
 
+// ListFollowers mocks base method.
+func (m *Mockservice) ListFollowers(ctx context.Context, userID string, pagination models.Pagination) ([]*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowers", ctx, userID, pagination)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFollowers indicates an expected call of ListFollowers.
+func (mr *MockserviceMockRecorder) ListFollowers(ctx, userID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowers", reflect.TypeOf((*Mockservice)(nil).ListFollowers), ctx, userID, pagination)
+}
+
+// ListFollowing mocks base method.
+func (m *Mockservice) ListFollowing(ctx context.Context, userID string, pagination models.Pagination) ([]*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowing", ctx, userID, pagination)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFollowing indicates an expected call of ListFollowing.
+func (mr *MockserviceMockRecorder) ListFollowing(ctx, userID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowing", reflect.TypeOf((*Mockservice)(nil).ListFollowing), ctx, userID, pagination)
+}
+
+// ListNotifications mocks base method.
+func (m *Mockservice) ListNotifications(ctx context.Context, pagination models.Pagination, unreadOnly bool) (*models.NotificationList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotifications", ctx, pagination, unreadOnly)
+	ret0, _ := ret[0].(*models.NotificationList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotifications indicates an expected call of ListNotifications.
+func (mr *MockserviceMockRecorder) ListNotifications(ctx, pagination, unreadOnly interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotifications", reflect.TypeOf((*Mockservice)(nil).ListNotifications), ctx, pagination, unreadOnly)
+}
+
+// ListPostRevisions mocks base method.
+func (m *Mockservice) ListPostRevisions(ctx context.Context, postID string) ([]*models.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPostRevisions", ctx, postID)
+	ret0, _ := ret[0].([]*models.PostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPostRevisions indicates an expected call of ListPostRevisions.
+func (mr *MockserviceMockRecorder) ListPostRevisions(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPostRevisions", reflect.TypeOf((*Mockservice)(nil).ListPostRevisions), ctx, postID)
+}
+
 // ListPosts mocks base method.
-func (m *Mockservice) ListPosts(ctx context.Context, pagination models.Pagination, filter models.FilterPosts, sort models.SortPosts) ([]*models.Post, error) {
+func (m *Mockservice) ListPosts(ctx context.Context, pagination models.Pagination, filter models.FilterPosts, sort models.SortPosts) (*models.PostsPage, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListPosts", ctx, pagination, filter, sort)
-	ret0, _ := ret[0].([]*models.Post)
+	ret0, _ := ret[0].(*models.PostsPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -198,11 +539,41 @@ func (mr *MockserviceMockRecorder) ListPosts(ctx, pagination, filter, sort inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPosts", reflect.TypeOf((*Mockservice)(nil).ListPosts), ctx, pagination, filter, sort)
 }
 
+// ListReplies mocks base method.
+func (m *Mockservice) ListReplies(ctx context.Context, commentID string) ([]*models.CommentThread, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReplies", ctx, commentID)
+	ret0, _ := ret[0].([]*models.CommentThread)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReplies indicates an expected call of ListReplies.
+func (mr *MockserviceMockRecorder) ListReplies(ctx, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReplies", reflect.TypeOf((*Mockservice)(nil).ListReplies), ctx, commentID)
+}
+
+// ListTags mocks base method.
+func (m *Mockservice) ListTags(ctx context.Context, pagination models.Pagination) ([]*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", ctx, pagination)
+	ret0, _ := ret[0].([]*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockserviceMockRecorder) ListTags(ctx, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*Mockservice)(nil).ListTags), ctx, pagination)
+}
+
 // Login mocks base method.
-func (m *Mockservice) Login(ctx context.Context, payload models.LoginPayload) (string, error) {
+func (m *Mockservice) Login(ctx context.Context, payload models.LoginPayload) (*models.Tokens, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Login", ctx, payload)
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].(*models.Tokens)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -213,19 +584,334 @@ func (mr *MockserviceMockRecorder) Login(ctx, payload interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*Mockservice)(nil).Login), ctx, payload)
 }
 
+// Logout mocks base method.
+func (m *Mockservice) Logout(ctx context.Context, payload models.LogoutPayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockserviceMockRecorder) Logout(ctx, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*Mockservice)(nil).Logout), ctx, payload)
+}
+
+// MarkAllNotificationsRead mocks base method.
+func (m *Mockservice) MarkAllNotificationsRead(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllNotificationsRead", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllNotificationsRead indicates an expected call of MarkAllNotificationsRead.
+func (mr *MockserviceMockRecorder) MarkAllNotificationsRead(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllNotificationsRead", reflect.TypeOf((*Mockservice)(nil).MarkAllNotificationsRead), ctx)
+}
+
+// MarkNotificationRead mocks base method.
+func (m *Mockservice) MarkNotificationRead(ctx context.Context, notificationID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationRead", ctx, notificationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationRead indicates an expected call of MarkNotificationRead.
+func (mr *MockserviceMockRecorder) MarkNotificationRead(ctx, notificationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*Mockservice)(nil).MarkNotificationRead), ctx, notificationID)
+}
+
+// MergeTags mocks base method.
+func (m *Mockservice) MergeTags(ctx context.Context, payload models.MergeTagsPayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeTags", ctx, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeTags indicates an expected call of MergeTags.
+func (mr *MockserviceMockRecorder) MergeTags(ctx, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTags", reflect.TypeOf((*Mockservice)(nil).MergeTags), ctx, payload)
+}
+
+// OpenAttachment mocks base method.
+func (m *Mockservice) OpenAttachment(ctx context.Context, attachmentID string) (*models.Attachment, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenAttachment", ctx, attachmentID)
+	ret0, _ := ret[0].(*models.Attachment)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenAttachment indicates an expected call of OpenAttachment.
+func (mr *MockserviceMockRecorder) OpenAttachment(ctx, attachmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenAttachment", reflect.TypeOf((*Mockservice)(nil).OpenAttachment), ctx, attachmentID)
+}
+
+// OpenAttachmentVariant mocks base method.
+func (m *Mockservice) OpenAttachmentVariant(ctx context.Context, attachmentID, name string) (*models.AttachmentVariant, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenAttachmentVariant", ctx, attachmentID, name)
+	ret0, _ := ret[0].(*models.AttachmentVariant)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenAttachmentVariant indicates an expected call of OpenAttachmentVariant.
+func (mr *MockserviceMockRecorder) OpenAttachmentVariant(ctx, attachmentID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenAttachmentVariant", reflect.TypeOf((*Mockservice)(nil).OpenAttachmentVariant), ctx, attachmentID, name)
+}
+
 // ParseToken mocks base method.
-func (m *Mockservice) ParseToken(tokenString string) (string, error) {
+func (m *Mockservice) ParseToken(ctx context.Context, tokenString string) (*models.AuthInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ParseToken", tokenString)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "ParseToken", ctx, tokenString)
+	ret0, _ := ret[0].(*models.AuthInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ParseToken indicates an expected call of ParseToken.
-func (mr *MockserviceMockRecorder) ParseToken(tokenString interface{}) *gomock.Call {
+func (mr *MockserviceMockRecorder) ParseToken(ctx, tokenString interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*Mockservice)(nil).ParseToken), ctx, tokenString)
+}
+
+// PublishPost mocks base method.
+func (m *Mockservice) PublishPost(ctx context.Context, postID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishPost", ctx, postID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishPost indicates an expected call of PublishPost.
+func (mr *MockserviceMockRecorder) PublishPost(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishPost", reflect.TypeOf((*Mockservice)(nil).PublishPost), ctx, postID)
+}
+
+// Refresh mocks base method.
+func (m *Mockservice) Refresh(ctx context.Context, payload models.RefreshPayload) (*models.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, payload)
+	ret0, _ := ret[0].(*models.Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockserviceMockRecorder) Refresh(ctx, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*Mockservice)(nil).Refresh), ctx, payload)
+}
+
+// RemoveReaction mocks base method.
+func (m *Mockservice) RemoveReaction(ctx context.Context, payload models.ReactionPayload) (models.ReactionCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReaction", ctx, payload)
+	ret0, _ := ret[0].(models.ReactionCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveReaction indicates an expected call of RemoveReaction.
+func (mr *MockserviceMockRecorder) RemoveReaction(ctx, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReaction", reflect.TypeOf((*Mockservice)(nil).RemoveReaction), ctx, payload)
+}
+
+// RenameTag mocks base method.
+func (m *Mockservice) RenameTag(ctx context.Context, payload models.RenameTagPayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTag", ctx, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockserviceMockRecorder) RenameTag(ctx, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*Mockservice)(nil).RenameTag), ctx, payload)
+}
+
+// RequestPasswordReset mocks base method.
+func (m *Mockservice) RequestPasswordReset(ctx context.Context, payload models.RequestPasswordResetPayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", ctx, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockserviceMockRecorder) RequestPasswordReset(ctx, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*Mockservice)(nil).RequestPasswordReset), ctx, payload)
+}
+
+// ResendEmailVerification mocks base method.
+func (m *Mockservice) ResendEmailVerification(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendEmailVerification", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendEmailVerification indicates an expected call of ResendEmailVerification.
+func (mr *MockserviceMockRecorder) ResendEmailVerification(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendEmailVerification", reflect.TypeOf((*Mockservice)(nil).ResendEmailVerification), ctx)
+}
+
+// ResetPassword mocks base method.
+func (m *Mockservice) ResetPassword(ctx context.Context, payload models.ResetPasswordPayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockserviceMockRecorder) ResetPassword(ctx, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*Mockservice)(nil).ResetPassword), ctx, payload)
+}
+
+// RestoreComment mocks base method.
+func (m *Mockservice) RestoreComment(ctx context.Context, commentID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreComment", ctx, commentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreComment indicates an expected call of RestoreComment.
+func (mr *MockserviceMockRecorder) RestoreComment(ctx, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreComment", reflect.TypeOf((*Mockservice)(nil).RestoreComment), ctx, commentID)
+}
+
+// RestorePost mocks base method.
+func (m *Mockservice) RestorePost(ctx context.Context, postID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestorePost", ctx, postID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestorePost indicates an expected call of RestorePost.
+func (mr *MockserviceMockRecorder) RestorePost(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestorePost", reflect.TypeOf((*Mockservice)(nil).RestorePost), ctx, postID)
+}
+
+// RestoreUser mocks base method.
+func (m *Mockservice) RestoreUser(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockserviceMockRecorder) RestoreUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*Mockservice)(nil).RestoreUser), ctx, userID)
+}
+
+// RollbackComment mocks base method.
+func (m *Mockservice) RollbackComment(ctx context.Context, commentID, revisionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackComment", ctx, commentID, revisionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RollbackComment indicates an expected call of RollbackComment.
+func (mr *MockserviceMockRecorder) RollbackComment(ctx, commentID, revisionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackComment", reflect.TypeOf((*Mockservice)(nil).RollbackComment), ctx, commentID, revisionID)
+}
+
+// RollbackPost mocks base method.
+func (m *Mockservice) RollbackPost(ctx context.Context, postID, revisionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackPost", ctx, postID, revisionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RollbackPost indicates an expected call of RollbackPost.
+func (mr *MockserviceMockRecorder) RollbackPost(ctx, postID, revisionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackPost", reflect.TypeOf((*Mockservice)(nil).RollbackPost), ctx, postID, revisionID)
+}
+
+// Search mocks base method.
+func (m *Mockservice) Search(ctx context.Context, query models.SearchQuery, pagination models.Pagination) ([]*models.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, pagination)
+	ret0, _ := ret[0].([]*models.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockserviceMockRecorder) Search(ctx, query, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*Mockservice)(nil).Search), ctx, query, pagination)
+}
+
+// SubmitPost mocks base method.
+func (m *Mockservice) SubmitPost(ctx context.Context, postID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitPost", ctx, postID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubmitPost indicates an expected call of SubmitPost.
+func (mr *MockserviceMockRecorder) SubmitPost(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitPost", reflect.TypeOf((*Mockservice)(nil).SubmitPost), ctx, postID)
+}
+
+// Unfollow mocks base method.
+func (m *Mockservice) Unfollow(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unfollow", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unfollow indicates an expected call of Unfollow.
+func (mr *MockserviceMockRecorder) Unfollow(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unfollow", reflect.TypeOf((*Mockservice)(nil).Unfollow), ctx, userID)
+}
+
+// UnpublishPost mocks base method.
+func (m *Mockservice) UnpublishPost(ctx context.Context, postID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpublishPost", ctx, postID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnpublishPost indicates an expected call of UnpublishPost.
+func (mr *MockserviceMockRecorder) UnpublishPost(ctx, postID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*Mockservice)(nil).ParseToken), tokenString)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpublishPost", reflect.TypeOf((*Mockservice)(nil).UnpublishPost), ctx, postID)
 }
 
 // UpdateComment mocks base method.
@@ -242,6 +928,21 @@ func (mr *MockserviceMockRecorder) UpdateComment(ctx, payload interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*Mockservice)(nil).UpdateComment), ctx, payload)
 }
 
+// UpdateNotificationPreferences mocks base method.
+func (m *Mockservice) UpdateNotificationPreferences(ctx context.Context, changes models.NotificationPreferences) (models.NotificationPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotificationPreferences", ctx, changes)
+	ret0, _ := ret[0].(models.NotificationPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNotificationPreferences indicates an expected call of UpdateNotificationPreferences.
+func (mr *MockserviceMockRecorder) UpdateNotificationPreferences(ctx, changes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationPreferences", reflect.TypeOf((*Mockservice)(nil).UpdateNotificationPreferences), ctx, changes)
+}
+
 // UpdatePost mocks base method.
 func (m *Mockservice) UpdatePost(ctx context.Context, payload models.UpdatePostPayload) error {
 	m.ctrl.T.Helper()
@@ -270,6 +971,34 @@ func (mr *MockserviceMockRecorder) UpdateUser(ctx, payload interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*Mockservice)(nil).UpdateUser), ctx, payload)
 }
 
+// UpdateUserRole mocks base method.
+func (m *Mockservice) UpdateUserRole(ctx context.Context, payload models.UpdateUserRolePayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", ctx, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockserviceMockRecorder) UpdateUserRole(ctx, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*Mockservice)(nil).UpdateUserRole), ctx, payload)
+}
+
+// VerifyEmail mocks base method.
+func (m *Mockservice) VerifyEmail(ctx context.Context, payload models.VerifyEmailPayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockserviceMockRecorder) VerifyEmail(ctx, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*Mockservice)(nil).VerifyEmail), ctx, payload)
+}
+
 // MockauthMiddleware is a mock of authMiddleware interface.
 type MockauthMiddleware struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"github.com/serhiihuberniuk/blog-api/models"
+	viewmodels "github.com/serhiihuberniuk/blog-api/view/rest/models"
+)
+
+func mentionsResponse(mentions []models.Mention) []viewmodels.MentionResponse {
+	out := make([]viewmodels.MentionResponse, 0, len(mentions))
+	for _, mention := range mentions {
+		out = append(out, viewmodels.MentionResponse{
+			UserID:   mention.UserID,
+			Username: mention.Username,
+		})
+	}
+
+	return out
+}
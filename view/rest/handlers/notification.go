@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/serhiihuberniuk/blog-api/models"
+	viewmodels "github.com/serhiihuberniuk/blog-api/view/rest/models"
+)
+
+// ListNotifications writes the notifications of the current user. Only unread ones are listed
+// when the unread parameter is true.
+func (h *Handlers) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	queryParams, err := h.GetQueryParams(r, func(string) bool { return false }, func(string) bool { return false })
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	list, err := h.service.ListNotifications(r.Context(), models.Pagination{
+		Limit:  uint64(queryParams.limit),
+		Offset: uint64(queryParams.offset),
+	}, r.URL.Query().Get("unread") == "true")
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	out := viewmodels.ListNotificationsResponse{
+		Notifications: make([]viewmodels.GetNotificationResponse, 0, len(list.Notifications)),
+		UnreadCount:   list.UnreadCount,
+	}
+
+	for _, notification := range list.Notifications {
+		out.Notifications = append(out.Notifications, viewmodels.GetNotificationResponse{
+			ID:        notification.ID,
+			Type:      string(notification.Type),
+			ActorID:   notification.ActorID,
+			PostID:    notification.PostID,
+			CommentID: notification.CommentID,
+			Reaction:  string(notification.Reaction),
+			CreatedAt: notification.CreatedAt,
+			Read:      notification.IsRead(),
+			ReadAt:    notification.ReadAt,
+		})
+	}
+
+	if !encodeIntoJson(w, out) {
+		return
+	}
+}
+
+func (h *Handlers) MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.MarkNotificationRead(r.Context(), mux.Vars(r)["id"]); err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+}
+
+func (h *Handlers) MarkAllNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.MarkAllNotificationsRead(r.Context()); err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+}
+
+func (h *Handlers) GetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	preferences, err := h.service.GetNotificationPreferences(r.Context())
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	if !encodeIntoJson(w, notificationPreferencesResponse(preferences)) {
+		return
+	}
+}
+
+// UpdateNotificationPreferences turns the types of notifications from the body on or off
+// and writes the resulting preferences.
+func (h *Handlers) UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	var in viewmodels.NotificationPreferencesRequest
+	if !decodeFromJson(w, r, &in) {
+		return
+	}
+
+	changes := make(models.NotificationPreferences, len(in))
+	for t, enabled := range in {
+		changes[models.NotificationType(t)] = enabled
+	}
+
+	preferences, err := h.service.UpdateNotificationPreferences(r.Context(), changes)
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	if !encodeIntoJson(w, notificationPreferencesResponse(preferences)) {
+		return
+	}
+}
+
+func notificationPreferencesResponse(
+	preferences models.NotificationPreferences) viewmodels.NotificationPreferencesResponse {
+	out := make(viewmodels.NotificationPreferencesResponse, len(preferences))
+	for t, enabled := range preferences {
+		out[string(t)] = enabled
+	}
+
+	return out
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"net/url"
 
 	"github.com/gorilla/mux"
 	"github.com/serhiihuberniuk/blog-api/models"
@@ -13,10 +14,15 @@ var (
 		string(models.FilterPostsByCreatedBy): models.FilterPostsByCreatedBy,
 		string(models.FilterPostsByTitle):     models.FilterPostsByTitle,
 		string(models.FilterPostsByTags):      models.FilterPostsByTags,
+		string(models.FilterPostsByStatus):    models.FilterPostsByStatus,
+		string(models.FilterPostsByCreatedAt): models.FilterPostsByCreatedAt,
 	}
 	allowedSortPostsFields = map[string]models.SortPostsByField{
-		string(models.SortPostsByCreatedAt): models.SortPostsByCreatedAt,
-		string(models.SortPostsByTitle):     models.SortPostsByTitle,
+		string(models.SortPostsByCreatedAt):     models.SortPostsByCreatedAt,
+		string(models.SortPostsByTitle):         models.SortPostsByTitle,
+		string(models.SortPostsByUpdatedAt):     models.SortPostsByUpdatedAt,
+		string(models.SortPostsByCommentCount):  models.SortPostsByCommentCount,
+		string(models.SortPostsByReactionCount): models.SortPostsByReactionCount,
 	}
 )
 
@@ -30,6 +36,7 @@ func (h *Handlers) CreatePost(w http.ResponseWriter, r *http.Request) {
 	postID, err := h.service.CreatePost(r.Context(), models.CreatePostPayload{
 		Title:       in.Title,
 		Description: in.Description,
+		Format:      models.ContentFormat(in.Format),
 		Tags:        in.Tags,
 	})
 	if err != nil {
@@ -46,12 +53,24 @@ func (h *Handlers) CreatePost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	out := viewmodels.GetPostResponse{
-		ID:          post.ID,
-		Title:       post.Title,
-		Description: post.Description,
-		CreatedAt:   post.CreatedAt,
-		CreatedBy:   post.CreatedBy,
-		Tags:        post.Tags,
+		ID:              post.ID,
+		Title:           post.Title,
+		Description:     post.Description,
+		CreatedAt:       post.CreatedAt,
+		CreatedBy:       post.CreatedBy,
+		Tags:            post.Tags,
+		UpdatedAt:       post.UpdatedAt,
+		Edited:          post.IsEdited(),
+		Status:          string(post.Status),
+		Slug:            post.Slug,
+		Reactions:       reactionsResponse(post.Reactions),
+		Format:          string(post.Format),
+		DescriptionHTML: post.DescriptionHTML,
+		WordCount:       post.WordCount,
+		ReadingTime:     post.ReadingTime(),
+		Mentions:        mentionsResponse(post.Mentions),
+		CommentCount:    post.CommentCount,
+		LastCommentedAt: post.LastCommentedAt,
 	}
 
 	if !encodeIntoJson(w, out) {
@@ -60,6 +79,13 @@ func (h *Handlers) CreatePost(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handlers) GetPost(w http.ResponseWriter, r *http.Request) {
+	view, err := contentView(r)
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
 	postID := mux.Vars(r)["id"]
 
 	post, err := h.service.GetPost(r.Context(), postID)
@@ -70,15 +96,82 @@ func (h *Handlers) GetPost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	out := viewmodels.GetPostResponse{
-		ID:          post.ID,
-		Title:       post.Title,
-		Description: post.Description,
-		CreatedAt:   post.CreatedAt,
-		CreatedBy:   post.CreatedBy,
-		Tags:        post.Tags,
+		ID:              post.ID,
+		Title:           post.Title,
+		Description:     post.Description,
+		CreatedAt:       post.CreatedAt,
+		CreatedBy:       post.CreatedBy,
+		Tags:            post.Tags,
+		UpdatedAt:       post.UpdatedAt,
+		Edited:          post.IsEdited(),
+		Status:          string(post.Status),
+		Slug:            post.Slug,
+		Reactions:       reactionsResponse(post.Reactions),
+		Format:          string(post.Format),
+		DescriptionHTML: post.DescriptionHTML,
+		WordCount:       post.WordCount,
+		ReadingTime:     post.ReadingTime(),
+		Mentions:        mentionsResponse(post.Mentions),
+		CommentCount:    post.CommentCount,
+		LastCommentedAt: post.LastCommentedAt,
 	}
 
-	if !encodeIntoJson(w, out) {
+	if !encodeIntoJson(w, postContentView(out, view)) {
+		return
+	}
+}
+
+// GetPostBySlug returns the post by its slug. Previous slugs of the post redirect to its current slug.
+func (h *Handlers) GetPostBySlug(w http.ResponseWriter, r *http.Request) {
+	view, err := contentView(r)
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	slug := mux.Vars(r)["slug"]
+
+	post, err := h.service.GetPostBySlug(r.Context(), slug)
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	if post.Slug != slug {
+		location := url.URL{
+			Path:     "/posts/by-slug/" + post.Slug,
+			RawQuery: r.URL.RawQuery,
+		}
+
+		http.Redirect(w, r, location.String(), http.StatusMovedPermanently)
+
+		return
+	}
+
+	out := viewmodels.GetPostResponse{
+		ID:              post.ID,
+		Title:           post.Title,
+		Description:     post.Description,
+		CreatedAt:       post.CreatedAt,
+		CreatedBy:       post.CreatedBy,
+		Tags:            post.Tags,
+		UpdatedAt:       post.UpdatedAt,
+		Edited:          post.IsEdited(),
+		Status:          string(post.Status),
+		Slug:            post.Slug,
+		Reactions:       reactionsResponse(post.Reactions),
+		Format:          string(post.Format),
+		DescriptionHTML: post.DescriptionHTML,
+		WordCount:       post.WordCount,
+		ReadingTime:     post.ReadingTime(),
+		Mentions:        mentionsResponse(post.Mentions),
+		CommentCount:    post.CommentCount,
+		LastCommentedAt: post.LastCommentedAt,
+	}
+
+	if !encodeIntoJson(w, postContentView(out, view)) {
 		return
 	}
 }
@@ -96,6 +189,7 @@ func (h *Handlers) UpdatePost(w http.ResponseWriter, r *http.Request) {
 		PostID:      postID,
 		Title:       in.Title,
 		Description: in.Description,
+		Format:      contentFormat(in.Format),
 		Tags:        in.Tags,
 	})
 	if err != nil {
@@ -112,12 +206,24 @@ func (h *Handlers) UpdatePost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	out := viewmodels.GetPostResponse{
-		ID:          post.ID,
-		Title:       post.Title,
-		Description: post.Description,
-		CreatedAt:   post.CreatedAt,
-		CreatedBy:   post.CreatedBy,
-		Tags:        post.Tags,
+		ID:              post.ID,
+		Title:           post.Title,
+		Description:     post.Description,
+		CreatedAt:       post.CreatedAt,
+		CreatedBy:       post.CreatedBy,
+		Tags:            post.Tags,
+		UpdatedAt:       post.UpdatedAt,
+		Edited:          post.IsEdited(),
+		Status:          string(post.Status),
+		Slug:            post.Slug,
+		Reactions:       reactionsResponse(post.Reactions),
+		Format:          string(post.Format),
+		DescriptionHTML: post.DescriptionHTML,
+		WordCount:       post.WordCount,
+		ReadingTime:     post.ReadingTime(),
+		Mentions:        mentionsResponse(post.Mentions),
+		CommentCount:    post.CommentCount,
+		LastCommentedAt: post.LastCommentedAt,
 	}
 
 	if !encodeIntoJson(w, out) {
@@ -137,8 +243,50 @@ func (h *Handlers) DeletePost(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+func (h *Handlers) RestorePost(w http.ResponseWriter, r *http.Request) {
+	postID := mux.Vars(r)["id"]
+
+	if err := h.service.RestorePost(r.Context(), postID); err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	post, err := h.service.GetPost(r.Context(), postID)
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	out := viewmodels.GetPostResponse{
+		ID:              post.ID,
+		Title:           post.Title,
+		Description:     post.Description,
+		CreatedAt:       post.CreatedAt,
+		CreatedBy:       post.CreatedBy,
+		Tags:            post.Tags,
+		UpdatedAt:       post.UpdatedAt,
+		Edited:          post.IsEdited(),
+		Status:          string(post.Status),
+		Slug:            post.Slug,
+		Reactions:       reactionsResponse(post.Reactions),
+		Format:          string(post.Format),
+		DescriptionHTML: post.DescriptionHTML,
+		WordCount:       post.WordCount,
+		ReadingTime:     post.ReadingTime(),
+		Mentions:        mentionsResponse(post.Mentions),
+		CommentCount:    post.CommentCount,
+		LastCommentedAt: post.LastCommentedAt,
+	}
+
+	if !encodeIntoJson(w, out) {
+		return
+	}
+}
+
 func (h *Handlers) GetListOfPosts(w http.ResponseWriter, r *http.Request) {
-	queryParams, err := GetQueryParams(r, func(s string) bool {
+	queryParams, err := h.GetQueryParams(r, func(s string) bool {
 		_, ok := allowedFilterPostsFields[s]
 
 		return ok
@@ -153,40 +301,94 @@ func (h *Handlers) GetListOfPosts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	posts, err := h.service.ListPosts(r.Context(), models.Pagination{
-		Limit:  uint64(queryParams.limit),
-		Offset: uint64(queryParams.offset),
-	},
-		models.FilterPosts{
-			Field: models.FilterPostsByField(queryParams.filterByField),
-			Value: queryParams.filterValue,
-		},
-		models.SortPosts{
-			SortByField: models.SortPostsByField(queryParams.sortByField),
-			IsASC:       queryParams.isAsc,
-		})
+	view, err := contentView(r)
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	page, err := h.service.ListPosts(r.Context(), queryParams.pagination(),
+		filterPosts(queryParams.filters),
+		sortPosts(queryParams.sortKeys))
 	if err != nil {
 		errorStatusHttp(w, err)
 
 		return
 	}
 
-	outs := make([]viewmodels.GetPostResponse, 0)
+	outs := make([]viewmodels.GetPostResponse, 0, len(page.Posts))
 
-	for _, post := range posts {
+	for _, post := range page.Posts {
 		out := viewmodels.GetPostResponse{
-			ID:          post.ID,
-			Title:       post.Title,
-			Description: post.Description,
-			CreatedAt:   post.CreatedAt,
-			CreatedBy:   post.CreatedBy,
-			Tags:        post.Tags,
+			ID:              post.ID,
+			Title:           post.Title,
+			Description:     post.Description,
+			CreatedAt:       post.CreatedAt,
+			CreatedBy:       post.CreatedBy,
+			Tags:            post.Tags,
+			UpdatedAt:       post.UpdatedAt,
+			Edited:          post.IsEdited(),
+			Status:          string(post.Status),
+			Slug:            post.Slug,
+			Reactions:       reactionsResponse(post.Reactions),
+			Format:          string(post.Format),
+			DescriptionHTML: post.DescriptionHTML,
+			WordCount:       post.WordCount,
+			ReadingTime:     post.ReadingTime(),
+			Mentions:        mentionsResponse(post.Mentions),
+			CommentCount:    post.CommentCount,
+			LastCommentedAt: post.LastCommentedAt,
 		}
 
-		outs = append(outs, out)
+		outs = append(outs, postContentView(out, view))
 	}
 
+	setPageHeaders(w, r, page.PageInfo)
+
 	if !encodeIntoJson(w, outs) {
 		return
 	}
 }
+
+// postContentView leaves out the representations of the description which are not selected by the view.
+func postContentView(out viewmodels.GetPostResponse, view models.ContentView) viewmodels.GetPostResponse {
+	if !view.ShowsRaw() {
+		out.Description = ""
+	}
+
+	if !view.ShowsHTML() {
+		out.DescriptionHTML = ""
+	}
+
+	return out
+}
+
+func filterPosts(filters []filterParam) models.FilterPosts {
+	conditions := make([]models.PostCondition, 0, len(filters))
+
+	for _, filter := range filters {
+		conditions = append(conditions, models.PostCondition{
+			Field:    allowedFilterPostsFields[filter.field],
+			Operator: models.FilterOperator(filter.operator),
+			Values:   filter.values,
+		})
+	}
+
+	return models.FilterPosts{
+		Conditions: conditions,
+	}
+}
+
+func sortPosts(keys []models.SortKey) models.SortPosts {
+	sort := models.SortPosts{}
+
+	for _, key := range keys {
+		sort.Keys = append(sort.Keys, models.SortPostsKey{
+			Field: allowedSortPostsFields[key.Field],
+			IsASC: key.IsASC,
+		})
+	}
+
+	return sort
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	viewmodels "github.com/serhiihuberniuk/blog-api/view/rest/models"
+)
+
+func (h *Handlers) SubmitPost(w http.ResponseWriter, r *http.Request) {
+	h.changePostStatus(w, r, h.service.SubmitPost)
+}
+
+func (h *Handlers) PublishPost(w http.ResponseWriter, r *http.Request) {
+	h.changePostStatus(w, r, h.service.PublishPost)
+}
+
+func (h *Handlers) UnpublishPost(w http.ResponseWriter, r *http.Request) {
+	h.changePostStatus(w, r, h.service.UnpublishPost)
+}
+
+func (h *Handlers) ArchivePost(w http.ResponseWriter, r *http.Request) {
+	h.changePostStatus(w, r, h.service.ArchivePost)
+}
+
+// changePostStatus applies the status change to the post from the path and writes the changed post.
+func (h *Handlers) changePostStatus(w http.ResponseWriter, r *http.Request,
+	change func(ctx context.Context, postID string) error) {
+	postID := mux.Vars(r)["id"]
+
+	if err := change(r.Context(), postID); err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	post, err := h.service.GetPost(r.Context(), postID)
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	out := viewmodels.GetPostResponse{
+		ID:              post.ID,
+		Title:           post.Title,
+		Description:     post.Description,
+		CreatedAt:       post.CreatedAt,
+		CreatedBy:       post.CreatedBy,
+		Tags:            post.Tags,
+		UpdatedAt:       post.UpdatedAt,
+		Edited:          post.IsEdited(),
+		Status:          string(post.Status),
+		Slug:            post.Slug,
+		Reactions:       reactionsResponse(post.Reactions),
+		Format:          string(post.Format),
+		DescriptionHTML: post.DescriptionHTML,
+		WordCount:       post.WordCount,
+		ReadingTime:     post.ReadingTime(),
+		Mentions:        mentionsResponse(post.Mentions),
+		CommentCount:    post.CommentCount,
+		LastCommentedAt: post.LastCommentedAt,
+	}
+
+	if !encodeIntoJson(w, out) {
+		return
+	}
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/serhiihuberniuk/blog-api/models"
+)
+
+func (h *Handlers) AddPostReaction(w http.ResponseWriter, r *http.Request) {
+	h.changeReaction(w, r, models.ReactionTargetPost, h.service.AddReaction)
+}
+
+func (h *Handlers) RemovePostReaction(w http.ResponseWriter, r *http.Request) {
+	h.changeReaction(w, r, models.ReactionTargetPost, h.service.RemoveReaction)
+}
+
+func (h *Handlers) AddCommentReaction(w http.ResponseWriter, r *http.Request) {
+	h.changeReaction(w, r, models.ReactionTargetComment, h.service.AddReaction)
+}
+
+func (h *Handlers) RemoveCommentReaction(w http.ResponseWriter, r *http.Request) {
+	h.changeReaction(w, r, models.ReactionTargetComment, h.service.RemoveReaction)
+}
+
+// changeReaction applies the reaction from the path to the target and writes the reaction counts of the target.
+func (h *Handlers) changeReaction(w http.ResponseWriter, r *http.Request, target models.ReactionTarget,
+	change func(ctx context.Context, payload models.ReactionPayload) (models.ReactionCounts, error)) {
+	vars := mux.Vars(r)
+
+	counts, err := change(r.Context(), models.ReactionPayload{
+		TargetType: target,
+		TargetID:   vars["id"],
+		Type:       models.ReactionType(vars["type"]),
+	})
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	if !encodeIntoJson(w, reactionsResponse(counts)) {
+		return
+	}
+}
+
+func reactionsResponse(counts models.ReactionCounts) map[string]int {
+	out := make(map[string]int, len(models.ReactionTypes))
+	for _, reactionType := range models.ReactionTypes {
+		out[string(reactionType)] = counts[reactionType]
+	}
+
+	return out
+}
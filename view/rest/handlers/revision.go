@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/serhiihuberniuk/blog-api/models"
+	viewmodels "github.com/serhiihuberniuk/blog-api/view/rest/models"
+)
+
+func (h *Handlers) ListPostRevisions(w http.ResponseWriter, r *http.Request) {
+	revisions, err := h.service.ListPostRevisions(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	outs := make([]viewmodels.GetPostRevisionResponse, 0)
+
+	for _, revision := range revisions {
+		outs = append(outs, postRevisionResponse(revision))
+	}
+
+	if !encodeIntoJson(w, outs) {
+		return
+	}
+}
+
+func (h *Handlers) GetPostRevision(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	revision, err := h.service.GetPostRevision(r.Context(), vars["id"], vars["revisionId"])
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	if !encodeIntoJson(w, postRevisionResponse(revision)) {
+		return
+	}
+}
+
+func (h *Handlers) DiffPostRevisions(w http.ResponseWriter, r *http.Request) {
+	from, to, ok := revisionsToDiff(w, r)
+	if !ok {
+		return
+	}
+
+	changes, err := h.service.DiffPostRevisions(r.Context(), mux.Vars(r)["id"], from, to)
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	if !encodeIntoJson(w, fieldChangesResponse(changes)) {
+		return
+	}
+}
+
+func (h *Handlers) RollbackPost(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := h.service.RollbackPost(r.Context(), vars["id"], vars["revisionId"]); err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	post, err := h.service.GetPost(r.Context(), vars["id"])
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	out := viewmodels.GetPostResponse{
+		ID:              post.ID,
+		Title:           post.Title,
+		Description:     post.Description,
+		CreatedAt:       post.CreatedAt,
+		CreatedBy:       post.CreatedBy,
+		Tags:            post.Tags,
+		UpdatedAt:       post.UpdatedAt,
+		Edited:          post.IsEdited(),
+		Status:          string(post.Status),
+		Slug:            post.Slug,
+		Reactions:       reactionsResponse(post.Reactions),
+		Format:          string(post.Format),
+		DescriptionHTML: post.DescriptionHTML,
+		WordCount:       post.WordCount,
+		ReadingTime:     post.ReadingTime(),
+		Mentions:        mentionsResponse(post.Mentions),
+		CommentCount:    post.CommentCount,
+		LastCommentedAt: post.LastCommentedAt,
+	}
+
+	if !encodeIntoJson(w, out) {
+		return
+	}
+}
+
+func (h *Handlers) ListCommentRevisions(w http.ResponseWriter, r *http.Request) {
+	revisions, err := h.service.ListCommentRevisions(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	outs := make([]viewmodels.GetCommentRevisionResponse, 0)
+
+	for _, revision := range revisions {
+		outs = append(outs, commentRevisionResponse(revision))
+	}
+
+	if !encodeIntoJson(w, outs) {
+		return
+	}
+}
+
+func (h *Handlers) GetCommentRevision(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	revision, err := h.service.GetCommentRevision(r.Context(), vars["id"], vars["revisionId"])
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	if !encodeIntoJson(w, commentRevisionResponse(revision)) {
+		return
+	}
+}
+
+func (h *Handlers) DiffCommentRevisions(w http.ResponseWriter, r *http.Request) {
+	from, to, ok := revisionsToDiff(w, r)
+	if !ok {
+		return
+	}
+
+	changes, err := h.service.DiffCommentRevisions(r.Context(), mux.Vars(r)["id"], from, to)
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	if !encodeIntoJson(w, fieldChangesResponse(changes)) {
+		return
+	}
+}
+
+func (h *Handlers) RollbackComment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := h.service.RollbackComment(r.Context(), vars["id"], vars["revisionId"]); err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	comment, err := h.service.GetComment(r.Context(), vars["id"])
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	out := viewmodels.GetCommentResponse{
+		ID:          comment.ID,
+		Content:     comment.Content,
+		CreatedAt:   comment.CreatedAt,
+		CreatedBy:   comment.CreatedBy,
+		PostID:      comment.PostID,
+		UpdatedAt:   comment.UpdatedAt,
+		Edited:      comment.IsEdited(),
+		ParentID:    comment.ParentID,
+		Reactions:   reactionsResponse(comment.Reactions),
+		Format:      string(comment.Format),
+		ContentHTML: comment.ContentHTML,
+		WordCount:   comment.WordCount,
+		ReadingTime: comment.ReadingTime(),
+		Mentions:    mentionsResponse(comment.Mentions),
+	}
+
+	if !encodeIntoJson(w, out) {
+		return
+	}
+}
+
+func revisionsToDiff(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	from := r.URL.Query().Get("from")
+	to := r.URL.Query().Get("to")
+
+	if from == "" || to == "" {
+		http.Error(w, "revisions to compare are not specified", http.StatusBadRequest)
+
+		return "", "", false
+	}
+
+	return from, to, true
+}
+
+func postRevisionResponse(revision *models.PostRevision) viewmodels.GetPostRevisionResponse {
+	return viewmodels.GetPostRevisionResponse{
+		ID:          revision.ID,
+		PostID:      revision.PostID,
+		EditedBy:    revision.EditedBy,
+		EditedAt:    revision.EditedAt,
+		Title:       revision.Title,
+		Description: revision.Description,
+		Tags:        revision.Tags,
+	}
+}
+
+func commentRevisionResponse(revision *models.CommentRevision) viewmodels.GetCommentRevisionResponse {
+	return viewmodels.GetCommentRevisionResponse{
+		ID:        revision.ID,
+		CommentID: revision.CommentID,
+		EditedBy:  revision.EditedBy,
+		EditedAt:  revision.EditedAt,
+		Content:   revision.Content,
+	}
+}
+
+func fieldChangesResponse(changes []models.FieldChange) []viewmodels.FieldChangeResponse {
+	outs := make([]viewmodels.FieldChangeResponse, 0, len(changes))
+
+	for _, change := range changes {
+		outs = append(outs, viewmodels.FieldChangeResponse{
+			Field: change.Field,
+			From:  change.From,
+			To:    change.To,
+		})
+	}
+
+	return outs
+}
@@ -6,22 +6,89 @@ func (h *Handlers) ApiRouter() *mux.Router {
 	router := mux.NewRouter()
 
 	router.HandleFunc("/auth", h.Login).Methods("POST")
+	router.HandleFunc("/auth/refresh", h.Refresh).Methods("POST")
+	router.HandleFunc("/auth/logout", h.authMiddleware.Auth(h.Logout)).Methods("POST")
+	router.HandleFunc("/auth/password", h.authMiddleware.Auth(h.ChangePassword)).Methods("PUT")
+	router.HandleFunc("/auth/password-reset", h.RequestPasswordReset).Methods("POST")
+	router.HandleFunc("/auth/password-reset/confirm", h.ResetPassword).Methods("POST")
+	router.HandleFunc("/auth/verify-email", h.VerifyEmail).Methods("POST")
+	router.HandleFunc("/auth/verify-email/resend", h.authMiddleware.Auth(h.ResendEmailVerification)).Methods("POST")
 	router.HandleFunc("/users", h.CreateUser).Methods("POST")
 
+	router.HandleFunc("/users/by-username/{username}", h.authMiddleware.Auth(h.GetUserByUsername)).Methods("GET")
 	router.HandleFunc("/users/{id}", h.authMiddleware.Auth(h.GetUser)).Methods("GET")
 	router.HandleFunc("/users", h.authMiddleware.Auth(h.UpdateUser)).Methods("PUT")
+	router.HandleFunc("/users/{id}", h.authMiddleware.Auth(h.UpdateUser)).Methods("PUT")
+	router.HandleFunc("/users", h.authMiddleware.Auth(h.UpdateUser)).Methods("PATCH")
+	router.HandleFunc("/users/{id}", h.authMiddleware.Auth(h.UpdateUser)).Methods("PATCH")
+	router.HandleFunc("/users/{id}/role", h.authMiddleware.Auth(h.UpdateUserRole)).Methods("PUT")
 	router.HandleFunc("/users", h.authMiddleware.Auth(h.DeleteUser)).Methods("DELETE")
+	router.HandleFunc("/users/{id}", h.authMiddleware.Auth(h.DeleteUserByID)).Methods("DELETE")
+	router.HandleFunc("/users/{id}/restore", h.authMiddleware.Auth(h.RestoreUser)).Methods("POST")
+	router.HandleFunc("/users/{id}/follow", h.authMiddleware.Auth(h.Follow)).Methods("PUT")
+	router.HandleFunc("/users/{id}/follow", h.authMiddleware.Auth(h.Unfollow)).Methods("DELETE")
+	router.HandleFunc("/users/{id}/followers", h.authMiddleware.Auth(h.ListFollowers)).Methods("GET")
+	router.HandleFunc("/users/{id}/following", h.authMiddleware.Auth(h.ListFollowing)).Methods("GET")
+
+	router.HandleFunc("/feed", h.authMiddleware.Auth(h.Feed)).Methods("GET")
+	router.HandleFunc("/notifications", h.authMiddleware.Auth(h.ListNotifications)).Methods("GET")
+	router.HandleFunc("/notifications/read", h.authMiddleware.Auth(h.MarkAllNotificationsRead)).Methods("POST")
+	router.HandleFunc("/notifications/{id}/read", h.authMiddleware.Auth(h.MarkNotificationRead)).Methods("POST")
+	router.HandleFunc("/notifications/preferences",
+		h.authMiddleware.Auth(h.GetNotificationPreferences)).Methods("GET")
+	router.HandleFunc("/notifications/preferences",
+		h.authMiddleware.Auth(h.UpdateNotificationPreferences)).Methods("PATCH")
+	router.HandleFunc("/search", h.authMiddleware.Auth(h.Search)).Methods("GET")
+
+	router.HandleFunc("/tags", h.authMiddleware.Auth(h.ListTags)).Methods("GET")
+	router.HandleFunc("/tags/autocomplete", h.authMiddleware.Auth(h.AutocompleteTags)).Methods("GET")
+	router.HandleFunc("/tags/{name}", h.authMiddleware.Auth(h.RenameTag)).Methods("PUT")
+	router.HandleFunc("/tags/{name}/merge", h.authMiddleware.Auth(h.MergeTags)).Methods("POST")
 
 	router.HandleFunc("/posts", h.authMiddleware.Auth(h.CreatePost)).Methods("POST")
+	router.HandleFunc("/posts/by-slug/{slug}", h.authMiddleware.Auth(h.GetPostBySlug)).Methods("GET")
 	router.HandleFunc("/posts/{id}", h.authMiddleware.Auth(h.GetPost)).Methods("GET")
 	router.HandleFunc("/posts/{id}", h.authMiddleware.Auth(h.UpdatePost)).Methods("PUT")
+	router.HandleFunc("/posts/{id}", h.authMiddleware.Auth(h.UpdatePost)).Methods("PATCH")
 	router.HandleFunc("/posts/{id}", h.authMiddleware.Auth(h.DeletePost)).Methods("DELETE")
+	router.HandleFunc("/posts/{id}/restore", h.authMiddleware.Auth(h.RestorePost)).Methods("POST")
+	router.HandleFunc("/posts/{id}/submit", h.authMiddleware.Auth(h.SubmitPost)).Methods("POST")
+	router.HandleFunc("/posts/{id}/publish", h.authMiddleware.Auth(h.PublishPost)).Methods("POST")
+	router.HandleFunc("/posts/{id}/unpublish", h.authMiddleware.Auth(h.UnpublishPost)).Methods("POST")
+	router.HandleFunc("/posts/{id}/archive", h.authMiddleware.Auth(h.ArchivePost)).Methods("POST")
+	router.HandleFunc("/posts/{id}/reactions/{type}", h.authMiddleware.Auth(h.AddPostReaction)).Methods("PUT")
+	router.HandleFunc("/posts/{id}/reactions/{type}", h.authMiddleware.Auth(h.RemovePostReaction)).Methods("DELETE")
+	router.HandleFunc("/posts/{id}/revisions", h.authMiddleware.Auth(h.ListPostRevisions)).Methods("GET")
+	router.HandleFunc("/posts/{id}/revisions/diff", h.authMiddleware.Auth(h.DiffPostRevisions)).Methods("GET")
+	router.HandleFunc("/posts/{id}/revisions/{revisionId}", h.authMiddleware.Auth(h.GetPostRevision)).Methods("GET")
+	router.HandleFunc("/posts/{id}/revisions/{revisionId}/rollback",
+		h.authMiddleware.Auth(h.RollbackPost)).Methods("POST")
+	router.HandleFunc("/posts/{id}/attachments", h.authMiddleware.Auth(h.UploadAttachment)).Methods("POST")
+	router.HandleFunc("/posts/{id}/attachments", h.authMiddleware.Auth(h.ListAttachments)).Methods("GET")
 	router.HandleFunc("/posts", h.authMiddleware.Auth(h.GetListOfPosts)).Methods("GET")
 
+	router.HandleFunc("/attachments/{id}", h.authMiddleware.Auth(h.GetAttachment)).Methods("GET")
+	router.HandleFunc("/attachments/{id}", h.authMiddleware.Auth(h.DeleteAttachment)).Methods("DELETE")
+	router.HandleFunc("/attachments/{id}/content", h.authMiddleware.Auth(h.GetAttachmentContent)).Methods("GET")
+	router.HandleFunc("/attachments/{id}/variants/{name}",
+		h.authMiddleware.Auth(h.GetAttachmentVariant)).Methods("GET")
+
 	router.HandleFunc("/comments", h.authMiddleware.Auth(h.CreateComment)).Methods("POST")
 	router.HandleFunc("/comments/{id}", h.authMiddleware.Auth(h.GetComment)).Methods("GET")
 	router.HandleFunc("/comments/{id}", h.authMiddleware.Auth(h.UpdateComment)).Methods("PUT")
+	router.HandleFunc("/comments/{id}", h.authMiddleware.Auth(h.UpdateComment)).Methods("PATCH")
 	router.HandleFunc("/comments/{id}", h.authMiddleware.Auth(h.DeleteComment)).Methods("DELETE")
+	router.HandleFunc("/comments/{id}/restore", h.authMiddleware.Auth(h.RestoreComment)).Methods("POST")
+	router.HandleFunc("/comments/{id}/replies", h.authMiddleware.Auth(h.ListReplies)).Methods("GET")
+	router.HandleFunc("/comments/{id}/reactions/{type}", h.authMiddleware.Auth(h.AddCommentReaction)).Methods("PUT")
+	router.HandleFunc("/comments/{id}/reactions/{type}",
+		h.authMiddleware.Auth(h.RemoveCommentReaction)).Methods("DELETE")
+	router.HandleFunc("/comments/{id}/revisions", h.authMiddleware.Auth(h.ListCommentRevisions)).Methods("GET")
+	router.HandleFunc("/comments/{id}/revisions/diff", h.authMiddleware.Auth(h.DiffCommentRevisions)).Methods("GET")
+	router.HandleFunc("/comments/{id}/revisions/{revisionId}",
+		h.authMiddleware.Auth(h.GetCommentRevision)).Methods("GET")
+	router.HandleFunc("/comments/{id}/revisions/{revisionId}/rollback",
+		h.authMiddleware.Auth(h.RollbackComment)).Methods("POST")
 	router.HandleFunc("/comments", h.authMiddleware.Auth(h.GetListOfComments)).Methods("GET")
 
 	return router
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/serhiihuberniuk/blog-api/models"
+	viewmodels "github.com/serhiihuberniuk/blog-api/view/rest/models"
+)
+
+func (h *Handlers) Search(w http.ResponseWriter, r *http.Request) {
+	queryParams, err := h.GetQueryParams(r, func(string) bool { return false }, func(string) bool { return false })
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	query := models.SearchQuery{
+		Text: r.URL.Query().Get("q"),
+	}
+
+	for _, target := range r.URL.Query()["type"] {
+		query.Targets = append(query.Targets, models.SearchTarget(target))
+	}
+
+	results, err := h.service.Search(r.Context(), query, models.Pagination{
+		Limit:  uint64(queryParams.limit),
+		Offset: uint64(queryParams.offset),
+	})
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	outs := make([]viewmodels.SearchResultResponse, 0, len(results))
+
+	for _, result := range results {
+		out := viewmodels.SearchResultResponse{
+			Type:    string(result.Target),
+			ID:      result.ID,
+			Rank:    result.Rank,
+			Snippet: result.Snippet,
+		}
+
+		if post := result.Post; post != nil {
+			out.Post = &viewmodels.GetPostResponse{
+				ID:              post.ID,
+				Title:           post.Title,
+				Description:     post.Description,
+				CreatedAt:       post.CreatedAt,
+				CreatedBy:       post.CreatedBy,
+				Tags:            post.Tags,
+				UpdatedAt:       post.UpdatedAt,
+				Edited:          post.IsEdited(),
+				Status:          string(post.Status),
+				Slug:            post.Slug,
+				Reactions:       reactionsResponse(post.Reactions),
+				Format:          string(post.Format),
+				DescriptionHTML: post.DescriptionHTML,
+				WordCount:       post.WordCount,
+				ReadingTime:     post.ReadingTime(),
+				Mentions:        mentionsResponse(post.Mentions),
+				CommentCount:    post.CommentCount,
+				LastCommentedAt: post.LastCommentedAt,
+			}
+		}
+
+		if comment := result.Comment; comment != nil {
+			out.Comment = &viewmodels.GetCommentResponse{
+				ID:          comment.ID,
+				Content:     comment.Content,
+				CreatedAt:   comment.CreatedAt,
+				CreatedBy:   comment.CreatedBy,
+				PostID:      comment.PostID,
+				UpdatedAt:   comment.UpdatedAt,
+				Edited:      comment.IsEdited(),
+				ParentID:    comment.ParentID,
+				Reactions:   reactionsResponse(comment.Reactions),
+				Format:      string(comment.Format),
+				ContentHTML: comment.ContentHTML,
+				WordCount:   comment.WordCount,
+				ReadingTime: comment.ReadingTime(),
+				Mentions:    mentionsResponse(comment.Mentions),
+			}
+		}
+
+		outs = append(outs, out)
+	}
+
+	if !encodeIntoJson(w, outs) {
+		return
+	}
+}
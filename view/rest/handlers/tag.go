@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/serhiihuberniuk/blog-api/models"
+	viewmodels "github.com/serhiihuberniuk/blog-api/view/rest/models"
+)
+
+func (h *Handlers) ListTags(w http.ResponseWriter, r *http.Request) {
+	queryParams, err := h.GetQueryParams(r, func(string) bool { return false }, func(string) bool { return false })
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	tags, err := h.service.ListTags(r.Context(), models.Pagination{
+		Limit:  uint64(queryParams.limit),
+		Offset: uint64(queryParams.offset),
+	})
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	if !encodeIntoJson(w, tagsResponse(tags)) {
+		return
+	}
+}
+
+func (h *Handlers) AutocompleteTags(w http.ResponseWriter, r *http.Request) {
+	vars := r.URL.Query()
+
+	limit, err := strconv.Atoi(vars.Get("limit"))
+	if err != nil && vars.Get("limit") != "" {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	limit = h.pageLimit(limit)
+
+	tags, err := h.service.AutocompleteTags(r.Context(), vars.Get("prefix"), uint64(limit))
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	if !encodeIntoJson(w, tagsResponse(tags)) {
+		return
+	}
+}
+
+func (h *Handlers) RenameTag(w http.ResponseWriter, r *http.Request) {
+	var in viewmodels.RenameTagRequest
+
+	if !decodeFromJson(w, r, &in) {
+		return
+	}
+
+	err := h.service.RenameTag(r.Context(), models.RenameTagPayload{
+		Name:    mux.Vars(r)["name"],
+		NewName: in.Name,
+	})
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+}
+
+func (h *Handlers) MergeTags(w http.ResponseWriter, r *http.Request) {
+	var in viewmodels.MergeTagsRequest
+
+	if !decodeFromJson(w, r, &in) {
+		return
+	}
+
+	err := h.service.MergeTags(r.Context(), models.MergeTagsPayload{
+		From: mux.Vars(r)["name"],
+		Into: in.Into,
+	})
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+}
+
+func tagsResponse(tags []*models.Tag) []viewmodels.TagResponse {
+	outs := make([]viewmodels.TagResponse, 0, len(tags))
+
+	for _, tag := range tags {
+		outs = append(outs, viewmodels.TagResponse{
+			Name:      tag.Name,
+			PostCount: tag.PostCount,
+		})
+	}
+
+	return outs
+}
@@ -17,6 +17,7 @@ func (h *Handlers) CreateUser(w http.ResponseWriter, r *http.Request) {
 
 	userID, err := h.service.CreateUser(r.Context(), models.CreateUserPayload{
 		Name:     in.Name,
+		Username: in.Username,
 		Email:    in.Email,
 		Password: in.Password,
 	})
@@ -33,12 +34,18 @@ func (h *Handlers) CreateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The new user is not signed in yet, so the email is not given back by the service.
 	out := viewmodels.GetUserResponse{
 		ID:        user.ID,
 		Name:      user.Name,
-		Email:     user.Email,
+		Username:  user.Username,
+		Email:     in.Email,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
+		Role:      string(user.Role),
+
+		EmailVerified: user.IsEmailVerified(),
+		PendingEmail:  user.PendingEmail,
 	}
 
 	if !encodeIntoJson(w, out) {
@@ -59,9 +66,42 @@ func (h *Handlers) GetUser(w http.ResponseWriter, r *http.Request) {
 	out := viewmodels.GetUserResponse{
 		ID:        user.ID,
 		Name:      user.Name,
+		Username:  user.Username,
 		Email:     user.Email,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
+		Role:      string(user.Role),
+
+		EmailVerified: user.IsEmailVerified(),
+		PendingEmail:  user.PendingEmail,
+	}
+
+	if !encodeIntoJson(w, out) {
+		return
+	}
+}
+
+func (h *Handlers) GetUserByUsername(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+
+	user, err := h.service.GetUserByUsername(r.Context(), username)
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	out := viewmodels.GetUserResponse{
+		ID:        user.ID,
+		Name:      user.Name,
+		Username:  user.Username,
+		Email:     user.Email,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+		Role:      string(user.Role),
+
+		EmailVerified: user.IsEmailVerified(),
+		PendingEmail:  user.PendingEmail,
 	}
 
 	if !encodeIntoJson(w, out) {
@@ -70,6 +110,11 @@ func (h *Handlers) GetUser(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handlers) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["id"]
+	if userID == "" {
+		userID = h.currentUserInformationProvider.GetCurrentUserID(r.Context())
+	}
+
 	var in viewmodels.UpdateUserRequest
 
 	if !decodeFromJson(w, r, &in) {
@@ -77,9 +122,9 @@ func (h *Handlers) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	err := h.service.UpdateUser(r.Context(), models.UpdateUserPayload{
-		Name:     in.Name,
-		Email:    in.Email,
-		Password: in.Password,
+		UserID: userID,
+		Name:   in.Name,
+		Email:  in.Email,
 	})
 	if err != nil {
 		errorStatusHttp(w, err)
@@ -87,7 +132,7 @@ func (h *Handlers) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := h.service.GetUser(r.Context(), h.currentUserInformationProvider.GetCurrentUserID(r.Context()))
+	user, err := h.service.GetUser(r.Context(), userID)
 	if err != nil {
 		errorStatusHttp(w, err)
 
@@ -97,9 +142,58 @@ func (h *Handlers) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	out := viewmodels.GetUserResponse{
 		ID:        user.ID,
 		Name:      user.Name,
+		Username:  user.Username,
 		Email:     user.Email,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
+		Role:      string(user.Role),
+
+		EmailVerified: user.IsEmailVerified(),
+		PendingEmail:  user.PendingEmail,
+	}
+
+	if !encodeIntoJson(w, out) {
+		return
+	}
+}
+
+func (h *Handlers) UpdateUserRole(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["id"]
+
+	var in viewmodels.UpdateUserRoleRequest
+
+	if !decodeFromJson(w, r, &in) {
+		return
+	}
+
+	err := h.service.UpdateUserRole(r.Context(), models.UpdateUserRolePayload{
+		UserID: userID,
+		Role:   models.Role(in.Role),
+	})
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	user, err := h.service.GetUser(r.Context(), userID)
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	out := viewmodels.GetUserResponse{
+		ID:        user.ID,
+		Name:      user.Name,
+		Username:  user.Username,
+		Email:     user.Email,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+		Role:      string(user.Role),
+
+		EmailVerified: user.IsEmailVerified(),
+		PendingEmail:  user.PendingEmail,
 	}
 
 	if !encodeIntoJson(w, out) {
@@ -116,3 +210,49 @@ func (h *Handlers) DeleteUser(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusOK)
 }
+
+func (h *Handlers) DeleteUserByID(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["id"]
+
+	if err := h.service.DeleteUserByID(r.Context(), userID); err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handlers) RestoreUser(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["id"]
+
+	if err := h.service.RestoreUser(r.Context(), userID); err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	user, err := h.service.GetUser(r.Context(), userID)
+	if err != nil {
+		errorStatusHttp(w, err)
+
+		return
+	}
+
+	out := viewmodels.GetUserResponse{
+		ID:        user.ID,
+		Name:      user.Name,
+		Username:  user.Username,
+		Email:     user.Email,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+		Role:      string(user.Role),
+
+		EmailVerified: user.IsEmailVerified(),
+		PendingEmail:  user.PendingEmail,
+	}
+
+	if !encodeIntoJson(w, out) {
+		return
+	}
+}
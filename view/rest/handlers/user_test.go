@@ -24,12 +24,13 @@ func TestHandlers_CreateUser(t *testing.T) {
 
 	input := models.CreateUserPayload{
 		Name:     "name",
+		Username: "username",
 		Email:    "email",
 		Password: "password",
 	}
 
-	inputBody := `{"name": "name", "email": "email", "password": "password"}`
-	expectedBody := `{"id":"id","name":"name","email":"email","createdAt":"0001-01-01T00:00:00Z","updatedAt":"0001-01-01T00:00:00Z"}
+	inputBody := `{"name": "name", "username": "username", "email": "email", "password": "password"}`
+	expectedBody := `{"id":"id","name":"name","username":"username","email":"email","createdAt":"0001-01-01T00:00:00Z","updatedAt":"0001-01-01T00:00:00Z","role":"user","emailVerified":false}
 `
 	testCases := []struct {
 		name                          string
@@ -55,9 +56,11 @@ func TestHandlers_CreateUser(t *testing.T) {
 				s.EXPECT().GetUser(ctx, userID).Return(user, nil)
 			},
 			gotUser: &models.User{
-				ID:    "id",
-				Name:  input.Name,
-				Email: input.Email,
+				ID:       "id",
+				Name:     input.Name,
+				Username: input.Username,
+				Email:    input.Email,
+				Role:     models.RoleUser,
 			},
 			expectedCode: http.StatusOK,
 			expectedBody: expectedBody,
@@ -107,7 +110,7 @@ func TestHandlers_CreateUser(t *testing.T) {
 			servMock := NewMockservice(ctrl)
 			providerMock := NewMockcurrentUserInformationProvider(ctrl)
 			middlewareMock := NewMockauthMiddleware(ctrl)
-			handlersRest := handlers.NewRestHandlers(servMock, middlewareMock, providerMock)
+			handlersRest := handlers.NewRestHandlers(servMock, middlewareMock, providerMock, 50)
 
 			w := httptest.NewRecorder()
 			r := httptest.NewRequest("POST", "/users", bytes.NewBufferString(tc.inputBody))
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+type GetAttachmentResponse struct {
+	ID          string    `json:"id"`
+	PostID      string    `json:"postId"`
+	CreatedBy   string    `json:"createdBy"`
+	CreatedAt   time.Time `json:"createdAt"`
+	FileName    string    `json:"fileName"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	URL         string    `json:"url"`
+	// VariantStatus is empty for files which have no variants.
+	VariantStatus string                      `json:"variantStatus,omitempty"`
+	Variants      []AttachmentVariantResponse `json:"variants"`
+}
+
+type AttachmentVariantResponse struct {
+	Name        string `json:"name"`
+	ContentType string `json:"contentType"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	Size        int64  `json:"size"`
+	URL         string `json:"url"`
+}
@@ -5,18 +5,46 @@ import (
 )
 
 type CreateCommentRequest struct {
-	Content string `json:"content"`
-	PostID  string `json:"postId"`
+	Content  string `json:"content"`
+	Format   string `json:"format"`
+	PostID   string `json:"postId"`
+	ParentID string `json:"parentId"`
 }
 
 type GetCommentResponse struct {
-	ID        string    `json:"id"`
-	Content   string    `json:"content"`
-	CreatedBy string    `json:"createdBy"`
-	CreatedAt time.Time `json:"createdAt"`
-	PostID    string    `json:"postId"`
+	ID        string         `json:"id"`
+	Content   string         `json:"content,omitempty"`
+	CreatedBy string         `json:"createdBy"`
+	CreatedAt time.Time      `json:"createdAt"`
+	PostID    string         `json:"postId"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+	Edited    bool           `json:"edited"`
+	ParentID  *string        `json:"parentId"`
+	Reactions map[string]int `json:"reactions"`
+
+	Format      string `json:"format"`
+	ContentHTML string `json:"contentHtml,omitempty"`
+	WordCount   int    `json:"wordCount"`
+	ReadingTime int    `json:"readingTime"`
+
+	Mentions []MentionResponse `json:"mentions"`
+}
+
+// CommentReplyResponse is a reply to a comment together with its own replies.
+type CommentReplyResponse struct {
+	GetCommentResponse
+	Replies []CommentReplyResponse `json:"replies"`
 }
 
 type UpdateCommentRequest struct {
-	Content string `json:"content"`
+	Content *string `json:"content"`
+	Format  *string `json:"format"`
+}
+
+type GetCommentRevisionResponse struct {
+	ID        string    `json:"id"`
+	CommentID string    `json:"commentId"`
+	EditedBy  string    `json:"editedBy"`
+	EditedAt  time.Time `json:"editedAt"`
+	Content   string    `json:"content"`
 }
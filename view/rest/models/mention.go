@@ -0,0 +1,6 @@
+package models
+
+type MentionResponse struct {
+	UserID   string `json:"userId"`
+	Username string `json:"username"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+type GetNotificationResponse struct {
+	ID        string     `json:"id"`
+	Type      string     `json:"type"`
+	ActorID   string     `json:"actorId"`
+	PostID    string     `json:"postId,omitempty"`
+	CommentID string     `json:"commentId,omitempty"`
+	Reaction  string     `json:"reaction,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	Read      bool       `json:"read"`
+	ReadAt    *time.Time `json:"readAt,omitempty"`
+}
+
+type ListNotificationsResponse struct {
+	Notifications []GetNotificationResponse `json:"notifications"`
+	UnreadCount   int                       `json:"unreadCount"`
+}
+
+// NotificationPreferencesRequest turns the listed types of notifications on or off.
+type NotificationPreferencesRequest map[string]bool
+
+type NotificationPreferencesResponse map[string]bool
@@ -7,20 +7,47 @@ import (
 type CreatePostRequest struct {
 	Title       string   `json:"title"`
 	Description string   `json:"description"`
+	Format      string   `json:"format"`
 	Tags        []string `json:"tags"`
 }
 
 type GetPostResponse struct {
+	ID          string         `json:"id"`
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	CreatedBy   string         `json:"createdBy"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	Tags        []string       `json:"tags"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+	Edited      bool           `json:"edited"`
+	Status      string         `json:"status"`
+	Slug        string         `json:"slug"`
+	Reactions   map[string]int `json:"reactions"`
+
+	CommentCount    int        `json:"commentCount"`
+	LastCommentedAt *time.Time `json:"lastCommentedAt"`
+
+	Format          string `json:"format"`
+	DescriptionHTML string `json:"descriptionHtml,omitempty"`
+	WordCount       int    `json:"wordCount"`
+	ReadingTime     int    `json:"readingTime"`
+
+	Mentions []MentionResponse `json:"mentions"`
+}
+
+type UpdatePostRequest struct {
+	Title       *string   `json:"title"`
+	Description *string   `json:"description"`
+	Format      *string   `json:"format"`
+	Tags        *[]string `json:"tags"`
+}
+
+type GetPostRevisionResponse struct {
 	ID          string    `json:"id"`
+	PostID      string    `json:"postId"`
+	EditedBy    string    `json:"editedBy"`
+	EditedAt    time.Time `json:"editedAt"`
 	Title       string    `json:"title"`
 	Description string    `json:"description"`
-	CreatedBy   string    `json:"createdBy"`
-	CreatedAt   time.Time `json:"createdAt"`
 	Tags        []string  `json:"tags"`
 }
-
-type UpdatePostRequest struct {
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Tags        []string `json:"tags"`
-}
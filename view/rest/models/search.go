@@ -0,0 +1,11 @@
+package models
+
+// SearchResultResponse is a found post or comment. Only the field of the found object is set.
+type SearchResultResponse struct {
+	Type    string              `json:"type"`
+	ID      string              `json:"id"`
+	Rank    float64             `json:"rank"`
+	Snippet string              `json:"snippet"`
+	Post    *GetPostResponse    `json:"post,omitempty"`
+	Comment *GetCommentResponse `json:"comment,omitempty"`
+}
@@ -0,0 +1,14 @@
+package models
+
+type TagResponse struct {
+	Name      string `json:"name"`
+	PostCount int    `json:"postCount"`
+}
+
+type RenameTagRequest struct {
+	Name string `json:"name"`
+}
+
+type MergeTagsRequest struct {
+	Into string `json:"into"`
+}